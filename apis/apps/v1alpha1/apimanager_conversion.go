@@ -0,0 +1,1143 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	appsv1beta1 "github.com/3scale/3scale-operator/apis/apps/v1beta1"
+)
+
+// DeprecatedFieldsAnnotation keeps the v1alpha1 fields removed from v1beta1,
+// so converting an APIManager to v1beta1 and back does not lose them
+const DeprecatedFieldsAnnotation = "apps.3scale.net/v1alpha1-deprecated-fields"
+
+// deprecatedFields are the v1alpha1 fields without v1beta1 counterpart
+type deprecatedFields struct {
+	HighAvailability             *HighAvailabilitySpec   `json:"highAvailability,omitempty"`
+	ApicastProductionOpenTracing *APIcastOpenTracingSpec `json:"apicastProductionOpenTracing,omitempty"`
+	ApicastStagingOpenTracing    *APIcastOpenTracingSpec `json:"apicastStagingOpenTracing,omitempty"`
+	SystemSphinx                 *SystemSphinxSpec       `json:"systemSphinx,omitempty"`
+	SystemAmazonS3               *DeprecatedSystemS3Spec `json:"systemAmazonS3,omitempty"`
+}
+
+func (d *deprecatedFields) empty() bool {
+	return *d == deprecatedFields{}
+}
+
+// ConvertTo converts this APIManager to the Hub version (v1beta1)
+func (src *APIManager) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*appsv1beta1.APIManager)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = convertSpecToV1beta1(&src.Spec)
+	dst.Status = convertStatusToV1beta1(&src.Status)
+
+	deprecated := getDeprecatedFields(&src.Spec)
+	if _, ok := src.Annotations[DeprecatedFieldsAnnotation]; !ok && deprecated.empty() {
+		return nil
+	}
+
+	// the annotation is only set by the conversion, a stale one is dropped
+	dst.Annotations = withoutDeprecatedFieldsAnnotation(src.Annotations)
+	if deprecated.empty() {
+		return nil
+	}
+
+	deprecatedJSON, err := json.Marshal(deprecated)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the deprecated fields: %w", err)
+	}
+	if dst.Annotations == nil {
+		dst.Annotations = map[string]string{}
+	}
+	dst.Annotations[DeprecatedFieldsAnnotation] = string(deprecatedJSON)
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version
+func (dst *APIManager) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*appsv1beta1.APIManager)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = convertSpecFromV1beta1(&src.Spec)
+	dst.Status = convertStatusFromV1beta1(&src.Status)
+
+	deprecatedJSON, ok := src.Annotations[DeprecatedFieldsAnnotation]
+	if !ok {
+		return nil
+	}
+
+	deprecated := &deprecatedFields{}
+	if err := json.Unmarshal([]byte(deprecatedJSON), deprecated); err != nil {
+		return fmt.Errorf("failed to unmarshal the %s annotation: %w", DeprecatedFieldsAnnotation, err)
+	}
+	setDeprecatedFields(&dst.Spec, deprecated)
+	dst.Annotations = withoutDeprecatedFieldsAnnotation(src.Annotations)
+
+	return nil
+}
+
+// withoutDeprecatedFieldsAnnotation returns a copy of the annotations without the deprecated fields, nil when empty
+func withoutDeprecatedFieldsAnnotation(annotations map[string]string) map[string]string {
+	var result map[string]string
+	for key, value := range annotations {
+		if key == DeprecatedFieldsAnnotation {
+			continue
+		}
+		if result == nil {
+			result = map[string]string{}
+		}
+		result[key] = value
+	}
+	return result
+}
+
+func getDeprecatedFields(spec *APIManagerSpec) *deprecatedFields {
+	deprecated := &deprecatedFields{HighAvailability: spec.HighAvailability}
+	if spec.Apicast != nil {
+		if spec.Apicast.ProductionSpec != nil {
+			deprecated.ApicastProductionOpenTracing = spec.Apicast.ProductionSpec.OpenTracing
+		}
+		if spec.Apicast.StagingSpec != nil {
+			deprecated.ApicastStagingOpenTracing = spec.Apicast.StagingSpec.OpenTracing
+		}
+	}
+	if spec.System != nil {
+		deprecated.SystemSphinx = spec.System.SphinxSpec
+		if spec.System.FileStorageSpec != nil {
+			deprecated.SystemAmazonS3 = spec.System.FileStorageSpec.DeprecatedS3
+		}
+	}
+	return deprecated
+}
+
+// setDeprecatedFields restores the deprecated fields, creating their parents when needed
+func setDeprecatedFields(spec *APIManagerSpec, deprecated *deprecatedFields) {
+	spec.HighAvailability = deprecated.HighAvailability
+	if deprecated.ApicastProductionOpenTracing != nil || deprecated.ApicastStagingOpenTracing != nil {
+		if spec.Apicast == nil {
+			spec.Apicast = &ApicastSpec{}
+		}
+		if deprecated.ApicastProductionOpenTracing != nil {
+			if spec.Apicast.ProductionSpec == nil {
+				spec.Apicast.ProductionSpec = &ApicastProductionSpec{}
+			}
+			spec.Apicast.ProductionSpec.OpenTracing = deprecated.ApicastProductionOpenTracing
+		}
+		if deprecated.ApicastStagingOpenTracing != nil {
+			if spec.Apicast.StagingSpec == nil {
+				spec.Apicast.StagingSpec = &ApicastStagingSpec{}
+			}
+			spec.Apicast.StagingSpec.OpenTracing = deprecated.ApicastStagingOpenTracing
+		}
+	}
+	if deprecated.SystemSphinx != nil || deprecated.SystemAmazonS3 != nil {
+		if spec.System == nil {
+			spec.System = &SystemSpec{}
+		}
+		spec.System.SphinxSpec = deprecated.SystemSphinx
+		if deprecated.SystemAmazonS3 != nil {
+			if spec.System.FileStorageSpec == nil {
+				spec.System.FileStorageSpec = &SystemFileStorageSpec{}
+			}
+			spec.System.FileStorageSpec.DeprecatedS3 = deprecated.SystemAmazonS3
+		}
+	}
+}
+
+func convertSpecToV1beta1(src *APIManagerSpec) appsv1beta1.APIManagerSpec {
+	dst := appsv1beta1.APIManagerSpec{
+		WildcardDomain:              src.WildcardDomain,
+		AppLabel:                    src.AppLabel,
+		TenantName:                  src.TenantName,
+		ResourceRequirementsEnabled: src.ResourceRequirementsEnabled,
+		ImagePullSecrets:            src.ImagePullSecrets,
+		Profile:                     (*appsv1beta1.APIManagerProfile)(src.Profile),
+		Apicast:                     convertApicastToV1beta1(src.Apicast),
+		Backend:                     convertBackendToV1beta1(src.Backend),
+		System:                      convertSystemToV1beta1(src.System),
+		Zync:                        convertZyncToV1beta1(src.Zync),
+		Monitoring:                  convertMonitoringToV1beta1(src.Monitoring),
+		NetworkPolicies:             (*appsv1beta1.NetworkPoliciesSpec)(src.NetworkPolicies),
+		PodDisruptionBudget:         (*appsv1beta1.PodDisruptionBudgetSpec)(src.PodDisruptionBudget),
+		Certificates:                (*appsv1beta1.CertificatesSpec)(src.Certificates),
+	}
+	if src.ExternalComponents != nil {
+		dst.ExternalComponents = &appsv1beta1.ExternalComponentsSpec{
+			System:  (*appsv1beta1.ExternalSystemComponents)(src.ExternalComponents.System),
+			Backend: (*appsv1beta1.ExternalBackendComponents)(src.ExternalComponents.Backend),
+			Zync:    (*appsv1beta1.ExternalZyncComponents)(src.ExternalComponents.Zync),
+		}
+	}
+	if src.Maintenance != nil {
+		dst.Maintenance = &appsv1beta1.MaintenanceSpec{Mode: appsv1beta1.MaintenanceMode(src.Maintenance.Mode)}
+	}
+	return dst
+}
+
+func convertSpecFromV1beta1(src *appsv1beta1.APIManagerSpec) APIManagerSpec {
+	dst := APIManagerSpec{
+		APIManagerCommonSpec: APIManagerCommonSpec{
+			WildcardDomain:              src.WildcardDomain,
+			AppLabel:                    src.AppLabel,
+			TenantName:                  src.TenantName,
+			ResourceRequirementsEnabled: src.ResourceRequirementsEnabled,
+			ImagePullSecrets:            src.ImagePullSecrets,
+			Profile:                     (*APIManagerProfile)(src.Profile),
+		},
+		Apicast:             convertApicastFromV1beta1(src.Apicast),
+		Backend:             convertBackendFromV1beta1(src.Backend),
+		System:              convertSystemFromV1beta1(src.System),
+		Zync:                convertZyncFromV1beta1(src.Zync),
+		Monitoring:          convertMonitoringFromV1beta1(src.Monitoring),
+		NetworkPolicies:     (*NetworkPoliciesSpec)(src.NetworkPolicies),
+		PodDisruptionBudget: (*PodDisruptionBudgetSpec)(src.PodDisruptionBudget),
+		Certificates:        (*CertificatesSpec)(src.Certificates),
+	}
+	if src.ExternalComponents != nil {
+		dst.ExternalComponents = &ExternalComponentsSpec{
+			System:  (*ExternalSystemComponents)(src.ExternalComponents.System),
+			Backend: (*ExternalBackendComponents)(src.ExternalComponents.Backend),
+			Zync:    (*ExternalZyncComponents)(src.ExternalComponents.Zync),
+		}
+	}
+	if src.Maintenance != nil {
+		dst.Maintenance = &MaintenanceSpec{Mode: MaintenanceMode(src.Maintenance.Mode)}
+	}
+	return dst
+}
+
+func convertApicastToV1beta1(src *ApicastSpec) *appsv1beta1.ApicastSpec {
+	if src == nil {
+		return nil
+	}
+	dst := &appsv1beta1.ApicastSpec{
+		ManagementAPI: src.ApicastManagementAPI,
+		OpenSSLVerify: src.OpenSSLVerify,
+		ResponseCodes: src.IncludeResponseCodes,
+		RegistryURL:   src.RegistryURL,
+		Image:         src.Image,
+	}
+	if src.ProductionSpec != nil {
+		p := src.ProductionSpec
+		dst.Production = &appsv1beta1.ApicastProductionSpec{
+			AutoscaledDeploymentSpec: appsv1beta1.AutoscaledDeploymentSpec{
+				DeploymentSpec: appsv1beta1.DeploymentSpec{
+					Replicas:         p.Replicas,
+					Resources:        p.Resources,
+					ComponentPodSpec: podSpecToV1beta1(p.Affinity, p.Tolerations, p.PriorityClassName, p.TopologySpreadConstraints, p.Labels, p.Annotations),
+				},
+				Hpa: p.Hpa,
+			},
+			Workers: p.Workers,
+			ApicastGatewaySpec: appsv1beta1.ApicastGatewaySpec{
+				LogLevel:                  p.LogLevel,
+				CustomPolicies:            convertCustomPoliciesToV1beta1(p.CustomPolicies),
+				OpenTelemetry:             (*appsv1beta1.OpenTelemetrySpec)(p.OpenTelemetry),
+				CustomEnvironments:        convertCustomEnvironmentsToV1beta1(p.CustomEnvironments),
+				HTTPS:                     apicastHTTPSToV1beta1(p.HTTPSPort, p.HTTPSVerifyDepth, p.HTTPSCertificateSecretRef),
+				Proxy:                     apicastProxyToV1beta1(p.AllProxy, p.HTTPProxy, p.HTTPSProxy, p.NoProxy),
+				ServiceCacheSize:          p.ServiceCacheSize,
+				ConfigurationLoadMode:     p.ConfigurationLoadMode,
+				CacheConfigurationSeconds: p.CacheConfigurationSeconds,
+				PathRoutingEnabled:        p.PathRoutingEnabled,
+				PathRoutingOnly:           p.PathRoutingOnly,
+				CacheMaxTime:              p.CacheMaxTime,
+				CacheStatusCodes:          p.CacheStatusCodes,
+				UpstreamRetryCases:        p.UpstreamRetryCases,
+				HTTPKeepaliveTimeout:      p.HTTPKeepaliveTimeout,
+				BatcherSharedMemorySize:   p.BatcherSharedMemorySize,
+				AccessLogFile:             p.AccessLogFile,
+			},
+		}
+	}
+	if src.StagingSpec != nil {
+		s := src.StagingSpec
+		dst.Staging = &appsv1beta1.ApicastStagingSpec{
+			DeploymentSpec: appsv1beta1.DeploymentSpec{
+				Replicas:         s.Replicas,
+				Resources:        s.Resources,
+				ComponentPodSpec: podSpecToV1beta1(s.Affinity, s.Tolerations, s.PriorityClassName, s.TopologySpreadConstraints, s.Labels, s.Annotations),
+			},
+			ApicastGatewaySpec: appsv1beta1.ApicastGatewaySpec{
+				LogLevel:                  s.LogLevel,
+				CustomPolicies:            convertCustomPoliciesToV1beta1(s.CustomPolicies),
+				OpenTelemetry:             (*appsv1beta1.OpenTelemetrySpec)(s.OpenTelemetry),
+				CustomEnvironments:        convertCustomEnvironmentsToV1beta1(s.CustomEnvironments),
+				HTTPS:                     apicastHTTPSToV1beta1(s.HTTPSPort, s.HTTPSVerifyDepth, s.HTTPSCertificateSecretRef),
+				Proxy:                     apicastProxyToV1beta1(s.AllProxy, s.HTTPProxy, s.HTTPSProxy, s.NoProxy),
+				ServiceCacheSize:          s.ServiceCacheSize,
+				ConfigurationLoadMode:     s.ConfigurationLoadMode,
+				CacheConfigurationSeconds: s.CacheConfigurationSeconds,
+				PathRoutingEnabled:        s.PathRoutingEnabled,
+				PathRoutingOnly:           s.PathRoutingOnly,
+				CacheMaxTime:              s.CacheMaxTime,
+				CacheStatusCodes:          s.CacheStatusCodes,
+				UpstreamRetryCases:        s.UpstreamRetryCases,
+				HTTPKeepaliveTimeout:      s.HTTPKeepaliveTimeout,
+				BatcherSharedMemorySize:   s.BatcherSharedMemorySize,
+				AccessLogFile:             s.AccessLogFile,
+			},
+		}
+	}
+	return dst
+}
+
+func convertApicastFromV1beta1(src *appsv1beta1.ApicastSpec) *ApicastSpec {
+	if src == nil {
+		return nil
+	}
+	dst := &ApicastSpec{
+		ApicastManagementAPI: src.ManagementAPI,
+		OpenSSLVerify:        src.OpenSSLVerify,
+		IncludeResponseCodes: src.ResponseCodes,
+		RegistryURL:          src.RegistryURL,
+		Image:                src.Image,
+	}
+	if src.Production != nil {
+		p := src.Production
+		https := p.HTTPS
+		if https == nil {
+			https = &appsv1beta1.ApicastHTTPSSpec{}
+		}
+		proxy := p.Proxy
+		if proxy == nil {
+			proxy = &appsv1beta1.ApicastProxySpec{}
+		}
+		dst.ProductionSpec = &ApicastProductionSpec{
+			Replicas:                  p.Replicas,
+			Affinity:                  p.Affinity,
+			Tolerations:               p.Tolerations,
+			Resources:                 p.Resources,
+			Workers:                   p.Workers,
+			LogLevel:                  p.LogLevel,
+			CustomPolicies:            convertCustomPoliciesFromV1beta1(p.CustomPolicies),
+			Hpa:                       p.Hpa,
+			OpenTelemetry:             (*OpenTelemetrySpec)(p.OpenTelemetry),
+			CustomEnvironments:        convertCustomEnvironmentsFromV1beta1(p.CustomEnvironments),
+			HTTPSPort:                 https.Port,
+			HTTPSVerifyDepth:          https.VerifyDepth,
+			HTTPSCertificateSecretRef: https.CertificateSecretRef,
+			AllProxy:                  proxy.AllProxy,
+			HTTPProxy:                 proxy.HTTPProxy,
+			HTTPSProxy:                proxy.HTTPSProxy,
+			NoProxy:                   proxy.NoProxy,
+			ServiceCacheSize:          p.ServiceCacheSize,
+			ConfigurationLoadMode:     p.ConfigurationLoadMode,
+			CacheConfigurationSeconds: p.CacheConfigurationSeconds,
+			PathRoutingEnabled:        p.PathRoutingEnabled,
+			PathRoutingOnly:           p.PathRoutingOnly,
+			CacheMaxTime:              p.CacheMaxTime,
+			CacheStatusCodes:          p.CacheStatusCodes,
+			UpstreamRetryCases:        p.UpstreamRetryCases,
+			HTTPKeepaliveTimeout:      p.HTTPKeepaliveTimeout,
+			BatcherSharedMemorySize:   p.BatcherSharedMemorySize,
+			AccessLogFile:             p.AccessLogFile,
+			PriorityClassName:         p.PriorityClassName,
+			TopologySpreadConstraints: p.TopologySpreadConstraints,
+			Labels:                    p.Labels,
+			Annotations:               p.Annotations,
+		}
+	}
+	if src.Staging != nil {
+		s := src.Staging
+		https := s.HTTPS
+		if https == nil {
+			https = &appsv1beta1.ApicastHTTPSSpec{}
+		}
+		proxy := s.Proxy
+		if proxy == nil {
+			proxy = &appsv1beta1.ApicastProxySpec{}
+		}
+		dst.StagingSpec = &ApicastStagingSpec{
+			Replicas:                  s.Replicas,
+			Affinity:                  s.Affinity,
+			Tolerations:               s.Tolerations,
+			Resources:                 s.Resources,
+			LogLevel:                  s.LogLevel,
+			CustomPolicies:            convertCustomPoliciesFromV1beta1(s.CustomPolicies),
+			OpenTelemetry:             (*OpenTelemetrySpec)(s.OpenTelemetry),
+			CustomEnvironments:        convertCustomEnvironmentsFromV1beta1(s.CustomEnvironments),
+			HTTPSPort:                 https.Port,
+			HTTPSVerifyDepth:          https.VerifyDepth,
+			HTTPSCertificateSecretRef: https.CertificateSecretRef,
+			AllProxy:                  proxy.AllProxy,
+			HTTPProxy:                 proxy.HTTPProxy,
+			HTTPSProxy:                proxy.HTTPSProxy,
+			NoProxy:                   proxy.NoProxy,
+			ServiceCacheSize:          s.ServiceCacheSize,
+			ConfigurationLoadMode:     s.ConfigurationLoadMode,
+			CacheConfigurationSeconds: s.CacheConfigurationSeconds,
+			PathRoutingEnabled:        s.PathRoutingEnabled,
+			PathRoutingOnly:           s.PathRoutingOnly,
+			CacheMaxTime:              s.CacheMaxTime,
+			CacheStatusCodes:          s.CacheStatusCodes,
+			UpstreamRetryCases:        s.UpstreamRetryCases,
+			HTTPKeepaliveTimeout:      s.HTTPKeepaliveTimeout,
+			BatcherSharedMemorySize:   s.BatcherSharedMemorySize,
+			AccessLogFile:             s.AccessLogFile,
+			PriorityClassName:         s.PriorityClassName,
+			TopologySpreadConstraints: s.TopologySpreadConstraints,
+			Labels:                    s.Labels,
+			Annotations:               s.Annotations,
+		}
+	}
+	return dst
+}
+
+// apicastHTTPSToV1beta1 groups the HTTPS fields, nil when none is set
+func apicastHTTPSToV1beta1(port *int32, verifyDepth *int64, certificateSecretRef *v1.LocalObjectReference) *appsv1beta1.ApicastHTTPSSpec {
+	if port == nil && verifyDepth == nil && certificateSecretRef == nil {
+		return nil
+	}
+	return &appsv1beta1.ApicastHTTPSSpec{Port: port, VerifyDepth: verifyDepth, CertificateSecretRef: certificateSecretRef}
+}
+
+// apicastProxyToV1beta1 groups the proxy fields, nil when none is set
+func apicastProxyToV1beta1(allProxy, httpProxy, httpsProxy, noProxy *string) *appsv1beta1.ApicastProxySpec {
+	if allProxy == nil && httpProxy == nil && httpsProxy == nil && noProxy == nil {
+		return nil
+	}
+	return &appsv1beta1.ApicastProxySpec{AllProxy: allProxy, HTTPProxy: httpProxy, HTTPSProxy: httpsProxy, NoProxy: noProxy}
+}
+
+func convertCustomPoliciesToV1beta1(src []CustomPolicySpec) []appsv1beta1.CustomPolicySpec {
+	if src == nil {
+		return nil
+	}
+	dst := make([]appsv1beta1.CustomPolicySpec, 0, len(src))
+	for _, policy := range src {
+		dst = append(dst, appsv1beta1.CustomPolicySpec(policy))
+	}
+	return dst
+}
+
+func convertCustomPoliciesFromV1beta1(src []appsv1beta1.CustomPolicySpec) []CustomPolicySpec {
+	if src == nil {
+		return nil
+	}
+	dst := make([]CustomPolicySpec, 0, len(src))
+	for _, policy := range src {
+		dst = append(dst, CustomPolicySpec(policy))
+	}
+	return dst
+}
+
+func convertCustomEnvironmentsToV1beta1(src []CustomEnvironmentSpec) []appsv1beta1.CustomEnvironmentSpec {
+	if src == nil {
+		return nil
+	}
+	dst := make([]appsv1beta1.CustomEnvironmentSpec, 0, len(src))
+	for _, environment := range src {
+		dst = append(dst, appsv1beta1.CustomEnvironmentSpec(environment))
+	}
+	return dst
+}
+
+func convertCustomEnvironmentsFromV1beta1(src []appsv1beta1.CustomEnvironmentSpec) []CustomEnvironmentSpec {
+	if src == nil {
+		return nil
+	}
+	dst := make([]CustomEnvironmentSpec, 0, len(src))
+	for _, environment := range src {
+		dst = append(dst, CustomEnvironmentSpec(environment))
+	}
+	return dst
+}
+
+func podSpecToV1beta1(affinity *v1.Affinity, tolerations []v1.Toleration, priorityClassName *string,
+	topologySpreadConstraints []v1.TopologySpreadConstraint, labels, annotations map[string]string) appsv1beta1.ComponentPodSpec {
+	return appsv1beta1.ComponentPodSpec{
+		Affinity:                  affinity,
+		Tolerations:               tolerations,
+		PriorityClassName:         priorityClassName,
+		TopologySpreadConstraints: topologySpreadConstraints,
+		Labels:                    labels,
+		Annotations:               annotations,
+	}
+}
+
+func isEmptyPodSpec(pod *appsv1beta1.ComponentPodSpec) bool {
+	return pod.Affinity == nil && pod.Tolerations == nil && pod.PriorityClassName == nil &&
+		pod.TopologySpreadConstraints == nil && pod.Labels == nil && pod.Annotations == nil
+}
+
+func convertBackendToV1beta1(src *BackendSpec) *appsv1beta1.BackendSpec {
+	if src == nil {
+		return nil
+	}
+	dst := &appsv1beta1.BackendSpec{
+		Image:         src.Image,
+		OpenTelemetry: (*appsv1beta1.ComponentOpenTelemetrySpec)(src.OpenTelemetry),
+	}
+	redis := &appsv1beta1.RedisSpec{
+		Image:            src.RedisImage,
+		Resources:        src.RedisResources,
+		ComponentPodSpec: podSpecToV1beta1(src.RedisAffinity, src.RedisTolerations, src.RedisPriorityClassName, src.RedisTopologySpreadConstraints, src.RedisLabels, src.RedisAnnotations),
+	}
+	if src.RedisPersistentVolumeClaimSpec != nil {
+		redis.PersistentVolumeClaim = &appsv1beta1.RedisPersistentVolumeClaimSpec{StorageClassName: src.RedisPersistentVolumeClaimSpec.StorageClassName}
+	}
+	if !isEmptyRedis(redis) {
+		dst.Redis = redis
+	}
+	if l := src.ListenerSpec; l != nil {
+		dst.Listener = &appsv1beta1.AutoscaledDeploymentSpec{
+			DeploymentSpec: appsv1beta1.DeploymentSpec{
+				Replicas:         l.Replicas,
+				Resources:        l.Resources,
+				ComponentPodSpec: podSpecToV1beta1(l.Affinity, l.Tolerations, l.PriorityClassName, l.TopologySpreadConstraints, l.Labels, l.Annotations),
+			},
+			Hpa: l.Hpa,
+		}
+	}
+	if w := src.WorkerSpec; w != nil {
+		dst.Worker = &appsv1beta1.AutoscaledDeploymentSpec{
+			DeploymentSpec: appsv1beta1.DeploymentSpec{
+				Replicas:         w.Replicas,
+				Resources:        w.Resources,
+				ComponentPodSpec: podSpecToV1beta1(w.Affinity, w.Tolerations, w.PriorityClassName, w.TopologySpreadConstraints, w.Labels, w.Annotations),
+			},
+			Hpa: w.Hpa,
+		}
+	}
+	if c := src.CronSpec; c != nil {
+		dst.Cron = &appsv1beta1.DeploymentSpec{
+			Replicas:         c.Replicas,
+			Resources:        c.Resources,
+			ComponentPodSpec: podSpecToV1beta1(c.Affinity, c.Tolerations, c.PriorityClassName, c.TopologySpreadConstraints, c.Labels, c.Annotations),
+		}
+	}
+	return dst
+}
+
+func convertBackendFromV1beta1(src *appsv1beta1.BackendSpec) *BackendSpec {
+	if src == nil {
+		return nil
+	}
+	dst := &BackendSpec{
+		Image:         src.Image,
+		OpenTelemetry: (*ComponentOpenTelemetrySpec)(src.OpenTelemetry),
+	}
+	if r := src.Redis; r != nil {
+		dst.RedisImage = r.Image
+		dst.RedisResources = r.Resources
+		dst.RedisAffinity = r.Affinity
+		dst.RedisTolerations = r.Tolerations
+		dst.RedisPriorityClassName = r.PriorityClassName
+		dst.RedisTopologySpreadConstraints = r.TopologySpreadConstraints
+		dst.RedisLabels = r.Labels
+		dst.RedisAnnotations = r.Annotations
+		if r.PersistentVolumeClaim != nil {
+			dst.RedisPersistentVolumeClaimSpec = &BackendRedisPersistentVolumeClaimSpec{StorageClassName: r.PersistentVolumeClaim.StorageClassName}
+		}
+	}
+	if l := src.Listener; l != nil {
+		dst.ListenerSpec = &BackendListenerSpec{
+			Replicas:                  l.Replicas,
+			Affinity:                  l.Affinity,
+			Tolerations:               l.Tolerations,
+			Resources:                 l.Resources,
+			PriorityClassName:         l.PriorityClassName,
+			Hpa:                       l.Hpa,
+			TopologySpreadConstraints: l.TopologySpreadConstraints,
+			Labels:                    l.Labels,
+			Annotations:               l.Annotations,
+		}
+	}
+	if w := src.Worker; w != nil {
+		dst.WorkerSpec = &BackendWorkerSpec{
+			Replicas:                  w.Replicas,
+			Affinity:                  w.Affinity,
+			Tolerations:               w.Tolerations,
+			Resources:                 w.Resources,
+			PriorityClassName:         w.PriorityClassName,
+			Hpa:                       w.Hpa,
+			TopologySpreadConstraints: w.TopologySpreadConstraints,
+			Labels:                    w.Labels,
+			Annotations:               w.Annotations,
+		}
+	}
+	if c := src.Cron; c != nil {
+		dst.CronSpec = &BackendCronSpec{
+			Replicas:                  c.Replicas,
+			Affinity:                  c.Affinity,
+			Tolerations:               c.Tolerations,
+			Resources:                 c.Resources,
+			PriorityClassName:         c.PriorityClassName,
+			TopologySpreadConstraints: c.TopologySpreadConstraints,
+			Labels:                    c.Labels,
+			Annotations:               c.Annotations,
+		}
+	}
+	return dst
+}
+
+func isEmptyRedis(redis *appsv1beta1.RedisSpec) bool {
+	return redis.Image == nil && redis.PersistentVolumeClaim == nil && redis.Resources == nil && isEmptyPodSpec(&redis.ComponentPodSpec)
+}
+
+func convertSystemToV1beta1(src *SystemSpec) *appsv1beta1.SystemSpec {
+	if src == nil {
+		return nil
+	}
+	dst := &appsv1beta1.SystemSpec{
+		Image:         src.Image,
+		OpenTelemetry: (*appsv1beta1.ComponentOpenTelemetrySpec)(src.OpenTelemetry),
+	}
+	if a := src.AppSpec; a != nil {
+		dst.App = &appsv1beta1.SystemAppSpec{
+			Replicas:                    a.Replicas,
+			MasterContainerResources:    a.MasterContainerResources,
+			ProviderContainerResources:  a.ProviderContainerResources,
+			DeveloperContainerResources: a.DeveloperContainerResources,
+			ComponentPodSpec:            podSpecToV1beta1(a.Affinity, a.Tolerations, a.PriorityClassName, a.TopologySpreadConstraints, a.Labels, a.Annotations),
+		}
+	}
+	if s := src.SidekiqSpec; s != nil {
+		dst.Sidekiq = &appsv1beta1.DeploymentSpec{
+			Replicas:         s.Replicas,
+			Resources:        s.Resources,
+			ComponentPodSpec: podSpecToV1beta1(s.Affinity, s.Tolerations, s.PriorityClassName, s.TopologySpreadConstraints, s.Labels, s.Annotations),
+		}
+	}
+	if s := src.SearchdSpec; s != nil {
+		dst.Searchd = &appsv1beta1.SystemSearchdSpec{
+			Image:                 s.Image,
+			Resources:             s.Resources,
+			PersistentVolumeClaim: convertPVCToV1beta1(s.PVC),
+			ComponentPodSpec:      podSpecToV1beta1(s.Affinity, s.Tolerations, s.PriorityClassName, s.TopologySpreadConstraints, s.Labels, s.Annotations),
+		}
+	}
+	memcached := &appsv1beta1.MemcachedSpec{
+		Image:            src.MemcachedImage,
+		Resources:        src.MemcachedResources,
+		ComponentPodSpec: podSpecToV1beta1(src.MemcachedAffinity, src.MemcachedTolerations, src.MemcachedPriorityClassName, src.MemcachedTopologySpreadConstraints, src.MemcachedLabels, src.MemcachedAnnotations),
+	}
+	if memcached.Image != nil || memcached.Resources != nil || !isEmptyPodSpec(&memcached.ComponentPodSpec) {
+		dst.Memcached = memcached
+	}
+	redis := &appsv1beta1.RedisSpec{
+		Image:            src.RedisImage,
+		Resources:        src.RedisResources,
+		ComponentPodSpec: podSpecToV1beta1(src.RedisAffinity, src.RedisTolerations, src.RedisPriorityClassName, src.RedisTopologySpreadConstraints, src.RedisLabels, src.RedisAnnotations),
+	}
+	if src.RedisPersistentVolumeClaimSpec != nil {
+		redis.PersistentVolumeClaim = &appsv1beta1.RedisPersistentVolumeClaimSpec{StorageClassName: src.RedisPersistentVolumeClaimSpec.StorageClassName}
+	}
+	if !isEmptyRedis(redis) {
+		dst.Redis = redis
+	}
+	if src.FileStorageSpec != nil {
+		dst.FileStorage = &appsv1beta1.SystemFileStorageSpec{
+			PersistentVolumeClaim: convertPVCToV1beta1(src.FileStorageSpec.PVC),
+		}
+		if s3 := src.FileStorageSpec.S3; s3 != nil {
+			dst.FileStorage.SimpleStorageService = &appsv1beta1.SystemS3Spec{
+				ConfigurationSecretRef: s3.ConfigurationSecretRef,
+				STS:                    (*appsv1beta1.STSSpec)(s3.STS),
+			}
+		}
+	}
+	if src.DatabaseSpec != nil || src.SystemDatabaseTLSEnabled != nil {
+		dst.Database = &appsv1beta1.SystemDatabaseSpec{TLSEnabled: src.SystemDatabaseTLSEnabled}
+		if src.DatabaseSpec != nil {
+			dst.Database.MySQL = convertSystemMySQLToV1beta1(src.DatabaseSpec.MySQL)
+			dst.Database.PostgreSQL = convertSystemPostgreSQLToV1beta1(src.DatabaseSpec.PostgreSQL)
+		}
+	}
+	if s := src.SMTP; s != nil {
+		dst.SMTP = &appsv1beta1.SystemSMTPSpec{
+			Host:                 s.Host,
+			Port:                 s.Port,
+			AuthenticationMethod: (*appsv1beta1.SMTPAuthenticationMethod)(s.AuthenticationMethod),
+			TLSMode:              (*appsv1beta1.SMTPTLSMode)(s.TLSMode),
+			InsecureSkipVerify:   s.InsecureSkipVerify,
+			FromAddress:          s.FromAddress,
+			Domain:               s.Domain,
+			CredentialsSecretRef: s.CredentialsSecretRef,
+		}
+	}
+	return dst
+}
+
+func convertSystemFromV1beta1(src *appsv1beta1.SystemSpec) *SystemSpec {
+	if src == nil {
+		return nil
+	}
+	dst := &SystemSpec{
+		Image:         src.Image,
+		OpenTelemetry: (*ComponentOpenTelemetrySpec)(src.OpenTelemetry),
+	}
+	if a := src.App; a != nil {
+		dst.AppSpec = &SystemAppSpec{
+			Replicas:                    a.Replicas,
+			Affinity:                    a.Affinity,
+			Tolerations:                 a.Tolerations,
+			MasterContainerResources:    a.MasterContainerResources,
+			ProviderContainerResources:  a.ProviderContainerResources,
+			DeveloperContainerResources: a.DeveloperContainerResources,
+			PriorityClassName:           a.PriorityClassName,
+			TopologySpreadConstraints:   a.TopologySpreadConstraints,
+			Labels:                      a.Labels,
+			Annotations:                 a.Annotations,
+		}
+	}
+	if s := src.Sidekiq; s != nil {
+		dst.SidekiqSpec = &SystemSidekiqSpec{
+			Replicas:                  s.Replicas,
+			Affinity:                  s.Affinity,
+			Tolerations:               s.Tolerations,
+			Resources:                 s.Resources,
+			PriorityClassName:         s.PriorityClassName,
+			TopologySpreadConstraints: s.TopologySpreadConstraints,
+			Labels:                    s.Labels,
+			Annotations:               s.Annotations,
+		}
+	}
+	if s := src.Searchd; s != nil {
+		dst.SearchdSpec = &SystemSearchdSpec{
+			Image:                     s.Image,
+			Affinity:                  s.Affinity,
+			Tolerations:               s.Tolerations,
+			Resources:                 s.Resources,
+			PVC:                       convertPVCFromV1beta1(s.PersistentVolumeClaim),
+			PriorityClassName:         s.PriorityClassName,
+			TopologySpreadConstraints: s.TopologySpreadConstraints,
+			Labels:                    s.Labels,
+			Annotations:               s.Annotations,
+		}
+	}
+	if m := src.Memcached; m != nil {
+		dst.MemcachedImage = m.Image
+		dst.MemcachedAffinity = m.Affinity
+		dst.MemcachedTolerations = m.Tolerations
+		dst.MemcachedResources = m.Resources
+		dst.MemcachedPriorityClassName = m.PriorityClassName
+		dst.MemcachedTopologySpreadConstraints = m.TopologySpreadConstraints
+		dst.MemcachedLabels = m.Labels
+		dst.MemcachedAnnotations = m.Annotations
+	}
+	if r := src.Redis; r != nil {
+		dst.RedisImage = r.Image
+		dst.RedisAffinity = r.Affinity
+		dst.RedisTolerations = r.Tolerations
+		dst.RedisResources = r.Resources
+		dst.RedisPriorityClassName = r.PriorityClassName
+		dst.RedisTopologySpreadConstraints = r.TopologySpreadConstraints
+		dst.RedisLabels = r.Labels
+		dst.RedisAnnotations = r.Annotations
+		if r.PersistentVolumeClaim != nil {
+			dst.RedisPersistentVolumeClaimSpec = &SystemRedisPersistentVolumeClaimSpec{StorageClassName: r.PersistentVolumeClaim.StorageClassName}
+		}
+	}
+	if src.FileStorage != nil {
+		dst.FileStorageSpec = &SystemFileStorageSpec{
+			PVC: convertPVCFromV1beta1(src.FileStorage.PersistentVolumeClaim),
+		}
+		if s3 := src.FileStorage.SimpleStorageService; s3 != nil {
+			dst.FileStorageSpec.S3 = &SystemS3Spec{
+				ConfigurationSecretRef: s3.ConfigurationSecretRef,
+				STS:                    (*STSSpec)(s3.STS),
+			}
+		}
+	}
+	if d := src.Database; d != nil {
+		dst.SystemDatabaseTLSEnabled = d.TLSEnabled
+		// a database only holding the TLS flag comes from the v1alpha1 systemDatabaseTLSEnabled field
+		if d.MySQL != nil || d.PostgreSQL != nil || d.TLSEnabled == nil {
+			dst.DatabaseSpec = &SystemDatabaseSpec{
+				MySQL:      convertSystemMySQLFromV1beta1(d.MySQL),
+				PostgreSQL: convertSystemPostgreSQLFromV1beta1(d.PostgreSQL),
+			}
+		}
+	}
+	if s := src.SMTP; s != nil {
+		dst.SMTP = &SystemSMTPSpec{
+			Host:                 s.Host,
+			Port:                 s.Port,
+			AuthenticationMethod: (*SMTPAuthenticationMethod)(s.AuthenticationMethod),
+			TLSMode:              (*SMTPTLSMode)(s.TLSMode),
+			InsecureSkipVerify:   s.InsecureSkipVerify,
+			FromAddress:          s.FromAddress,
+			Domain:               s.Domain,
+			CredentialsSecretRef: s.CredentialsSecretRef,
+		}
+	}
+	return dst
+}
+
+func convertPVCToV1beta1(src *PVCGenericSpec) *appsv1beta1.PVCGenericSpec {
+	if src == nil {
+		return nil
+	}
+	return &appsv1beta1.PVCGenericSpec{
+		StorageClassName: src.StorageClassName,
+		Resources:        (*appsv1beta1.PersistentVolumeClaimResources)(src.Resources),
+		VolumeName:       src.VolumeName,
+	}
+}
+
+func convertPVCFromV1beta1(src *appsv1beta1.PVCGenericSpec) *PVCGenericSpec {
+	if src == nil {
+		return nil
+	}
+	return &PVCGenericSpec{
+		StorageClassName: src.StorageClassName,
+		Resources:        (*PersistentVolumeClaimResources)(src.Resources),
+		VolumeName:       src.VolumeName,
+	}
+}
+
+func convertSystemMySQLToV1beta1(src *SystemMySQLSpec) *appsv1beta1.SystemMySQLSpec {
+	if src == nil {
+		return nil
+	}
+	return &appsv1beta1.SystemMySQLSpec{
+		Image:                 src.Image,
+		PersistentVolumeClaim: convertPVCToV1beta1(src.PersistentVolumeClaimSpec),
+		Resources:             src.Resources,
+		ComponentPodSpec:      podSpecToV1beta1(src.Affinity, src.Tolerations, src.PriorityClassName, src.TopologySpreadConstraints, src.Labels, src.Annotations),
+	}
+}
+
+func convertSystemMySQLFromV1beta1(src *appsv1beta1.SystemMySQLSpec) *SystemMySQLSpec {
+	if src == nil {
+		return nil
+	}
+	return &SystemMySQLSpec{
+		Image:                     src.Image,
+		PersistentVolumeClaimSpec: convertPVCFromV1beta1(src.PersistentVolumeClaim),
+		Affinity:                  src.Affinity,
+		Tolerations:               src.Tolerations,
+		Resources:                 src.Resources,
+		PriorityClassName:         src.PriorityClassName,
+		TopologySpreadConstraints: src.TopologySpreadConstraints,
+		Labels:                    src.Labels,
+		Annotations:               src.Annotations,
+	}
+}
+
+func convertSystemPostgreSQLToV1beta1(src *SystemPostgreSQLSpec) *appsv1beta1.SystemPostgreSQLSpec {
+	if src == nil {
+		return nil
+	}
+	dst := &appsv1beta1.SystemPostgreSQLSpec{
+		Image:                 src.Image,
+		PersistentVolumeClaim: convertPVCToV1beta1(src.PersistentVolumeClaimSpec),
+		Resources:             src.Resources,
+		ComponentPodSpec:      podSpecToV1beta1(src.Affinity, src.Tolerations, src.PriorityClassName, src.TopologySpreadConstraints, src.Labels, src.Annotations),
+	}
+	if p := src.ConnectionPooler; p != nil {
+		dst.ConnectionPooler = &appsv1beta1.SystemDatabaseConnectionPoolerSpec{
+			Enabled:              p.Enabled,
+			Image:                p.Image,
+			PoolMode:             p.PoolMode,
+			DefaultPoolSize:      p.DefaultPoolSize,
+			MaxClientConnections: p.MaxClientConnections,
+			DeploymentSpec: appsv1beta1.DeploymentSpec{
+				Replicas:         p.Replicas,
+				Resources:        p.Resources,
+				ComponentPodSpec: podSpecToV1beta1(p.Affinity, p.Tolerations, p.PriorityClassName, p.TopologySpreadConstraints, p.Labels, p.Annotations),
+			},
+		}
+	}
+	return dst
+}
+
+func convertSystemPostgreSQLFromV1beta1(src *appsv1beta1.SystemPostgreSQLSpec) *SystemPostgreSQLSpec {
+	if src == nil {
+		return nil
+	}
+	dst := &SystemPostgreSQLSpec{
+		Image:                     src.Image,
+		PersistentVolumeClaimSpec: convertPVCFromV1beta1(src.PersistentVolumeClaim),
+		Affinity:                  src.Affinity,
+		Tolerations:               src.Tolerations,
+		Resources:                 src.Resources,
+		PriorityClassName:         src.PriorityClassName,
+		TopologySpreadConstraints: src.TopologySpreadConstraints,
+		Labels:                    src.Labels,
+		Annotations:               src.Annotations,
+	}
+	if p := src.ConnectionPooler; p != nil {
+		dst.ConnectionPooler = &SystemDatabaseConnectionPoolerSpec{
+			Enabled:                   p.Enabled,
+			Image:                     p.Image,
+			Replicas:                  p.Replicas,
+			PoolMode:                  p.PoolMode,
+			DefaultPoolSize:           p.DefaultPoolSize,
+			MaxClientConnections:      p.MaxClientConnections,
+			Affinity:                  p.Affinity,
+			Tolerations:               p.Tolerations,
+			Resources:                 p.Resources,
+			PriorityClassName:         p.PriorityClassName,
+			TopologySpreadConstraints: p.TopologySpreadConstraints,
+			Labels:                    p.Labels,
+			Annotations:               p.Annotations,
+		}
+	}
+	return dst
+}
+
+func convertZyncToV1beta1(src *ZyncSpec) *appsv1beta1.ZyncSpec {
+	if src == nil {
+		return nil
+	}
+	dst := &appsv1beta1.ZyncSpec{
+		Enabled:       src.Enabled,
+		Image:         src.Image,
+		OpenTelemetry: (*appsv1beta1.ComponentOpenTelemetrySpec)(src.OpenTelemetry),
+	}
+	if a := src.AppSpec; a != nil {
+		dst.App = &appsv1beta1.DeploymentSpec{
+			Replicas:         a.Replicas,
+			Resources:        a.Resources,
+			ComponentPodSpec: podSpecToV1beta1(a.Affinity, a.Tolerations, a.PriorityClassName, a.TopologySpreadConstraints, a.Labels, a.Annotations),
+		}
+	}
+	if q := src.QueSpec; q != nil {
+		dst.Que = &appsv1beta1.DeploymentSpec{
+			Replicas:         q.Replicas,
+			Resources:        q.Resources,
+			ComponentPodSpec: podSpecToV1beta1(q.Affinity, q.Tolerations, q.PriorityClassName, q.TopologySpreadConstraints, q.Labels, q.Annotations),
+		}
+	}
+	database := &appsv1beta1.ZyncDatabaseSpec{
+		Image:            src.PostgreSQLImage,
+		Resources:        src.DatabaseResources,
+		TLSEnabled:       src.ZyncDatabaseTLSEnabled,
+		ComponentPodSpec: podSpecToV1beta1(src.DatabaseAffinity, src.DatabaseTolerations, src.DatabasePriorityClassName, src.DatabaseTopologySpreadConstraints, src.DatabaseLabels, src.DatabaseAnnotations),
+	}
+	if database.Image != nil || database.Resources != nil || database.TLSEnabled != nil || !isEmptyPodSpec(&database.ComponentPodSpec) {
+		dst.Database = database
+	}
+	return dst
+}
+
+func convertZyncFromV1beta1(src *appsv1beta1.ZyncSpec) *ZyncSpec {
+	if src == nil {
+		return nil
+	}
+	dst := &ZyncSpec{
+		Enabled:       src.Enabled,
+		Image:         src.Image,
+		OpenTelemetry: (*ComponentOpenTelemetrySpec)(src.OpenTelemetry),
+	}
+	if a := src.App; a != nil {
+		dst.AppSpec = &ZyncAppSpec{
+			Replicas:                  a.Replicas,
+			Affinity:                  a.Affinity,
+			Tolerations:               a.Tolerations,
+			Resources:                 a.Resources,
+			PriorityClassName:         a.PriorityClassName,
+			TopologySpreadConstraints: a.TopologySpreadConstraints,
+			Labels:                    a.Labels,
+			Annotations:               a.Annotations,
+		}
+	}
+	if q := src.Que; q != nil {
+		dst.QueSpec = &ZyncQueSpec{
+			Replicas:                  q.Replicas,
+			Affinity:                  q.Affinity,
+			Tolerations:               q.Tolerations,
+			Resources:                 q.Resources,
+			PriorityClassName:         q.PriorityClassName,
+			TopologySpreadConstraints: q.TopologySpreadConstraints,
+			Labels:                    q.Labels,
+			Annotations:               q.Annotations,
+		}
+	}
+	if d := src.Database; d != nil {
+		dst.PostgreSQLImage = d.Image
+		dst.DatabaseAffinity = d.Affinity
+		dst.DatabaseTolerations = d.Tolerations
+		dst.DatabaseResources = d.Resources
+		dst.DatabasePriorityClassName = d.PriorityClassName
+		dst.DatabaseTopologySpreadConstraints = d.TopologySpreadConstraints
+		dst.DatabaseLabels = d.Labels
+		dst.DatabaseAnnotations = d.Annotations
+		dst.ZyncDatabaseTLSEnabled = d.TLSEnabled
+	}
+	return dst
+}
+
+func convertMonitoringToV1beta1(src *MonitoringSpec) *appsv1beta1.MonitoringSpec {
+	if src == nil {
+		return nil
+	}
+	dst := &appsv1beta1.MonitoringSpec{
+		Enabled:                  src.Enabled,
+		EnablePrometheusRules:    src.EnablePrometheusRules,
+		EnableDatastoreExporters: src.EnableDatastoreExporters,
+	}
+	if src.AlertOverrides != nil {
+		dst.AlertOverrides = make([]appsv1beta1.AlertOverrideSpec, 0, len(src.AlertOverrides))
+		for _, alertOverride := range src.AlertOverrides {
+			dst.AlertOverrides = append(dst.AlertOverrides, appsv1beta1.AlertOverrideSpec(alertOverride))
+		}
+	}
+	if g := src.Grafana; g != nil {
+		dst.Grafana = &appsv1beta1.GrafanaSpec{
+			InstanceSelector:   g.InstanceSelector,
+			Folder:             g.Folder,
+			Datasource:         g.Datasource,
+			DisabledDashboards: g.DisabledDashboards,
+		}
+		if g.Dashboards != nil {
+			dst.Grafana.Dashboards = make([]appsv1beta1.GrafanaDashboardSpec, 0, len(g.Dashboards))
+			for _, dashboard := range g.Dashboards {
+				dst.Grafana.Dashboards = append(dst.Grafana.Dashboards, appsv1beta1.GrafanaDashboardSpec(dashboard))
+			}
+		}
+	}
+	return dst
+}
+
+func convertMonitoringFromV1beta1(src *appsv1beta1.MonitoringSpec) *MonitoringSpec {
+	if src == nil {
+		return nil
+	}
+	dst := &MonitoringSpec{
+		Enabled:                  src.Enabled,
+		EnablePrometheusRules:    src.EnablePrometheusRules,
+		EnableDatastoreExporters: src.EnableDatastoreExporters,
+	}
+	if src.AlertOverrides != nil {
+		dst.AlertOverrides = make([]AlertOverrideSpec, 0, len(src.AlertOverrides))
+		for _, alertOverride := range src.AlertOverrides {
+			dst.AlertOverrides = append(dst.AlertOverrides, AlertOverrideSpec(alertOverride))
+		}
+	}
+	if g := src.Grafana; g != nil {
+		dst.Grafana = &GrafanaSpec{
+			InstanceSelector:   g.InstanceSelector,
+			Folder:             g.Folder,
+			Datasource:         g.Datasource,
+			DisabledDashboards: g.DisabledDashboards,
+		}
+		if g.Dashboards != nil {
+			dst.Grafana.Dashboards = make([]GrafanaDashboardSpec, 0, len(g.Dashboards))
+			for _, dashboard := range g.Dashboards {
+				dst.Grafana.Dashboards = append(dst.Grafana.Dashboards, GrafanaDashboardSpec(dashboard))
+			}
+		}
+	}
+	return dst
+}
+
+func convertStatusToV1beta1(src *APIManagerStatus) appsv1beta1.APIManagerStatus {
+	dst := appsv1beta1.APIManagerStatus{
+		Conditions:  src.Conditions,
+		Deployments: src.Deployments,
+	}
+	if c := src.Components; c != nil {
+		dst.Components = &appsv1beta1.APIManagerComponentsStatus{
+			Apicast:   convertComponentStatusToV1beta1(c.Apicast),
+			Backend:   convertComponentStatusToV1beta1(c.Backend),
+			System:    convertComponentStatusToV1beta1(c.System),
+			Zync:      convertComponentStatusToV1beta1(c.Zync),
+			Memcached: convertComponentStatusToV1beta1(c.Memcached),
+			Searchd:   convertComponentStatusToV1beta1(c.Searchd),
+		}
+	}
+	if p := src.Profile; p != nil {
+		dst.Profile = &appsv1beta1.APIManagerProfileStatus{Name: appsv1beta1.APIManagerProfile(p.Name)}
+		if p.Deployments != nil {
+			dst.Profile.Deployments = make([]appsv1beta1.APIManagerDeploymentSizing, 0, len(p.Deployments))
+			for _, sizing := range p.Deployments {
+				dst.Profile.Deployments = append(dst.Profile.Deployments, appsv1beta1.APIManagerDeploymentSizing(sizing))
+			}
+		}
+	}
+	if src.Certificates != nil {
+		dst.Certificates = make([]appsv1beta1.APIManagerCertificateStatus, 0, len(src.Certificates))
+		for _, certificate := range src.Certificates {
+			dst.Certificates = append(dst.Certificates, appsv1beta1.APIManagerCertificateStatus(certificate))
+		}
+	}
+	return dst
+}
+
+func convertStatusFromV1beta1(src *appsv1beta1.APIManagerStatus) APIManagerStatus {
+	dst := APIManagerStatus{
+		Conditions:  src.Conditions,
+		Deployments: src.Deployments,
+	}
+	if c := src.Components; c != nil {
+		dst.Components = &APIManagerComponentsStatus{
+			Apicast:   convertComponentStatusFromV1beta1(c.Apicast),
+			Backend:   convertComponentStatusFromV1beta1(c.Backend),
+			System:    convertComponentStatusFromV1beta1(c.System),
+			Zync:      convertComponentStatusFromV1beta1(c.Zync),
+			Memcached: convertComponentStatusFromV1beta1(c.Memcached),
+			Searchd:   convertComponentStatusFromV1beta1(c.Searchd),
+		}
+	}
+	if p := src.Profile; p != nil {
+		dst.Profile = &APIManagerProfileStatus{Name: APIManagerProfile(p.Name)}
+		if p.Deployments != nil {
+			dst.Profile.Deployments = make([]APIManagerDeploymentSizing, 0, len(p.Deployments))
+			for _, sizing := range p.Deployments {
+				dst.Profile.Deployments = append(dst.Profile.Deployments, APIManagerDeploymentSizing(sizing))
+			}
+		}
+	}
+	if src.Certificates != nil {
+		dst.Certificates = make([]APIManagerCertificateStatus, 0, len(src.Certificates))
+		for _, certificate := range src.Certificates {
+			dst.Certificates = append(dst.Certificates, APIManagerCertificateStatus(certificate))
+		}
+	}
+	return dst
+}
+
+func convertComponentStatusToV1beta1(src *APIManagerComponentStatus) *appsv1beta1.APIManagerComponentStatus {
+	if src == nil {
+		return nil
+	}
+	dst := &appsv1beta1.APIManagerComponentStatus{
+		Ready:           src.Ready,
+		ReadyReplicas:   src.ReadyReplicas,
+		DesiredReplicas: src.DesiredReplicas,
+		Image:           src.Image,
+		Version:         src.Version,
+		LastRolloutTime: src.LastRolloutTime,
+		Reason:          src.Reason,
+		Message:         src.Message,
+	}
+	if src.Endpoints != nil {
+		dst.Endpoints = make([]appsv1beta1.APIManagerComponentEndpoint, 0, len(src.Endpoints))
+		for _, endpoint := range src.Endpoints {
+			dst.Endpoints = append(dst.Endpoints, appsv1beta1.APIManagerComponentEndpoint(endpoint))
+		}
+	}
+	return dst
+}
+
+func convertComponentStatusFromV1beta1(src *appsv1beta1.APIManagerComponentStatus) *APIManagerComponentStatus {
+	if src == nil {
+		return nil
+	}
+	dst := &APIManagerComponentStatus{
+		Ready:           src.Ready,
+		ReadyReplicas:   src.ReadyReplicas,
+		DesiredReplicas: src.DesiredReplicas,
+		Image:           src.Image,
+		Version:         src.Version,
+		LastRolloutTime: src.LastRolloutTime,
+		Reason:          src.Reason,
+		Message:         src.Message,
+	}
+	if src.Endpoints != nil {
+		dst.Endpoints = make([]APIManagerComponentEndpoint, 0, len(src.Endpoints))
+		for _, endpoint := range src.Endpoints {
+			dst.Endpoints = append(dst.Endpoints, APIManagerComponentEndpoint(endpoint))
+		}
+	}
+	return dst
+}
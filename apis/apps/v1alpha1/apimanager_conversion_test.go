@@ -0,0 +1,226 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1beta1 "github.com/3scale/3scale-operator/apis/apps/v1beta1"
+)
+
+var (
+	quantityType = reflect.TypeOf(resource.Quantity{})
+	timeType     = reflect.TypeOf(metav1.Time{})
+)
+
+// fieldFiller sets every field reachable from a value, each leaf to a different value,
+// so fields swapped or dropped by the conversion are detected
+type fieldFiller struct {
+	counter int
+}
+
+func (f *fieldFiller) fill(value reflect.Value) {
+	f.counter++
+	switch value.Kind() {
+	case reflect.Ptr:
+		value.Set(reflect.New(value.Type().Elem()))
+		f.fill(value.Elem())
+	case reflect.Struct:
+		switch value.Type() {
+		case quantityType:
+			value.Set(reflect.ValueOf(resource.MustParse(fmt.Sprintf("%dMi", f.counter))))
+		case timeType:
+			value.Set(reflect.ValueOf(metav1.NewTime(time.Unix(int64(f.counter), 0))))
+		default:
+			for idx := 0; idx < value.NumField(); idx++ {
+				if value.Field(idx).CanSet() {
+					f.fill(value.Field(idx))
+				}
+			}
+		}
+	case reflect.Slice:
+		value.Set(reflect.MakeSlice(value.Type(), 1, 1))
+		f.fill(value.Index(0))
+	case reflect.Map:
+		key := reflect.New(value.Type().Key()).Elem()
+		f.fill(key)
+		elem := reflect.New(value.Type().Elem()).Elem()
+		f.fill(elem)
+		value.Set(reflect.MakeMap(value.Type()))
+		value.SetMapIndex(key, elem)
+	case reflect.String:
+		value.SetString(fmt.Sprintf("value-%d", f.counter))
+	case reflect.Bool:
+		value.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value.SetInt(int64(f.counter))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value.SetUint(uint64(f.counter))
+	case reflect.Float32, reflect.Float64:
+		value.SetFloat(float64(f.counter))
+	}
+}
+
+func filledAPIManager() *APIManager {
+	apimanager := &APIManager{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "example-apimanager",
+			Namespace:   "3scale",
+			Annotations: map[string]string{"annotation": "value"},
+		},
+	}
+	filler := &fieldFiller{}
+	filler.fill(reflect.ValueOf(&apimanager.Spec).Elem())
+	filler.fill(reflect.ValueOf(&apimanager.Status).Elem())
+	return apimanager
+}
+
+func filledHubAPIManager() *appsv1beta1.APIManager {
+	apimanager := &appsv1beta1.APIManager{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "example-apimanager",
+			Namespace:   "3scale",
+			Annotations: map[string]string{"annotation": "value"},
+		},
+	}
+	filler := &fieldFiller{}
+	filler.fill(reflect.ValueOf(&apimanager.Spec).Elem())
+	filler.fill(reflect.ValueOf(&apimanager.Status).Elem())
+	return apimanager
+}
+
+func TestAPIManagerConversionRoundTrip(t *testing.T) {
+	cases := []struct {
+		testName   string
+		apimanager *APIManager
+	}{
+		{"every field", filledAPIManager()},
+		{"empty", &APIManager{}},
+		{"deprecated fields only", &APIManager{
+			Spec: APIManagerSpec{
+				HighAvailability: &HighAvailabilitySpec{Enabled: true},
+				Apicast: &ApicastSpec{
+					ProductionSpec: &ApicastProductionSpec{OpenTracing: &APIcastOpenTracingSpec{}},
+					StagingSpec:    &ApicastStagingSpec{OpenTracing: &APIcastOpenTracingSpec{}},
+				},
+				System: &SystemSpec{
+					SphinxSpec:      &SystemSphinxSpec{},
+					FileStorageSpec: &SystemFileStorageSpec{DeprecatedS3: &DeprecatedSystemS3Spec{AWSBucket: "bucket"}},
+				},
+			},
+		}},
+		{"empty components", &APIManager{
+			Spec: APIManagerSpec{
+				Apicast: &ApicastSpec{ProductionSpec: &ApicastProductionSpec{}, StagingSpec: &ApicastStagingSpec{}},
+				Backend: &BackendSpec{},
+				System:  &SystemSpec{DatabaseSpec: &SystemDatabaseSpec{}, FileStorageSpec: &SystemFileStorageSpec{}},
+				Zync:    &ZyncSpec{},
+			},
+		}},
+		{"database TLS only", &APIManager{
+			Spec: APIManagerSpec{
+				System: &SystemSpec{SystemDatabaseTLSEnabled: &[]bool{true}[0]},
+			},
+		}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			original := tc.apimanager.DeepCopy()
+
+			hub := &appsv1beta1.APIManager{}
+			if err := tc.apimanager.ConvertTo(hub); err != nil {
+				subT.Fatal(err)
+			}
+			converted := &APIManager{}
+			if err := converted.ConvertFrom(hub); err != nil {
+				subT.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(original, converted) {
+				subT.Errorf("round trip through v1beta1 is not lossless: %s", cmp.Diff(original, converted))
+			}
+			if !reflect.DeepEqual(original, tc.apimanager) {
+				subT.Errorf("conversion modified the source: %s", cmp.Diff(original, tc.apimanager))
+			}
+		})
+	}
+}
+
+func TestAPIManagerHubConversionRoundTrip(t *testing.T) {
+	cases := []struct {
+		testName   string
+		apimanager *appsv1beta1.APIManager
+	}{
+		{"every field", filledHubAPIManager()},
+		{"empty", &appsv1beta1.APIManager{}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			original := tc.apimanager.DeepCopy()
+
+			spoke := &APIManager{}
+			if err := spoke.ConvertFrom(tc.apimanager); err != nil {
+				subT.Fatal(err)
+			}
+			converted := &appsv1beta1.APIManager{}
+			if err := spoke.ConvertTo(converted); err != nil {
+				subT.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(original, converted) {
+				subT.Errorf("round trip through v1alpha1 is not lossless: %s", cmp.Diff(original, converted))
+			}
+		})
+	}
+}
+
+func TestAPIManagerConversionDeprecatedFields(t *testing.T) {
+	apimanager := filledAPIManager()
+
+	hub := &appsv1beta1.APIManager{}
+	if err := apimanager.ConvertTo(hub); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := hub.Annotations[DeprecatedFieldsAnnotation]; !ok {
+		t.Fatalf("deprecated fields annotation not set: %v", hub.Annotations)
+	}
+	if hub.Annotations["annotation"] != "value" {
+		t.Errorf("annotations not kept: %v", hub.Annotations)
+	}
+
+	// the annotation is not exposed in v1alpha1
+	converted := &APIManager{}
+	if err := converted.ConvertFrom(hub); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := converted.Annotations[DeprecatedFieldsAnnotation]; ok {
+		t.Errorf("deprecated fields annotation exposed in v1alpha1: %v", converted.Annotations)
+	}
+
+	// a stale annotation does not restore removed fields
+	converted.Annotations[DeprecatedFieldsAnnotation] = hub.Annotations[DeprecatedFieldsAnnotation]
+	converted.Spec.HighAvailability = nil
+	converted.Spec.Apicast.ProductionSpec.OpenTracing = nil
+	converted.Spec.Apicast.StagingSpec.OpenTracing = nil
+	converted.Spec.System.SphinxSpec = nil
+	converted.Spec.System.FileStorageSpec.DeprecatedS3 = nil
+	hub = &appsv1beta1.APIManager{}
+	if err := converted.ConvertTo(hub); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := hub.Annotations[DeprecatedFieldsAnnotation]; ok {
+		t.Errorf("stale deprecated fields annotation kept: %v", hub.Annotations)
+	}
+
+	hub.Annotations = map[string]string{DeprecatedFieldsAnnotation: "{"}
+	if err := converted.ConvertFrom(hub); err == nil {
+		t.Error("expected an error with an invalid annotation")
+	}
+}
@@ -18,7 +18,10 @@ package v1alpha1
 
 import (
 	"fmt"
+	"net/mail"
 	"reflect"
+	"slices"
+	"strings"
 
 	"github.com/RHsyseng/operator-utils/pkg/olm"
 	"github.com/go-logr/logr"
@@ -42,6 +45,15 @@ const (
 	Default3scaleAppLabel           = "3scale-api-management"
 	ThreescaleRequirementsConfirmed = "apps.3scale.net/apimanager-confirmed-requirements-version"
 	DisableAsyncAnnotation          = "apps.3scale.net/disable-async"
+	// SystemStorageMigrationConfirmedAnnotation confirms the system-storage PVC can be removed
+	// once its contents have been migrated to the S3 bucket
+	SystemStorageMigrationConfirmedAnnotation = "apps.3scale.net/system-storage-migration-confirmed"
+	// SystemStorageMigratedAnnotation is set by the operator once the system-storage PVC contents
+	// have been copied to the S3 bucket and System switched to S3
+	SystemStorageMigratedAnnotation = "apps.3scale.net/system-storage-migrated"
+	// SMTPTestEmailAnnotation holds the recipient of a probe email sent through the system SMTP configuration.
+	// Changing the recipient sends a new one
+	SMTPTestEmailAnnotation = "apps.3scale.net/smtp-test-email"
 )
 
 const (
@@ -84,6 +96,12 @@ type APIManagerSpec struct {
 	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
 	// +optional
 	Monitoring *MonitoringSpec `json:"monitoring,omitempty"`
+	// +optional
+	NetworkPolicies *NetworkPoliciesSpec `json:"networkPolicies,omitempty"`
+	// +optional
+	Maintenance *MaintenanceSpec `json:"maintenance,omitempty"`
+	// +optional
+	Certificates *CertificatesSpec `json:"certificates,omitempty"`
 }
 
 // APIManagerStatus defines the observed state of APIManager
@@ -101,6 +119,139 @@ type APIManagerStatus struct {
 	// APIManager Deployments
 	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Deployments",xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses"
 	Deployments olm.DeploymentStatus `json:"deployments"`
+
+	// Per component status
+	// +optional
+	Components *APIManagerComponentsStatus `json:"components,omitempty"`
+
+	// Sizing profile and the effective sizing of the Deployments
+	// +optional
+	Profile *APIManagerProfileStatus `json:"profile,omitempty"`
+
+	// Expiry of the TLS certificates referenced by the APIManager
+	// +optional
+	Certificates []APIManagerCertificateStatus `json:"certificates,omitempty"`
+}
+
+// certificateStatusesEqual compares the expiry times as instants, the status read from
+// the API server has a different location than the parsed certificates
+func certificateStatusesEqual(a, b []APIManagerCertificateStatus) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for idx := range a {
+		if a[idx].Name != b[idx].Name || a[idx].Kind != b[idx].Kind || a[idx].ObjectName != b[idx].ObjectName ||
+			a[idx].Subject != b[idx].Subject || !a[idx].NotAfter.Equal(&b[idx].NotAfter) {
+			return false
+		}
+	}
+	return true
+}
+
+type APIManagerCertificateStatus struct {
+	// Name identifies the certificate, i.e. apicast-production-https or route-backend
+	Name string `json:"name"`
+
+	// Kind of the object holding the certificate, Secret or Route
+	Kind string `json:"kind"`
+
+	// ObjectName is the name of the object holding the certificate
+	ObjectName string `json:"objectName"`
+
+	// Subject of the certificate
+	// +optional
+	Subject string `json:"subject,omitempty"`
+
+	// NotAfter is the expiry time of the certificate
+	NotAfter metav1.Time `json:"notAfter"`
+}
+
+type APIManagerProfileStatus struct {
+	// Name of the selected sizing profile
+	Name APIManagerProfile `json:"name"`
+
+	// Effective sizing of the Deployments
+	// +optional
+	Deployments []APIManagerDeploymentSizing `json:"deployments,omitempty"`
+}
+
+type APIManagerDeploymentSizing struct {
+	// Name of the Deployment
+	Name string `json:"name"`
+
+	// Replicas of the Deployment
+	Replicas int32 `json:"replicas"`
+
+	// MinReplicas of the Deployment HPA
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas of the Deployment HPA
+	// +optional
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+
+	// Workers of the Deployment main process
+	// +optional
+	Workers *int32 `json:"workers,omitempty"`
+
+	// Resources of the Deployment containers, indexed by container name
+	// +optional
+	Resources map[string]v1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+type APIManagerComponentsStatus struct {
+	// +optional
+	Apicast *APIManagerComponentStatus `json:"apicast,omitempty"`
+	// +optional
+	Backend *APIManagerComponentStatus `json:"backend,omitempty"`
+	// +optional
+	System *APIManagerComponentStatus `json:"system,omitempty"`
+	// +optional
+	Zync *APIManagerComponentStatus `json:"zync,omitempty"`
+	// +optional
+	Memcached *APIManagerComponentStatus `json:"memcached,omitempty"`
+	// +optional
+	Searchd *APIManagerComponentStatus `json:"searchd,omitempty"`
+}
+
+type APIManagerComponentStatus struct {
+	// Ready is true when all the Deployments of the component are available
+	Ready bool `json:"ready"`
+
+	// ReadyReplicas is the number of ready pods summed over the Deployments of the component
+	ReadyReplicas int32 `json:"readyReplicas"`
+
+	// DesiredReplicas is the number of desired pods summed over the Deployments of the component
+	DesiredReplicas int32 `json:"desiredReplicas"`
+
+	// Image running in the main container of the component
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Version of the component
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// LastRolloutTime is the last time any of the Deployments of the component progressed
+	// +optional
+	LastRolloutTime *metav1.Time `json:"lastRolloutTime,omitempty"`
+
+	// Reason of the component degradation. Empty when the component is ready
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message describing the component degradation. Empty when the component is ready
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Externally reachable URLs of the component
+	// +optional
+	Endpoints []APIManagerComponentEndpoint `json:"endpoints,omitempty"`
+}
+
+type APIManagerComponentEndpoint struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
 }
 
 func (s *APIManagerStatus) Equals(other *APIManagerStatus, logger logr.Logger) bool {
@@ -121,6 +272,24 @@ func (s *APIManagerStatus) Equals(other *APIManagerStatus, logger logr.Logger) b
 		return false
 	}
 
+	if !reflect.DeepEqual(s.Components, other.Components) {
+		diff := cmp.Diff(s.Components, other.Components)
+		logger.V(1).Info("Components not equal", "difference", diff)
+		return false
+	}
+
+	if !reflect.DeepEqual(s.Profile, other.Profile) {
+		diff := cmp.Diff(s.Profile, other.Profile)
+		logger.V(1).Info("Profile not equal", "difference", diff)
+		return false
+	}
+
+	if !certificateStatusesEqual(s.Certificates, other.Certificates) {
+		diff := cmp.Diff(s.Certificates, other.Certificates)
+		logger.V(1).Info("Certificates not equal", "difference", diff)
+		return false
+	}
+
 	return true
 }
 
@@ -149,9 +318,12 @@ type APIManager struct {
 }
 
 const (
-	APIManagerAvailableConditionType  common.ConditionType = "Available"
-	APIManagerWarningConditionType    common.ConditionType = "Warning"
-	APIManagerPreflightsConditionType common.ConditionType = "Preflights"
+	APIManagerAvailableConditionType           common.ConditionType = "Available"
+	APIManagerWarningConditionType             common.ConditionType = "Warning"
+	APIManagerPreflightsConditionType          common.ConditionType = "Preflights"
+	APIManagerMaintenanceConditionType         common.ConditionType = "Maintenance"
+	APIManagerSMTPTestEmailConditionType       common.ConditionType = "SMTPTestEmail"
+	APIManagerCertificateExpiringConditionType common.ConditionType = "CertificateExpiring"
 )
 
 type APIManagerCommonSpec struct {
@@ -166,25 +338,118 @@ type APIManagerCommonSpec struct {
 	ResourceRequirementsEnabled *bool `json:"resourceRequirementsEnabled,omitempty"`
 	// +optional
 	ImagePullSecrets []v1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	// Profile sets the default replicas, resources, HPA bounds and workers of every component.
+	// Values set explicitly on a component have priority over the profile
+	// +kubebuilder:validation:Enum=evaluation;small;medium;large;xlarge
+	// +optional
+	Profile *APIManagerProfile `json:"profile,omitempty"`
 }
 
+type APIManagerProfile string
+
+const (
+	APIManagerProfileEvaluation APIManagerProfile = "evaluation"
+	APIManagerProfileSmall      APIManagerProfile = "small"
+	APIManagerProfileMedium     APIManagerProfile = "medium"
+	APIManagerProfileLarge      APIManagerProfile = "large"
+	APIManagerProfileXLarge     APIManagerProfile = "xlarge"
+)
+
 // CustomEnvironmentSpec contains or has reference to an APIcast custom environment
 type CustomEnvironmentSpec struct {
 	SecretRef *v1.LocalObjectReference `json:"secretRef"`
 }
 
-// CustomPolicySpec contains or has reference to an APIcast custom policy
+// CustomPolicySpec contains or has reference to an APIcast custom policy.
+// The policy is loaded from exactly one of a secret, a configmap or an OCI image
 type CustomPolicySpec struct {
 	// Name specifies the name of the custom policy
 	Name string `json:"name"`
-	// Version specifies the name of the custom policy
-	Version string `json:"version"`
+	// Version specifies the version of the custom policy.
+	// Defaults to the image tag with the image source
+	// +optional
+	Version string `json:"version,omitempty"`
 	// SecretRef specifies the secret holding the custom policy metadata and lua code
-	SecretRef *v1.LocalObjectReference `json:"secretRef"`
+	// +optional
+	SecretRef *v1.LocalObjectReference `json:"secretRef,omitempty"`
+	// ConfigMapRef specifies the configmap holding the custom policy metadata and lua code
+	// +optional
+	ConfigMapRef *v1.LocalObjectReference `json:"configMapRef,omitempty"`
+	// Image specifies the OCI image holding the custom policy directory at /policy.
+	// An init container copies the directory, so the image must provide the cp command
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	Image *string `json:"image,omitempty"`
+}
+
+// PolicyVersion returns the version of the custom policy, the image tag when the version is not set
+func (c *CustomPolicySpec) PolicyVersion() string {
+	if c.Version != "" || c.Image == nil {
+		return c.Version
+	}
+	return imageTag(*c.Image)
 }
 
 func (c *CustomPolicySpec) VersionName() string {
-	return fmt.Sprintf("%s%s", c.Name, c.Version)
+	return fmt.Sprintf("%s%s", c.Name, c.PolicyVersion())
+}
+
+// imageTag returns the tag of the image reference, empty when the reference has no tag
+func imageTag(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+	lastSlash := strings.LastIndex(image, "/")
+	lastColon := strings.LastIndex(image, ":")
+	if lastColon <= lastSlash {
+		// no tag, or the colon belongs to the registry port
+		return ""
+	}
+	return image[lastColon+1:]
+}
+
+func validateCustomPolicies(fldPath *field.Path, customPolicies []CustomPolicySpec) field.ErrorList {
+	fieldErrors := field.ErrorList{}
+
+	duplicatePolicyMap := make(map[string]int)
+	for idx, customPolicySpec := range customPolicies {
+		customPoliciesIdxFldPath := fldPath.Index(idx)
+
+		// check exactly one custom policy source is set
+		sources := 0
+		if customPolicySpec.SecretRef != nil {
+			sources++
+			if customPolicySpec.SecretRef.Name == "" {
+				fieldErrors = append(fieldErrors, field.Invalid(customPoliciesIdxFldPath, customPolicySpec, "custom policy secret name is empty"))
+			}
+		}
+		if customPolicySpec.ConfigMapRef != nil {
+			sources++
+			if customPolicySpec.ConfigMapRef.Name == "" {
+				fieldErrors = append(fieldErrors, field.Invalid(customPoliciesIdxFldPath, customPolicySpec, "custom policy configmap name is empty"))
+			}
+		}
+		if customPolicySpec.Image != nil {
+			sources++
+		}
+		switch {
+		case sources == 0:
+			fieldErrors = append(fieldErrors, field.Invalid(customPoliciesIdxFldPath, customPolicySpec, "custom policy secret, configmap or image is mandatory"))
+		case sources > 1:
+			fieldErrors = append(fieldErrors, field.Invalid(customPoliciesIdxFldPath, customPolicySpec, "only one of custom policy secret, configmap or image can be set"))
+		}
+
+		if customPolicySpec.PolicyVersion() == "" {
+			fieldErrors = append(fieldErrors, field.Required(customPoliciesIdxFldPath.Child("version"), "custom policy version is mandatory unless set by the image tag"))
+		}
+
+		// check duplicated custom policy version name
+		if _, ok := duplicatePolicyMap[customPolicySpec.VersionName()]; ok {
+			fieldErrors = append(fieldErrors, field.Invalid(customPoliciesIdxFldPath, customPolicySpec, "custom policy name version tuple is duplicated"))
+			break
+		}
+		duplicatePolicyMap[customPolicySpec.VersionName()] = 0
+	}
+
+	return fieldErrors
 }
 
 type ApicastSpec struct {
@@ -271,6 +536,48 @@ type ApicastProductionSpec struct {
 	// ServiceCacheSize specifies the number of services that APICast can store in the internal cache
 	// +optional
 	ServiceCacheSize *int32 `json:"serviceCacheSize,omitempty"` // APICAST_SERVICE_CACHE_SIZE
+	// ConfigurationLoadMode sets when APIcast loads the configuration, either on startup (boot) or on the first request (lazy).
+	// Defaults to boot
+	// +kubebuilder:validation:Enum=boot;lazy
+	// +optional
+	ConfigurationLoadMode *string `json:"configurationLoadMode,omitempty"` // APICAST_CONFIGURATION_LOADER
+	// CacheConfigurationSeconds sets the period in seconds the configuration is cached for.
+	// -1 never reloads the configuration, 0 disables the cache and is only supported with the lazy load mode.
+	// Defaults to 300
+	// +kubebuilder:validation:Minimum=-1
+	// +optional
+	CacheConfigurationSeconds *int64 `json:"cacheConfigurationSeconds,omitempty"` // APICAST_CONFIGURATION_CACHE
+	// PathRoutingEnabled matches the request path, in addition to the host, to select the service
+	// +optional
+	PathRoutingEnabled *bool `json:"pathRoutingEnabled,omitempty"` // APICAST_PATH_ROUTING
+	// PathRoutingOnly selects the service by the request path only
+	// +optional
+	PathRoutingOnly *bool `json:"pathRoutingOnly,omitempty"` // APICAST_PATH_ROUTING_ONLY
+	// CacheMaxTime is the maximum time a response is cached when the upstream response does not set Cache-Control.
+	// Format is a number with an optional s, m, h or d unit, e.g. 1m
+	// +kubebuilder:validation:Pattern=`^[0-9]+[smhd]?$`
+	// +optional
+	CacheMaxTime *string `json:"cacheMaxTime,omitempty"` // APICAST_CACHE_MAX_TIME
+	// CacheStatusCodes is the space separated list of upstream response status codes that are cached, e.g. "200 302"
+	// +kubebuilder:validation:Pattern=`^[1-5][0-9]{2}( [1-5][0-9]{2})*$`
+	// +optional
+	CacheStatusCodes *string `json:"cacheStatusCodes,omitempty"` // APICAST_CACHE_STATUS_CODES
+	// UpstreamRetryCases is the space separated list of cases a request is retried on the next upstream server,
+	// e.g. "error timeout http_503". Used by the retry policy
+	// +optional
+	UpstreamRetryCases *string `json:"upstreamRetryCases,omitempty"` // APICAST_UPSTREAM_RETRY_CASES
+	// HTTPKeepaliveTimeout sets the timeout in seconds a keep-alive client connection stays open on the server side
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	HTTPKeepaliveTimeout *int32 `json:"httpKeepaliveTimeout,omitempty"` // HTTP_KEEPALIVE_TIMEOUT
+	// BatcherSharedMemorySize sets the shared memory size of the batcher policy, e.g. 20m
+	// +kubebuilder:validation:Pattern=`^[0-9]+[kKmM]?$`
+	// +optional
+	BatcherSharedMemorySize *string `json:"batcherSharedMemorySize,omitempty"` // APICAST_POLICY_BATCHER_SHARED_MEMORY_SIZE
+	// AccessLogFile sets the file the access logs are written to. Defaults to /dev/stdout
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	AccessLogFile *string `json:"accessLogFile,omitempty"` // APICAST_ACCESS_LOG_FILE
 	// +optional
 	PriorityClassName *string `json:"priorityClassName,omitempty"`
 	// +optional
@@ -342,6 +649,48 @@ type ApicastStagingSpec struct {
 	// ServiceCacheSize specifies the number of services that APICast can store in the internal cache
 	// +optional
 	ServiceCacheSize *int32 `json:"serviceCacheSize,omitempty"` // APICAST_SERVICE_CACHE_SIZE
+	// ConfigurationLoadMode sets when APIcast loads the configuration, either on startup (boot) or on the first request (lazy).
+	// Defaults to lazy
+	// +kubebuilder:validation:Enum=boot;lazy
+	// +optional
+	ConfigurationLoadMode *string `json:"configurationLoadMode,omitempty"` // APICAST_CONFIGURATION_LOADER
+	// CacheConfigurationSeconds sets the period in seconds the configuration is cached for.
+	// -1 never reloads the configuration, 0 disables the cache and is only supported with the lazy load mode.
+	// Defaults to 0
+	// +kubebuilder:validation:Minimum=-1
+	// +optional
+	CacheConfigurationSeconds *int64 `json:"cacheConfigurationSeconds,omitempty"` // APICAST_CONFIGURATION_CACHE
+	// PathRoutingEnabled matches the request path, in addition to the host, to select the service
+	// +optional
+	PathRoutingEnabled *bool `json:"pathRoutingEnabled,omitempty"` // APICAST_PATH_ROUTING
+	// PathRoutingOnly selects the service by the request path only
+	// +optional
+	PathRoutingOnly *bool `json:"pathRoutingOnly,omitempty"` // APICAST_PATH_ROUTING_ONLY
+	// CacheMaxTime is the maximum time a response is cached when the upstream response does not set Cache-Control.
+	// Format is a number with an optional s, m, h or d unit, e.g. 1m
+	// +kubebuilder:validation:Pattern=`^[0-9]+[smhd]?$`
+	// +optional
+	CacheMaxTime *string `json:"cacheMaxTime,omitempty"` // APICAST_CACHE_MAX_TIME
+	// CacheStatusCodes is the space separated list of upstream response status codes that are cached, e.g. "200 302"
+	// +kubebuilder:validation:Pattern=`^[1-5][0-9]{2}( [1-5][0-9]{2})*$`
+	// +optional
+	CacheStatusCodes *string `json:"cacheStatusCodes,omitempty"` // APICAST_CACHE_STATUS_CODES
+	// UpstreamRetryCases is the space separated list of cases a request is retried on the next upstream server,
+	// e.g. "error timeout http_503". Used by the retry policy
+	// +optional
+	UpstreamRetryCases *string `json:"upstreamRetryCases,omitempty"` // APICAST_UPSTREAM_RETRY_CASES
+	// HTTPKeepaliveTimeout sets the timeout in seconds a keep-alive client connection stays open on the server side
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	HTTPKeepaliveTimeout *int32 `json:"httpKeepaliveTimeout,omitempty"` // HTTP_KEEPALIVE_TIMEOUT
+	// BatcherSharedMemorySize sets the shared memory size of the batcher policy, e.g. 20m
+	// +kubebuilder:validation:Pattern=`^[0-9]+[kKmM]?$`
+	// +optional
+	BatcherSharedMemorySize *string `json:"batcherSharedMemorySize,omitempty"` // APICAST_POLICY_BATCHER_SHARED_MEMORY_SIZE
+	// AccessLogFile sets the file the access logs are written to. Defaults to /dev/stdout
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	AccessLogFile *string `json:"accessLogFile,omitempty"` // APICAST_ACCESS_LOG_FILE
 	// +optional
 	PriorityClassName *string `json:"priorityClassName,omitempty"`
 	// +optional
@@ -379,6 +728,9 @@ type BackendSpec struct {
 	WorkerSpec *BackendWorkerSpec `json:"workerSpec,omitempty"`
 	// +optional
 	CronSpec *BackendCronSpec `json:"cronSpec,omitempty"`
+	// OpenTelemetry configures the instrumentation of the listener, worker and cron
+	// +optional
+	OpenTelemetry *ComponentOpenTelemetrySpec `json:"openTelemetry,omitempty"`
 }
 
 type BackendRedisPersistentVolumeClaimSpec struct {
@@ -519,6 +871,67 @@ type SystemSpec struct {
 
 	// +optional
 	SystemDatabaseTLSEnabled *bool `json:"systemDatabaseTLSEnabled,omitempty"`
+
+	// SMTP configures the delivery of the system emails. When set, the operator
+	// manages the system-smtp secret from it
+	// +optional
+	SMTP *SystemSMTPSpec `json:"smtp,omitempty"`
+
+	// OpenTelemetry configures the instrumentation of system-app and system-sidekiq
+	// +optional
+	OpenTelemetry *ComponentOpenTelemetrySpec `json:"openTelemetry,omitempty"`
+}
+
+type SMTPAuthenticationMethod string
+
+const (
+	SMTPAuthenticationPlain   SMTPAuthenticationMethod = "plain"
+	SMTPAuthenticationLogin   SMTPAuthenticationMethod = "login"
+	SMTPAuthenticationCramMD5 SMTPAuthenticationMethod = "cram_md5"
+	SMTPAuthenticationNone    SMTPAuthenticationMethod = "none"
+)
+
+type SMTPTLSMode string
+
+const (
+	// SMTPTLSModeNone sends the emails unencrypted
+	SMTPTLSModeNone SMTPTLSMode = "none"
+	// SMTPTLSModeStartTLS upgrades the connection with STARTTLS, failing when the server does not support it
+	SMTPTLSModeStartTLS SMTPTLSMode = "starttls"
+	// SMTPTLSModeTLS connects with implicit TLS (SMTPS)
+	SMTPTLSModeTLS SMTPTLSMode = "tls"
+)
+
+type SystemSMTPSpec struct {
+	// Host of the SMTP server
+	// +kubebuilder:validation:MinLength=1
+	Host string `json:"host"`
+	// Port of the SMTP server. Defaults to 587, or 465 with the tls mode
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+	// AuthenticationMethod with the SMTP server. Defaults to plain, or none without credentials
+	// +kubebuilder:validation:Enum=plain;login;cram_md5;none
+	// +optional
+	AuthenticationMethod *SMTPAuthenticationMethod `json:"authenticationMethod,omitempty"`
+	// TLSMode of the connection to the SMTP server. Defaults to starttls
+	// +kubebuilder:validation:Enum=none;starttls;tls
+	// +optional
+	TLSMode *SMTPTLSMode `json:"tlsMode,omitempty"`
+	// InsecureSkipVerify disables the verification of the SMTP server certificate
+	// +optional
+	InsecureSkipVerify *bool `json:"insecureSkipVerify,omitempty"`
+	// FromAddress of the system emails
+	// +kubebuilder:validation:Pattern=`^[^@\s]+@[^@\s]+$`
+	FromAddress string `json:"fromAddress"`
+	// Domain sent in the HELO command
+	// +optional
+	Domain *string `json:"domain,omitempty"`
+	// CredentialsSecretRef references the secret with the username and password keys.
+	// Required unless the authentication method is none
+	// +optional
+	CredentialsSecretRef *v1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
 }
 
 type SystemAppSpec struct {
@@ -700,6 +1113,51 @@ type SystemPostgreSQLSpec struct {
 	Labels map[string]string `json:"labels,omitempty"`
 	// +optional
 	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// ConnectionPooler deploys PgBouncer in front of the system database.
+	// It is also honored when the system database is external.
+	// +optional
+	ConnectionPooler *SystemDatabaseConnectionPoolerSpec `json:"connectionPooler,omitempty"`
+}
+
+type SystemDatabaseConnectionPoolerSpec struct {
+	// Enabled controls whether PgBouncer is deployed and the system
+	// components connect to the database through it.
+	// By default it is not enabled.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// +optional
+	Image *string `json:"image,omitempty"`
+	// +optional
+	Replicas *int64 `json:"replicas,omitempty"`
+
+	// PoolMode specifies when a server connection can be reused by other clients.
+	// +kubebuilder:validation:Enum=session;transaction
+	// +optional
+	PoolMode *string `json:"poolMode,omitempty"`
+	// DefaultPoolSize is the number of server connections allowed per user/database pair.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	DefaultPoolSize *int32 `json:"defaultPoolSize,omitempty"`
+	// MaxClientConnections is the maximum number of client connections allowed.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxClientConnections *int32 `json:"maxClientConnections,omitempty"`
+
+	// +optional
+	Affinity *v1.Affinity `json:"affinity,omitempty"`
+	// +optional
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+	// +optional
+	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+	// +optional
+	PriorityClassName *string `json:"priorityClassName,omitempty"`
+	// +optional
+	TopologySpreadConstraints []v1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
 type ZyncSpec struct {
@@ -734,6 +1192,9 @@ type ZyncSpec struct {
 	DatabaseAnnotations map[string]string `json:"databaseAnnotations,omitempty"`
 	// +optional
 	ZyncDatabaseTLSEnabled *bool `json:"zyncDatabaseTLSEnabled,omitempty"`
+	// OpenTelemetry configures the instrumentation of zync and zync-que
+	// +optional
+	OpenTelemetry *ComponentOpenTelemetrySpec `json:"openTelemetry,omitempty"`
 }
 
 type ZyncAppSpec struct {
@@ -814,6 +1275,129 @@ type MonitoringSpec struct {
 	Enabled bool `json:"enabled,omitempty"`
 	// +optional
 	EnablePrometheusRules *bool `json:"enablePrometheusRules,omitempty"`
+	// EnableDatastoreExporters deploys the redis, mysql and postgresql exporters
+	// of the 3scale datastores along with their monitoring resources.
+	// Defaults to true when monitoring is enabled
+	// +optional
+	EnableDatastoreExporters *bool `json:"enableDatastoreExporters,omitempty"`
+	// AlertOverrides tunes the alerts of the generated PrometheusRules.
+	// Overridden alerts are kept in sync with the APIManager,
+	// the remaining alerts are only created.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	AlertOverrides []AlertOverrideSpec `json:"alertOverrides,omitempty"`
+	// Grafana configures the installed GrafanaDashboards
+	// +optional
+	Grafana *GrafanaSpec `json:"grafana,omitempty"`
+}
+
+// GrafanaSpec configures the placement of the GrafanaDashboards and the dashboards installed
+type GrafanaSpec struct {
+	// InstanceSelector labels select the Grafana instances the dashboards are installed in.
+	// Replaces the default apim-management=grafana selector with grafana-operator v5,
+	// added to the dashboard labels with grafana-operator v4.
+	// +optional
+	InstanceSelector map[string]string `json:"instanceSelector,omitempty"`
+	// Folder the dashboards are placed in
+	// +optional
+	Folder *string `json:"folder,omitempty"`
+	// Datasource is the name of the Prometheus datasource the dashboards query
+	// +optional
+	Datasource *string `json:"datasource,omitempty"`
+	// DisabledDashboards are the names of the built-in dashboards not installed, i.e. apicast-mainapp
+	// +optional
+	DisabledDashboards []string `json:"disabledDashboards,omitempty"`
+	// Dashboards are installed alongside the built-in ones
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Dashboards []GrafanaDashboardSpec `json:"dashboards,omitempty"`
+}
+
+// GrafanaDashboardSpec references a user supplied dashboard
+type GrafanaDashboardSpec struct {
+	// Name of the GrafanaDashboard
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+	// ConfigMapRef selects the ConfigMap key holding the dashboard JSON
+	ConfigMapRef v1.ConfigMapKeySelector `json:"configMapRef"`
+}
+
+// AlertOverrideSpec overrides the settings of a generated alert
+type AlertOverrideSpec struct {
+	// Name of the alert, i.e. ThreescaleApicastHttp4xxErrorRate
+	Name string `json:"name"`
+	// Enabled set to false removes the alert
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// Threshold replaces the value the alert expression is compared with
+	// +kubebuilder:validation:Pattern=`^-?[0-9]+(\.[0-9]+)?$`
+	// +optional
+	Threshold *string `json:"threshold,omitempty"`
+	// For is the time the alert condition must hold before firing, i.e. 5m
+	// +kubebuilder:validation:Pattern=`^([0-9]+(ms|s|m|h|d|w|y))+$`
+	// +optional
+	For *string `json:"for,omitempty"`
+	// Severity label of the alert
+	// +kubebuilder:validation:Enum=critical;warning;info
+	// +optional
+	Severity *string `json:"severity,omitempty"`
+	// Labels are added to the alert labels
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// IsEnabled returns whether the alert is generated. Alerts are enabled by default
+func (a *AlertOverrideSpec) IsEnabled() bool {
+	return a.Enabled == nil || *a.Enabled
+}
+
+type NetworkPoliciesSpec struct {
+	// Enabled controls whether the operator manages a NetworkPolicy
+	// for each 3scale component. By default it is not enabled.
+	Enabled bool `json:"enabled,omitempty"`
+	// IngressControllerNamespaceSelector selects the namespaces where the
+	// ingress controller runs. Defaults to the OpenShift router namespaces.
+	// +optional
+	IngressControllerNamespaceSelector *metav1.LabelSelector `json:"ingressControllerNamespaceSelector,omitempty"`
+	// MonitoringNamespaceSelector selects the namespaces allowed to scrape
+	// component metrics. Defaults to the OpenShift monitoring namespaces.
+	// +optional
+	MonitoringNamespaceSelector *metav1.LabelSelector `json:"monitoringNamespaceSelector,omitempty"`
+	// AllowedNamespaces lists extra namespaces allowed to reach the
+	// exposed components: apicast, backend-listener and system-app.
+	// +optional
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+}
+
+type MaintenanceMode string
+
+const (
+	// MaintenanceModeFull scales every Deployment to zero
+	MaintenanceModeFull MaintenanceMode = "full"
+	// MaintenanceModeReadOnly keeps the gateways and backend serving traffic
+	// and scales system-app and system-sidekiq to zero
+	MaintenanceModeReadOnly MaintenanceMode = "readOnly"
+)
+
+type MaintenanceSpec struct {
+	// Mode selects which Deployments are scaled to zero. The original replicas
+	// are restored when the maintenance field is removed
+	// +kubebuilder:validation:Enum=full;readOnly
+	Mode MaintenanceMode `json:"mode"`
+}
+
+const DefaultCertificateExpiryWarningDays int32 = 30
+
+// CertificatesSpec configures the expiry monitoring of the TLS certificates
+// referenced by the APIManager
+type CertificatesSpec struct {
+	// ExpiryWarningDays is the number of days before the expiry of a certificate
+	// the CertificateExpiring condition and alert are raised. Defaults to 30
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ExpiryWarningDays *int32 `json:"expiryWarningDays,omitempty"`
 }
 
 // PersistentVolumeClaimResources defines the resources configuration
@@ -859,6 +1443,69 @@ type OpenTelemetrySpec struct {
 	TracingConfigSecretKey *string `json:"tracingConfigSecretKey,omitempty"`
 }
 
+// ComponentOpenTelemetrySpec configures the OpenTelemetry instrumentation of the system, backend and zync components
+type ComponentOpenTelemetrySpec struct {
+	// Enabled controls whether the OpenTelemetry instrumentation is enabled.
+	// By default it is not enabled.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Endpoint is the OTLP endpoint traces are exported to. For example http://otel-collector:4318
+	// +kubebuilder:validation:Pattern=`^https?://`
+	Endpoint string `json:"endpoint"`
+
+	// SamplingRatio is the ratio of traces sampled when no parent span is sampled, between 0 and 1.
+	// Defaults to 1
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	// +optional
+	SamplingRatio *string `json:"samplingRatio,omitempty"`
+
+	// ResourceAttributes are added to the resource of every span
+	// +optional
+	ResourceAttributes map[string]string `json:"resourceAttributes,omitempty"`
+
+	// TLSSecretRef references a Secret with the `ca.crt` key to verify the endpoint certificate.
+	// The optional `tls.crt` and `tls.key` keys are used as client certificate
+	// +optional
+	TLSSecretRef *v1.LocalObjectReference `json:"tlsSecretRef,omitempty"`
+}
+
+// IsEnabled returns whether the instrumentation is enabled. It is safe to call on nil
+func (o *ComponentOpenTelemetrySpec) IsEnabled() bool {
+	return o != nil && o.Enabled != nil && *o.Enabled
+}
+
+func validateComponentOpenTelemetry(fldPath *field.Path, openTelemetrySpec *ComponentOpenTelemetrySpec) field.ErrorList {
+	fieldErrors := field.ErrorList{}
+	if !openTelemetrySpec.IsEnabled() {
+		return fieldErrors
+	}
+
+	if openTelemetrySpec.TLSSecretRef != nil && openTelemetrySpec.TLSSecretRef.Name == "" {
+		fieldErrors = append(fieldErrors, field.Invalid(fldPath.Child("tlsSecretRef"), openTelemetrySpec.TLSSecretRef, "tls secret name is empty"))
+	}
+
+	for key := range openTelemetrySpec.ResourceAttributes {
+		if key == "" || strings.ContainsAny(key, ",=") {
+			fieldErrors = append(fieldErrors, field.Invalid(fldPath.Child("resourceAttributes").Key(key), key, "resource attribute keys must be non empty and must not contain ',' or '='"))
+		}
+	}
+
+	return fieldErrors
+}
+
+func (a *APIManager) OpenTelemetryEnabledForSystem() bool {
+	return a.Spec.System != nil && a.Spec.System.OpenTelemetry.IsEnabled()
+}
+
+func (a *APIManager) OpenTelemetryEnabledForBackend() bool {
+	return a.Spec.Backend != nil && a.Spec.Backend.OpenTelemetry.IsEnabled()
+}
+
+func (a *APIManager) OpenTelemetryEnabledForZync() bool {
+	return a.Spec.Zync != nil && a.Spec.Zync.OpenTelemetry.IsEnabled()
+}
+
 func (a *APIManager) OpenTelemetryEnabledForStaging() bool {
 	return a.Spec.Apicast != nil && a.Spec.Apicast.StagingSpec != nil && a.Spec.Apicast.StagingSpec.OpenTelemetry != nil && a.Spec.Apicast.StagingSpec.OpenTelemetry.Enabled != nil && *a.Spec.Apicast.StagingSpec.OpenTelemetry.Enabled
 }
@@ -1147,8 +1794,19 @@ func (apimanager *APIManager) setSystemDatabaseSpecDefaults() (bool, error) {
 	systemSpec := apimanager.Spec.System
 
 	if apimanager.IsExternal(SystemDatabase) {
-		if systemSpec.DatabaseSpec != nil {
-			systemSpec.DatabaseSpec = nil
+		// Only the connection pooler applies to external databases
+		var desiredDatabaseSpec *SystemDatabaseSpec
+		if systemSpec.DatabaseSpec != nil &&
+			systemSpec.DatabaseSpec.PostgreSQL != nil &&
+			systemSpec.DatabaseSpec.PostgreSQL.ConnectionPooler != nil {
+			desiredDatabaseSpec = &SystemDatabaseSpec{
+				PostgreSQL: &SystemPostgreSQLSpec{
+					ConnectionPooler: systemSpec.DatabaseSpec.PostgreSQL.ConnectionPooler,
+				},
+			}
+		}
+		if !reflect.DeepEqual(systemSpec.DatabaseSpec, desiredDatabaseSpec) {
+			systemSpec.DatabaseSpec = desiredDatabaseSpec
 			changed = true
 		}
 		return changed, nil
@@ -1296,15 +1954,77 @@ func (apimanager *APIManager) IsSystemMysqlEnabled() bool {
 	return !apimanager.IsExternal(SystemDatabase) && !apimanager.IsSystemPostgreSQLEnabled()
 }
 
+func (apimanager *APIManager) IsSystemDatabaseConnectionPoolerEnabled() bool {
+	return apimanager.Spec.System != nil &&
+		apimanager.Spec.System.DatabaseSpec != nil &&
+		apimanager.Spec.System.DatabaseSpec.PostgreSQL != nil &&
+		apimanager.Spec.System.DatabaseSpec.PostgreSQL.ConnectionPooler != nil &&
+		apimanager.Spec.System.DatabaseSpec.PostgreSQL.ConnectionPooler.Enabled
+}
+
 func (apimanager *APIManager) IsMonitoringEnabled() bool {
 	return apimanager.Spec.Monitoring != nil && apimanager.Spec.Monitoring.Enabled
 }
 
+func (apimanager *APIManager) IsNetworkPoliciesEnabled() bool {
+	return apimanager.Spec.NetworkPolicies != nil && apimanager.Spec.NetworkPolicies.Enabled
+}
+
+func (apimanager *APIManager) CertificateExpiryWarningDays() int32 {
+	if apimanager.Spec.Certificates == nil || apimanager.Spec.Certificates.ExpiryWarningDays == nil {
+		return DefaultCertificateExpiryWarningDays
+	}
+	return *apimanager.Spec.Certificates.ExpiryWarningDays
+}
+
+func (apimanager *APIManager) IsMaintenanceEnabled() bool {
+	return apimanager.Spec.Maintenance != nil
+}
+
+func (apimanager *APIManager) MaintenanceMode() MaintenanceMode {
+	if !apimanager.IsMaintenanceEnabled() {
+		return ""
+	}
+	return apimanager.Spec.Maintenance.Mode
+}
+
+func (apimanager *APIManager) IsProfileSet() bool {
+	return apimanager.Spec.Profile != nil
+}
+
 func (apimanager *APIManager) IsPrometheusRulesEnabled() bool {
 	return (apimanager.IsMonitoringEnabled() &&
 		(apimanager.Spec.Monitoring.EnablePrometheusRules == nil || *apimanager.Spec.Monitoring.EnablePrometheusRules))
 }
 
+func (apimanager *APIManager) IsDatastoreExportersEnabled() bool {
+	return (apimanager.IsMonitoringEnabled() &&
+		(apimanager.Spec.Monitoring.EnableDatastoreExporters == nil || *apimanager.Spec.Monitoring.EnableDatastoreExporters))
+}
+
+func (apimanager *APIManager) GrafanaSpec() *GrafanaSpec {
+	if apimanager.Spec.Monitoring == nil || apimanager.Spec.Monitoring.Grafana == nil {
+		return &GrafanaSpec{}
+	}
+	return apimanager.Spec.Monitoring.Grafana
+}
+
+func (apimanager *APIManager) IsGrafanaDashboardDisabled(name string) bool {
+	for _, disabled := range apimanager.GrafanaSpec().DisabledDashboards {
+		if disabled == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (apimanager *APIManager) PrometheusRulesAlertOverrides() []AlertOverrideSpec {
+	if apimanager.Spec.Monitoring == nil {
+		return nil
+	}
+	return apimanager.Spec.Monitoring.AlertOverrides
+}
+
 func (apimanager *APIManager) IsAPIcastProductionOpenTracingEnabled() bool {
 	return apimanager.Spec.Apicast != nil && apimanager.Spec.Apicast.ProductionSpec != nil &&
 		apimanager.Spec.Apicast.ProductionSpec.OpenTracing != nil &&
@@ -1324,6 +2044,23 @@ func (apimanager *APIManager) IsS3Enabled() bool {
 		apimanager.Spec.System.FileStorageSpec.S3 != nil
 }
 
+func (apimanager *APIManager) IsSMTPSpecEnabled() bool {
+	return apimanager.Spec.System != nil && apimanager.Spec.System.SMTP != nil
+}
+
+// SMTPTestEmailRecipient returns the recipient of the SMTP probe email, an empty string when not requested
+func (apimanager *APIManager) SMTPTestEmailRecipient() string {
+	return strings.TrimSpace(apimanager.Annotations[SMTPTestEmailAnnotation])
+}
+
+func (apimanager *APIManager) IsSystemStorageMigrationConfirmed() bool {
+	return apimanager.Annotations[SystemStorageMigrationConfirmedAnnotation] == "true"
+}
+
+func (apimanager *APIManager) IsSystemStorageMigrated() bool {
+	return apimanager.Annotations[SystemStorageMigratedAnnotation] == "true"
+}
+
 func (apimanager *APIManager) IsS3STSEnabled() bool {
 	return apimanager.IsS3Enabled() &&
 		apimanager.Spec.System.FileStorageSpec.S3.STS != nil &&
@@ -1461,6 +2198,14 @@ func (a *APIManager) GetZyncSecretRefs() []*v1.LocalObjectReference {
 	return secretRefs
 }
 
+func (a *APIManager) GetSystemSMTPSecretRefs() []*v1.LocalObjectReference {
+	secretRefs := []*v1.LocalObjectReference{}
+	if a.IsSMTPSpecEnabled() && a.Spec.System.SMTP.CredentialsSecretRef != nil && a.Spec.System.SMTP.CredentialsSecretRef.Name != "" {
+		secretRefs = append(secretRefs, a.Spec.System.SMTP.CredentialsSecretRef)
+	}
+	return secretRefs
+}
+
 func (apimanager *APIManager) Get3scaleSecretRefs() []*v1.LocalObjectReference {
 	secretRefs := []*v1.LocalObjectReference{}
 
@@ -1496,6 +2241,11 @@ func (apimanager *APIManager) Get3scaleSecretRefs() []*v1.LocalObjectReference {
 		secretRefs = append(secretRefs, zyncSecretRefs...)
 	}
 
+	systemSMTPSecretRefs := apimanager.GetSystemSMTPSecretRefs()
+	if len(systemSMTPSecretRefs) > 0 {
+		secretRefs = append(secretRefs, systemSMTPSecretRefs...)
+	}
+
 	secretRefs = removeDuplicateSecretRefs(secretRefs)
 
 	return secretRefs
@@ -1516,6 +2266,45 @@ func removeDuplicateSecretRefs(refs []*v1.LocalObjectReference) []*v1.LocalObjec
 	return uniqueRefs
 }
 
+// apicastUpstreamRetryCases are the values accepted by APICAST_UPSTREAM_RETRY_CASES
+var apicastUpstreamRetryCases = []string{
+	"error", "timeout", "invalid_header", "http_500", "http_502", "http_503",
+	"http_504", "http_403", "http_404", "http_429", "non_idempotent", "off",
+}
+
+// validateAPIcastTuning validates the effective tuning of one APIcast environment,
+// the defaults being the values rendered when the fields are not set
+func validateAPIcastTuning(fldPath *field.Path, defaultLoadMode string, defaultCacheSeconds int64, loadMode *string, cacheSeconds *int64, retryCases *string) field.ErrorList {
+	fieldErrors := field.ErrorList{}
+
+	mode := defaultLoadMode
+	if loadMode != nil {
+		mode = *loadMode
+	}
+	cache := defaultCacheSeconds
+	if cacheSeconds != nil {
+		cache = *cacheSeconds
+	}
+	// the boot mode needs a cache to reload the configuration from
+	if mode == "boot" && cache == 0 {
+		fieldErrors = append(fieldErrors, field.Invalid(fldPath.Child("cacheConfigurationSeconds"), cache, "0 is only supported with the lazy configuration load mode"))
+	}
+
+	if retryCases != nil {
+		cases := strings.Fields(*retryCases)
+		if len(cases) == 0 {
+			fieldErrors = append(fieldErrors, field.Invalid(fldPath.Child("upstreamRetryCases"), *retryCases, "retry cases are empty"))
+		}
+		for _, retryCase := range cases {
+			if !slices.Contains(apicastUpstreamRetryCases, retryCase) {
+				fieldErrors = append(fieldErrors, field.NotSupported(fldPath.Child("upstreamRetryCases"), retryCase, apicastUpstreamRetryCases))
+			}
+		}
+	}
+
+	return fieldErrors
+}
+
 func (apimanager *APIManager) Validate() field.ErrorList {
 	fieldErrors := field.ErrorList{}
 
@@ -1527,25 +2316,7 @@ func (apimanager *APIManager) Validate() field.ErrorList {
 		if apimanager.Spec.Apicast.ProductionSpec != nil {
 			prodSpecFldPath := apicastFldPath.Child("productionSpec")
 
-			customPoliciesFldPath := prodSpecFldPath.Child("customPolicies")
-			duplicatePolicyMap := make(map[string]int)
-			for idx, customPolicySpec := range apimanager.Spec.Apicast.ProductionSpec.CustomPolicies {
-				customPoliciesIdxFldPath := customPoliciesFldPath.Index(idx)
-
-				// check custom policy secret is set
-				if customPolicySpec.SecretRef == nil {
-					fieldErrors = append(fieldErrors, field.Invalid(customPoliciesIdxFldPath, customPolicySpec, "custom policy secret is mandatory"))
-				} else if customPolicySpec.SecretRef.Name == "" {
-					fieldErrors = append(fieldErrors, field.Invalid(customPoliciesIdxFldPath, customPolicySpec, "custom policy secret name is empty"))
-				}
-
-				// check duplicated custom policy version name
-				if _, ok := duplicatePolicyMap[customPolicySpec.VersionName()]; ok {
-					fieldErrors = append(fieldErrors, field.Invalid(customPoliciesIdxFldPath, customPolicySpec, "custom policy secret name version tuple is duplicated"))
-					break
-				}
-				duplicatePolicyMap[customPolicySpec.VersionName()] = 0
-			}
+			fieldErrors = append(fieldErrors, validateCustomPolicies(prodSpecFldPath.Child("customPolicies"), apimanager.Spec.Apicast.ProductionSpec.CustomPolicies)...)
 
 			if apimanager.OpenTelemetryEnabledForProduction() {
 				openTelemetrySpec := apimanager.Spec.Apicast.ProductionSpec.OpenTelemetry
@@ -1605,30 +2376,15 @@ func (apimanager *APIManager) Validate() field.ErrorList {
 			if apimanager.Spec.Apicast.ProductionSpec.HTTPSPort != nil && *apimanager.Spec.Apicast.ProductionSpec.HTTPSPort == DefaultHTTPPort {
 				fieldErrors = append(fieldErrors, field.Invalid(httpsPortFldPath, apimanager.Spec.Apicast.ProductionSpec.HTTPSPort, "HTTPS port conflicts with HTTP port"))
 			}
+
+			productionSpec := apimanager.Spec.Apicast.ProductionSpec
+			fieldErrors = append(fieldErrors, validateAPIcastTuning(prodSpecFldPath, "boot", 300,
+				productionSpec.ConfigurationLoadMode, productionSpec.CacheConfigurationSeconds, productionSpec.UpstreamRetryCases)...)
 		}
 
 		if apimanager.Spec.Apicast.StagingSpec != nil {
 			stagingSpecFldPath := apicastFldPath.Child("stagingSpec")
-			customPoliciesFldPath := stagingSpecFldPath.Child("customPolicies")
-			duplicatePolicyMap := make(map[string]int)
-			for idx, customPolicySpec := range apimanager.Spec.Apicast.StagingSpec.CustomPolicies {
-				// TODO(eastizle): DRY!!
-				customPoliciesIdxFldPath := customPoliciesFldPath.Index(idx)
-
-				// check custom policy secret is set
-				if customPolicySpec.SecretRef == nil {
-					fieldErrors = append(fieldErrors, field.Invalid(customPoliciesIdxFldPath, customPolicySpec, "custom policy secret is mandatory"))
-				} else if customPolicySpec.SecretRef.Name == "" {
-					fieldErrors = append(fieldErrors, field.Invalid(customPoliciesIdxFldPath, customPolicySpec, "custom policy secret name is empty"))
-				}
-
-				// check duplicated custom policy version name
-				if _, ok := duplicatePolicyMap[customPolicySpec.VersionName()]; ok {
-					fieldErrors = append(fieldErrors, field.Invalid(customPoliciesIdxFldPath, customPolicySpec, "custom policy secret name version tuple is duplicated"))
-					break
-				}
-				duplicatePolicyMap[customPolicySpec.VersionName()] = 0
-			}
+			fieldErrors = append(fieldErrors, validateCustomPolicies(stagingSpecFldPath.Child("customPolicies"), apimanager.Spec.Apicast.StagingSpec.CustomPolicies)...)
 
 			if apimanager.OpenTelemetryEnabledForStaging() {
 				openTelemetrySpec := apimanager.Spec.Apicast.StagingSpec.OpenTelemetry
@@ -1687,7 +2443,50 @@ func (apimanager *APIManager) Validate() field.ErrorList {
 			if apimanager.Spec.Apicast.StagingSpec.HTTPSPort != nil && *apimanager.Spec.Apicast.StagingSpec.HTTPSPort == DefaultHTTPPort {
 				fieldErrors = append(fieldErrors, field.Invalid(httpsPortFldPath, apimanager.Spec.Apicast.StagingSpec.HTTPSPort, "HTTPS port conflicts with HTTP port"))
 			}
+
+			stagingSpec := apimanager.Spec.Apicast.StagingSpec
+			fieldErrors = append(fieldErrors, validateAPIcastTuning(stagingSpecFldPath, "lazy", 0,
+				stagingSpec.ConfigurationLoadMode, stagingSpec.CacheConfigurationSeconds, stagingSpec.UpstreamRetryCases)...)
+		}
+	}
+
+	if apimanager.IsSMTPSpecEnabled() {
+		smtpSpec := apimanager.Spec.System.SMTP
+		smtpFldPath := specFldPath.Child("system").Child("smtp")
+		authenticationNone := smtpSpec.AuthenticationMethod != nil && *smtpSpec.AuthenticationMethod == SMTPAuthenticationNone
+		if smtpSpec.CredentialsSecretRef == nil && smtpSpec.AuthenticationMethod != nil && !authenticationNone {
+			fieldErrors = append(fieldErrors, field.Required(smtpFldPath.Child("credentialsSecretRef"), "credentials secret is required by the authentication method"))
+		}
+		if smtpSpec.CredentialsSecretRef != nil && smtpSpec.CredentialsSecretRef.Name == "" {
+			fieldErrors = append(fieldErrors, field.Invalid(smtpFldPath.Child("credentialsSecretRef"), smtpSpec.CredentialsSecretRef, "credentials secret name is empty"))
+		}
+		if smtpSpec.CredentialsSecretRef != nil && authenticationNone {
+			fieldErrors = append(fieldErrors, field.Invalid(smtpFldPath.Child("credentialsSecretRef"), smtpSpec.CredentialsSecretRef, "credentials secret is not used without authentication"))
 		}
+		if _, err := mail.ParseAddress(smtpSpec.FromAddress); err != nil {
+			fieldErrors = append(fieldErrors, field.Invalid(smtpFldPath.Child("fromAddress"), smtpSpec.FromAddress, "invalid email address"))
+		}
+	}
+
+	if recipient := apimanager.SMTPTestEmailRecipient(); recipient != "" {
+		if _, err := mail.ParseAddress(recipient); err != nil {
+			fieldErrors = append(fieldErrors, field.Invalid(field.NewPath("metadata").Child("annotations").Key(SMTPTestEmailAnnotation), recipient, "invalid email address"))
+		}
+	}
+
+	if apimanager.Spec.System != nil {
+		fieldErrors = append(fieldErrors, validateComponentOpenTelemetry(specFldPath.Child("system").Child("openTelemetry"), apimanager.Spec.System.OpenTelemetry)...)
+	}
+	if apimanager.Spec.Backend != nil {
+		fieldErrors = append(fieldErrors, validateComponentOpenTelemetry(specFldPath.Child("backend").Child("openTelemetry"), apimanager.Spec.Backend.OpenTelemetry)...)
+	}
+	if apimanager.Spec.Zync != nil {
+		fieldErrors = append(fieldErrors, validateComponentOpenTelemetry(specFldPath.Child("zync").Child("openTelemetry"), apimanager.Spec.Zync.OpenTelemetry)...)
+	}
+
+	if apimanager.IsSystemDatabaseConnectionPoolerEnabled() && apimanager.IsSystemDatabaseTLSEnabled() {
+		connectionPoolerFldPath := specFldPath.Child("system").Child("database").Child("postgresql").Child("connectionPooler")
+		fieldErrors = append(fieldErrors, field.Invalid(connectionPoolerFldPath, apimanager.Spec.System.DatabaseSpec.PostgreSQL.ConnectionPooler, "connection pooler cannot be enabled together with systemDatabaseTLSEnabled"))
 	}
 
 	return fieldErrors
@@ -9,6 +9,7 @@ import (
 	"github.com/3scale/3scale-operator/version"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 func TestSetDefaults(t *testing.T) {
@@ -156,6 +157,58 @@ func TestZyncExternalDatabaseIsEnabled(t *testing.T) {
 	}
 }
 
+func TestSystemDatabaseConnectionPoolerIsEnabled(t *testing.T) {
+	cases := []struct {
+		testName          string
+		apimanagerFactory func() *APIManager
+		expectedResult    bool
+	}{
+		{"WithDefaultAPIManager", minimumAPIManagerTest, false},
+		{"WithConnectionPoolerEnabled",
+			func() *APIManager {
+				apimanager := minimumAPIManagerTest()
+				apimanager.Spec.System = &SystemSpec{
+					DatabaseSpec: &SystemDatabaseSpec{
+						PostgreSQL: &SystemPostgreSQLSpec{
+							ConnectionPooler: &SystemDatabaseConnectionPoolerSpec{Enabled: true},
+						},
+					},
+				}
+				return apimanager
+			},
+			true,
+		},
+		{"WithConnectionPoolerDisabled",
+			func() *APIManager {
+				apimanager := minimumAPIManagerTest()
+				apimanager.Spec.System = &SystemSpec{
+					DatabaseSpec: &SystemDatabaseSpec{
+						PostgreSQL: &SystemPostgreSQLSpec{
+							ConnectionPooler: &SystemDatabaseConnectionPoolerSpec{Enabled: false},
+						},
+					},
+				}
+				return apimanager
+			},
+			false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			apimanager := tc.apimanagerFactory()
+			_, err := apimanager.SetDefaults()
+			if err != nil {
+				subT.Fatal(err)
+			}
+			receivedResult := apimanager.IsSystemDatabaseConnectionPoolerEnabled()
+			if !reflect.DeepEqual(tc.expectedResult, receivedResult) {
+				subT.Errorf("Expected result differs: Expected: %t, Received: %t", tc.expectedResult, receivedResult)
+			}
+		})
+	}
+}
+
 func minimumAPIManagerTest() *APIManager {
 	return &APIManager{
 		Spec: APIManagerSpec{
@@ -346,3 +399,110 @@ func TestAPIManager_Get3scaleSecretRefs(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateAPIcastTuning(t *testing.T) {
+	zero := int64(0)
+	cases := []struct {
+		testName            string
+		defaultLoadMode     string
+		defaultCacheSeconds int64
+		loadMode            *string
+		cacheSeconds        *int64
+		retryCases          *string
+		expectedErrors      int
+	}{
+		{"Defaults", "boot", 300, nil, nil, nil, 0},
+		{"BootWithoutCache", "boot", 300, nil, &zero, nil, 1},
+		{"LazyWithoutCache", "boot", 300, &[]string{"lazy"}[0], &zero, nil, 0},
+		{"StagingWithoutCache", "lazy", 0, nil, &zero, nil, 0},
+		{"StagingDefaults", "lazy", 0, nil, nil, nil, 0},
+		{"StagingBootWithDefaultCache", "lazy", 0, &[]string{"boot"}[0], nil, nil, 1},
+		{"StagingBootWithCache", "lazy", 0, &[]string{"boot"}[0], &[]int64{60}[0], nil, 0},
+		{"RetryCases", "boot", 300, nil, nil, &[]string{"error timeout http_503"}[0], 0},
+		{"InvalidRetryCase", "boot", 300, nil, nil, &[]string{"error http_501"}[0], 1},
+		{"EmptyRetryCases", "boot", 300, nil, nil, &[]string{" "}[0], 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			errs := validateAPIcastTuning(field.NewPath("spec"), tc.defaultLoadMode, tc.defaultCacheSeconds, tc.loadMode, tc.cacheSeconds, tc.retryCases)
+			if len(errs) != tc.expectedErrors {
+				subT.Fatalf("expected %d errors, got %v", tc.expectedErrors, errs)
+			}
+		})
+	}
+}
+
+func TestValidateCustomPolicies(t *testing.T) {
+	secretRef := &v1.LocalObjectReference{Name: "policy-secret"}
+	configMapRef := &v1.LocalObjectReference{Name: "policy-configmap"}
+	image := "quay.io/example/policy:1.2.0"
+	untaggedImage := "quay.io/example/policy"
+
+	cases := []struct {
+		testName       string
+		policies       []CustomPolicySpec
+		expectedErrors int
+	}{
+		{"Secret", []CustomPolicySpec{{Name: "p", Version: "0.1", SecretRef: secretRef}}, 0},
+		{"ConfigMap", []CustomPolicySpec{{Name: "p", Version: "0.1", ConfigMapRef: configMapRef}}, 0},
+		{"ImageTagVersion", []CustomPolicySpec{{Name: "p", Image: &image}}, 0},
+		{"UntaggedImageWithoutVersion", []CustomPolicySpec{{Name: "p", Image: &untaggedImage}}, 1},
+		{"NoSource", []CustomPolicySpec{{Name: "p", Version: "0.1"}}, 1},
+		{"SeveralSources", []CustomPolicySpec{{Name: "p", Version: "0.1", SecretRef: secretRef, Image: &image}}, 1},
+		{"EmptyConfigMapName", []CustomPolicySpec{{Name: "p", Version: "0.1", ConfigMapRef: &v1.LocalObjectReference{}}}, 1},
+		{"DuplicatedFromImageTag", []CustomPolicySpec{
+			{Name: "p", Version: "1.2.0", ConfigMapRef: configMapRef},
+			{Name: "p", Image: &image},
+		}, 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			errs := validateCustomPolicies(field.NewPath("spec"), tc.policies)
+			if len(errs) != tc.expectedErrors {
+				subT.Fatalf("expected %d errors, got %v", tc.expectedErrors, errs)
+			}
+		})
+	}
+}
+
+func TestImageTag(t *testing.T) {
+	cases := map[string]string{
+		"quay.io/example/policy:1.2.0":                       "1.2.0",
+		"quay.io/example/policy":                             "",
+		"registry:5000/example/policy":                       "",
+		"registry:5000/example/policy:v2":                    "v2",
+		"quay.io/example/policy:1.0@sha256:0123456789abcdef": "1.0",
+	}
+
+	for image, expected := range cases {
+		if got := imageTag(image); got != expected {
+			t.Errorf("imageTag(%q) = %q, want %q", image, got, expected)
+		}
+	}
+}
+
+func TestValidateComponentOpenTelemetry(t *testing.T) {
+	trueValue := true
+	cases := []struct {
+		testName       string
+		spec           *ComponentOpenTelemetrySpec
+		expectedErrors int
+	}{
+		{"Unset", nil, 0},
+		{"Enabled", &ComponentOpenTelemetrySpec{Enabled: &trueValue, Endpoint: "http://collector:4318", ResourceAttributes: map[string]string{"team": "api"}}, 0},
+		{"EmptyTLSSecretName", &ComponentOpenTelemetrySpec{Enabled: &trueValue, Endpoint: "http://collector:4318", TLSSecretRef: &v1.LocalObjectReference{}}, 1},
+		{"InvalidResourceAttributeKey", &ComponentOpenTelemetrySpec{Enabled: &trueValue, Endpoint: "http://collector:4318", ResourceAttributes: map[string]string{"a=b": "c"}}, 1},
+		{"DisabledIsNotValidated", &ComponentOpenTelemetrySpec{TLSSecretRef: &v1.LocalObjectReference{}}, 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			errs := validateComponentOpenTelemetry(field.NewPath("spec"), tc.spec)
+			if len(errs) != tc.expectedErrors {
+				subT.Fatalf("expected %d errors, got %v", tc.expectedErrors, errs)
+			}
+		})
+	}
+}
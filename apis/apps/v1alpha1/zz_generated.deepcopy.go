@@ -23,6 +23,7 @@ package v1alpha1
 import (
 	"github.com/3scale/3scale-operator/pkg/apispkg/common"
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -191,6 +192,22 @@ func (in *APIManagerBackupStatus) DeepCopy() *APIManagerBackupStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIManagerCertificateStatus) DeepCopyInto(out *APIManagerCertificateStatus) {
+	*out = *in
+	in.NotAfter.DeepCopyInto(&out.NotAfter)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerCertificateStatus.
+func (in *APIManagerCertificateStatus) DeepCopy() *APIManagerCertificateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(APIManagerCertificateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APIManagerCommonSpec) DeepCopyInto(out *APIManagerCommonSpec) {
 	*out = *in
@@ -214,6 +231,11 @@ func (in *APIManagerCommonSpec) DeepCopyInto(out *APIManagerCommonSpec) {
 		*out = make([]v1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.Profile != nil {
+		in, out := &in.Profile, &out.Profile
+		*out = new(APIManagerProfile)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerCommonSpec.
@@ -226,6 +248,127 @@ func (in *APIManagerCommonSpec) DeepCopy() *APIManagerCommonSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIManagerComponentEndpoint) DeepCopyInto(out *APIManagerComponentEndpoint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerComponentEndpoint.
+func (in *APIManagerComponentEndpoint) DeepCopy() *APIManagerComponentEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(APIManagerComponentEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIManagerComponentStatus) DeepCopyInto(out *APIManagerComponentStatus) {
+	*out = *in
+	if in.LastRolloutTime != nil {
+		in, out := &in.LastRolloutTime, &out.LastRolloutTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]APIManagerComponentEndpoint, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerComponentStatus.
+func (in *APIManagerComponentStatus) DeepCopy() *APIManagerComponentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(APIManagerComponentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIManagerComponentsStatus) DeepCopyInto(out *APIManagerComponentsStatus) {
+	*out = *in
+	if in.Apicast != nil {
+		in, out := &in.Apicast, &out.Apicast
+		*out = new(APIManagerComponentStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Backend != nil {
+		in, out := &in.Backend, &out.Backend
+		*out = new(APIManagerComponentStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.System != nil {
+		in, out := &in.System, &out.System
+		*out = new(APIManagerComponentStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Zync != nil {
+		in, out := &in.Zync, &out.Zync
+		*out = new(APIManagerComponentStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Memcached != nil {
+		in, out := &in.Memcached, &out.Memcached
+		*out = new(APIManagerComponentStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Searchd != nil {
+		in, out := &in.Searchd, &out.Searchd
+		*out = new(APIManagerComponentStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerComponentsStatus.
+func (in *APIManagerComponentsStatus) DeepCopy() *APIManagerComponentsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(APIManagerComponentsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIManagerDeploymentSizing) DeepCopyInto(out *APIManagerDeploymentSizing) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxReplicas != nil {
+		in, out := &in.MaxReplicas, &out.MaxReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Workers != nil {
+		in, out := &in.Workers, &out.Workers
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(map[string]v1.ResourceRequirements, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerDeploymentSizing.
+func (in *APIManagerDeploymentSizing) DeepCopy() *APIManagerDeploymentSizing {
+	if in == nil {
+		return nil
+	}
+	out := new(APIManagerDeploymentSizing)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APIManagerList) DeepCopyInto(out *APIManagerList) {
 	*out = *in
@@ -258,6 +401,28 @@ func (in *APIManagerList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIManagerProfileStatus) DeepCopyInto(out *APIManagerProfileStatus) {
+	*out = *in
+	if in.Deployments != nil {
+		in, out := &in.Deployments, &out.Deployments
+		*out = make([]APIManagerDeploymentSizing, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerProfileStatus.
+func (in *APIManagerProfileStatus) DeepCopy() *APIManagerProfileStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(APIManagerProfileStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APIManagerRestore) DeepCopyInto(out *APIManagerRestore) {
 	*out = *in
@@ -435,6 +600,21 @@ func (in *APIManagerSpec) DeepCopyInto(out *APIManagerSpec) {
 		*out = new(MonitoringSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NetworkPolicies != nil {
+		in, out := &in.NetworkPolicies, &out.NetworkPolicies
+		*out = new(NetworkPoliciesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Maintenance != nil {
+		in, out := &in.Maintenance, &out.Maintenance
+		*out = new(MaintenanceSpec)
+		**out = **in
+	}
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = new(CertificatesSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerSpec.
@@ -458,6 +638,23 @@ func (in *APIManagerStatus) DeepCopyInto(out *APIManagerStatus) {
 		}
 	}
 	in.Deployments.DeepCopyInto(&out.Deployments)
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = new(APIManagerComponentsStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Profile != nil {
+		in, out := &in.Profile, &out.Profile
+		*out = new(APIManagerProfileStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make([]APIManagerCertificateStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerStatus.
@@ -500,6 +697,48 @@ func (in *APIcastOpenTracingSpec) DeepCopy() *APIcastOpenTracingSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertOverrideSpec) DeepCopyInto(out *AlertOverrideSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Threshold != nil {
+		in, out := &in.Threshold, &out.Threshold
+		*out = new(string)
+		**out = **in
+	}
+	if in.For != nil {
+		in, out := &in.For, &out.For
+		*out = new(string)
+		**out = **in
+	}
+	if in.Severity != nil {
+		in, out := &in.Severity, &out.Severity
+		*out = new(string)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertOverrideSpec.
+func (in *AlertOverrideSpec) DeepCopy() *AlertOverrideSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertOverrideSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApicastProductionSpec) DeepCopyInto(out *ApicastProductionSpec) {
 	*out = *in
@@ -599,6 +838,56 @@ func (in *ApicastProductionSpec) DeepCopyInto(out *ApicastProductionSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ConfigurationLoadMode != nil {
+		in, out := &in.ConfigurationLoadMode, &out.ConfigurationLoadMode
+		*out = new(string)
+		**out = **in
+	}
+	if in.CacheConfigurationSeconds != nil {
+		in, out := &in.CacheConfigurationSeconds, &out.CacheConfigurationSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PathRoutingEnabled != nil {
+		in, out := &in.PathRoutingEnabled, &out.PathRoutingEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PathRoutingOnly != nil {
+		in, out := &in.PathRoutingOnly, &out.PathRoutingOnly
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CacheMaxTime != nil {
+		in, out := &in.CacheMaxTime, &out.CacheMaxTime
+		*out = new(string)
+		**out = **in
+	}
+	if in.CacheStatusCodes != nil {
+		in, out := &in.CacheStatusCodes, &out.CacheStatusCodes
+		*out = new(string)
+		**out = **in
+	}
+	if in.UpstreamRetryCases != nil {
+		in, out := &in.UpstreamRetryCases, &out.UpstreamRetryCases
+		*out = new(string)
+		**out = **in
+	}
+	if in.HTTPKeepaliveTimeout != nil {
+		in, out := &in.HTTPKeepaliveTimeout, &out.HTTPKeepaliveTimeout
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BatcherSharedMemorySize != nil {
+		in, out := &in.BatcherSharedMemorySize, &out.BatcherSharedMemorySize
+		*out = new(string)
+		**out = **in
+	}
+	if in.AccessLogFile != nil {
+		in, out := &in.AccessLogFile, &out.AccessLogFile
+		*out = new(string)
+		**out = **in
+	}
 	if in.PriorityClassName != nil {
 		in, out := &in.PriorityClassName, &out.PriorityClassName
 		*out = new(string)
@@ -781,6 +1070,56 @@ func (in *ApicastStagingSpec) DeepCopyInto(out *ApicastStagingSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ConfigurationLoadMode != nil {
+		in, out := &in.ConfigurationLoadMode, &out.ConfigurationLoadMode
+		*out = new(string)
+		**out = **in
+	}
+	if in.CacheConfigurationSeconds != nil {
+		in, out := &in.CacheConfigurationSeconds, &out.CacheConfigurationSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PathRoutingEnabled != nil {
+		in, out := &in.PathRoutingEnabled, &out.PathRoutingEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PathRoutingOnly != nil {
+		in, out := &in.PathRoutingOnly, &out.PathRoutingOnly
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CacheMaxTime != nil {
+		in, out := &in.CacheMaxTime, &out.CacheMaxTime
+		*out = new(string)
+		**out = **in
+	}
+	if in.CacheStatusCodes != nil {
+		in, out := &in.CacheStatusCodes, &out.CacheStatusCodes
+		*out = new(string)
+		**out = **in
+	}
+	if in.UpstreamRetryCases != nil {
+		in, out := &in.UpstreamRetryCases, &out.UpstreamRetryCases
+		*out = new(string)
+		**out = **in
+	}
+	if in.HTTPKeepaliveTimeout != nil {
+		in, out := &in.HTTPKeepaliveTimeout, &out.HTTPKeepaliveTimeout
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BatcherSharedMemorySize != nil {
+		in, out := &in.BatcherSharedMemorySize, &out.BatcherSharedMemorySize
+		*out = new(string)
+		**out = **in
+	}
+	if in.AccessLogFile != nil {
+		in, out := &in.AccessLogFile, &out.AccessLogFile
+		*out = new(string)
+		**out = **in
+	}
 	if in.PriorityClassName != nil {
 		in, out := &in.PriorityClassName, &out.PriorityClassName
 		*out = new(string)
@@ -1041,6 +1380,11 @@ func (in *BackendSpec) DeepCopyInto(out *BackendSpec) {
 		*out = new(BackendCronSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OpenTelemetry != nil {
+		in, out := &in.OpenTelemetry, &out.OpenTelemetry
+		*out = new(ComponentOpenTelemetrySpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendSpec.
@@ -1116,6 +1460,63 @@ func (in *BackendWorkerSpec) DeepCopy() *BackendWorkerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificatesSpec) DeepCopyInto(out *CertificatesSpec) {
+	*out = *in
+	if in.ExpiryWarningDays != nil {
+		in, out := &in.ExpiryWarningDays, &out.ExpiryWarningDays
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificatesSpec.
+func (in *CertificatesSpec) DeepCopy() *CertificatesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificatesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentOpenTelemetrySpec) DeepCopyInto(out *ComponentOpenTelemetrySpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SamplingRatio != nil {
+		in, out := &in.SamplingRatio, &out.SamplingRatio
+		*out = new(string)
+		**out = **in
+	}
+	if in.ResourceAttributes != nil {
+		in, out := &in.ResourceAttributes, &out.ResourceAttributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TLSSecretRef != nil {
+		in, out := &in.TLSSecretRef, &out.TLSSecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentOpenTelemetrySpec.
+func (in *ComponentOpenTelemetrySpec) DeepCopy() *ComponentOpenTelemetrySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentOpenTelemetrySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CustomEnvironmentSpec) DeepCopyInto(out *CustomEnvironmentSpec) {
 	*out = *in
@@ -1144,6 +1545,16 @@ func (in *CustomPolicySpec) DeepCopyInto(out *CustomPolicySpec) {
 		*out = new(v1.LocalObjectReference)
 		**out = **in
 	}
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomPolicySpec.
@@ -1267,6 +1678,66 @@ func (in *ExternalZyncComponents) DeepCopy() *ExternalZyncComponents {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaDashboardSpec) DeepCopyInto(out *GrafanaDashboardSpec) {
+	*out = *in
+	in.ConfigMapRef.DeepCopyInto(&out.ConfigMapRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaDashboardSpec.
+func (in *GrafanaDashboardSpec) DeepCopy() *GrafanaDashboardSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaDashboardSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaSpec) DeepCopyInto(out *GrafanaSpec) {
+	*out = *in
+	if in.InstanceSelector != nil {
+		in, out := &in.InstanceSelector, &out.InstanceSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Folder != nil {
+		in, out := &in.Folder, &out.Folder
+		*out = new(string)
+		**out = **in
+	}
+	if in.Datasource != nil {
+		in, out := &in.Datasource, &out.Datasource
+		*out = new(string)
+		**out = **in
+	}
+	if in.DisabledDashboards != nil {
+		in, out := &in.DisabledDashboards, &out.DisabledDashboards
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Dashboards != nil {
+		in, out := &in.Dashboards, &out.Dashboards
+		*out = make([]GrafanaDashboardSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaSpec.
+func (in *GrafanaSpec) DeepCopy() *GrafanaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HighAvailabilitySpec) DeepCopyInto(out *HighAvailabilitySpec) {
 	*out = *in
@@ -1287,6 +1758,21 @@ func (in *HighAvailabilitySpec) DeepCopy() *HighAvailabilitySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceSpec) DeepCopyInto(out *MaintenanceSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceSpec.
+func (in *MaintenanceSpec) DeepCopy() *MaintenanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MonitoringSpec) DeepCopyInto(out *MonitoringSpec) {
 	*out = *in
@@ -1295,6 +1781,23 @@ func (in *MonitoringSpec) DeepCopyInto(out *MonitoringSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.EnableDatastoreExporters != nil {
+		in, out := &in.EnableDatastoreExporters, &out.EnableDatastoreExporters
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AlertOverrides != nil {
+		in, out := &in.AlertOverrides, &out.AlertOverrides
+		*out = make([]AlertOverrideSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Grafana != nil {
+		in, out := &in.Grafana, &out.Grafana
+		*out = new(GrafanaSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringSpec.
@@ -1307,6 +1810,36 @@ func (in *MonitoringSpec) DeepCopy() *MonitoringSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPoliciesSpec) DeepCopyInto(out *NetworkPoliciesSpec) {
+	*out = *in
+	if in.IngressControllerNamespaceSelector != nil {
+		in, out := &in.IngressControllerNamespaceSelector, &out.IngressControllerNamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MonitoringNamespaceSelector != nil {
+		in, out := &in.MonitoringNamespaceSelector, &out.MonitoringNamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPoliciesSpec.
+func (in *NetworkPoliciesSpec) DeepCopy() *NetworkPoliciesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPoliciesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpenTelemetrySpec) DeepCopyInto(out *OpenTelemetrySpec) {
 	*out = *in
@@ -1542,6 +2075,89 @@ func (in *SystemAppSpec) DeepCopy() *SystemAppSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SystemDatabaseConnectionPoolerSpec) DeepCopyInto(out *SystemDatabaseConnectionPoolerSpec) {
+	*out = *in
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(string)
+		**out = **in
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PoolMode != nil {
+		in, out := &in.PoolMode, &out.PoolMode
+		*out = new(string)
+		**out = **in
+	}
+	if in.DefaultPoolSize != nil {
+		in, out := &in.DefaultPoolSize, &out.DefaultPoolSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxClientConnections != nil {
+		in, out := &in.MaxClientConnections, &out.MaxClientConnections
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PriorityClassName != nil {
+		in, out := &in.PriorityClassName, &out.PriorityClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SystemDatabaseConnectionPoolerSpec.
+func (in *SystemDatabaseConnectionPoolerSpec) DeepCopy() *SystemDatabaseConnectionPoolerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SystemDatabaseConnectionPoolerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SystemDatabaseSpec) DeepCopyInto(out *SystemDatabaseSpec) {
 	*out = *in
@@ -1721,6 +2337,11 @@ func (in *SystemPostgreSQLSpec) DeepCopyInto(out *SystemPostgreSQLSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.ConnectionPooler != nil {
+		in, out := &in.ConnectionPooler, &out.ConnectionPooler
+		*out = new(SystemDatabaseConnectionPoolerSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SystemPostgreSQLSpec.
@@ -1774,6 +2395,51 @@ func (in *SystemS3Spec) DeepCopy() *SystemS3Spec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SystemSMTPSpec) DeepCopyInto(out *SystemSMTPSpec) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AuthenticationMethod != nil {
+		in, out := &in.AuthenticationMethod, &out.AuthenticationMethod
+		*out = new(SMTPAuthenticationMethod)
+		**out = **in
+	}
+	if in.TLSMode != nil {
+		in, out := &in.TLSMode, &out.TLSMode
+		*out = new(SMTPTLSMode)
+		**out = **in
+	}
+	if in.InsecureSkipVerify != nil {
+		in, out := &in.InsecureSkipVerify, &out.InsecureSkipVerify
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Domain != nil {
+		in, out := &in.Domain, &out.Domain
+		*out = new(string)
+		**out = **in
+	}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SystemSMTPSpec.
+func (in *SystemSMTPSpec) DeepCopy() *SystemSMTPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SystemSMTPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SystemSearchdSpec) DeepCopyInto(out *SystemSearchdSpec) {
 	*out = *in
@@ -2049,6 +2715,16 @@ func (in *SystemSpec) DeepCopyInto(out *SystemSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.SMTP != nil {
+		in, out := &in.SMTP, &out.SMTP
+		*out = new(SystemSMTPSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OpenTelemetry != nil {
+		in, out := &in.OpenTelemetry, &out.OpenTelemetry
+		*out = new(ComponentOpenTelemetrySpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SystemSpec.
@@ -2307,6 +2983,11 @@ func (in *ZyncSpec) DeepCopyInto(out *ZyncSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.OpenTelemetry != nil {
+		in, out := &in.OpenTelemetry, &out.OpenTelemetry
+		*out = new(ComponentOpenTelemetrySpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZyncSpec.
@@ -0,0 +1,887 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/RHsyseng/operator-utils/pkg/olm"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/3scale/3scale-operator/pkg/apispkg/common"
+)
+
+// APIManagerSpec defines the desired state of APIManager
+type APIManagerSpec struct {
+	// Wildcard domain as configured in the API Manager object
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Wildcard Domain",xDescriptors="urn:alm:descriptor:com.tectonic.ui:label"
+	WildcardDomain string `json:"wildcardDomain"`
+	// +optional
+	AppLabel *string `json:"appLabel,omitempty"`
+	// +optional
+	TenantName *string `json:"tenantName,omitempty"`
+	// +optional
+	ResourceRequirementsEnabled *bool `json:"resourceRequirementsEnabled,omitempty"`
+	// +optional
+	ImagePullSecrets []v1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	// Profile sets the default replicas, resources, HPA bounds and workers of every component.
+	// Values set explicitly on a component have priority over the profile
+	// +kubebuilder:validation:Enum=evaluation;small;medium;large;xlarge
+	// +optional
+	Profile *APIManagerProfile `json:"profile,omitempty"`
+
+	// +optional
+	Apicast *ApicastSpec `json:"apicast,omitempty"`
+	// +optional
+	Backend *BackendSpec `json:"backend,omitempty"`
+	// +optional
+	System *SystemSpec `json:"system,omitempty"`
+	// +optional
+	Zync *ZyncSpec `json:"zync,omitempty"`
+	// +optional
+	ExternalComponents *ExternalComponentsSpec `json:"externalComponents,omitempty"`
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+	// +optional
+	Monitoring *MonitoringSpec `json:"monitoring,omitempty"`
+	// +optional
+	NetworkPolicies *NetworkPoliciesSpec `json:"networkPolicies,omitempty"`
+	// +optional
+	Maintenance *MaintenanceSpec `json:"maintenance,omitempty"`
+	// +optional
+	Certificates *CertificatesSpec `json:"certificates,omitempty"`
+}
+
+type APIManagerProfile string
+
+// ComponentPodSpec holds the scheduling and metadata options of the pods of a component
+type ComponentPodSpec struct {
+	// +optional
+	Affinity *v1.Affinity `json:"affinity,omitempty"`
+	// +optional
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+	// +optional
+	PriorityClassName *string `json:"priorityClassName,omitempty"`
+	// +optional
+	TopologySpreadConstraints []v1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// DeploymentSpec configures a Deployment running a single container
+type DeploymentSpec struct {
+	// +optional
+	Replicas *int64 `json:"replicas,omitempty"`
+	// +optional
+	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	ComponentPodSpec `json:",inline"`
+}
+
+// AutoscaledDeploymentSpec configures a Deployment that can be scaled by an HPA
+type AutoscaledDeploymentSpec struct {
+	DeploymentSpec `json:",inline"`
+
+	// Hpa creates an HPA for the Deployment. Replicas are ignored when enabled
+	// +optional
+	Hpa bool `json:"hpa,omitempty"`
+}
+
+// CustomEnvironmentSpec contains or has reference to an APIcast custom environment
+type CustomEnvironmentSpec struct {
+	SecretRef *v1.LocalObjectReference `json:"secretRef"`
+}
+
+// CustomPolicySpec contains or has reference to an APIcast custom policy.
+// The policy is loaded from exactly one of a secret, a configmap or an OCI image
+type CustomPolicySpec struct {
+	// Name specifies the name of the custom policy
+	Name string `json:"name"`
+	// Version specifies the version of the custom policy.
+	// Defaults to the image tag with the image source
+	// +optional
+	Version string `json:"version,omitempty"`
+	// SecretRef specifies the secret holding the custom policy metadata and lua code
+	// +optional
+	SecretRef *v1.LocalObjectReference `json:"secretRef,omitempty"`
+	// ConfigMapRef specifies the configmap holding the custom policy metadata and lua code
+	// +optional
+	ConfigMapRef *v1.LocalObjectReference `json:"configMapRef,omitempty"`
+	// Image specifies the OCI image holding the custom policy directory at /policy.
+	// An init container copies the directory, so the image must provide the cp command
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	Image *string `json:"image,omitempty"`
+}
+
+type ApicastSpec struct {
+	// +optional
+	ManagementAPI *string `json:"managementAPI,omitempty"`
+	// +optional
+	OpenSSLVerify *bool `json:"openSSLVerify,omitempty"`
+	// +optional
+	ResponseCodes *bool `json:"responseCodes,omitempty"`
+	// +optional
+	RegistryURL *string `json:"registryURL,omitempty"`
+	// +optional
+	Image *string `json:"image,omitempty"`
+	// +optional
+	Production *ApicastProductionSpec `json:"production,omitempty"`
+	// +optional
+	Staging *ApicastStagingSpec `json:"staging,omitempty"`
+}
+
+type ApicastProductionSpec struct {
+	AutoscaledDeploymentSpec `json:",inline"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Workers *int32 `json:"workers,omitempty"`
+
+	ApicastGatewaySpec `json:",inline"`
+}
+
+type ApicastStagingSpec struct {
+	DeploymentSpec `json:",inline"`
+
+	ApicastGatewaySpec `json:",inline"`
+}
+
+// ApicastGatewaySpec configures the gateway of an APIcast environment
+type ApicastGatewaySpec struct {
+	// +optional
+	// +kubebuilder:validation:Enum=debug;info;notice;warn;error;crit;alert;emerg
+	LogLevel *string `json:"logLevel,omitempty"` // APICAST_LOG_LEVEL
+	// CustomPolicies specifies an array of defined custom policies to be loaded
+	// +optional
+	CustomPolicies []CustomPolicySpec `json:"customPolicies,omitempty"`
+	// OpenTelemetry contains the gateway instrumentation configuration
+	// with APIcast.
+	// +optional
+	OpenTelemetry *OpenTelemetrySpec `json:"openTelemetry,omitempty"`
+	// CustomEnvironments specifies an array of defined custom environments to be loaded
+	// +optional
+	CustomEnvironments []CustomEnvironmentSpec `json:"customEnvironments,omitempty"` // APICAST_ENVIRONMENT
+	// HTTPS enables TLS at APIcast pod level
+	// +optional
+	HTTPS *ApicastHTTPSSpec `json:"https,omitempty"`
+	// Proxy configures the HTTP(S) proxies used to connect to the upstream services
+	// +optional
+	Proxy *ApicastProxySpec `json:"proxy,omitempty"`
+	// ServiceCacheSize specifies the number of services that APICast can store in the internal cache
+	// +optional
+	ServiceCacheSize *int32 `json:"serviceCacheSize,omitempty"` // APICAST_SERVICE_CACHE_SIZE
+	// ConfigurationLoadMode sets when APIcast loads the configuration, either on startup (boot) or on the first request (lazy).
+	// Defaults to boot
+	// +kubebuilder:validation:Enum=boot;lazy
+	// +optional
+	ConfigurationLoadMode *string `json:"configurationLoadMode,omitempty"` // APICAST_CONFIGURATION_LOADER
+	// CacheConfigurationSeconds sets the period in seconds the configuration is cached for.
+	// -1 never reloads the configuration, 0 disables the cache and is only supported with the lazy load mode.
+	// Defaults to 300
+	// +kubebuilder:validation:Minimum=-1
+	// +optional
+	CacheConfigurationSeconds *int64 `json:"cacheConfigurationSeconds,omitempty"` // APICAST_CONFIGURATION_CACHE
+	// PathRoutingEnabled matches the request path, in addition to the host, to select the service
+	// +optional
+	PathRoutingEnabled *bool `json:"pathRoutingEnabled,omitempty"` // APICAST_PATH_ROUTING
+	// PathRoutingOnly selects the service by the request path only
+	// +optional
+	PathRoutingOnly *bool `json:"pathRoutingOnly,omitempty"` // APICAST_PATH_ROUTING_ONLY
+	// CacheMaxTime is the maximum time a response is cached when the upstream response does not set Cache-Control.
+	// Format is a number with an optional s, m, h or d unit, e.g. 1m
+	// +kubebuilder:validation:Pattern=`^[0-9]+[smhd]?$`
+	// +optional
+	CacheMaxTime *string `json:"cacheMaxTime,omitempty"` // APICAST_CACHE_MAX_TIME
+	// CacheStatusCodes is the space separated list of upstream response status codes that are cached, e.g. "200 302"
+	// +kubebuilder:validation:Pattern=`^[1-5][0-9]{2}( [1-5][0-9]{2})*$`
+	// +optional
+	CacheStatusCodes *string `json:"cacheStatusCodes,omitempty"` // APICAST_CACHE_STATUS_CODES
+	// UpstreamRetryCases is the space separated list of cases a request is retried on the next upstream server,
+	// e.g. "error timeout http_503". Used by the retry policy
+	// +optional
+	UpstreamRetryCases *string `json:"upstreamRetryCases,omitempty"` // APICAST_UPSTREAM_RETRY_CASES
+	// HTTPKeepaliveTimeout sets the timeout in seconds a keep-alive client connection stays open on the server side
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	HTTPKeepaliveTimeout *int32 `json:"httpKeepaliveTimeout,omitempty"` // HTTP_KEEPALIVE_TIMEOUT
+	// BatcherSharedMemorySize sets the shared memory size of the batcher policy, e.g. 20m
+	// +kubebuilder:validation:Pattern=`^[0-9]+[kKmM]?$`
+	// +optional
+	BatcherSharedMemorySize *string `json:"batcherSharedMemorySize,omitempty"` // APICAST_POLICY_BATCHER_SHARED_MEMORY_SIZE
+	// AccessLogFile sets the file the access logs are written to. Defaults to /dev/stdout
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	AccessLogFile *string `json:"accessLogFile,omitempty"` // APICAST_ACCESS_LOG_FILE
+}
+
+type ApicastHTTPSSpec struct {
+	// Port controls on which port APIcast should start listening for HTTPS connections.
+	// If this clashes with HTTP port it will be used only for HTTPS.
+	// +optional
+	Port *int32 `json:"port,omitempty"` // APICAST_HTTPS_PORT
+	// VerifyDepth defines the maximum length of the client certificate chain.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	VerifyDepth *int64 `json:"verifyDepth,omitempty"` // APICAST_HTTPS_VERIFY_DEPTH
+	// CertificateSecretRef references secret containing the X.509 certificate in the PEM format and the X.509 certificate secret key.
+	// +optional
+	CertificateSecretRef *v1.LocalObjectReference `json:"certificateSecretRef,omitempty"`
+}
+
+type ApicastProxySpec struct {
+	// AllProxy specifies a HTTP(S) proxy to be used for connecting to services if
+	// a protocol-specific proxy is not specified. Authentication is not supported.
+	// Format is <scheme>://<host>:<port>
+	// +optional
+	AllProxy *string `json:"allProxy,omitempty"` // ALL_PROXY
+	// HTTPProxy specifies a HTTP(S) Proxy to be used for connecting to HTTP services.
+	// Authentication is not supported. Format is <scheme>://<host>:<port>
+	// +optional
+	HTTPProxy *string `json:"httpProxy,omitempty"` // HTTP_PROXY
+	// HTTPSProxy specifies a HTTP(S) Proxy to be used for connecting to HTTPS services.
+	// Authentication is not supported. Format is <scheme>://<host>:<port>
+	// +optional
+	HTTPSProxy *string `json:"httpsProxy,omitempty"` // HTTPS_PROXY
+	// NoProxy specifies a comma-separated list of hostnames and domain
+	// names for which the requests should not be proxied. Setting to a single
+	// * character, which matches all hosts, effectively disables the proxy.
+	// +optional
+	NoProxy *string `json:"noProxy,omitempty"` // NO_PROXY
+}
+
+type BackendSpec struct {
+	// +optional
+	Image *string `json:"image,omitempty"`
+	// +optional
+	Redis *RedisSpec `json:"redis,omitempty"`
+	// +optional
+	Listener *AutoscaledDeploymentSpec `json:"listener,omitempty"`
+	// +optional
+	Worker *AutoscaledDeploymentSpec `json:"worker,omitempty"`
+	// +optional
+	Cron *DeploymentSpec `json:"cron,omitempty"`
+	// OpenTelemetry configures the instrumentation of the listener, worker and cron
+	// +optional
+	OpenTelemetry *ComponentOpenTelemetrySpec `json:"openTelemetry,omitempty"`
+}
+
+// RedisSpec configures an internal redis datastore
+type RedisSpec struct {
+	// +optional
+	Image *string `json:"image,omitempty"`
+	// +optional
+	PersistentVolumeClaim *RedisPersistentVolumeClaimSpec `json:"persistentVolumeClaim,omitempty"`
+	// +optional
+	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	ComponentPodSpec `json:",inline"`
+}
+
+type RedisPersistentVolumeClaimSpec struct {
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+}
+
+type SystemSpec struct {
+	// +optional
+	Image *string `json:"image,omitempty"`
+	// +optional
+	App *SystemAppSpec `json:"app,omitempty"`
+	// +optional
+	Sidekiq *DeploymentSpec `json:"sidekiq,omitempty"`
+	// +optional
+	Searchd *SystemSearchdSpec `json:"searchd,omitempty"`
+	// +optional
+	Memcached *MemcachedSpec `json:"memcached,omitempty"`
+	// +optional
+	Redis *RedisSpec `json:"redis,omitempty"`
+	// +optional
+	FileStorage *SystemFileStorageSpec `json:"fileStorage,omitempty"`
+	// +optional
+	Database *SystemDatabaseSpec `json:"database,omitempty"`
+	// SMTP configures the delivery of the system emails. When set, the operator
+	// manages the system-smtp secret from it
+	// +optional
+	SMTP *SystemSMTPSpec `json:"smtp,omitempty"`
+	// OpenTelemetry configures the instrumentation of system-app and system-sidekiq
+	// +optional
+	OpenTelemetry *ComponentOpenTelemetrySpec `json:"openTelemetry,omitempty"`
+}
+
+type SystemAppSpec struct {
+	// +optional
+	Replicas *int64 `json:"replicas,omitempty"`
+	// +optional
+	MasterContainerResources *v1.ResourceRequirements `json:"masterContainerResources,omitempty"`
+	// +optional
+	ProviderContainerResources *v1.ResourceRequirements `json:"providerContainerResources,omitempty"`
+	// +optional
+	DeveloperContainerResources *v1.ResourceRequirements `json:"developerContainerResources,omitempty"`
+
+	ComponentPodSpec `json:",inline"`
+}
+
+type SystemSearchdSpec struct {
+	// +optional
+	Image *string `json:"image,omitempty"`
+	// +optional
+	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+	// +optional
+	PersistentVolumeClaim *PVCGenericSpec `json:"persistentVolumeClaim,omitempty"`
+
+	ComponentPodSpec `json:",inline"`
+}
+
+type MemcachedSpec struct {
+	// +optional
+	Image *string `json:"image,omitempty"`
+	// +optional
+	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	ComponentPodSpec `json:",inline"`
+}
+
+type SystemFileStorageSpec struct {
+	// Union type. Only one of the fields can be set.
+	// +optional
+	PersistentVolumeClaim *PVCGenericSpec `json:"persistentVolumeClaim,omitempty"`
+	// +optional
+	SimpleStorageService *SystemS3Spec `json:"simpleStorageService,omitempty"`
+}
+
+type PVCGenericSpec struct {
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+	// Resources represents the minimum resources the volume should have.
+	// Ignored when VolumeName field is set
+	// +optional
+	Resources *PersistentVolumeClaimResources `json:"resources,omitempty"`
+	// VolumeName is the binding reference to the PersistentVolume backing this claim.
+	// +optional
+	VolumeName *string `json:"volumeName,omitempty"`
+}
+
+// PersistentVolumeClaimResources defines the resources configuration of a PersistentVolumeClaim
+type PersistentVolumeClaimResources struct {
+	// Storage Resource requests to be used on the PersistentVolumeClaim.
+	// To learn more about resource requests see:
+	// https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
+	Requests resource.Quantity `json:"requests"`
+}
+
+type SystemS3Spec struct {
+	ConfigurationSecretRef v1.LocalObjectReference `json:"configurationSecretRef"`
+	// STS authentication spec
+	// +optional
+	STS *STSSpec `json:"sts,omitempty"`
+}
+
+type STSSpec struct {
+	// Enable Secure Token Service for  short-term, limited-privilege security credentials
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// The ID the token is intended for
+	// +optional
+	Audience *string `json:"audience,omitempty"`
+}
+
+type SystemDatabaseSpec struct {
+	// Union type. Only one of the fields can be set
+	// +optional
+	MySQL *SystemMySQLSpec `json:"mysql,omitempty"`
+	// +optional
+	PostgreSQL *SystemPostgreSQLSpec `json:"postgresql,omitempty"`
+	// TLSEnabled connects the system components to the database with TLS
+	// +optional
+	TLSEnabled *bool `json:"tlsEnabled,omitempty"`
+}
+
+type SystemMySQLSpec struct {
+	// +optional
+	Image *string `json:"image,omitempty"`
+	// +optional
+	PersistentVolumeClaim *PVCGenericSpec `json:"persistentVolumeClaim,omitempty"`
+	// +optional
+	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	ComponentPodSpec `json:",inline"`
+}
+
+type SystemPostgreSQLSpec struct {
+	// +optional
+	Image *string `json:"image,omitempty"`
+	// +optional
+	PersistentVolumeClaim *PVCGenericSpec `json:"persistentVolumeClaim,omitempty"`
+	// +optional
+	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	ComponentPodSpec `json:",inline"`
+
+	// ConnectionPooler deploys PgBouncer in front of the system database.
+	// It is also honored when the system database is external.
+	// +optional
+	ConnectionPooler *SystemDatabaseConnectionPoolerSpec `json:"connectionPooler,omitempty"`
+}
+
+type SystemDatabaseConnectionPoolerSpec struct {
+	// Enabled controls whether PgBouncer is deployed and the system
+	// components connect to the database through it.
+	// By default it is not enabled.
+	Enabled bool `json:"enabled,omitempty"`
+	// +optional
+	Image *string `json:"image,omitempty"`
+	// PoolMode specifies when a server connection can be reused by other clients.
+	// +kubebuilder:validation:Enum=session;transaction
+	// +optional
+	PoolMode *string `json:"poolMode,omitempty"`
+	// DefaultPoolSize is the number of server connections allowed per user/database pair.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	DefaultPoolSize *int32 `json:"defaultPoolSize,omitempty"`
+	// MaxClientConnections is the maximum number of client connections allowed.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxClientConnections *int32 `json:"maxClientConnections,omitempty"`
+
+	DeploymentSpec `json:",inline"`
+}
+
+type SMTPAuthenticationMethod string
+
+type SMTPTLSMode string
+
+type SystemSMTPSpec struct {
+	// Host of the SMTP server
+	// +kubebuilder:validation:MinLength=1
+	Host string `json:"host"`
+	// Port of the SMTP server. Defaults to 587, or 465 with the tls mode
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+	// AuthenticationMethod with the SMTP server. Defaults to plain, or none without credentials
+	// +kubebuilder:validation:Enum=plain;login;cram_md5;none
+	// +optional
+	AuthenticationMethod *SMTPAuthenticationMethod `json:"authenticationMethod,omitempty"`
+	// TLSMode of the connection to the SMTP server. Defaults to starttls
+	// +kubebuilder:validation:Enum=none;starttls;tls
+	// +optional
+	TLSMode *SMTPTLSMode `json:"tlsMode,omitempty"`
+	// InsecureSkipVerify disables the verification of the SMTP server certificate
+	// +optional
+	InsecureSkipVerify *bool `json:"insecureSkipVerify,omitempty"`
+	// FromAddress of the system emails
+	// +kubebuilder:validation:Pattern=`^[^@\s]+@[^@\s]+$`
+	FromAddress string `json:"fromAddress"`
+	// Domain sent in the HELO command
+	// +optional
+	Domain *string `json:"domain,omitempty"`
+	// CredentialsSecretRef references the secret with the username and password keys.
+	// Required unless the authentication method is none
+	// +optional
+	CredentialsSecretRef *v1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+}
+
+type ZyncSpec struct {
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// +optional
+	Image *string `json:"image,omitempty"`
+	// +optional
+	App *DeploymentSpec `json:"app,omitempty"`
+	// +optional
+	Que *DeploymentSpec `json:"que,omitempty"`
+	// +optional
+	Database *ZyncDatabaseSpec `json:"database,omitempty"`
+	// OpenTelemetry configures the instrumentation of zync and zync-que
+	// +optional
+	OpenTelemetry *ComponentOpenTelemetrySpec `json:"openTelemetry,omitempty"`
+}
+
+// ZyncDatabaseSpec configures the internal postgresql database of zync
+type ZyncDatabaseSpec struct {
+	// +optional
+	Image *string `json:"image,omitempty"`
+	// +optional
+	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+	// TLSEnabled connects zync to the database with TLS
+	// +optional
+	TLSEnabled *bool `json:"tlsEnabled,omitempty"`
+
+	ComponentPodSpec `json:",inline"`
+}
+
+type ExternalComponentsSpec struct {
+	// +optional
+	System *ExternalSystemComponents `json:"system,omitempty"`
+	// +optional
+	Backend *ExternalBackendComponents `json:"backend,omitempty"`
+	// +optional
+	Zync *ExternalZyncComponents `json:"zync,omitempty"`
+}
+
+type ExternalSystemComponents struct {
+	// +optional
+	Redis *bool `json:"redis,omitempty"`
+	// +optional
+	Database *bool `json:"database,omitempty"`
+}
+
+type ExternalBackendComponents struct {
+	// +optional
+	Redis *bool `json:"redis,omitempty"`
+}
+
+type ExternalZyncComponents struct {
+	// +optional
+	Database *bool `json:"database,omitempty"`
+}
+
+type PodDisruptionBudgetSpec struct {
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+type MonitoringSpec struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// +optional
+	EnablePrometheusRules *bool `json:"enablePrometheusRules,omitempty"`
+	// EnableDatastoreExporters deploys the redis, mysql and postgresql exporters
+	// of the 3scale datastores along with their monitoring resources.
+	// Defaults to true when monitoring is enabled
+	// +optional
+	EnableDatastoreExporters *bool `json:"enableDatastoreExporters,omitempty"`
+	// AlertOverrides tunes the alerts of the generated PrometheusRules.
+	// Overridden alerts are kept in sync with the APIManager,
+	// the remaining alerts are only created.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	AlertOverrides []AlertOverrideSpec `json:"alertOverrides,omitempty"`
+	// Grafana configures the installed GrafanaDashboards
+	// +optional
+	Grafana *GrafanaSpec `json:"grafana,omitempty"`
+}
+
+// GrafanaSpec configures the placement of the GrafanaDashboards and the dashboards installed
+type GrafanaSpec struct {
+	// InstanceSelector labels select the Grafana instances the dashboards are installed in.
+	// Replaces the default apim-management=grafana selector with grafana-operator v5,
+	// added to the dashboard labels with grafana-operator v4.
+	// +optional
+	InstanceSelector map[string]string `json:"instanceSelector,omitempty"`
+	// Folder the dashboards are placed in
+	// +optional
+	Folder *string `json:"folder,omitempty"`
+	// Datasource is the name of the Prometheus datasource the dashboards query
+	// +optional
+	Datasource *string `json:"datasource,omitempty"`
+	// DisabledDashboards are the names of the built-in dashboards not installed, i.e. apicast-mainapp
+	// +optional
+	DisabledDashboards []string `json:"disabledDashboards,omitempty"`
+	// Dashboards are installed alongside the built-in ones
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Dashboards []GrafanaDashboardSpec `json:"dashboards,omitempty"`
+}
+
+// GrafanaDashboardSpec references a user supplied dashboard
+type GrafanaDashboardSpec struct {
+	// Name of the GrafanaDashboard
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+	// ConfigMapRef selects the ConfigMap key holding the dashboard JSON
+	ConfigMapRef v1.ConfigMapKeySelector `json:"configMapRef"`
+}
+
+// AlertOverrideSpec overrides the settings of a generated alert
+type AlertOverrideSpec struct {
+	// Name of the alert, i.e. ThreescaleApicastHttp4xxErrorRate
+	Name string `json:"name"`
+	// Enabled set to false removes the alert
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// Threshold replaces the value the alert expression is compared with
+	// +kubebuilder:validation:Pattern=`^-?[0-9]+(\.[0-9]+)?$`
+	// +optional
+	Threshold *string `json:"threshold,omitempty"`
+	// For is the time the alert condition must hold before firing, i.e. 5m
+	// +kubebuilder:validation:Pattern=`^([0-9]+(ms|s|m|h|d|w|y))+$`
+	// +optional
+	For *string `json:"for,omitempty"`
+	// Severity label of the alert
+	// +kubebuilder:validation:Enum=critical;warning;info
+	// +optional
+	Severity *string `json:"severity,omitempty"`
+	// Labels are added to the alert labels
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+type NetworkPoliciesSpec struct {
+	// Enabled controls whether the operator manages a NetworkPolicy
+	// for each 3scale component. By default it is not enabled.
+	Enabled bool `json:"enabled,omitempty"`
+	// IngressControllerNamespaceSelector selects the namespaces where the
+	// ingress controller runs. Defaults to the OpenShift router namespaces.
+	// +optional
+	IngressControllerNamespaceSelector *metav1.LabelSelector `json:"ingressControllerNamespaceSelector,omitempty"`
+	// MonitoringNamespaceSelector selects the namespaces allowed to scrape
+	// component metrics. Defaults to the OpenShift monitoring namespaces.
+	// +optional
+	MonitoringNamespaceSelector *metav1.LabelSelector `json:"monitoringNamespaceSelector,omitempty"`
+	// AllowedNamespaces lists extra namespaces allowed to reach the
+	// exposed components: apicast, backend-listener and system-app.
+	// +optional
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+}
+
+type MaintenanceMode string
+
+type MaintenanceSpec struct {
+	// Mode selects which Deployments are scaled to zero. The original replicas
+	// are restored when the maintenance field is removed
+	// +kubebuilder:validation:Enum=full;readOnly
+	Mode MaintenanceMode `json:"mode"`
+}
+
+// CertificatesSpec configures the expiry monitoring of the TLS certificates
+// referenced by the APIManager
+type CertificatesSpec struct {
+	// ExpiryWarningDays is the number of days before the expiry of a certificate
+	// the CertificateExpiring condition and alert are raised. Defaults to 30
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ExpiryWarningDays *int32 `json:"expiryWarningDays,omitempty"`
+}
+
+type OpenTelemetrySpec struct {
+	// Enabled controls whether OpenTelemetry integration with APIcast is enabled.
+	// By default it is not enabled.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// TracingConfigSecretRef contains a Secret reference the Opentelemetry configuration.
+	// The configuration file specification is defined in the Nginx instrumentation library repo
+	// https://github.com/open-telemetry/opentelemetry-cpp-contrib/tree/main/instrumentation/nginx
+	// +optional
+	TracingConfigSecretRef *v1.LocalObjectReference `json:"tracingConfigSecretRef,omitempty"`
+
+	// TracingConfigSecretKey contains the key of the secret to select the configuration from.
+	// if unspecified, the first secret key in lexicographical order will be selected.
+	// +optional
+	TracingConfigSecretKey *string `json:"tracingConfigSecretKey,omitempty"`
+}
+
+// ComponentOpenTelemetrySpec configures the OpenTelemetry instrumentation of the system, backend and zync components
+type ComponentOpenTelemetrySpec struct {
+	// Enabled controls whether the OpenTelemetry instrumentation is enabled.
+	// By default it is not enabled.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Endpoint is the OTLP endpoint traces are exported to. For example http://otel-collector:4318
+	// +kubebuilder:validation:Pattern=`^https?://`
+	Endpoint string `json:"endpoint"`
+
+	// SamplingRatio is the ratio of traces sampled when no parent span is sampled, between 0 and 1.
+	// Defaults to 1
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	// +optional
+	SamplingRatio *string `json:"samplingRatio,omitempty"`
+
+	// ResourceAttributes are added to the resource of every span
+	// +optional
+	ResourceAttributes map[string]string `json:"resourceAttributes,omitempty"`
+
+	// TLSSecretRef references a Secret with the `ca.crt` key to verify the endpoint certificate.
+	// The optional `tls.crt` and `tls.key` keys are used as client certificate
+	// +optional
+	TLSSecretRef *v1.LocalObjectReference `json:"tlsSecretRef,omitempty"`
+}
+
+// APIManagerStatus defines the observed state of APIManager
+type APIManagerStatus struct {
+	// Current state of the APIManager resource.
+	// Conditions represent the latest available observations of an object's state
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	Conditions common.Conditions `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,2,rep,name=conditions"`
+
+	// APIManager Deployments
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Deployments",xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses"
+	Deployments olm.DeploymentStatus `json:"deployments"`
+
+	// Per component status
+	// +optional
+	Components *APIManagerComponentsStatus `json:"components,omitempty"`
+
+	// Sizing profile and the effective sizing of the Deployments
+	// +optional
+	Profile *APIManagerProfileStatus `json:"profile,omitempty"`
+
+	// Expiry of the TLS certificates referenced by the APIManager
+	// +optional
+	Certificates []APIManagerCertificateStatus `json:"certificates,omitempty"`
+}
+
+type APIManagerCertificateStatus struct {
+	// Name identifies the certificate, i.e. apicast-production-https or route-backend
+	Name string `json:"name"`
+
+	// Kind of the object holding the certificate, Secret or Route
+	Kind string `json:"kind"`
+
+	// ObjectName is the name of the object holding the certificate
+	ObjectName string `json:"objectName"`
+
+	// Subject of the certificate
+	// +optional
+	Subject string `json:"subject,omitempty"`
+
+	// NotAfter is the expiry time of the certificate
+	NotAfter metav1.Time `json:"notAfter"`
+}
+
+type APIManagerProfileStatus struct {
+	// Name of the selected sizing profile
+	Name APIManagerProfile `json:"name"`
+
+	// Effective sizing of the Deployments
+	// +optional
+	Deployments []APIManagerDeploymentSizing `json:"deployments,omitempty"`
+}
+
+type APIManagerDeploymentSizing struct {
+	// Name of the Deployment
+	Name string `json:"name"`
+
+	// Replicas of the Deployment
+	Replicas int32 `json:"replicas"`
+
+	// MinReplicas of the Deployment HPA
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas of the Deployment HPA
+	// +optional
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+
+	// Workers of the Deployment main process
+	// +optional
+	Workers *int32 `json:"workers,omitempty"`
+
+	// Resources of the Deployment containers, indexed by container name
+	// +optional
+	Resources map[string]v1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+type APIManagerComponentsStatus struct {
+	// +optional
+	Apicast *APIManagerComponentStatus `json:"apicast,omitempty"`
+	// +optional
+	Backend *APIManagerComponentStatus `json:"backend,omitempty"`
+	// +optional
+	System *APIManagerComponentStatus `json:"system,omitempty"`
+	// +optional
+	Zync *APIManagerComponentStatus `json:"zync,omitempty"`
+	// +optional
+	Memcached *APIManagerComponentStatus `json:"memcached,omitempty"`
+	// +optional
+	Searchd *APIManagerComponentStatus `json:"searchd,omitempty"`
+}
+
+type APIManagerComponentStatus struct {
+	// Ready is true when all the Deployments of the component are available
+	Ready bool `json:"ready"`
+
+	// ReadyReplicas is the number of ready pods summed over the Deployments of the component
+	ReadyReplicas int32 `json:"readyReplicas"`
+
+	// DesiredReplicas is the number of desired pods summed over the Deployments of the component
+	DesiredReplicas int32 `json:"desiredReplicas"`
+
+	// Image running in the main container of the component
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Version of the component
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// LastRolloutTime is the last time any of the Deployments of the component progressed
+	// +optional
+	LastRolloutTime *metav1.Time `json:"lastRolloutTime,omitempty"`
+
+	// Reason of the component degradation. Empty when the component is ready
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message describing the component degradation. Empty when the component is ready
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Externally reachable URLs of the component
+	// +optional
+	Endpoints []APIManagerComponentEndpoint `json:"endpoints,omitempty"`
+}
+
+type APIManagerComponentEndpoint struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// APIManager is the Schema for the apimanagers API
+// +kubebuilder:resource:path=apimanagers,scope=Namespaced
+// +operator-sdk:csv:customresourcedefinitions:displayName="APIManager"
+// +operator-sdk:csv:customresourcedefinitions:resources={{"Deployment","apps/v1"}}
+// +operator-sdk:csv:customresourcedefinitions:resources={{"ConfigMap","v1"}}
+// +operator-sdk:csv:customresourcedefinitions:resources={{"PersistentVolumeClaim","v1"}}
+// +operator-sdk:csv:customresourcedefinitions:resources={{"Service","v1"}}
+// +operator-sdk:csv:customresourcedefinitions:resources={{"Route","route.openshift.io/v1"}}
+type APIManager struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec APIManagerSpec `json:"spec,omitempty"`
+
+	Status APIManagerStatus `json:"status,omitempty"`
+}
+
+// Hub marks v1beta1 as the version the other APIManager versions are converted through
+func (*APIManager) Hub() {}
+
+// +kubebuilder:object:root=true
+
+// APIManagerList contains a list of APIManager
+type APIManagerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []APIManager `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&APIManager{}, &APIManagerList{})
+}
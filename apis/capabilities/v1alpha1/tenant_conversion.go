@@ -0,0 +1,152 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+)
+
+// V1beta1FieldsAnnotation keeps the v1beta1 fields not available in v1alpha1,
+// so reading a Tenant in v1alpha1 and writing it back does not lose them
+const V1beta1FieldsAnnotation = "capabilities.3scale.net/v1beta1-fields"
+
+// tenantV1beta1Fields are the v1beta1 fields without v1alpha1 counterpart
+type tenantV1beta1Fields struct {
+	State           *capabilitiesv1beta1.TenantState          `json:"state,omitempty"`
+	ProviderPlan    *string                                   `json:"providerPlan,omitempty"`
+	AdminDomain     *string                                   `json:"adminDomain,omitempty"`
+	DeveloperDomain *string                                   `json:"developerDomain,omitempty"`
+	DeletionPolicy  *capabilitiesv1beta1.TenantDeletionPolicy `json:"deletionPolicy,omitempty"`
+	Status          *tenantV1beta1StatusFields                `json:"status,omitempty"`
+}
+
+type tenantV1beta1StatusFields struct {
+	AccountState    string `json:"accountState,omitempty"`
+	ProviderPlan    string `json:"providerPlan,omitempty"`
+	AdminDomain     string `json:"adminDomain,omitempty"`
+	DeveloperDomain string `json:"developerDomain,omitempty"`
+}
+
+// ConvertTo converts this Tenant to the Hub version (v1beta1)
+func (src *Tenant) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*capabilitiesv1beta1.Tenant)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = capabilitiesv1beta1.TenantSpec{
+		Username:               src.Spec.Username,
+		Email:                  src.Spec.Email,
+		OrganizationName:       src.Spec.OrganizationName,
+		SystemMasterUrl:        src.Spec.SystemMasterUrl,
+		TenantSecretRef:        src.Spec.TenantSecretRef,
+		PasswordCredentialsRef: src.Spec.PasswordCredentialsRef,
+		MasterCredentialsRef:   src.Spec.MasterCredentialsRef,
+		FromEmail:              src.Spec.FromEmail,
+		SupportEmail:           src.Spec.SupportEmail,
+		FinanceSupportEmail:    src.Spec.FinanceSupportEmail,
+		SiteAccessCode:         src.Spec.SiteAccessCode,
+	}
+	dst.Status = capabilitiesv1beta1.TenantStatus{
+		TenantId:   src.Status.TenantId,
+		AdminId:    src.Status.AdminId,
+		Conditions: src.Status.Conditions,
+	}
+
+	fieldsJSON, ok := src.Annotations[V1beta1FieldsAnnotation]
+	if !ok {
+		return nil
+	}
+
+	fields := &tenantV1beta1Fields{}
+	if err := json.Unmarshal([]byte(fieldsJSON), fields); err != nil {
+		return fmt.Errorf("failed to unmarshal the %s annotation: %w", V1beta1FieldsAnnotation, err)
+	}
+	dst.Spec.State = fields.State
+	dst.Spec.ProviderPlan = fields.ProviderPlan
+	dst.Spec.AdminDomain = fields.AdminDomain
+	dst.Spec.DeveloperDomain = fields.DeveloperDomain
+	dst.Spec.DeletionPolicy = fields.DeletionPolicy
+	if fields.Status != nil {
+		dst.Status.AccountState = fields.Status.AccountState
+		dst.Status.ProviderPlan = fields.Status.ProviderPlan
+		dst.Status.AdminDomain = fields.Status.AdminDomain
+		dst.Status.DeveloperDomain = fields.Status.DeveloperDomain
+	}
+	dst.Annotations = withoutV1beta1FieldsAnnotation(src.Annotations)
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version
+func (dst *Tenant) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*capabilitiesv1beta1.Tenant)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = TenantSpec{
+		Username:               src.Spec.Username,
+		Email:                  src.Spec.Email,
+		OrganizationName:       src.Spec.OrganizationName,
+		SystemMasterUrl:        src.Spec.SystemMasterUrl,
+		TenantSecretRef:        src.Spec.TenantSecretRef,
+		PasswordCredentialsRef: src.Spec.PasswordCredentialsRef,
+		MasterCredentialsRef:   src.Spec.MasterCredentialsRef,
+		FromEmail:              src.Spec.FromEmail,
+		SupportEmail:           src.Spec.SupportEmail,
+		FinanceSupportEmail:    src.Spec.FinanceSupportEmail,
+		SiteAccessCode:         src.Spec.SiteAccessCode,
+	}
+	dst.Status = TenantStatus{
+		TenantId:   src.Status.TenantId,
+		AdminId:    src.Status.AdminId,
+		Conditions: src.Status.Conditions,
+	}
+
+	fields := &tenantV1beta1Fields{
+		State:           src.Spec.State,
+		ProviderPlan:    src.Spec.ProviderPlan,
+		AdminDomain:     src.Spec.AdminDomain,
+		DeveloperDomain: src.Spec.DeveloperDomain,
+		DeletionPolicy:  src.Spec.DeletionPolicy,
+	}
+	status := tenantV1beta1StatusFields{
+		AccountState:    src.Status.AccountState,
+		ProviderPlan:    src.Status.ProviderPlan,
+		AdminDomain:     src.Status.AdminDomain,
+		DeveloperDomain: src.Status.DeveloperDomain,
+	}
+	if status != (tenantV1beta1StatusFields{}) {
+		fields.Status = &status
+	}
+	if *fields == (tenantV1beta1Fields{}) {
+		return nil
+	}
+
+	fieldsJSON, err := json.Marshal(fields)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the v1beta1 fields: %w", err)
+	}
+	// the source annotations are not modified
+	dst.Annotations = withoutV1beta1FieldsAnnotation(src.Annotations)
+	if dst.Annotations == nil {
+		dst.Annotations = map[string]string{}
+	}
+	dst.Annotations[V1beta1FieldsAnnotation] = string(fieldsJSON)
+
+	return nil
+}
+
+func withoutV1beta1FieldsAnnotation(annotations map[string]string) map[string]string {
+	var result map[string]string
+	for key, value := range annotations {
+		if key == V1beta1FieldsAnnotation {
+			continue
+		}
+		if result == nil {
+			result = map[string]string{}
+		}
+		result[key] = value
+	}
+	return result
+}
@@ -0,0 +1,162 @@
+package v1alpha1
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/apispkg/common"
+)
+
+func testTenant() *Tenant {
+	return &Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "example-tenant",
+			Namespace:   "3scale",
+			Annotations: map[string]string{"tenantID": "3"},
+		},
+		Spec: TenantSpec{
+			Username:               "admin",
+			Email:                  "admin@example.com",
+			OrganizationName:       "example",
+			SystemMasterUrl:        "https://master.example.com",
+			TenantSecretRef:        corev1.SecretReference{Name: "tenant-secret", Namespace: "3scale"},
+			PasswordCredentialsRef: corev1.SecretReference{Name: "password", Namespace: "3scale"},
+			MasterCredentialsRef:   corev1.SecretReference{Name: "master", Namespace: "3scale"},
+			FromEmail:              &[]string{"from@example.com"}[0],
+			SupportEmail:           &[]string{"support@example.com"}[0],
+			FinanceSupportEmail:    &[]string{"finance@example.com"}[0],
+			SiteAccessCode:         &[]string{"code"}[0],
+		},
+		Status: TenantStatus{
+			TenantId: 3,
+			AdminId:  4,
+			Conditions: common.Conditions{
+				{Type: "Ready", Status: corev1.ConditionTrue},
+			},
+		},
+	}
+}
+
+func testHubTenant() *capabilitiesv1beta1.Tenant {
+	tenant := &capabilitiesv1beta1.Tenant{}
+	if err := testTenant().ConvertTo(tenant); err != nil {
+		panic(err)
+	}
+	state := capabilitiesv1beta1.TenantStateSuspended
+	deletionPolicy := capabilitiesv1beta1.TenantDeletionPolicyRetain
+	tenant.Spec.State = &state
+	tenant.Spec.ProviderPlan = &[]string{"enterprise"}[0]
+	tenant.Spec.AdminDomain = &[]string{"example-admin.example.com"}[0]
+	tenant.Spec.DeveloperDomain = &[]string{"example.example.com"}[0]
+	tenant.Spec.DeletionPolicy = &deletionPolicy
+	tenant.Status.AccountState = "suspended"
+	tenant.Status.ProviderPlan = "enterprise"
+	tenant.Status.AdminDomain = "example-admin.example.com"
+	tenant.Status.DeveloperDomain = "example.example.com"
+	return tenant
+}
+
+func TestTenantConversionRoundTrip(t *testing.T) {
+	cases := []struct {
+		testName string
+		tenant   *Tenant
+	}{
+		{"every field", testTenant()},
+		{"empty", &Tenant{}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			original := tc.tenant.DeepCopy()
+
+			hub := &capabilitiesv1beta1.Tenant{}
+			if err := tc.tenant.ConvertTo(hub); err != nil {
+				subT.Fatal(err)
+			}
+			converted := &Tenant{}
+			if err := converted.ConvertFrom(hub); err != nil {
+				subT.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(original, converted) {
+				subT.Errorf("round trip through v1beta1 is not lossless: %s", cmp.Diff(original, converted))
+			}
+		})
+	}
+}
+
+func TestTenantHubConversionRoundTrip(t *testing.T) {
+	statusOnly := &capabilitiesv1beta1.Tenant{Status: capabilitiesv1beta1.TenantStatus{AccountState: "approved"}}
+
+	cases := []struct {
+		testName string
+		tenant   *capabilitiesv1beta1.Tenant
+	}{
+		{"every field", testHubTenant()},
+		{"status only", statusOnly},
+		{"empty", &capabilitiesv1beta1.Tenant{}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			original := tc.tenant.DeepCopy()
+
+			spoke := &Tenant{}
+			if err := spoke.ConvertFrom(tc.tenant); err != nil {
+				subT.Fatal(err)
+			}
+			converted := &capabilitiesv1beta1.Tenant{}
+			if err := spoke.ConvertTo(converted); err != nil {
+				subT.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(original, converted) {
+				subT.Errorf("round trip through v1alpha1 is not lossless: %s", cmp.Diff(original, converted))
+			}
+			if !reflect.DeepEqual(original, tc.tenant) {
+				subT.Errorf("conversion modified the source: %s", cmp.Diff(original, tc.tenant))
+			}
+		})
+	}
+}
+
+func TestTenantConversionV1beta1FieldsAnnotation(t *testing.T) {
+	spoke := &Tenant{}
+	if err := spoke.ConvertFrom(testHubTenant()); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := spoke.Annotations[V1beta1FieldsAnnotation]; !ok {
+		t.Fatalf("v1beta1 fields annotation not set: %v", spoke.Annotations)
+	}
+	if spoke.Annotations["tenantID"] != "3" {
+		t.Errorf("annotations not kept: %v", spoke.Annotations)
+	}
+
+	// the annotation is not exposed in v1beta1
+	hub := &capabilitiesv1beta1.Tenant{}
+	if err := spoke.ConvertTo(hub); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := hub.Annotations[V1beta1FieldsAnnotation]; ok {
+		t.Errorf("v1beta1 fields annotation exposed in v1beta1: %v", hub.Annotations)
+	}
+
+	// no annotation when the v1beta1 fields are not set
+	spoke = &Tenant{}
+	if err := spoke.ConvertFrom(&capabilitiesv1beta1.Tenant{}); err != nil {
+		t.Fatal(err)
+	}
+	if spoke.Annotations != nil {
+		t.Errorf("unexpected annotations: %v", spoke.Annotations)
+	}
+
+	spoke.Annotations = map[string]string{V1beta1FieldsAnnotation: "{"}
+	if err := spoke.ConvertTo(hub); err == nil {
+		t.Error("expected an error with an invalid annotation")
+	}
+}
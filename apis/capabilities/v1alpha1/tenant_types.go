@@ -17,14 +17,8 @@ limitations under the License.
 package v1alpha1
 
 import (
-	"fmt"
-	"reflect"
-	"strings"
-
-	"github.com/go-logr/logr"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/3scale/3scale-operator/pkg/apispkg/common"
 )
@@ -91,114 +85,6 @@ type Tenant struct {
 	Status TenantStatus `json:"status,omitempty"`
 }
 
-// SetDefaults sets the default vaules for the tenant spec and returns true if the spec was changed
-func (t *Tenant) SetDefaults() bool {
-	changed := false
-	ts := &t.Spec
-	if ts.TenantSecretRef.Name == "" {
-		ts.TenantSecretRef.Name = fmt.Sprintf("%s-%s", strings.ToLower(t.Name), strings.ToLower(t.Spec.OrganizationName))
-		changed = true
-	}
-	if ts.TenantSecretRef.Namespace == "" {
-		ts.TenantSecretRef.Namespace = t.Namespace
-		changed = true
-	}
-	return changed
-}
-
-func (t *Tenant) MasterSecretKey() client.ObjectKey {
-	namespace := t.Spec.MasterCredentialsRef.Namespace
-
-	if namespace == "" {
-		namespace = t.Namespace
-	}
-
-	return client.ObjectKey{
-		Name:      t.Spec.MasterCredentialsRef.Name,
-		Namespace: namespace,
-	}
-}
-
-func (t *Tenant) AdminPassSecretKey() client.ObjectKey {
-	namespace := t.Spec.PasswordCredentialsRef.Namespace
-
-	if namespace == "" {
-		namespace = t.Namespace
-	}
-
-	return client.ObjectKey{
-		Name:      t.Spec.PasswordCredentialsRef.Name,
-		Namespace: namespace,
-	}
-}
-
-func (t *Tenant) TenantSecretKey() client.ObjectKey {
-	namespace := t.Spec.TenantSecretRef.Namespace
-
-	if namespace == "" {
-		namespace = t.Namespace
-	}
-
-	return client.ObjectKey{
-		Name:      t.Spec.TenantSecretRef.Name,
-		Namespace: namespace,
-	}
-}
-
-func (b *Tenant) SpecEqual(other *Tenant, logger logr.Logger) bool {
-	equal := true
-
-	if !reflect.DeepEqual(b.ObjectMeta, other.ObjectMeta) || !reflect.DeepEqual(b.Spec, other.Spec) {
-		equal = false
-	}
-
-	return equal
-}
-
-func (b *TenantStatus) StatusEqual(other *TenantStatus, logger logr.Logger) bool {
-	equal := true
-
-	if b.TenantId != other.TenantId {
-		equal = false
-	}
-
-	if b.AdminId != other.AdminId {
-		equal = false
-	}
-
-	if other.Conditions == nil {
-		equal = false
-	}
-
-	equal = conditionsEqual(TenantReadyConditionType, b.Conditions, other.Conditions)
-
-	return equal
-}
-
-// Compare conditions of a specific type
-func conditionsEqual(typeToCompare common.ConditionType, currentConditions, incomingConditions []common.Condition) bool {
-	for _, condition1 := range incomingConditions {
-		if condition1.Type != typeToCompare {
-			continue
-		}
-
-		// Find the corresponding condition in conditions2
-		var condition2 common.Condition
-		for _, c := range currentConditions {
-			if c.Type == typeToCompare {
-				condition2 = c
-				break
-			}
-		}
-
-		// Compare the status and message
-		if condition1.Status != condition2.Status || condition1.Message != condition2.Message {
-			return false
-		}
-	}
-	return true
-}
-
 // +kubebuilder:object:root=true
 
 // TenantList contains a list of Tenant
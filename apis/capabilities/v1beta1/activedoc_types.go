@@ -214,6 +214,13 @@ func (a *ActiveDoc) SetDefaults(logger logr.Logger) bool {
 
 func (a *ActiveDoc) Validate() field.ErrorList {
 	errors := field.ErrorList{}
+
+	// spec.activeDocOpenAPIRef is oneOf by CRD openapiV3 validation
+	openapiRefFldPath := field.NewPath("spec").Child("activeDocOpenAPIRef")
+	if (a.Spec.ActiveDocOpenAPIRef.SecretRef == nil) == (a.Spec.ActiveDocOpenAPIRef.URL == nil) {
+		errors = append(errors, field.Invalid(openapiRefFldPath, a.Spec.ActiveDocOpenAPIRef, "exactly one of secretRef or url must be set."))
+	}
+
 	return errors
 }
 
@@ -29,6 +29,8 @@ import (
 )
 
 const (
+	ApplicationKind = "Application"
+
 	ApplicationReadyConditionType common.ConditionType = "Ready"
 )
 
@@ -28,6 +28,8 @@ import (
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
 const (
+	ApplicationAuthKind = "ApplicationAuth"
+
 	ApplicationAuthReadyConditionType  common.ConditionType = "Ready"
 	ApplicationAuthFailedConditionType common.ConditionType = "Failed"
 )
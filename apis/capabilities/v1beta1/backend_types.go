@@ -256,6 +256,14 @@ func (backend *Backend) Validate() field.ErrorList {
 			errors = append(errors, field.Invalid(mappingRulesIdxFldPath, spec.MetricMethodRef, "mappingrule does not have valid metric or method reference."))
 		}
 	}
+
+	// Check mapping rules patterns
+	for idx, spec := range backend.Spec.MappingRules {
+		if msg := validateMappingRulePattern(spec.Pattern); msg != "" {
+			patternFldPath := mappingRulesFldPath.Index(idx).Child("pattern")
+			errors = append(errors, field.Invalid(patternFldPath, spec.Pattern, msg))
+		}
+	}
 	return errors
 }
 
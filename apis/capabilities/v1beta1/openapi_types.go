@@ -200,6 +200,13 @@ func (o *OpenAPI) SetDefaults(logger logr.Logger) bool {
 
 func (o *OpenAPI) Validate() field.ErrorList {
 	errors := field.ErrorList{}
+
+	// spec.openapiRef is oneOf by CRD openapiV3 validation
+	openapiRefFldPath := field.NewPath("spec").Child("openapiRef")
+	if (o.Spec.OpenAPIRef.SecretRef == nil) == (o.Spec.OpenAPIRef.URL == nil) {
+		errors = append(errors, field.Invalid(openapiRefFldPath, o.Spec.OpenAPIRef, "exactly one of secretRef or url must be set."))
+	}
+
 	return errors
 }
 
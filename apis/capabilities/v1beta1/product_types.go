@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"strings"
 
 	"github.com/3scale/3scale-operator/pkg/apispkg/common"
 	"github.com/go-logr/logr"
@@ -1316,6 +1317,42 @@ func (product *Product) Validate() field.ErrorList {
 		}
 	}
 
+	// Check mapping rules patterns
+	for idx, spec := range product.Spec.MappingRules {
+		if msg := validateMappingRulePattern(spec.Pattern); msg != "" {
+			patternFldPath := mappingRulesFldPath.Index(idx).Child("pattern")
+			errors = append(errors, field.Invalid(patternFldPath, spec.Pattern, msg))
+		}
+	}
+
+	// Check deployment and authentication options are mutually exclusive
+	if product.Spec.Deployment != nil {
+		deploymentFldPath := specFldPath.Child("deployment")
+		if product.Spec.Deployment.ApicastHosted != nil && product.Spec.Deployment.ApicastSelfManaged != nil {
+			errors = append(errors, field.Invalid(deploymentFldPath, product.Spec.Deployment.DeploymentOption(), "only one of apicastHosted or apicastSelfManaged can be set."))
+		}
+
+		var authentication *AuthenticationSpec
+		authenticationFldPath := deploymentFldPath.Child("apicastSelfManaged").Child("authentication")
+		if product.Spec.Deployment.ApicastHosted != nil {
+			authentication = product.Spec.Deployment.ApicastHosted.Authentication
+			authenticationFldPath = deploymentFldPath.Child("apicastHosted").Child("authentication")
+		} else if product.Spec.Deployment.ApicastSelfManaged != nil {
+			authentication = product.Spec.Deployment.ApicastSelfManaged.Authentication
+		}
+		if authentication != nil {
+			options := 0
+			for _, set := range []bool{authentication.UserKeyAuthentication != nil, authentication.AppKeyAppIDAuthentication != nil, authentication.OIDC != nil} {
+				if set {
+					options++
+				}
+			}
+			if options != 1 {
+				errors = append(errors, field.Invalid(authenticationFldPath, options, "exactly one of userkey, appKeyAppID or oidc must be set."))
+			}
+		}
+	}
+
 	// Check application plan limits local metricOrMethod ref exists
 	for planSystemName, planSpec := range product.Spec.ApplicationPlans {
 		planFldPath := applicationPlansFldPath.Key(planSystemName)
@@ -1408,6 +1445,58 @@ func (product *Product) FindMetricOrMethod(ref string) bool {
 	return false
 }
 
+// validateMappingRulePattern returns why the pattern is not accepted by 3scale, empty when valid.
+// Patterns start with a slash, may use {placeholders} in the path and end with $ for exact matching
+func validateMappingRulePattern(pattern string) string {
+	if !strings.HasPrefix(pattern, "/") {
+		return "pattern must start with '/'."
+	}
+
+	if strings.ContainsAny(pattern, " \t\r\n") {
+		return "pattern must not contain whitespaces."
+	}
+
+	if idx := strings.Index(pattern, "$"); idx >= 0 && idx != len(pattern)-1 {
+		return "'$' is only allowed at the end of the pattern."
+	}
+
+	path := pattern
+	if idx := strings.Index(pattern, "?"); idx >= 0 {
+		path = pattern[:idx]
+	}
+	open := false
+	placeholderLen := 0
+	for _, c := range path {
+		switch c {
+		case '{':
+			if open {
+				return "placeholders cannot be nested."
+			}
+			open = true
+			placeholderLen = 0
+		case '}':
+			if !open {
+				return "unbalanced '}' in pattern."
+			}
+			if placeholderLen == 0 {
+				return "placeholders cannot be empty."
+			}
+			open = false
+		case '/':
+			if open {
+				return "placeholders cannot contain '/'."
+			}
+		default:
+			placeholderLen++
+		}
+	}
+	if open {
+		return "unbalanced '{' in pattern."
+	}
+
+	return ""
+}
+
 func detectOverlappingPricingRuleRanges(rules []PricingRuleSpec) int {
 	rulesPerMetricMap := make(map[string][]PricingRuleSpec)
 	for _, spec := range rules {
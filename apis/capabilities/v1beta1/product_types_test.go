@@ -260,3 +260,61 @@ func TestValidateProductHappyPath(t *testing.T) {
 		t.Errorf("product validation fails: %s", errors.ToAggregate().Error())
 	}
 }
+
+func TestValidateProductMappingRulePatterns(t *testing.T) {
+	cases := []struct {
+		pattern string
+		valid   bool
+	}{
+		{"/", true},
+		{"/pets/{id}/owners$", true},
+		{"/pets?name={name}&status=available", true},
+		{"pets", false},
+		{"/pets/{id", false},
+		{"/pets/id}", false},
+		{"/pets/{}", false},
+		{"/pets/{{id}}", false},
+		{"/pets/{id/owner}", false},
+		{"/pets$/owners", false},
+		{"/pets /owners", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.pattern, func(subT *testing.T) {
+			product := defaultTestingProduct()
+			product.Spec.MappingRules = []MappingRuleSpec{
+				{
+					HTTPMethod:      "GET",
+					Pattern:         tc.pattern,
+					MetricMethodRef: "hits",
+					Increment:       1,
+				},
+			}
+
+			errors := product.Validate()
+			if tc.valid && len(errors) > 0 {
+				subT.Errorf("pattern should be valid: %s", errors.ToAggregate().Error())
+			}
+			if !tc.valid && (len(errors) == 0 || errors[0].Field != "spec.mappingRules[0].pattern") {
+				subT.Errorf("pattern should be invalid: %v", errors)
+			}
+		})
+	}
+}
+
+func TestValidateProductAuthenticationUnion(t *testing.T) {
+	product := defaultTestingProduct()
+	product.Spec.Deployment = &ProductDeploymentSpec{
+		ApicastHosted: &ApicastHostedSpec{
+			Authentication: &AuthenticationSpec{
+				UserKeyAuthentication:     &UserKeyAuthenticationSpec{},
+				AppKeyAppIDAuthentication: &AppKeyAppIDAuthenticationSpec{},
+			},
+		},
+	}
+
+	errors := product.Validate()
+	if len(errors) == 0 || !strings.Contains(errors.ToAggregate().Error(), "exactly one of userkey, appKeyAppID or oidc must be set") {
+		t.Error("product validation fails when several authentication options are set")
+	}
+}
@@ -0,0 +1,305 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/3scale/3scale-operator/pkg/apispkg/common"
+)
+
+const (
+	SmokeTestKind = "SmokeTest"
+
+	// SmokeTestReadyConditionType indicates the last smoke test run passed
+	SmokeTestReadyConditionType common.ConditionType = "Ready"
+	// SmokeTestFailedConditionType indicates the last smoke test run failed or could not be run
+	SmokeTestFailedConditionType common.ConditionType = "Failed"
+	// SmokeTestRunningConditionType indicates a smoke test run is in progress
+	SmokeTestRunningConditionType common.ConditionType = "Running"
+
+	SmokeTestEnvironmentStaging    = "staging"
+	SmokeTestEnvironmentProduction = "production"
+
+	SmokeTestDefaultIntervalSeconds int32 = 3600
+	SmokeTestDefaultHistoryLimit    int32 = 10
+)
+
+// SmokeTestSpec defines the desired state of SmokeTest
+type SmokeTestSpec struct {
+	// Product CR metadata name. Requests are sent through the APIcast endpoints of the product
+	ProductCRName string `json:"productCRName"`
+
+	// AuthSecretRef references the secret with the application credentials, in the ApplicationAuth secret format:
+	// either UserKey, or ApplicationID and ApplicationKey
+	AuthSecretRef *corev1.LocalObjectReference `json:"authSecretRef"`
+
+	// Environments the requests are sent to. Defaults to staging and production
+	// +optional
+	Environments []SmokeTestEnvironment `json:"environments,omitempty"`
+
+	// Requests sent in each environment
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=20
+	Requests []SmokeTestRequestSpec `json:"requests"`
+
+	// IntervalSeconds between the start of two runs. Defaults to 3600
+	// +kubebuilder:validation:Minimum=60
+	// +optional
+	IntervalSeconds *int32 `json:"intervalSeconds,omitempty"`
+
+	// VerifyUsage checks that backend reported the hits of the requests answered with a 2xx status code. Defaults to true
+	// +optional
+	VerifyUsage *bool `json:"verifyUsage,omitempty"`
+
+	// HistoryLimit is the number of runs kept in the status history. Defaults to 10
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=50
+	// +optional
+	HistoryLimit *int32 `json:"historyLimit,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=staging;production
+type SmokeTestEnvironment string
+
+type SmokeTestRequestSpec struct {
+	// Method of the request. Defaults to GET
+	// +kubebuilder:validation:Enum=GET;HEAD;POST;PUT;DELETE;OPTIONS;PATCH
+	// +optional
+	Method *string `json:"method,omitempty"`
+
+	// Path of the request, including the query string
+	// +kubebuilder:validation:Pattern=`^/`
+	Path string `json:"path"`
+
+	// Headers of the request
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// Body of the request
+	// +optional
+	Body *string `json:"body,omitempty"`
+
+	// ExpectedStatusCode of the response. Defaults to 200
+	// +kubebuilder:validation:Minimum=100
+	// +kubebuilder:validation:Maximum=599
+	// +optional
+	ExpectedStatusCode *int32 `json:"expectedStatusCode,omitempty"`
+}
+
+func (r *SmokeTestRequestSpec) MethodOrDefault() string {
+	if r.Method == nil {
+		return "GET"
+	}
+	return *r.Method
+}
+
+func (r *SmokeTestRequestSpec) ExpectedStatusCodeOrDefault() int32 {
+	if r.ExpectedStatusCode == nil {
+		return 200
+	}
+	return *r.ExpectedStatusCode
+}
+
+// SmokeTestStatus defines the observed state of SmokeTest
+type SmokeTestStatus struct {
+	// ProductID of the tested product
+	// +optional
+	ProductID *int64 `json:"productID,omitempty"`
+
+	// LastRun is the result of the last completed run
+	// +optional
+	LastRun *SmokeTestRunStatus `json:"lastRun,omitempty"`
+
+	// History of the completed runs, most recent first
+	// +optional
+	History []SmokeTestRunSummary `json:"history,omitempty"`
+
+	// LastFailureReason is the reason of the last failed run
+	// +optional
+	LastFailureReason string `json:"lastFailureReason,omitempty"`
+
+	// LastFailureTime is the start time of the last failed run
+	// +optional
+	LastFailureTime *metav1.Time `json:"lastFailureTime,omitempty"`
+
+	// ObservedGeneration reflects the generation of the most recently observed spec.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Current state of the SmokeTest resource.
+	// Conditions represent the latest available observations of an object's state
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	Conditions common.Conditions `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,2,rep,name=conditions"`
+}
+
+type SmokeTestRunSummary struct {
+	// StartTime of the run
+	StartTime metav1.Time `json:"startTime"`
+
+	// CompletionTime of the run
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Passed is true when all requests got the expected status code and backend reported their usage
+	Passed bool `json:"passed"`
+
+	// Reason of the failure
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+type SmokeTestRunStatus struct {
+	SmokeTestRunSummary `json:",inline"`
+
+	// Results of each request sent
+	// +optional
+	Results []SmokeTestRequestResult `json:"results,omitempty"`
+
+	// ReportedHits is the number of hits backend reported for the product during the run
+	// +optional
+	ReportedHits *int64 `json:"reportedHits,omitempty"`
+}
+
+type SmokeTestRequestResult struct {
+	// Environment the request was sent to
+	Environment SmokeTestEnvironment `json:"environment"`
+
+	// Method of the request
+	Method string `json:"method"`
+
+	// Path of the request
+	Path string `json:"path"`
+
+	// StatusCode of the response. 0 when no response was received
+	StatusCode int32 `json:"statusCode"`
+
+	// ExpectedStatusCode of the response
+	ExpectedStatusCode int32 `json:"expectedStatusCode"`
+
+	// LatencyMilliseconds of the request
+	LatencyMilliseconds int64 `json:"latencyMilliseconds"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:JSONPath=".spec.productCRName",name=Product,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.conditions[?(@.type=='Ready')].status",name=Ready,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.lastRun.startTime",name="Last Run",type=date
+
+// SmokeTest is the Schema for the smoketests API
+type SmokeTest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SmokeTestSpec   `json:"spec,omitempty"`
+	Status SmokeTestStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SmokeTestList contains a list of SmokeTest
+type SmokeTestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SmokeTest `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SmokeTest{}, &SmokeTestList{})
+}
+
+func (s *SmokeTest) EnvironmentsOrDefault() []SmokeTestEnvironment {
+	if len(s.Spec.Environments) == 0 {
+		return []SmokeTestEnvironment{SmokeTestEnvironmentStaging, SmokeTestEnvironmentProduction}
+	}
+	return s.Spec.Environments
+}
+
+func (s *SmokeTest) IntervalSeconds() int32 {
+	if s.Spec.IntervalSeconds == nil {
+		return SmokeTestDefaultIntervalSeconds
+	}
+	return *s.Spec.IntervalSeconds
+}
+
+func (s *SmokeTest) IsUsageVerified() bool {
+	return s.Spec.VerifyUsage == nil || *s.Spec.VerifyUsage
+}
+
+func (s *SmokeTest) HistoryLimit() int32 {
+	if s.Spec.HistoryLimit == nil {
+		return SmokeTestDefaultHistoryLimit
+	}
+	return *s.Spec.HistoryLimit
+}
+
+func (s *SmokeTestStatus) Equals(other *SmokeTestStatus, logger logr.Logger) bool {
+	if !reflect.DeepEqual(s.ProductID, other.ProductID) {
+		diff := cmp.Diff(s.ProductID, other.ProductID)
+		logger.V(1).Info("ProductID not equal", "difference", diff)
+		return false
+	}
+
+	if !reflect.DeepEqual(s.LastRun, other.LastRun) {
+		diff := cmp.Diff(s.LastRun, other.LastRun)
+		logger.V(1).Info("LastRun not equal", "difference", diff)
+		return false
+	}
+
+	if !reflect.DeepEqual(s.History, other.History) {
+		diff := cmp.Diff(s.History, other.History)
+		logger.V(1).Info("History not equal", "difference", diff)
+		return false
+	}
+
+	if s.LastFailureReason != other.LastFailureReason {
+		diff := cmp.Diff(s.LastFailureReason, other.LastFailureReason)
+		logger.V(1).Info("LastFailureReason not equal", "difference", diff)
+		return false
+	}
+
+	if !reflect.DeepEqual(s.LastFailureTime, other.LastFailureTime) {
+		diff := cmp.Diff(s.LastFailureTime, other.LastFailureTime)
+		logger.V(1).Info("LastFailureTime not equal", "difference", diff)
+		return false
+	}
+
+	if s.ObservedGeneration != other.ObservedGeneration {
+		diff := cmp.Diff(s.ObservedGeneration, other.ObservedGeneration)
+		logger.V(1).Info("ObservedGeneration not equal", "difference", diff)
+		return false
+	}
+
+	// Marshalling sorts by condition type
+	currentMarshaledJSON, _ := s.Conditions.MarshalJSON()
+	otherMarshaledJSON, _ := other.Conditions.MarshalJSON()
+	if string(currentMarshaledJSON) != string(otherMarshaledJSON) {
+		diff := cmp.Diff(string(currentMarshaledJSON), string(otherMarshaledJSON))
+		logger.V(1).Info("Conditions not equal", "difference", diff)
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,327 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/3scale/3scale-operator/pkg/apispkg/common"
+	"github.com/3scale/3scale-operator/pkg/apispkg/helper"
+)
+
+const (
+	TenantKind = "Tenant"
+
+	// TenantReadyConditionType indicates the tenant has been successfully created.
+	// Steady state
+	TenantReadyConditionType common.ConditionType = "Ready"
+)
+
+// TenantState is the lifecycle state of the tenant account
+// +kubebuilder:validation:Enum=Active;Suspended;ScheduledForDeletion
+type TenantState string
+
+const (
+	TenantStateActive               TenantState = "Active"
+	TenantStateSuspended            TenantState = "Suspended"
+	TenantStateScheduledForDeletion TenantState = "ScheduledForDeletion"
+)
+
+// TenantDeletionPolicy tells what happens to the tenant account when the Tenant resource is deleted
+// +kubebuilder:validation:Enum=Delete;Retain
+type TenantDeletionPolicy string
+
+const (
+	// TenantDeletionPolicyDelete schedules the tenant account for deletion
+	TenantDeletionPolicyDelete TenantDeletionPolicy = "Delete"
+	// TenantDeletionPolicyRetain keeps the tenant account
+	TenantDeletionPolicyRetain TenantDeletionPolicy = "Retain"
+)
+
+// TenantSpec defines the desired state of Tenant
+type TenantSpec struct {
+	Username               string                 `json:"username"`
+	Email                  string                 `json:"email"`
+	OrganizationName       string                 `json:"organizationName"`
+	SystemMasterUrl        string                 `json:"systemMasterUrl"`
+	TenantSecretRef        corev1.SecretReference `json:"tenantSecretRef"`
+	PasswordCredentialsRef corev1.SecretReference `json:"passwordCredentialsRef"`
+	MasterCredentialsRef   corev1.SecretReference `json:"masterCredentialsRef"`
+	// additional parameters, used for Update, as in master portal Api Docs
+	// +optional
+	FromEmail *string `json:"fromEmail,omitempty"`
+	// +optional
+	SupportEmail *string `json:"supportEmail,omitempty"`
+	// +optional
+	FinanceSupportEmail *string `json:"financeSupportEmail,omitempty"`
+	// +optional
+	SiteAccessCode *string `json:"siteAccessCode,omitempty"`
+
+	// State is the lifecycle state of the tenant account. Suspended tenants cannot be used until they are
+	// active again. Tenants scheduled for deletion are deleted by 3scale at the end of the deletion period,
+	// unless they are set active or suspended before. When unset, the state of the tenant account is not managed
+	// +optional
+	State *TenantState `json:"state,omitempty"`
+
+	// ProviderPlan is the system name of the master account application plan assigned to the tenant
+	// +optional
+	ProviderPlan *string `json:"providerPlan,omitempty"`
+
+	// AdminDomain is the domain of the tenant admin portal
+	// +optional
+	AdminDomain *string `json:"adminDomain,omitempty"`
+
+	// DeveloperDomain is the domain of the tenant developer portal
+	// +optional
+	DeveloperDomain *string `json:"developerDomain,omitempty"`
+
+	// DeletionPolicy tells whether the tenant account is scheduled for deletion (Delete) or kept (Retain)
+	// when the resource is deleted. Defaults to Delete
+	// +optional
+	DeletionPolicy *TenantDeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// TenantStatus defines the observed state of Tenant
+type TenantStatus struct {
+	TenantId int64 `json:"tenantId"`
+	AdminId  int64 `json:"adminId"`
+
+	// AccountState is the 3scale state of the tenant account, i.e. approved, suspended or scheduled_for_deletion
+	// +optional
+	AccountState string `json:"accountState,omitempty"`
+
+	// ProviderPlan is the system name of the master account application plan assigned to the tenant
+	// +optional
+	ProviderPlan string `json:"providerPlan,omitempty"`
+
+	// AdminDomain is the domain of the tenant admin portal
+	// +optional
+	AdminDomain string `json:"adminDomain,omitempty"`
+
+	// DeveloperDomain is the domain of the tenant developer portal
+	// +optional
+	DeveloperDomain string `json:"developerDomain,omitempty"`
+
+	// Current state of the tenant resource.
+	// Conditions represent the latest available observations of an object's state
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	Conditions common.Conditions `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,2,rep,name=conditions"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// Tenant is the Schema for the tenants API
+// +kubebuilder:resource:path=tenants,scope=Namespaced
+// +kubebuilder:printcolumn:JSONPath=".status.adminDomain",name="Admin Domain",type=string
+// +kubebuilder:printcolumn:JSONPath=".status.accountState",name=State,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.providerPlan",name=Plan,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.conditions[?(@.type=='Ready')].status",name=Ready,type=string
+// +operator-sdk:csv:customresourcedefinitions:displayName="Tenant"
+type Tenant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TenantSpec   `json:"spec,omitempty"`
+	Status TenantStatus `json:"status,omitempty"`
+}
+
+// Hub marks v1beta1 as the version the other Tenant versions are converted to
+func (*Tenant) Hub() {}
+
+// SetDefaults sets the default vaules for the tenant spec and returns true if the spec was changed
+func (t *Tenant) SetDefaults() bool {
+	changed := false
+	ts := &t.Spec
+	if ts.TenantSecretRef.Name == "" {
+		ts.TenantSecretRef.Name = fmt.Sprintf("%s-%s", strings.ToLower(t.Name), strings.ToLower(t.Spec.OrganizationName))
+		changed = true
+	}
+	if ts.TenantSecretRef.Namespace == "" {
+		ts.TenantSecretRef.Namespace = t.Namespace
+		changed = true
+	}
+	return changed
+}
+
+// Validate checks the tenant admin and support addresses, the master URL, the portal domains and the provider plan
+func (t *Tenant) Validate() field.ErrorList {
+	errors := field.ErrorList{}
+	specFldPath := field.NewPath("spec")
+
+	emails := []struct {
+		name  string
+		email *string
+	}{
+		{"email", &t.Spec.Email},
+		{"fromEmail", t.Spec.FromEmail},
+		{"supportEmail", t.Spec.SupportEmail},
+		{"financeSupportEmail", t.Spec.FinanceSupportEmail},
+	}
+	for _, e := range emails {
+		if e.email != nil && !helper.IsEmailValid(*e.email) {
+			errors = append(errors, field.Invalid(specFldPath.Child(e.name), *e.email, "invalid email address."))
+		}
+	}
+
+	masterURL, err := url.Parse(t.Spec.SystemMasterUrl)
+	if err != nil || masterURL.Scheme == "" || masterURL.Host == "" {
+		errors = append(errors, field.Invalid(specFldPath.Child("systemMasterUrl"), t.Spec.SystemMasterUrl, "must be an absolute URL."))
+	}
+
+	domains := []struct {
+		name   string
+		domain *string
+	}{
+		{"adminDomain", t.Spec.AdminDomain},
+		{"developerDomain", t.Spec.DeveloperDomain},
+	}
+	for _, d := range domains {
+		if d.domain == nil {
+			continue
+		}
+		for _, msg := range validation.IsDNS1123Subdomain(*d.domain) {
+			errors = append(errors, field.Invalid(specFldPath.Child(d.name), *d.domain, msg))
+		}
+	}
+	if t.Spec.AdminDomain != nil && t.Spec.DeveloperDomain != nil && *t.Spec.AdminDomain == *t.Spec.DeveloperDomain {
+		errors = append(errors, field.Invalid(specFldPath.Child("developerDomain"), *t.Spec.DeveloperDomain, "must be different from the admin domain."))
+	}
+
+	if t.Spec.ProviderPlan != nil && *t.Spec.ProviderPlan == "" {
+		errors = append(errors, field.Invalid(specFldPath.Child("providerPlan"), *t.Spec.ProviderPlan, "must not be empty."))
+	}
+
+	return errors
+}
+
+// DeletionPolicy returns what happens to the tenant account when the resource is deleted
+func (t *Tenant) DeletionPolicy() TenantDeletionPolicy {
+	if t.Spec.DeletionPolicy == nil {
+		return TenantDeletionPolicyDelete
+	}
+	return *t.Spec.DeletionPolicy
+}
+
+func (t *Tenant) MasterSecretKey() client.ObjectKey {
+	namespace := t.Spec.MasterCredentialsRef.Namespace
+
+	if namespace == "" {
+		namespace = t.Namespace
+	}
+
+	return client.ObjectKey{
+		Name:      t.Spec.MasterCredentialsRef.Name,
+		Namespace: namespace,
+	}
+}
+
+func (t *Tenant) AdminPassSecretKey() client.ObjectKey {
+	namespace := t.Spec.PasswordCredentialsRef.Namespace
+
+	if namespace == "" {
+		namespace = t.Namespace
+	}
+
+	return client.ObjectKey{
+		Name:      t.Spec.PasswordCredentialsRef.Name,
+		Namespace: namespace,
+	}
+}
+
+func (t *Tenant) TenantSecretKey() client.ObjectKey {
+	namespace := t.Spec.TenantSecretRef.Namespace
+
+	if namespace == "" {
+		namespace = t.Namespace
+	}
+
+	return client.ObjectKey{
+		Name:      t.Spec.TenantSecretRef.Name,
+		Namespace: namespace,
+	}
+}
+
+func (b *Tenant) SpecEqual(other *Tenant, logger logr.Logger) bool {
+	return reflect.DeepEqual(b.ObjectMeta, other.ObjectMeta) && reflect.DeepEqual(b.Spec, other.Spec)
+}
+
+func (b *TenantStatus) StatusEqual(other *TenantStatus, logger logr.Logger) bool {
+	if b.TenantId != other.TenantId || b.AdminId != other.AdminId {
+		return false
+	}
+
+	if b.AccountState != other.AccountState || b.ProviderPlan != other.ProviderPlan {
+		return false
+	}
+
+	if b.AdminDomain != other.AdminDomain || b.DeveloperDomain != other.DeveloperDomain {
+		return false
+	}
+
+	return conditionsEqual(TenantReadyConditionType, b.Conditions, other.Conditions)
+}
+
+// Compare conditions of a specific type
+func conditionsEqual(typeToCompare common.ConditionType, currentConditions, incomingConditions []common.Condition) bool {
+	for _, condition1 := range incomingConditions {
+		if condition1.Type != typeToCompare {
+			continue
+		}
+
+		// Find the corresponding condition in conditions2
+		var condition2 common.Condition
+		for _, c := range currentConditions {
+			if c.Type == typeToCompare {
+				condition2 = c
+				break
+			}
+		}
+
+		// Compare the status and message
+		if condition1.Status != condition2.Status || condition1.Message != condition2.Message {
+			return false
+		}
+	}
+	return true
+}
+
+// +kubebuilder:object:root=true
+
+// TenantList contains a list of Tenant
+type TenantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Tenant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Tenant{}, &TenantList{})
+}
@@ -0,0 +1,85 @@
+package controllers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/operator"
+	"github.com/3scale/3scale-operator/pkg/apispkg/common"
+	threescalemetrics "github.com/3scale/3scale-operator/pkg/metrics"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// reconcileCertificatesStatus records the expiry of the referenced TLS certificates
+// and raises the CertificateExpiring condition within the warning window.
+// A Warning Event is emitted when the set of expiring certificates changes
+func (s *APIManagerStatusReconciler) reconcileCertificatesStatus(newStatus *appsv1alpha1.APIManagerStatus) error {
+	certificates, err := operator.APIManagerCertificates(s.Context(), s.apimanagerResource, s.Client())
+	if err != nil {
+		return err
+	}
+	newStatus.Certificates = certificates
+
+	expiry := map[string]time.Time{}
+	for _, certificate := range certificates {
+		expiry[certificate.Name] = certificate.NotAfter.Time
+	}
+	threescalemetrics.SetCertificateExpiry(s.apimanagerResource.Namespace, s.apimanagerResource.Name, s.apimanagerResource.CertificateExpiryWarningDays(), expiry)
+
+	previousCondition := newStatus.Conditions.GetCondition(appsv1alpha1.APIManagerCertificateExpiringConditionType)
+
+	condition := certificateExpiringCondition(certificates, s.apimanagerResource.CertificateExpiryWarningDays(), time.Now())
+	if condition == nil {
+		newStatus.Conditions.RemoveCondition(appsv1alpha1.APIManagerCertificateExpiringConditionType)
+		return nil
+	}
+
+	if previousCondition == nil || previousCondition.Message != condition.Message {
+		s.EventRecorder().Event(s.apimanagerResource, v1.EventTypeWarning, string(condition.Reason), condition.Message)
+	}
+	newStatus.Conditions.SetCondition(*condition)
+
+	return nil
+}
+
+// certificateExpiringCondition returns nil when no certificate expires within the warning days
+func certificateExpiringCondition(certificates []appsv1alpha1.APIManagerCertificateStatus, warningDays int32, now time.Time) *common.Condition {
+	warningLimit := now.Add(time.Duration(warningDays) * 24 * time.Hour)
+
+	expired := []string{}
+	expiring := []string{}
+	for _, certificate := range certificates {
+		description := fmt.Sprintf("%s (%s %s, expires %s)", certificate.Name, certificate.Kind, certificate.ObjectName, certificate.NotAfter.UTC().Format(time.RFC3339))
+		switch {
+		case !certificate.NotAfter.Time.After(now):
+			expired = append(expired, description)
+		case certificate.NotAfter.Time.Before(warningLimit):
+			expiring = append(expiring, description)
+		}
+	}
+
+	if len(expired) == 0 && len(expiring) == 0 {
+		return nil
+	}
+
+	condition := &common.Condition{
+		Type:   appsv1alpha1.APIManagerCertificateExpiringConditionType,
+		Status: v1.ConditionTrue,
+		Reason: common.ConditionReason("CertificateExpiring"),
+	}
+
+	messages := []string{}
+	if len(expired) > 0 {
+		condition.Reason = common.ConditionReason("CertificateExpired")
+		messages = append(messages, fmt.Sprintf("Expired certificates: %s", strings.Join(expired, ", ")))
+	}
+	if len(expiring) > 0 {
+		messages = append(messages, fmt.Sprintf("Certificates expiring within %d days: %s", warningDays, strings.Join(expiring, ", ")))
+	}
+	condition.Message = strings.Join(messages, ". ")
+
+	return condition
+}
@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+)
+
+func TestCertificateExpiringCondition(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	certificate := func(name string, notAfter time.Time) appsv1alpha1.APIManagerCertificateStatus {
+		return appsv1alpha1.APIManagerCertificateStatus{Name: name, Kind: "Secret", ObjectName: name, NotAfter: metav1.NewTime(notAfter)}
+	}
+
+	cases := []struct {
+		testName         string
+		certificates     []appsv1alpha1.APIManagerCertificateStatus
+		expectedReason   string
+		expectedMessages []string
+	}{
+		{"NoCertificates", nil, "", nil},
+		{"Valid", []appsv1alpha1.APIManagerCertificateStatus{certificate("apicast-production-https", now.AddDate(0, 2, 0))}, "", nil},
+		{"Expiring", []appsv1alpha1.APIManagerCertificateStatus{
+			certificate("apicast-production-https", now.AddDate(0, 0, 10)),
+			certificate("route-backend", now.AddDate(1, 0, 0)),
+		}, "CertificateExpiring", []string{"within 30 days: apicast-production-https"}},
+		{"Expired", []appsv1alpha1.APIManagerCertificateStatus{
+			certificate("apicast-production-https", now.AddDate(0, 0, 10)),
+			certificate("system-database-ca", now.AddDate(0, 0, -1)),
+		}, "CertificateExpired", []string{"Expired certificates: system-database-ca", "within 30 days: apicast-production-https"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			condition := certificateExpiringCondition(tc.certificates, 30, now)
+			if tc.expectedReason == "" {
+				if condition != nil {
+					subT.Fatalf("unexpected condition %v", condition)
+				}
+				return
+			}
+			if condition == nil {
+				subT.Fatal("expected condition")
+			}
+			if condition.Status != v1.ConditionTrue || string(condition.Reason) != tc.expectedReason {
+				subT.Fatalf("expected True/%s, got %s/%s", tc.expectedReason, condition.Status, condition.Reason)
+			}
+			for _, message := range tc.expectedMessages {
+				if !strings.Contains(condition.Message, message) {
+					subT.Fatalf("message %q does not contain %q", condition.Message, message)
+				}
+			}
+		})
+	}
+}
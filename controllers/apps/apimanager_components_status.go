@@ -0,0 +1,178 @@
+package controllers
+
+import (
+	"fmt"
+	"strings"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/helper"
+
+	routev1 "github.com/openshift/api/route/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	componentDeploymentNotFoundReason       = "DeploymentNotFound"
+	componentProgressDeadlineExceededReason = "ProgressDeadlineExceeded"
+	componentDeploymentUnavailableReason    = "DeploymentUnavailable"
+	componentVersionPodTemplateLabel        = "rht.comp_ver"
+)
+
+// componentEndpoint is a default route host published as a component endpoint
+type componentEndpoint struct {
+	name string
+	host string
+}
+
+func (s *APIManagerStatusReconciler) componentsStatus(existingDeployments []k8sappsv1.Deployment) (*appsv1alpha1.APIManagerComponentsStatus, error) {
+	routeList := &routev1.RouteList{}
+	err := s.Client().List(s.Context(), routeList, client.InNamespace(s.apimanagerResource.Namespace))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list routes: %w", err)
+	}
+
+	expectedDeploymentNames := s.expectedDeploymentNames(s.apimanagerResource)
+	endpoints := defaultComponentEndpoints(s.apimanagerResource)
+
+	componentStatus := func(deploymentNames []string, endpointNames ...string) *appsv1alpha1.APIManagerComponentStatus {
+		var names []string
+		for _, name := range deploymentNames {
+			if helper.ArrayContains(expectedDeploymentNames, name) {
+				names = append(names, name)
+			}
+		}
+		if len(names) == 0 {
+			return nil
+		}
+
+		status := calculateComponentStatus(names, existingDeployments)
+		for _, endpointName := range endpointNames {
+			if endpoint, ok := endpoints[endpointName]; ok {
+				if url := routeURL(routeList.Items, endpoint); url != "" {
+					status.Endpoints = append(status.Endpoints, appsv1alpha1.APIManagerComponentEndpoint{Name: endpointName, URL: url})
+				}
+			}
+		}
+		return status
+	}
+
+	return &appsv1alpha1.APIManagerComponentsStatus{
+		Apicast:   componentStatus([]string{component.ApicastProductionName, component.ApicastStagingName}, "apicast-production", "apicast-staging"),
+		Backend:   componentStatus([]string{component.BackendListenerName, component.BackendWorkerName, component.BackendCronName}, "backend"),
+		System:    componentStatus([]string{component.SystemAppDeploymentName, component.SystemSidekiqName}, "master", "admin-portal", "developer-portal"),
+		Zync:      componentStatus([]string{component.ZyncName, component.ZyncQueDeploymentName, component.ZyncDatabaseDeploymentName}),
+		Memcached: componentStatus([]string{component.SystemMemcachedDeploymentName}),
+		Searchd:   componentStatus([]string{component.SystemSearchdDeploymentName}),
+	}, nil
+}
+
+// calculateComponentStatus aggregates the status of the component Deployments.
+// The first Deployment name is the one the image and version are read from
+func calculateComponentStatus(deploymentNames []string, existingDeployments []k8sappsv1.Deployment) *appsv1alpha1.APIManagerComponentStatus {
+	status := &appsv1alpha1.APIManagerComponentStatus{}
+	var messages []string
+
+	for idx, name := range deploymentNames {
+		deployment := findDeployment(existingDeployments, name)
+		if deployment == nil {
+			if status.Reason == "" {
+				status.Reason = componentDeploymentNotFoundReason
+			}
+			messages = append(messages, fmt.Sprintf("deployment %s not found", name))
+			continue
+		}
+
+		desiredReplicas := int32(1)
+		if deployment.Spec.Replicas != nil {
+			desiredReplicas = *deployment.Spec.Replicas
+		}
+		status.DesiredReplicas += desiredReplicas
+		status.ReadyReplicas += deployment.Status.ReadyReplicas
+
+		if idx == 0 {
+			if len(deployment.Spec.Template.Spec.Containers) > 0 {
+				status.Image = deployment.Spec.Template.Spec.Containers[0].Image
+			}
+			status.Version = deployment.Spec.Template.Labels[componentVersionPodTemplateLabel]
+		}
+
+		for _, condition := range deployment.Status.Conditions {
+			if condition.Type != k8sappsv1.DeploymentProgressing {
+				continue
+			}
+			if status.LastRolloutTime == nil || status.LastRolloutTime.Before(&condition.LastUpdateTime) {
+				lastUpdateTime := condition.LastUpdateTime
+				status.LastRolloutTime = &lastUpdateTime
+			}
+			if condition.Status == v1.ConditionFalse && condition.Reason == componentProgressDeadlineExceededReason {
+				if status.Reason == "" {
+					status.Reason = componentProgressDeadlineExceededReason
+				}
+				messages = append(messages, fmt.Sprintf("deployment %s: %s", name, condition.Message))
+			}
+		}
+
+		if !helper.IsDeploymentAvailable(deployment) {
+			if status.Reason == "" {
+				status.Reason = componentDeploymentUnavailableReason
+			}
+			messages = append(messages, fmt.Sprintf("deployment %s has %d/%d ready replicas", name, deployment.Status.ReadyReplicas, desiredReplicas))
+		}
+	}
+
+	status.Ready = status.Reason == ""
+	status.Message = strings.Join(messages, "; ")
+
+	return status
+}
+
+func findDeployment(deployments []k8sappsv1.Deployment, name string) *k8sappsv1.Deployment {
+	for idx := range deployments {
+		if deployments[idx].Name == name {
+			return &deployments[idx]
+		}
+	}
+	return nil
+}
+
+// defaultComponentEndpoints returns the default route hosts indexed by endpoint name
+func defaultComponentEndpoints(apimanager *appsv1alpha1.APIManager) map[string]componentEndpoint {
+	endpoints := map[string]componentEndpoint{}
+	if apimanager.Spec.TenantName == nil {
+		return endpoints
+	}
+
+	tenantName := *apimanager.Spec.TenantName
+	wildcardDomain := apimanager.Spec.WildcardDomain
+	for _, endpoint := range []componentEndpoint{
+		{"backend", fmt.Sprintf("backend-%s.%s", tenantName, wildcardDomain)},
+		{"apicast-production", fmt.Sprintf("api-%s-apicast-production.%s", tenantName, wildcardDomain)},
+		{"apicast-staging", fmt.Sprintf("api-%s-apicast-staging.%s", tenantName, wildcardDomain)},
+		{"master", fmt.Sprintf("master.%s", wildcardDomain)},
+		{"admin-portal", fmt.Sprintf("%s-admin.%s", tenantName, wildcardDomain)},
+		{"developer-portal", fmt.Sprintf("%s.%s", tenantName, wildcardDomain)},
+	} {
+		endpoints[endpoint.name] = endpoint
+	}
+
+	return endpoints
+}
+
+// routeURL returns the URL of the admitted route serving the endpoint host
+// or an empty string when there is no such route
+func routeURL(routes []routev1.Route, endpoint componentEndpoint) string {
+	routeIdx := helper.RouteFindByHost(routes, endpoint.host)
+	if routeIdx == -1 || !helper.IsRouteReady(&routes[routeIdx]) {
+		return ""
+	}
+
+	scheme := "http"
+	if routes[routeIdx].Spec.TLS != nil {
+		scheme = "https"
+	}
+
+	return fmt.Sprintf("%s://%s", scheme, endpoint.host)
+}
@@ -0,0 +1,173 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+
+	"github.com/google/go-cmp/cmp"
+	routev1 "github.com/openshift/api/route/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testComponentDeployment(name string, replicas, readyReplicas int32, available bool, lastUpdateTime metav1.Time) k8sappsv1.Deployment {
+	availableStatus := v1.ConditionFalse
+	if available {
+		availableStatus = v1.ConditionTrue
+	}
+
+	return k8sappsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: k8sappsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{componentVersionPodTemplateLabel: "2.16"},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{{Name: name, Image: name + ":latest"}},
+				},
+			},
+		},
+		Status: k8sappsv1.DeploymentStatus{
+			ReadyReplicas: readyReplicas,
+			Conditions: []k8sappsv1.DeploymentCondition{
+				{Type: k8sappsv1.DeploymentAvailable, Status: availableStatus},
+				{Type: k8sappsv1.DeploymentProgressing, Status: v1.ConditionTrue, Reason: "NewReplicaSetAvailable", LastUpdateTime: lastUpdateTime},
+			},
+		},
+	}
+}
+
+func TestCalculateComponentStatus(t *testing.T) {
+	earlier := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	later := metav1.NewTime(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC))
+
+	stuckDeployment := testComponentDeployment("backend-worker", 1, 0, false, earlier)
+	stuckDeployment.Status.Conditions[1] = k8sappsv1.DeploymentCondition{
+		Type:           k8sappsv1.DeploymentProgressing,
+		Status:         v1.ConditionFalse,
+		Reason:         componentProgressDeadlineExceededReason,
+		Message:        "ReplicaSet has timed out progressing.",
+		LastUpdateTime: later,
+	}
+
+	cases := []struct {
+		testName    string
+		names       []string
+		deployments []k8sappsv1.Deployment
+		expected    *appsv1alpha1.APIManagerComponentStatus
+	}{
+		{
+			"ready",
+			[]string{"apicast-production", "apicast-staging"},
+			[]k8sappsv1.Deployment{
+				testComponentDeployment("apicast-production", 2, 2, true, earlier),
+				testComponentDeployment("apicast-staging", 1, 1, true, later),
+			},
+			&appsv1alpha1.APIManagerComponentStatus{
+				Ready:           true,
+				ReadyReplicas:   3,
+				DesiredReplicas: 3,
+				Image:           "apicast-production:latest",
+				Version:         "2.16",
+				LastRolloutTime: &later,
+			},
+		},
+		{
+			"missing deployment",
+			[]string{"system-app", "system-sidekiq"},
+			[]k8sappsv1.Deployment{
+				testComponentDeployment("system-app", 1, 1, true, earlier),
+			},
+			&appsv1alpha1.APIManagerComponentStatus{
+				Ready:           false,
+				ReadyReplicas:   1,
+				DesiredReplicas: 1,
+				Image:           "system-app:latest",
+				Version:         "2.16",
+				LastRolloutTime: &earlier,
+				Reason:          componentDeploymentNotFoundReason,
+				Message:         "deployment system-sidekiq not found",
+			},
+		},
+		{
+			"unavailable deployment",
+			[]string{"system-searchd"},
+			[]k8sappsv1.Deployment{
+				testComponentDeployment("system-searchd", 1, 0, false, earlier),
+			},
+			&appsv1alpha1.APIManagerComponentStatus{
+				Ready:           false,
+				ReadyReplicas:   0,
+				DesiredReplicas: 1,
+				Image:           "system-searchd:latest",
+				Version:         "2.16",
+				LastRolloutTime: &earlier,
+				Reason:          componentDeploymentUnavailableReason,
+				Message:         "deployment system-searchd has 0/1 ready replicas",
+			},
+		},
+		{
+			"progress deadline exceeded",
+			[]string{"backend-listener", "backend-worker"},
+			[]k8sappsv1.Deployment{
+				testComponentDeployment("backend-listener", 1, 1, true, earlier),
+				stuckDeployment,
+			},
+			&appsv1alpha1.APIManagerComponentStatus{
+				Ready:           false,
+				ReadyReplicas:   1,
+				DesiredReplicas: 2,
+				Image:           "backend-listener:latest",
+				Version:         "2.16",
+				LastRolloutTime: &later,
+				Reason:          componentProgressDeadlineExceededReason,
+				Message:         "deployment backend-worker: ReplicaSet has timed out progressing.; deployment backend-worker has 0/1 ready replicas",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			status := calculateComponentStatus(tc.names, tc.deployments)
+			if diff := cmp.Diff(tc.expected, status); diff != "" {
+				subT.Fatalf("unexpected component status (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRouteURL(t *testing.T) {
+	admitted := []routev1.RouteIngress{
+		{Conditions: []routev1.RouteIngressCondition{{Type: routev1.RouteAdmitted, Status: v1.ConditionTrue}}},
+	}
+	routes := []routev1.Route{
+		{Spec: routev1.RouteSpec{Host: "master.example.com", TLS: &routev1.TLSConfig{}}, Status: routev1.RouteStatus{Ingress: admitted}},
+		{Spec: routev1.RouteSpec{Host: "backend-3scale.example.com"}, Status: routev1.RouteStatus{Ingress: admitted}},
+		{Spec: routev1.RouteSpec{Host: "3scale-admin.example.com", TLS: &routev1.TLSConfig{}}},
+	}
+
+	cases := []struct {
+		testName string
+		host     string
+		expected string
+	}{
+		{"tls route", "master.example.com", "https://master.example.com"},
+		{"plain route", "backend-3scale.example.com", "http://backend-3scale.example.com"},
+		{"route not admitted", "3scale-admin.example.com", ""},
+		{"route not found", "3scale.example.com", ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			url := routeURL(routes, componentEndpoint{host: tc.host})
+			if url != tc.expected {
+				subT.Fatalf("expected url %q, got %q", tc.expected, url)
+			}
+		})
+	}
+}
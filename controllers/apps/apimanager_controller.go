@@ -22,11 +22,13 @@ import (
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 	"time"
 
+	threescalemetrics "github.com/3scale/3scale-operator/pkg/metrics"
 	"github.com/3scale/3scale-operator/pkg/upgrade"
 
 	"github.com/go-logr/logr"
 	routev1 "github.com/openshift/api/route/v1"
 	k8sappsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	apimachinerymetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -42,8 +44,10 @@ import (
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	subController "github.com/3scale/3scale-operator/controllers/subscription"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/operator"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/prometheusrules"
 	"github.com/3scale/3scale-operator/pkg/handlers"
 	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/preflights"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 	"github.com/3scale/3scale-operator/version"
 )
@@ -71,12 +75,16 @@ var _ reconcile.Reconciler = &APIManagerReconciler{}
 // +kubebuilder:rbac:groups=route.openshift.io,namespace=placeholder,resources=routes/custom-host,verbs=create
 // +kubebuilder:rbac:groups=route.openshift.io,namespace=placeholder,resources=routes/status,verbs=get
 // +kubebuilder:rbac:groups=apps.openshift.io,namespace=placeholder,resources=deploymentconfigs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=batch,namespace=placeholder,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=policy,namespace=placeholder,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,namespace=placeholder,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=monitoring.coreos.com,namespace=placeholder,resources=podmonitors;servicemonitors;prometheusrules,verbs=get;list;watch;create;update;delete
 // +kubebuilder:rbac:groups=integreatly.org,namespace=placeholder,resources=grafanadashboards,verbs=get;list;watch;create;update;delete
 // +kubebuilder:rbac:groups=grafana.integreatly.org,namespace=placeholder,resources=grafanadashboards,verbs=get;list;watch;create;update;delete
 // +kubebuilder:rbac:groups=config.openshift.io,resources=clusterversions,verbs=get;list;watch
-// +kubebuilder:rbac:groups=autoscaling,namespace=placeholder,resources=horizontalpodautoscalers,verbs=create;delete;list;watch
+// +kubebuilder:rbac:groups=autoscaling,namespace=placeholder,resources=horizontalpodautoscalers,verbs=create;delete;list;watch;update
+// +kubebuilder:rbac:groups=core,namespace=placeholder,resources=resourcequotas;limitranges,verbs=get;list;watch
+// +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
 
 func (r *APIManagerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := r.BaseReconciler.Logger().WithValues("apimanager", req.NamespacedName)
@@ -92,6 +100,8 @@ func (r *APIManagerReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	}
 	if instance == nil {
 		logger.Info("resource not found. Ignoring since object must have been deleted")
+		threescalemetrics.SetCertificateExpiry(req.Namespace, req.Name, 0, nil)
+		preflightsWarnings.forget(req.NamespacedName)
 		return ctrl.Result{}, nil
 	}
 
@@ -185,6 +195,11 @@ func (r *APIManagerReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return statusResult, nil
 	}
 
+	if specResult.RequeueAfter > 0 {
+		logger.Info("Reconciling not finished. Requeueing.", "requeueAfter", specResult.RequeueAfter)
+		return specResult, nil
+	}
+
 	if delayedRequeue {
 		return ctrl.Result{RequeueAfter: time.Minute * 10}, nil
 	}
@@ -198,6 +213,7 @@ func (r *APIManagerReconciler) PreflightChecks(apimInstance *appsv1alpha1.APIMan
 	systemRedisVerified := false
 	backendRedisVerified := false
 	systemDatabaseVerified := false
+	var systemRedisUnmet, backendRedisUnmet, systemDatabaseUnmet string
 	var apimVersion string
 
 	reqConfigMap, err := subController.RetrieveRequirementsConfigMap(r.Client())
@@ -227,19 +243,19 @@ func (r *APIManagerReconciler) PreflightChecks(apimInstance *appsv1alpha1.APIMan
 
 	logger.Info("Starting preflight checks...")
 	if !systemRedisVerified {
-		systemRedisVerified, err = helper.VerifySystemRedis(r.Client(), reqConfigMap, systemRedisRequirement, apimInstance, logger)
+		systemRedisVerified, systemRedisUnmet, err = helper.VerifySystemRedis(r.Client(), reqConfigMap, systemRedisRequirement, apimInstance, logger)
 		if err != nil {
 			return ctrl.Result{RequeueAfter: time.Minute * 10}, nil, fmt.Errorf("Failed to verify system redis version. Ensure that the system-redis secret is correctly configured. Error: %s", err)
 		}
 	}
 	if !backendRedisVerified {
-		backendRedisVerified, err = helper.VerifyBackendRedis(r.Client(), reqConfigMap, backendRedisRequirement, apimInstance, logger)
+		backendRedisVerified, backendRedisUnmet, err = helper.VerifyBackendRedis(r.Client(), reqConfigMap, backendRedisRequirement, apimInstance, logger)
 		if err != nil {
 			return ctrl.Result{RequeueAfter: time.Minute * 10}, nil, fmt.Errorf("Failed to verify backend redis version. Ensure that the backend-redis secret is correctly configured. Error: %s", err)
 		}
 	}
 	if !systemDatabaseVerified {
-		systemDatabaseVerified, err = helper.VerifySystemDatabase(r.Client(), reqConfigMap, apimInstance, logger)
+		systemDatabaseVerified, systemDatabaseUnmet, err = helper.VerifySystemDatabase(r.Client(), reqConfigMap, apimInstance, logger)
 		if err != nil {
 			return ctrl.Result{RequeueAfter: time.Minute * 10}, nil, fmt.Errorf("Failed to verify system database version. Ensure that the system-database secret is correctly configured. Error: %s", err)
 		}
@@ -247,7 +263,7 @@ func (r *APIManagerReconciler) PreflightChecks(apimInstance *appsv1alpha1.APIMan
 
 	culprit := ""
 	if !systemDatabaseVerified || !backendRedisVerified || !systemRedisVerified {
-		culprit = retrieveCulprit(systemDatabaseVerified, backendRedisVerified, systemRedisVerified, postgresDatabaseRequirements, mysqlDatabaseRequirement, backendRedisRequirement, systemRedisRequirement)
+		culprit = retrieveCulprit(systemDatabaseVerified, backendRedisVerified, systemRedisVerified, systemDatabaseUnmet, backendRedisUnmet, systemRedisUnmet)
 	}
 
 	// Fresh install scenario
@@ -265,6 +281,12 @@ func (r *APIManagerReconciler) PreflightChecks(apimInstance *appsv1alpha1.APIMan
 		return ctrl.Result{Requeue: true}, nil, fmt.Errorf("attempted upgrade to %s have been performed but the requirements are not met, operator will keep reconciling but ensure requirements are met in order to proceed with upgrade, %s", incomingVersion, culprit)
 	}
 
+	// Environment checks: storage, routing, quotas and object storage
+	err = preflights.Run(r.Context(), r.Client(), apimInstance, preflights.DefaultChecks())
+	if err != nil {
+		return ctrl.Result{RequeueAfter: time.Minute * 10}, nil, err
+	}
+
 	// At this point, all requirements are confirmed
 	err = r.setRequirementsAnnotation(apimInstance, reqConfigMap.GetResourceVersion())
 	if err != nil {
@@ -273,16 +295,17 @@ func (r *APIManagerReconciler) PreflightChecks(apimInstance *appsv1alpha1.APIMan
 	return ctrl.Result{Requeue: true}, nil, nil
 }
 
-func retrieveCulprit(systemDatabaseVerified, backendRedisVerified, systemRedisVerified bool, postgresReq, mysqlReq, backendReq, systemReq string) string {
+// retrieveCulprit explains the constraints of the requirements that are not met
+func retrieveCulprit(systemDatabaseVerified, backendRedisVerified, systemRedisVerified bool, systemDatabaseUnmet, backendRedisUnmet, systemRedisUnmet string) string {
 	message := ""
 	if !systemDatabaseVerified {
-		message = message + fmt.Sprintf("system database version mismatch - required is Postgres %s, MySQL %s; ", postgresReq, mysqlReq)
+		message = message + fmt.Sprintf("system database version mismatch - %s; ", systemDatabaseUnmet)
 	}
 	if !backendRedisVerified {
-		message = message + fmt.Sprintf("backend redis version mismatch - required is Redis %s; ", backendReq)
+		message = message + fmt.Sprintf("backend redis version mismatch - %s; ", backendRedisUnmet)
 	}
 	if !systemRedisVerified {
-		message = message + fmt.Sprintf("system redis version mismatch - required is Redis %s; ", systemReq)
+		message = message + fmt.Sprintf("system redis version mismatch - %s; ", systemRedisUnmet)
 	}
 
 	return message
@@ -325,6 +348,13 @@ func (r *APIManagerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Namespace: r.WatchedNamespace,
 	}
 
+	watchedConfigMapToApimanagerEventMapper := &WatchedConfigMapToApimanagerEventMapper{
+		Context:   r.Context(),
+		K8sClient: r.Client(),
+		Logger:    r.Logger().WithName("watchedConfigMapToApimanagerEventMapper"),
+		Namespace: r.WatchedNamespace,
+	}
+
 	handlers := &handlers.APIManagerRoutesEventMapper{
 		Context:   r.Context(),
 		K8sClient: r.Client(),
@@ -361,6 +391,7 @@ func (r *APIManagerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			builder.WithPredicates(labelSelectorPredicate),
 		).
 		Owns(&k8sappsv1.Deployment{}).
+		Owns(&batchv1.Job{}).
 		Watches(&routev1.Route{}, handler.EnqueueRequestsFromMapFunc(handlers.Map)).
 		Watches(
 			&v1.ConfigMap{
@@ -372,8 +403,13 @@ func (r *APIManagerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			handler.EnqueueRequestsFromMapFunc(configMapToApimanagerEventMapper.Map),
 			builder.WithPredicates(resourceVersionChangePredicate),
 		).
+		Watches(
+			&v1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(watchedConfigMapToApimanagerEventMapper.Map),
+			builder.WithPredicates(labelSelectorPredicate),
+		).
 		Owns(&v1.ConfigMap{}, builder.WithPredicates(redisConfigLabelPredicate)).
-		Complete(r)
+		Complete(threescalemetrics.NewInstrumentedReconciler("APIManager", &appsv1alpha1.APIManager{}, mgr.GetClient(), r))
 }
 
 func (r *APIManagerReconciler) validateCR(cr *appsv1alpha1.APIManager) error {
@@ -383,8 +419,13 @@ func (r *APIManagerReconciler) validateCR(cr *appsv1alpha1.APIManager) error {
 
 	fieldError = append(fieldError, r.validateApicastTLSCertificates(cr)...)
 
+	fieldError = append(fieldError, prometheusrules.ValidateAlertOverrides(field.NewPath("spec").Child("monitoring").Child("alertOverrides"), cr.PrometheusRulesAlertOverrides())...)
+
 	if len(fieldError) > 0 {
-		return fieldError.ToAggregate()
+		return &helper.SpecFieldError{
+			ErrorType:      helper.InvalidError,
+			FieldErrorList: fieldError,
+		}
 	}
 
 	return nil
@@ -455,11 +496,20 @@ func (r *APIManagerReconciler) reconcileAPIManagerLogic(cr *appsv1alpha1.APIMana
 		return result, err
 	}
 
+	systemPgBouncerReconciler := operator.NewSystemPgBouncerReconciler(baseAPIManagerLogicReconciler)
+	result, err = systemPgBouncerReconciler.Reconcile()
+	if err != nil || result.Requeue {
+		return result, err
+	}
+
 	systemReconciler := operator.NewSystemReconciler(baseAPIManagerLogicReconciler)
 	result, err = systemReconciler.Reconcile()
 	if err != nil || result.Requeue {
 		return result, err
 	}
+	// System requeues after a delay while its components are not ready or its jobs are
+	// running. The remaining components are reconciled meanwhile
+	systemRequeueAfter := result.RequeueAfter
 
 	zyncReconciler := operator.NewZyncReconciler(baseAPIManagerLogicReconciler, cr.IsZyncEnabled())
 	result, err = zyncReconciler.Reconcile()
@@ -467,6 +517,12 @@ func (r *APIManagerReconciler) reconcileAPIManagerLogic(cr *appsv1alpha1.APIMana
 		return result, err
 	}
 
+	datastoreExportersReconciler := operator.NewDatastoreExportersReconciler(baseAPIManagerLogicReconciler)
+	result, err = datastoreExportersReconciler.Reconcile()
+	if err != nil || result.Requeue {
+		return result, err
+	}
+
 	apicastReconciler := operator.NewApicastReconciler(baseAPIManagerLogicReconciler)
 	result, err = apicastReconciler.Reconcile()
 	if err != nil || result.Requeue {
@@ -479,6 +535,12 @@ func (r *APIManagerReconciler) reconcileAPIManagerLogic(cr *appsv1alpha1.APIMana
 		return result, err
 	}
 
+	networkPoliciesReconciler := operator.NewNetworkPoliciesReconciler(baseAPIManagerLogicReconciler)
+	result, err = networkPoliciesReconciler.Reconcile()
+	if err != nil || result.Requeue {
+		return result, err
+	}
+
 	// Create the hashed secret to track watched secrets' changes
 	result, err = r.reconcileHashedSecret(cr)
 	if err != nil || result.Requeue {
@@ -491,7 +553,7 @@ func (r *APIManagerReconciler) reconcileAPIManagerLogic(cr *appsv1alpha1.APIMana
 		return ctrl.Result{Requeue: true}, err
 	}
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: systemRequeueAfter}, nil
 }
 
 func (r *APIManagerReconciler) reconcileAPIManagerStatus(cr *appsv1alpha1.APIManager, preflightsError error) (reconcile.Result, error) {
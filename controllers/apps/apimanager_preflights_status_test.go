@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"errors"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/apispkg/common"
+	"github.com/3scale/3scale-operator/pkg/preflights"
+)
+
+func TestSetPreflightsConditions(t *testing.T) {
+	failed := common.Condition{
+		Type:    appsv1alpha1.APIManagerPreflightsConditionType,
+		Status:  v1.ConditionFalse,
+		Reason:  common.ConditionReason("PreflightsPass"),
+		Message: "failed",
+	}
+	format := "Preflights failed - %s - re-running preflights in 10 minutes"
+
+	conditions := common.Conditions{
+		{Type: appsv1alpha1.APIManagerPreflightsConditionType, Status: v1.ConditionTrue, Reason: "PreflightsPass"},
+		{Type: appsv1alpha1.APIManagerWarningConditionType, Status: v1.ConditionTrue, Reason: "WildcardDomainUnresolvable"},
+	}
+
+	checksErr := &preflights.Error{Failures: []preflights.CheckFailure{
+		{Name: "storage-class", Reason: "StorageClassNotFound", Err: errors.New("no default storage class")},
+		{Name: "resource-quota", Reason: "ResourceQuotaExceeded", Err: errors.New("not enough cpu")},
+	}}
+	setPreflightsConditions(&conditions, failed, format, checksErr)
+
+	if len(conditions) != 3 {
+		t.Fatalf("expected the warning and one condition per failure, got %v", conditions)
+	}
+	for _, expected := range []struct{ reason, message string }{
+		{"StorageClassNotFound", "Preflights failed - no default storage class - re-running preflights in 10 minutes"},
+		{"ResourceQuotaExceeded", "Preflights failed - not enough cpu - re-running preflights in 10 minutes"},
+	} {
+		condition := conditions.GetConditionByReason(common.ConditionReason(expected.reason))
+		if condition == nil || condition.Type != appsv1alpha1.APIManagerPreflightsConditionType || condition.Status != v1.ConditionFalse || condition.Message != expected.message {
+			t.Fatalf("expected a failed %s condition, got %v", expected.reason, conditions)
+		}
+	}
+
+	passed := common.Condition{
+		Type:    appsv1alpha1.APIManagerPreflightsConditionType,
+		Status:  v1.ConditionTrue,
+		Reason:  common.ConditionReason("PreflightsPass"),
+		Message: "All requirements for the current version are met",
+	}
+	setPreflightsConditions(&conditions, passed, "", nil)
+
+	if len(conditions) != 2 || conditions.GetConditionByReason("PreflightsPass") == nil {
+		t.Fatalf("expected the failures to be replaced by a single condition, got %v", conditions)
+	}
+}
@@ -0,0 +1,104 @@
+package controllers
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/apispkg/common"
+	"github.com/3scale/3scale-operator/pkg/preflights"
+)
+
+func TestSetPreflightsWarnings(t *testing.T) {
+	wildcardDomainFailure := func(message string) error {
+		return &preflights.Error{Failures: []preflights.CheckFailure{
+			{Name: "wildcard-domain", Reason: "WildcardDomainUnresolvable", Err: errors.New(message)},
+		}}
+	}
+
+	conditions := common.Conditions{}
+	checks := preflights.WarningChecks()
+
+	if err := setPreflightsWarnings(&conditions, checks, wildcardDomainFailure("does not resolve")); err != nil {
+		t.Fatal(err)
+	}
+	condition := conditions.GetConditionByReason("WildcardDomainUnresolvable")
+	if condition == nil || condition.Type != "Warning" || condition.Message != "does not resolve" {
+		t.Fatalf("expected a wildcard domain warning, got %v", conditions)
+	}
+
+	if err := setPreflightsWarnings(&conditions, checks, wildcardDomainFailure("resolves elsewhere")); err != nil {
+		t.Fatal(err)
+	}
+	if len(conditions) != 1 || conditions[0].Message != "resolves elsewhere" {
+		t.Fatalf("expected the warning message to be updated, got %v", conditions)
+	}
+
+	if err := setPreflightsWarnings(&conditions, checks, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(conditions) != 0 {
+		t.Fatalf("expected the warning to be removed, got %v", conditions)
+	}
+
+	if err := setPreflightsWarnings(&conditions, checks, errors.New("defaults")); err == nil {
+		t.Fatal("expected errors other than check failures to be returned")
+	}
+}
+
+func TestPreflightsWarningsCache(t *testing.T) {
+	cache := &preflightsWarningsCache{results: map[types.NamespacedName]preflightsWarningsResult{}}
+	apimanager := &appsv1alpha1.APIManager{
+		ObjectMeta: metav1.ObjectMeta{Name: "apimanager", Namespace: "3scale", Generation: 1},
+		Spec: appsv1alpha1.APIManagerSpec{
+			APIManagerCommonSpec: appsv1alpha1.APIManagerCommonSpec{WildcardDomain: "apps.example.com"},
+		},
+	}
+
+	runs := 0
+	runChecks := func() error {
+		runs++
+		return nil
+	}
+
+	now := time.Now()
+	cases := []struct {
+		name         string
+		update       func()
+		at           time.Time
+		expectedRuns int
+	}{
+		{"FirstRun", func() {}, now, 1},
+		{"Unchanged", func() {}, now.Add(time.Minute), 1},
+		{"GenerationChanged", func() { apimanager.Generation = 2 }, now.Add(time.Minute), 2},
+		{"WildcardDomainChanged", func() { apimanager.Spec.WildcardDomain = "apps.example.org" }, now.Add(time.Minute), 3},
+		{"Expired", func() {}, now.Add(time.Minute + preflightsWarningsTTL), 4},
+	}
+
+	for _, tc := range cases {
+		tc.update()
+		if err := cache.run(apimanager, tc.at, runChecks); err != nil {
+			t.Fatal(err)
+		}
+		if runs != tc.expectedRuns {
+			t.Fatalf("%s: expected %d runs, got %d", tc.name, tc.expectedRuns, runs)
+		}
+	}
+
+	if err := cache.run(apimanager, now.Add(time.Hour), func() error { return errors.New("list routes") }); err == nil {
+		t.Fatal("expected the error to be returned")
+	}
+	if err := cache.run(apimanager, now.Add(time.Hour), runChecks); err != nil || runs != 5 {
+		t.Fatalf("expected errors other than check failures not to be cached, got %v after %d runs", err, runs)
+	}
+
+	cache.forget(client.ObjectKeyFromObject(apimanager))
+	if len(cache.results) != 0 {
+		t.Fatalf("expected the result to be forgotten, got %v", cache.results)
+	}
+}
@@ -0,0 +1,107 @@
+package controllers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+
+	k8sappsv1 "k8s.io/api/apps/v1"
+	hpa "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// profileWorkersEnvVars indexes by Deployment name the env var setting the workers of the main process
+var profileWorkersEnvVars = map[string]string{
+	component.ApicastProductionName:   "APICAST_WORKERS",
+	component.BackendListenerName:     "PUMA_WORKERS",
+	component.SystemAppDeploymentName: component.SystemAppWorkersEnvVarName,
+}
+
+// profileWorkersArgPrefixes indexes by Deployment name the argument prefix setting the workers of the main process
+var profileWorkersArgPrefixes = map[string]string{
+	component.SystemSidekiqName: component.SystemSidekiqConcurrencyArgPrefix,
+}
+
+func (s *APIManagerStatusReconciler) profileStatus(existingDeployments []k8sappsv1.Deployment) (*appsv1alpha1.APIManagerProfileStatus, error) {
+	if !s.apimanagerResource.IsProfileSet() {
+		return nil, nil
+	}
+
+	hpaList := &hpa.HorizontalPodAutoscalerList{}
+	err := s.Client().List(s.Context(), hpaList, client.InNamespace(s.apimanagerResource.Namespace))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list horizontal pod autoscalers: %w", err)
+	}
+
+	return calculateProfileStatus(*s.apimanagerResource.Spec.Profile, s.expectedDeploymentNames(s.apimanagerResource), existingDeployments, hpaList.Items), nil
+}
+
+// calculateProfileStatus reads the effective sizing from the existing Deployments and HPAs
+func calculateProfileStatus(profile appsv1alpha1.APIManagerProfile, deploymentNames []string, existingDeployments []k8sappsv1.Deployment, hpas []hpa.HorizontalPodAutoscaler) *appsv1alpha1.APIManagerProfileStatus {
+	status := &appsv1alpha1.APIManagerProfileStatus{Name: profile}
+
+	for _, name := range deploymentNames {
+		deployment := findDeployment(existingDeployments, name)
+		if deployment == nil {
+			continue
+		}
+
+		sizing := appsv1alpha1.APIManagerDeploymentSizing{Name: name, Replicas: 1}
+		if deployment.Spec.Replicas != nil {
+			sizing.Replicas = *deployment.Spec.Replicas
+		}
+
+		for idx := range hpas {
+			if hpas[idx].Spec.ScaleTargetRef.Name == name {
+				maxReplicas := hpas[idx].Spec.MaxReplicas
+				sizing.MinReplicas = hpas[idx].Spec.MinReplicas
+				sizing.MaxReplicas = &maxReplicas
+				break
+			}
+		}
+
+		for _, container := range deployment.Spec.Template.Spec.Containers {
+			if container.Resources.Limits == nil && container.Resources.Requests == nil {
+				continue
+			}
+			if sizing.Resources == nil {
+				sizing.Resources = map[string]v1.ResourceRequirements{}
+			}
+			sizing.Resources[container.Name] = container.Resources
+		}
+
+		if envVarName, ok := profileWorkersEnvVars[name]; ok && len(deployment.Spec.Template.Spec.Containers) > 0 {
+			for _, envVar := range deployment.Spec.Template.Spec.Containers[0].Env {
+				if envVar.Name != envVarName {
+					continue
+				}
+				sizing.Workers = parseWorkers(envVar.Value)
+			}
+		}
+
+		if argPrefix, ok := profileWorkersArgPrefixes[name]; ok && len(deployment.Spec.Template.Spec.Containers) > 0 {
+			for _, arg := range deployment.Spec.Template.Spec.Containers[0].Args {
+				if strings.HasPrefix(arg, argPrefix) {
+					sizing.Workers = parseWorkers(strings.TrimPrefix(arg, argPrefix))
+				}
+			}
+		}
+
+		status.Deployments = append(status.Deployments, sizing)
+	}
+
+	return status
+}
+
+func parseWorkers(value string) *int32 {
+	workers, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return nil
+	}
+	workersValue := int32(workers)
+	return &workersValue
+}
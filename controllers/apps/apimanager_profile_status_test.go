@@ -0,0 +1,89 @@
+package controllers
+
+import (
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/helper"
+
+	"github.com/google/go-cmp/cmp"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	hpa "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testProfileDeployment(name string, replicas int32, containers ...v1.Container) k8sappsv1.Deployment {
+	return k8sappsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: k8sappsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{Containers: containers},
+			},
+		},
+	}
+}
+
+func TestCalculateProfileStatus(t *testing.T) {
+	resources := v1.ResourceRequirements{
+		Limits: v1.ResourceList{v1.ResourceCPU: resource.MustParse("2")},
+	}
+
+	deployments := []k8sappsv1.Deployment{
+		testProfileDeployment("backend-listener", 3, v1.Container{
+			Name:      "backend-listener",
+			Resources: resources,
+			Env:       []v1.EnvVar{{Name: "PUMA_WORKERS", Value: "32"}},
+		}),
+		testProfileDeployment("apicast-production", 2, v1.Container{Name: "apicast-production"}),
+		testProfileDeployment("system-app", 2,
+			v1.Container{
+				Name:      "system-master",
+				Resources: resources,
+				Env:       []v1.EnvVar{{Name: "PUMA_WORKERS", Value: "3"}},
+			},
+			v1.Container{Name: "system-provider"},
+		),
+		testProfileDeployment("system-sidekiq", 2, v1.Container{
+			Name: "system-sidekiq",
+			Args: []string{"rake", "sidekiq:worker", "RAILS_MAX_THREADS=25"},
+		}),
+	}
+	hpas := []hpa.HorizontalPodAutoscaler{
+		{Spec: hpa.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: hpa.CrossVersionObjectReference{Name: "backend-listener"},
+			MinReplicas:    helper.Int32Ptr(3),
+			MaxReplicas:    10,
+		}},
+	}
+
+	status := calculateProfileStatus(appsv1alpha1.APIManagerProfileLarge,
+		[]string{"apicast-production", "backend-listener", "backend-worker", "system-app", "system-sidekiq"}, deployments, hpas)
+
+	expected := &appsv1alpha1.APIManagerProfileStatus{
+		Name: appsv1alpha1.APIManagerProfileLarge,
+		Deployments: []appsv1alpha1.APIManagerDeploymentSizing{
+			{Name: "apicast-production", Replicas: 2},
+			{
+				Name:        "backend-listener",
+				Replicas:    3,
+				MinReplicas: helper.Int32Ptr(3),
+				MaxReplicas: helper.Int32Ptr(10),
+				Workers:     helper.Int32Ptr(32),
+				Resources:   map[string]v1.ResourceRequirements{"backend-listener": resources},
+			},
+			{
+				Name:      "system-app",
+				Replicas:  2,
+				Workers:   helper.Int32Ptr(3),
+				Resources: map[string]v1.ResourceRequirements{"system-master": resources},
+			},
+			{Name: "system-sidekiq", Replicas: 2, Workers: helper.Int32Ptr(25)},
+		},
+	}
+	if diff := cmp.Diff(expected, status); diff != "" {
+		t.Fatalf("unexpected profile status (-want +got):\n%s", diff)
+	}
+}
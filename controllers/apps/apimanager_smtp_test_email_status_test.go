@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+)
+
+func TestSMTPTestEmailCondition(t *testing.T) {
+	job := func(recipient string, conditionType batchv1.JobConditionType) *batchv1.Job {
+		job := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        component.SystemSMTPTestEmailJobName,
+				Annotations: map[string]string{component.SystemSMTPTestEmailRecipientAnnotation: recipient},
+			},
+		}
+		if conditionType != "" {
+			job.Status.Conditions = []batchv1.JobCondition{{Type: conditionType, Status: v1.ConditionTrue}}
+		}
+		return job
+	}
+
+	cases := []struct {
+		testName       string
+		job            *batchv1.Job
+		expectedStatus v1.ConditionStatus
+		expectedReason string
+	}{
+		{"JobNotCreated", nil, v1.ConditionUnknown, "Sending"},
+		{"JobRunning", job("ops@example.com", ""), v1.ConditionUnknown, "Sending"},
+		{"JobCompleted", job("ops@example.com", batchv1.JobComplete), v1.ConditionTrue, "EmailSent"},
+		{"JobFailed", job("ops@example.com", batchv1.JobFailed), v1.ConditionFalse, "EmailFailed"},
+		{"PreviousRecipient", job("old@example.com", batchv1.JobComplete), v1.ConditionUnknown, "Sending"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			condition := smtpTestEmailCondition("ops@example.com", tc.job)
+			if condition.Status != tc.expectedStatus || string(condition.Reason) != tc.expectedReason {
+				subT.Fatalf("expected %s/%s, got %s/%s", tc.expectedStatus, tc.expectedReason, condition.Status, condition.Reason)
+			}
+		})
+	}
+}
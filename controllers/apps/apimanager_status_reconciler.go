@@ -2,21 +2,27 @@ package controllers
 
 import (
 	"context"
+	goerrors "errors"
 	"fmt"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	subController "github.com/3scale/3scale-operator/controllers/subscription"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/operator"
 	"github.com/3scale/3scale-operator/pkg/apispkg/common"
 	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/preflights"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 	"github.com/3scale/3scale-operator/version"
 
 	"github.com/RHsyseng/operator-utils/pkg/olm"
 	"github.com/go-logr/logr"
 	k8sappsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -106,16 +112,43 @@ func (s *APIManagerStatusReconciler) calculateStatus() (*appsv1alpha1.APIManager
 		s.reconcileOpenTracingDeprecationMessage(&newStatus.Conditions, s.apimanagerResource)
 	}
 
+	s.reconcileMaintenanceCondition(&newStatus.Conditions, s.apimanagerResource)
+
+	err = s.reconcileSMTPTestEmailCondition(&newStatus.Conditions, s.apimanagerResource)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.reconcileCertificatesStatus(newStatus)
+	if err != nil {
+		return nil, err
+	}
+
 	if !helper.IsPreflightBypassed() {
 		err = s.reconcilePreflightsStatus(&newStatus.Conditions, s.apimanagerResource)
 		if err != nil {
 			return nil, err
 		}
+
+		err = s.reconcilePreflightsWarnings(&newStatus.Conditions, s.apimanagerResource)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	deploymentStatus := olm.GetDeploymentStatus(deployments)
 	newStatus.Deployments = deploymentStatus
 
+	newStatus.Components, err = s.componentsStatus(deployments)
+	if err != nil {
+		return nil, err
+	}
+
+	newStatus.Profile, err = s.profileStatus(deployments)
+	if err != nil {
+		return nil, err
+	}
+
 	return newStatus, nil
 }
 
@@ -295,6 +328,71 @@ func (s *APIManagerStatusReconciler) reconcileOpenTracingDeprecationMessage(cond
 	}
 }
 
+func (s *APIManagerStatusReconciler) reconcileMaintenanceCondition(conditions *common.Conditions, cr *appsv1alpha1.APIManager) {
+	if !cr.IsMaintenanceEnabled() {
+		conditions.RemoveCondition(appsv1alpha1.APIManagerMaintenanceConditionType)
+		return
+	}
+
+	conditions.SetCondition(common.Condition{
+		Type:    appsv1alpha1.APIManagerMaintenanceConditionType,
+		Status:  v1.ConditionTrue,
+		Reason:  common.ConditionReason(cr.MaintenanceMode()),
+		Message: fmt.Sprintf("Paused deployments: %s", strings.Join(operator.MaintenancePausedDeployments(cr), ", ")),
+	})
+}
+
+func (s *APIManagerStatusReconciler) reconcileSMTPTestEmailCondition(conditions *common.Conditions, cr *appsv1alpha1.APIManager) error {
+	if cr.SMTPTestEmailRecipient() == "" {
+		conditions.RemoveCondition(appsv1alpha1.APIManagerSMTPTestEmailConditionType)
+		return nil
+	}
+
+	job := &batchv1.Job{}
+	err := s.Client().Get(s.Context(), types.NamespacedName{Name: component.SystemSMTPTestEmailJobName, Namespace: cr.Namespace}, job)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	if errors.IsNotFound(err) {
+		job = nil
+	}
+
+	conditions.SetCondition(smtpTestEmailCondition(cr.SMTPTestEmailRecipient(), job))
+	return nil
+}
+
+// smtpTestEmailCondition reports the result of the Job sending the SMTP probe email
+func smtpTestEmailCondition(recipient string, job *batchv1.Job) common.Condition {
+	condition := common.Condition{
+		Type:    appsv1alpha1.APIManagerSMTPTestEmailConditionType,
+		Status:  v1.ConditionUnknown,
+		Reason:  common.ConditionReason("Sending"),
+		Message: fmt.Sprintf("Sending test email to %s", recipient),
+	}
+
+	if job == nil || job.Annotations[component.SystemSMTPTestEmailRecipientAnnotation] != recipient {
+		return condition
+	}
+
+	for _, jobCondition := range job.Status.Conditions {
+		if jobCondition.Status != v1.ConditionTrue {
+			continue
+		}
+		switch jobCondition.Type {
+		case batchv1.JobComplete:
+			condition.Status = v1.ConditionTrue
+			condition.Reason = common.ConditionReason("EmailSent")
+			condition.Message = fmt.Sprintf("Test email sent to %s", recipient)
+		case batchv1.JobFailed:
+			condition.Status = v1.ConditionFalse
+			condition.Reason = common.ConditionReason("EmailFailed")
+			condition.Message = fmt.Sprintf("Test email to %s failed, check the logs of the %s job", recipient, component.SystemSMTPTestEmailJobName)
+		}
+	}
+
+	return condition
+}
+
 func apicastOpenTracingCondition(apicast string) common.Condition {
 	return common.Condition{
 		Type:    appsv1alpha1.APIManagerWarningConditionType,
@@ -304,6 +402,110 @@ func apicastOpenTracingCondition(apicast string) common.Condition {
 	}
 }
 
+// preflightsWarningsTTL is how long a warning checks result is reused while
+// neither the APIManager generation nor its wildcard domain change
+const preflightsWarningsTTL = 10 * time.Minute
+
+type preflightsWarningsResult struct {
+	generation     int64
+	wildcardDomain string
+	checkedAt      time.Time
+	err            error
+}
+
+// preflightsWarningsCache keeps the last warning checks result of each APIManager.
+// The wildcard domain check resolves DNS and lists the routes of the whole cluster,
+// running it on every status reconcile is too expensive
+type preflightsWarningsCache struct {
+	mutex   sync.Mutex
+	results map[types.NamespacedName]preflightsWarningsResult
+}
+
+var preflightsWarnings = &preflightsWarningsCache{results: map[types.NamespacedName]preflightsWarningsResult{}}
+
+// run returns the cached result of the checks, unless the APIManager generation or
+// wildcard domain changed or the result is older than preflightsWarningsTTL.
+// Errors other than check failures are not cached
+func (c *preflightsWarningsCache) run(cr *appsv1alpha1.APIManager, now time.Time, runChecks func() error) error {
+	key := client.ObjectKeyFromObject(cr)
+
+	c.mutex.Lock()
+	result, found := c.results[key]
+	c.mutex.Unlock()
+	if found && result.generation == cr.Generation && result.wildcardDomain == cr.Spec.WildcardDomain && now.Sub(result.checkedAt) < preflightsWarningsTTL {
+		return result.err
+	}
+
+	err := runChecks()
+	var checksErr *preflights.Error
+	if err != nil && !goerrors.As(err, &checksErr) {
+		return err
+	}
+
+	c.mutex.Lock()
+	c.results[key] = preflightsWarningsResult{
+		generation:     cr.Generation,
+		wildcardDomain: cr.Spec.WildcardDomain,
+		checkedAt:      now,
+		err:            err,
+	}
+	c.mutex.Unlock()
+
+	return err
+}
+
+// forget drops the cached result of a deleted APIManager
+func (c *preflightsWarningsCache) forget(key types.NamespacedName) {
+	c.mutex.Lock()
+	delete(c.results, key)
+	c.mutex.Unlock()
+}
+
+// reconcilePreflightsWarnings reports each failed warning check in a Warning condition
+// with the reason of the check
+func (s *APIManagerStatusReconciler) reconcilePreflightsWarnings(conditions *common.Conditions, cr *appsv1alpha1.APIManager) error {
+	checks := preflights.WarningChecks()
+	err := preflightsWarnings.run(cr, time.Now(), func() error {
+		return preflights.Run(s.Context(), s.Client(), cr, checks)
+	})
+	return setPreflightsWarnings(conditions, checks, err)
+}
+
+func setPreflightsWarnings(conditions *common.Conditions, checks []preflights.Check, err error) error {
+	failures := map[string]preflights.CheckFailure{}
+	var checksErr *preflights.Error
+	if goerrors.As(err, &checksErr) {
+		for _, failure := range checksErr.Failures {
+			failures[failure.Name] = failure
+		}
+	} else if err != nil {
+		return err
+	}
+
+	for _, check := range checks {
+		reason := common.ConditionReason(check.Reason())
+		failure, failed := failures[check.Name()]
+		if !failed {
+			conditions.RemoveConditionByReason(reason)
+			continue
+		}
+
+		foundCondition := conditions.GetConditionByReason(reason)
+		if foundCondition != nil && foundCondition.Message == failure.Err.Error() {
+			continue
+		}
+		conditions.RemoveConditionByReason(reason)
+		*conditions = append(*conditions, common.Condition{
+			Type:    appsv1alpha1.APIManagerWarningConditionType,
+			Status:  v1.ConditionStatus(metav1.ConditionTrue),
+			Reason:  reason,
+			Message: failure.Err.Error(),
+		})
+	}
+
+	return nil
+}
+
 func (s *APIManagerStatusReconciler) reconcilePreflightsStatus(conditions *common.Conditions, cr *appsv1alpha1.APIManager) error {
 	prefligtsCondition := common.Condition{
 		Type:    appsv1alpha1.APIManagerPreflightsConditionType,
@@ -314,9 +516,10 @@ func (s *APIManagerStatusReconciler) reconcilePreflightsStatus(conditions *commo
 
 	upgradeSuccessfulPreflight := "All requirement for incoming version are met. If using automatic upgrades the upgrade will start shortly, if manual, you can proceed with approval"
 	requirementConfigMapNotFoundPreflight := "Requirement config map is not found yet, it should be generated shortly"
-	freshInstallPreflightsErrorMessage := fmt.Sprintf("Preflights failed - %s - re-running preflights in 10 minutes", s.preflightsErr)
-	upgradePreflightsErrorMessage := fmt.Sprintf("Preflights failed - %s - re-running preflights in 10 minutes", s.preflightsErr)
-	multiMinorHopPreflightsMessage := fmt.Sprintf("Preflights failed - %s. Multi minor version hop detected. Reconciliation of this 3scale instance is stopped. Remove the operator and refer to official upgrade path for 3scale Operator", s.preflightsErr)
+	freshInstallPreflightsErrorFormat := "Preflights failed - %s - re-running preflights in 10 minutes"
+	upgradePreflightsErrorFormat := "Preflights failed - %s - re-running preflights in 10 minutes"
+	multiMinorHopPreflightsFormat := "Preflights failed - %s. Multi minor version hop detected. Reconciliation of this 3scale instance is stopped. Remove the operator and refer to official upgrade path for 3scale Operator"
+	failedMessageFormat := ""
 
 	reqConfigMap, err := subController.RetrieveRequirementsConfigMap(s.Client())
 	if err != nil {
@@ -333,18 +536,21 @@ func (s *APIManagerStatusReconciler) reconcilePreflightsStatus(conditions *commo
 		return err
 	}
 	if isMultiHopDetected {
-		prefligtsCondition.Message = multiMinorHopPreflightsMessage
+		failedMessageFormat = multiMinorHopPreflightsFormat
+		prefligtsCondition.Message = fmt.Sprintf(failedMessageFormat, s.preflightsErr)
 		prefligtsCondition.Status = v1.ConditionStatus(metav1.ConditionFalse)
 	}
 
 	if cr.IsInFreshInstallationScenario() && s.preflightsErr != nil && !isMultiHopDetected {
+		failedMessageFormat = freshInstallPreflightsErrorFormat
 		prefligtsCondition.Status = v1.ConditionStatus(metav1.ConditionFalse)
-		prefligtsCondition.Message = freshInstallPreflightsErrorMessage
+		prefligtsCondition.Message = fmt.Sprintf(failedMessageFormat, s.preflightsErr)
 	}
 
 	if !cr.IsInFreshInstallationScenario() && s.preflightsErr != nil && !isMultiHopDetected {
+		failedMessageFormat = upgradePreflightsErrorFormat
 		prefligtsCondition.Status = v1.ConditionStatus(metav1.ConditionFalse)
-		prefligtsCondition.Message = upgradePreflightsErrorMessage
+		prefligtsCondition.Message = fmt.Sprintf(failedMessageFormat, s.preflightsErr)
 	}
 
 	if !cr.IsInFreshInstallationScenario() && s.preflightsErr == nil && (version.ThreescaleVersionMajorMinor() != reqConfigMap.Data[helper.RHTThreescaleVersion]) && !isMultiHopDetected {
@@ -352,19 +558,31 @@ func (s *APIManagerStatusReconciler) reconcilePreflightsStatus(conditions *commo
 		prefligtsCondition.Message = upgradeSuccessfulPreflight
 	}
 
-	existingPreflightCondition := conditions.GetConditionByReason(prefligtsCondition.Reason)
-	if existingPreflightCondition == nil {
-		*conditions = append(*conditions, prefligtsCondition)
-	} else {
-		conditions.RemoveConditionByReason(prefligtsCondition.Reason)
-		existingPreflightCondition.Message = prefligtsCondition.Message
-		existingPreflightCondition.Status = prefligtsCondition.Status
-		*conditions = append(*conditions, prefligtsCondition)
-	}
+	setPreflightsConditions(conditions, prefligtsCondition, failedMessageFormat, s.preflightsErr)
 
 	return nil
 }
 
+// setPreflightsConditions replaces the Preflights conditions. Each failed environment
+// check is reported in its own condition with the reason of the check and the
+// failedMessageFormat applied to its error. Any other result is reported in condition
+func setPreflightsConditions(conditions *common.Conditions, condition common.Condition, failedMessageFormat string, err error) {
+	conditions.RemoveConditionsByType(appsv1alpha1.APIManagerPreflightsConditionType)
+
+	var checksErr *preflights.Error
+	if !goerrors.As(err, &checksErr) {
+		*conditions = append(*conditions, condition)
+		return
+	}
+
+	for _, failure := range checksErr.Failures {
+		failureCondition := condition
+		failureCondition.Reason = common.ConditionReason(failure.Reason)
+		failureCondition.Message = fmt.Sprintf(failedMessageFormat, failure.Err)
+		*conditions = append(*conditions, failureCondition)
+	}
+}
+
 func (s *APIManagerStatusReconciler) watchedSecretsExist(cr *appsv1alpha1.APIManager) (bool, string) {
 	secretsToCheck := cr.Get3scaleSecretRefs()
 	if len(secretsToCheck) == 0 {
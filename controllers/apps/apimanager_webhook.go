@@ -0,0 +1,27 @@
+package controllers
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+)
+
+// +kubebuilder:webhook:path=/validate-apps-3scale-net-v1alpha1-apimanager,mutating=false,failurePolicy=fail,sideEffects=None,groups=apps.3scale.net,resources=apimanagers,verbs=create;update,versions=v1alpha1,name=vapimanager.apps.3scale.net,admissionReviewVersions=v1
+
+// SetupWebhookWithManager rejects APIManager resources failing the validation run at the start of each reconciliation.
+// The builder also serves the /convert endpoint converting between v1alpha1 and the v1beta1 storage version
+func (r *APIManagerReconciler) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&appsv1alpha1.APIManager{}).
+		WithValidator(&controllerhelper.SpecValidator{
+			GroupKind: appsv1alpha1.GroupVersion.WithKind("APIManager").GroupKind(),
+			Spec:      func(obj runtime.Object) interface{} { return obj.(*appsv1alpha1.APIManager).Spec },
+			Validate: func(obj runtime.Object) (admission.Warnings, error) {
+				return nil, r.validateCR(obj.(*appsv1alpha1.APIManager))
+			},
+		}).
+		Complete()
+}
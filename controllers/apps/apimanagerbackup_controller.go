@@ -24,6 +24,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	threescalemetrics "github.com/3scale/3scale-operator/pkg/metrics"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 )
 
@@ -90,7 +91,7 @@ func (r *APIManagerBackupReconciler) Reconcile(ctx context.Context, req ctrl.Req
 func (r *APIManagerBackupReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&appsv1alpha1.APIManagerBackup{}).
-		Complete(r)
+		Complete(threescalemetrics.NewInstrumentedReconciler("APIManagerBackup", &appsv1alpha1.APIManagerBackup{}, mgr.GetClient(), r))
 }
 
 func (r *APIManagerBackupReconciler) getAPIManagerBackupCR(request reconcile.Request) (*appsv1alpha1.APIManagerBackup, error) {
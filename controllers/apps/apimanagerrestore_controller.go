@@ -23,6 +23,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	threescalemetrics "github.com/3scale/3scale-operator/pkg/metrics"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 	"github.com/3scale/3scale-operator/pkg/restore"
 	corev1 "k8s.io/api/core/v1"
@@ -121,5 +122,5 @@ func (r *APIManagerRestoreReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&appsv1alpha1.APIManagerRestore{}).
 		Owns(&corev1.Pod{}).
-		Complete(r)
+		Complete(threescalemetrics.NewInstrumentedReconciler("APIManagerRestore", &appsv1alpha1.APIManagerRestore{}, mgr.GetClient(), r))
 }
@@ -31,6 +31,7 @@ import (
 	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
 	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
 	"github.com/3scale/3scale-operator/pkg/helper"
+	threescalemetrics "github.com/3scale/3scale-operator/pkg/metrics"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 	"github.com/3scale/3scale-operator/version"
 )
@@ -203,5 +204,5 @@ func (r *ActiveDocReconciler) checkExternalRefs(resource *capabilitiesv1beta1.Ac
 func (r *ActiveDocReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&capabilitiesv1beta1.ActiveDoc{}).
-		Complete(r)
+		Complete(threescalemetrics.NewInstrumentedReconciler("ActiveDoc", &capabilitiesv1beta1.ActiveDoc{}, mgr.GetClient(), r))
 }
@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+)
+
+// +kubebuilder:webhook:path=/validate-capabilities-3scale-net-v1beta1-activedoc,mutating=false,failurePolicy=fail,sideEffects=None,groups=capabilities.3scale.net,resources=activedocs,verbs=create;update,versions=v1beta1,name=vactivedoc.capabilities.3scale.net,admissionReviewVersions=v1
+
+func (r *ActiveDocReconciler) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&capabilitiesv1beta1.ActiveDoc{}).
+		WithValidator(&controllerhelper.SpecValidator{
+			GroupKind: capabilitiesv1beta1.GroupVersion.WithKind(capabilitiesv1beta1.ActiveDocKind).GroupKind(),
+			Spec:      func(obj runtime.Object) interface{} { return obj.(*capabilitiesv1beta1.ActiveDoc).Spec },
+			Validate: func(obj runtime.Object) (admission.Warnings, error) {
+				return r.validateAdmission(obj.(*capabilitiesv1beta1.ActiveDoc))
+			},
+		}).
+		Complete()
+}
+
+// validateAdmission validates the defaulted spec, then checks the system name and the referenced product
+// against the ActiveDoc and Product resources of the same provider account
+func (r *ActiveDocReconciler) validateAdmission(resource *capabilitiesv1beta1.ActiveDoc) (admission.Warnings, error) {
+	logger := r.Logger().WithValues("activedoc", client.ObjectKeyFromObject(resource))
+
+	activeDoc := resource.DeepCopy()
+	activeDoc.SetDefaults(logger)
+
+	err := r.validateSpec(activeDoc)
+	if err != nil {
+		return nil, err
+	}
+
+	providerAccount, err := controllerhelper.LookupProviderAccount(r.Client(), activeDoc.Namespace, activeDoc.Spec.ProviderAccountRef, logger)
+	if err != nil {
+		return providerAccountWarning(err), nil
+	}
+
+	errors := field.ErrorList{}
+	specFldPath := field.NewPath("spec")
+
+	activeDocList := &capabilitiesv1beta1.ActiveDocList{}
+	err = r.Client().List(r.Context(), activeDocList, client.InNamespace(activeDoc.Namespace))
+	if err != nil {
+		return nil, err
+	}
+	for idx := range activeDocList.Items {
+		other := activeDocList.Items[idx].DeepCopy()
+		other.SetDefaults(logger)
+		if other.Name == activeDoc.Name || *other.Spec.SystemName != *activeDoc.Spec.SystemName {
+			continue
+		}
+		if sameProviderAccount(r.Client(), other.Namespace, other.Spec.ProviderAccountRef, providerAccount.AdminURLStr, logger) {
+			errors = append(errors, duplicateSystemNameError(specFldPath.Child("systemName"), *activeDoc.Spec.SystemName, capabilitiesv1beta1.ActiveDocKind, other.Name)...)
+			break
+		}
+	}
+
+	// Products not synchronized yet are valid references, unlike when reconciling
+	if activeDoc.Spec.ProductSystemName != nil {
+		productList := &capabilitiesv1beta1.ProductList{}
+		err = r.Client().List(r.Context(), productList, client.InNamespace(activeDoc.Namespace))
+		if err != nil {
+			return nil, err
+		}
+		found := false
+		for idx := range productList.Items {
+			product := productList.Items[idx].DeepCopy()
+			product.SetDefaults(logger)
+			if product.Spec.SystemName == *activeDoc.Spec.ProductSystemName &&
+				sameProviderAccount(r.Client(), product.Namespace, product.Spec.ProviderAccountRef, providerAccount.AdminURLStr, logger) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			errors = append(errors, field.Invalid(specFldPath.Child("productSystemName"), activeDoc.Spec.ProductSystemName, "not a valid reference"))
+		}
+	}
+
+	return nil, admissionError(errors)
+}
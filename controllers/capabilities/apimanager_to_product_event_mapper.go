@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+)
+
+// APIManagerToProductEventMapper is an EventHandler that maps an APIManager to the Product CRs
+// of its namespace, to reconcile the product dashboards when the monitoring settings change
+type APIManagerToProductEventMapper struct {
+	Context   context.Context
+	K8sClient client.Client
+	Logger    logr.Logger
+}
+
+func (a *APIManagerToProductEventMapper) Map(ctx context.Context, obj client.Object) []reconcile.Request {
+	productList := &capabilitiesv1beta1.ProductList{}
+
+	err := a.K8sClient.List(ctx, productList, client.InNamespace(obj.GetNamespace()))
+	if err != nil {
+		a.Logger.Error(err, "failed to list Product resources")
+		return nil
+	}
+
+	a.Logger.V(1).Info("Processing object", "key", client.ObjectKeyFromObject(obj), "accepted", len(productList.Items) > 0)
+
+	requests := []reconcile.Request{}
+	for idx := range productList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+			Name:      productList.Items[idx].GetName(),
+			Namespace: productList.Items[idx].GetNamespace(),
+		}})
+	}
+
+	return requests
+}
+
+// apimanagerDashboardsChangedPredicate filters APIManager updates not changing monitoring enablement
+// nor the grafana options
+var apimanagerDashboardsChangedPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldAPIManager, ok := e.ObjectOld.(*appsv1alpha1.APIManager)
+		if !ok {
+			return false
+		}
+		newAPIManager, ok := e.ObjectNew.(*appsv1alpha1.APIManager)
+		if !ok {
+			return false
+		}
+		return oldAPIManager.IsMonitoringEnabled() != newAPIManager.IsMonitoringEnabled() ||
+			!reflect.DeepEqual(oldAPIManager.GrafanaSpec(), newAPIManager.GrafanaSpec())
+	},
+}
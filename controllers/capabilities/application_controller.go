@@ -25,6 +25,7 @@ import (
 	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
 	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
 	"github.com/3scale/3scale-operator/pkg/helper"
+	threescalemetrics "github.com/3scale/3scale-operator/pkg/metrics"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 	"github.com/3scale/3scale-operator/version"
 	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
@@ -342,5 +343,5 @@ func (r *ApplicationReconciler) checkExternalResources(applicationResource *capa
 func (r *ApplicationReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&capabilitiesv1beta1.Application{}).
-		Complete(r)
+		Complete(threescalemetrics.NewInstrumentedReconciler("Application", &capabilitiesv1beta1.Application{}, mgr.GetClient(), r))
 }
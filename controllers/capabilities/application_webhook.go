@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+)
+
+// +kubebuilder:webhook:path=/validate-capabilities-3scale-net-v1beta1-application,mutating=false,failurePolicy=fail,sideEffects=None,groups=capabilities.3scale.net,resources=applications,verbs=create;update,versions=v1beta1,name=vapplication.capabilities.3scale.net,admissionReviewVersions=v1
+
+func (r *ApplicationReconciler) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&capabilitiesv1beta1.Application{}).
+		WithValidator(&controllerhelper.SpecValidator{
+			GroupKind: capabilitiesv1beta1.GroupVersion.WithKind(capabilitiesv1beta1.ApplicationKind).GroupKind(),
+			Spec:      func(obj runtime.Object) interface{} { return obj.(*capabilitiesv1beta1.Application).Spec },
+			Validate: func(obj runtime.Object) (admission.Warnings, error) {
+				return r.validateAdmission(obj.(*capabilitiesv1beta1.Application))
+			},
+		}).
+		Complete()
+}
+
+// validateAdmission checks the referenced DeveloperAccount and Product exist, are not invalid,
+// belong to the same provider account and the product has the application plan
+func (r *ApplicationReconciler) validateAdmission(application *capabilitiesv1beta1.Application) (admission.Warnings, error) {
+	logger := r.Logger().WithValues("application", client.ObjectKeyFromObject(application))
+
+	errors := field.ErrorList{}
+	specFldPath := field.NewPath("spec")
+	accountFldPath := specFldPath.Child("accountCR")
+	productFldPath := specFldPath.Child("productCR")
+
+	account := &capabilitiesv1beta1.DeveloperAccount{}
+	err := r.Client().Get(r.Context(), types.NamespacedName{Name: application.Spec.AccountCR.Name, Namespace: application.Namespace}, account)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		errors = append(errors, field.Invalid(accountFldPath, application.Spec.AccountCR, "developer account resource not found"))
+		account = nil
+	} else if account.Status.Conditions.IsTrueFor(capabilitiesv1beta1.DeveloperAccountInvalidConditionType) {
+		errors = append(errors, field.Invalid(accountFldPath, application.Spec.AccountCR, "account CR is in an invalid state"))
+	}
+
+	product := &capabilitiesv1beta1.Product{}
+	err = r.Client().Get(r.Context(), types.NamespacedName{Name: application.Spec.ProductCR.Name, Namespace: application.Namespace}, product)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		errors = append(errors, field.Invalid(productFldPath, application.Spec.ProductCR, "product resource not found"))
+		product = nil
+	} else {
+		if product.Status.Conditions.IsTrueFor(capabilitiesv1beta1.ProductInvalidConditionType) {
+			errors = append(errors, field.Invalid(productFldPath, application.Spec.ProductCR, "product CR is in an invalid state"))
+		}
+		if _, ok := product.Spec.ApplicationPlans[application.Spec.ApplicationPlanName]; !ok {
+			errors = append(errors, field.Invalid(specFldPath.Child("applicationPlanName"), application.Spec.ApplicationPlanName, "application plan not found in the product"))
+		}
+	}
+
+	if account != nil && product != nil {
+		accountProviderAccount, err := controllerhelper.LookupProviderAccount(r.Client(), application.Namespace, account.Spec.ProviderAccountRef, logger)
+		if err != nil {
+			return providerAccountWarning(err), admissionError(errors)
+		}
+		if !sameProviderAccount(r.Client(), application.Namespace, product.Spec.ProviderAccountRef, accountProviderAccount.AdminURLStr, logger) {
+			errors = append(errors, field.Invalid(productFldPath, application.Spec.ProductCR, "product and account providerAccounts dont match"))
+		}
+	}
+
+	return nil, admissionError(errors)
+}
@@ -25,6 +25,7 @@ import (
 	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
 	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
 	"github.com/3scale/3scale-operator/pkg/helper"
+	threescalemetrics "github.com/3scale/3scale-operator/pkg/metrics"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 	"github.com/3scale/3scale-operator/version"
 	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
@@ -222,7 +223,7 @@ func (r *ApplicationAuthReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 func (r *ApplicationAuthReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&capabilitiesv1beta1.ApplicationAuth{}).
-		Complete(r)
+		Complete(threescalemetrics.NewInstrumentedReconciler("ApplicationAuth", &capabilitiesv1beta1.ApplicationAuth{}, mgr.GetClient(), r))
 }
 
 func (r *ApplicationAuthReconciler) applicationAuthReconciler(
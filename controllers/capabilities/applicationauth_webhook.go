@@ -0,0 +1,51 @@
+package controllers
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+)
+
+// +kubebuilder:webhook:path=/validate-capabilities-3scale-net-v1beta1-applicationauth,mutating=false,failurePolicy=fail,sideEffects=None,groups=capabilities.3scale.net,resources=applicationauths,verbs=create;update,versions=v1beta1,name=vapplicationauth.capabilities.3scale.net,admissionReviewVersions=v1
+
+func (r *ApplicationAuthReconciler) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&capabilitiesv1beta1.ApplicationAuth{}).
+		WithValidator(&controllerhelper.SpecValidator{
+			GroupKind: capabilitiesv1beta1.GroupVersion.WithKind(capabilitiesv1beta1.ApplicationAuthKind).GroupKind(),
+			Spec:      func(obj runtime.Object) interface{} { return obj.(*capabilitiesv1beta1.ApplicationAuth).Spec },
+			Validate: func(obj runtime.Object) (admission.Warnings, error) {
+				return r.validateAdmission(obj.(*capabilitiesv1beta1.ApplicationAuth))
+			},
+		}).
+		Complete()
+}
+
+// validateAdmission checks the referenced Application exists and warns when the auth secret does not exist
+func (r *ApplicationAuthReconciler) validateAdmission(applicationAuth *capabilitiesv1beta1.ApplicationAuth) (admission.Warnings, error) {
+	specFldPath := field.NewPath("spec")
+
+	err := r.Client().Get(r.Context(), types.NamespacedName{Name: applicationAuth.Spec.ApplicationCRName, Namespace: applicationAuth.Namespace}, &capabilitiesv1beta1.Application{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		return nil, admissionError(field.ErrorList{
+			field.Invalid(specFldPath.Child("applicationCRName"), applicationAuth.Spec.ApplicationCRName, "application resource not found"),
+		})
+	}
+
+	if applicationAuth.Spec.AuthSecretRef == nil {
+		return nil, nil
+	}
+
+	err = r.Client().Get(r.Context(), types.NamespacedName{Name: applicationAuth.Spec.AuthSecretRef.Name, Namespace: applicationAuth.Namespace}, &corev1.Secret{})
+	return secretWarning(specFldPath.Child("authSecretRef"), applicationAuth.Spec.AuthSecretRef.Name, err), nil
+}
@@ -35,6 +35,7 @@ import (
 	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
 	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
 	"github.com/3scale/3scale-operator/pkg/helper"
+	threescalemetrics "github.com/3scale/3scale-operator/pkg/metrics"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 	"github.com/3scale/3scale-operator/version"
 )
@@ -347,5 +348,5 @@ func (r *BackendReconciler) fetchTenantProductCRs(productsCRsList *capabilitiesv
 func (r *BackendReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&capabilitiesv1beta1.Backend{}).
-		Complete(r)
+		Complete(threescalemetrics.NewInstrumentedReconciler("Backend", &capabilitiesv1beta1.Backend{}, mgr.GetClient(), r))
 }
@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+)
+
+// +kubebuilder:webhook:path=/validate-capabilities-3scale-net-v1beta1-backend,mutating=false,failurePolicy=fail,sideEffects=None,groups=capabilities.3scale.net,resources=backends,verbs=create;update,versions=v1beta1,name=vbackend.capabilities.3scale.net,admissionReviewVersions=v1
+
+func (r *BackendReconciler) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&capabilitiesv1beta1.Backend{}).
+		WithValidator(&controllerhelper.SpecValidator{
+			GroupKind: capabilitiesv1beta1.GroupVersion.WithKind(capabilitiesv1beta1.BackendKind).GroupKind(),
+			Spec:      func(obj runtime.Object) interface{} { return obj.(*capabilitiesv1beta1.Backend).Spec },
+			Validate: func(obj runtime.Object) (admission.Warnings, error) {
+				return r.validateAdmission(obj.(*capabilitiesv1beta1.Backend))
+			},
+		}).
+		Complete()
+}
+
+// validateAdmission validates the defaulted spec, then checks the system name against the
+// Backend resources of the same provider account
+func (r *BackendReconciler) validateAdmission(resource *capabilitiesv1beta1.Backend) (admission.Warnings, error) {
+	logger := r.Logger().WithValues("backend", client.ObjectKeyFromObject(resource))
+
+	backend := resource.DeepCopy()
+	backend.SetDefaults(logger)
+
+	err := r.validateSpec(backend)
+	if err != nil {
+		return nil, err
+	}
+
+	providerAccount, err := controllerhelper.LookupProviderAccount(r.Client(), backend.Namespace, backend.Spec.ProviderAccountRef, logger)
+	if err != nil {
+		return providerAccountWarning(err), nil
+	}
+
+	backendList := &capabilitiesv1beta1.BackendList{}
+	err = r.Client().List(r.Context(), backendList, client.InNamespace(backend.Namespace))
+	if err != nil {
+		return nil, err
+	}
+	for idx := range backendList.Items {
+		other := backendList.Items[idx].DeepCopy()
+		other.SetDefaults(logger)
+		if other.Name == backend.Name || other.Spec.SystemName != backend.Spec.SystemName {
+			continue
+		}
+		if sameProviderAccount(r.Client(), other.Namespace, other.Spec.ProviderAccountRef, providerAccount.AdminURLStr, logger) {
+			return nil, admissionError(duplicateSystemNameError(field.NewPath("spec").Child("systemName"), backend.Spec.SystemName, capabilitiesv1beta1.BackendKind, other.Name))
+		}
+	}
+
+	return nil, nil
+}
@@ -29,6 +29,7 @@ import (
 	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
 	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
 	"github.com/3scale/3scale-operator/pkg/helper"
+	threescalemetrics "github.com/3scale/3scale-operator/pkg/metrics"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 	"github.com/3scale/3scale-operator/version"
 	"github.com/go-logr/logr"
@@ -132,5 +133,5 @@ func (r *CustomPolicyDefinitionReconciler) reconcileSpec(customPolicyDefinitionC
 func (r *CustomPolicyDefinitionReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&capabilitiesv1beta1.CustomPolicyDefinition{}).
-		Complete(r)
+		Complete(threescalemetrics.NewInstrumentedReconciler("CustomPolicyDefinition", &capabilitiesv1beta1.CustomPolicyDefinition{}, mgr.GetClient(), r))
 }
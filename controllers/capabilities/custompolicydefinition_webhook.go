@@ -0,0 +1,59 @@
+package controllers
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+)
+
+// +kubebuilder:webhook:path=/validate-capabilities-3scale-net-v1beta1-custompolicydefinition,mutating=false,failurePolicy=fail,sideEffects=None,groups=capabilities.3scale.net,resources=custompolicydefinitions,verbs=create;update,versions=v1beta1,name=vcustompolicydefinition.capabilities.3scale.net,admissionReviewVersions=v1
+
+func (r *CustomPolicyDefinitionReconciler) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&capabilitiesv1beta1.CustomPolicyDefinition{}).
+		WithValidator(&controllerhelper.SpecValidator{
+			GroupKind: capabilitiesv1beta1.GroupVersion.WithKind(capabilitiesv1beta1.CustomPolicyDefinitionKind).GroupKind(),
+			Spec:      func(obj runtime.Object) interface{} { return obj.(*capabilitiesv1beta1.CustomPolicyDefinition).Spec },
+			Validate: func(obj runtime.Object) (admission.Warnings, error) {
+				return r.validateAdmission(obj.(*capabilitiesv1beta1.CustomPolicyDefinition))
+			},
+		}).
+		Complete()
+}
+
+// validateAdmission checks no other CustomPolicyDefinition of the same provider account defines the same policy name and version
+func (r *CustomPolicyDefinitionReconciler) validateAdmission(resource *capabilitiesv1beta1.CustomPolicyDefinition) (admission.Warnings, error) {
+	logger := r.Logger().WithValues("custompolicydefinition", client.ObjectKeyFromObject(resource))
+
+	providerAccount, err := controllerhelper.LookupProviderAccount(r.Client(), resource.Namespace, resource.Spec.ProviderAccountRef, logger)
+	if err != nil {
+		return providerAccountWarning(err), nil
+	}
+
+	policyList := &capabilitiesv1beta1.CustomPolicyDefinitionList{}
+	err = r.Client().List(r.Context(), policyList, client.InNamespace(resource.Namespace))
+	if err != nil {
+		return nil, err
+	}
+	for idx := range policyList.Items {
+		other := &policyList.Items[idx]
+		if other.Name == resource.Name || other.Spec.Name != resource.Spec.Name || other.Spec.Version != resource.Spec.Version {
+			continue
+		}
+		if sameProviderAccount(r.Client(), other.Namespace, other.Spec.ProviderAccountRef, providerAccount.AdminURLStr, logger) {
+			return nil, admissionError(field.ErrorList{
+				field.Invalid(field.NewPath("spec").Child("version"), resource.Spec.Version,
+					fmt.Sprintf("policy %s version already defined by CustomPolicyDefinition %s of the same provider account", resource.Spec.Name, other.Name)),
+			})
+		}
+	}
+
+	return nil, nil
+}
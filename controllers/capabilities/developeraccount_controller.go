@@ -30,6 +30,7 @@ import (
 	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
 	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
 	"github.com/3scale/3scale-operator/pkg/helper"
+	threescalemetrics "github.com/3scale/3scale-operator/pkg/metrics"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 	"github.com/3scale/3scale-operator/version"
 
@@ -253,7 +254,7 @@ func (r *DeveloperAccountReconciler) validateSpec(resource *capabilitiesv1beta1.
 func (r *DeveloperAccountReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&capabilitiesv1beta1.DeveloperAccount{}).
-		Complete(r)
+		Complete(threescalemetrics.NewInstrumentedReconciler("DeveloperAccount", &capabilitiesv1beta1.DeveloperAccount{}, mgr.GetClient(), r))
 }
 
 func (r *DeveloperAccountReconciler) removeDeveloperAccountFrom3scale(developerAccountCR *capabilitiesv1beta1.DeveloperAccount) error {
@@ -0,0 +1,25 @@
+package controllers
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+)
+
+// +kubebuilder:webhook:path=/validate-capabilities-3scale-net-v1beta1-developeraccount,mutating=false,failurePolicy=fail,sideEffects=None,groups=capabilities.3scale.net,resources=developeraccounts,verbs=create;update,versions=v1beta1,name=vdeveloperaccount.capabilities.3scale.net,admissionReviewVersions=v1
+
+func (r *DeveloperAccountReconciler) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&capabilitiesv1beta1.DeveloperAccount{}).
+		WithValidator(&controllerhelper.SpecValidator{
+			GroupKind: capabilitiesv1beta1.GroupVersion.WithKind(capabilitiesv1beta1.DeveloperAccountKind).GroupKind(),
+			Spec:      func(obj runtime.Object) interface{} { return obj.(*capabilitiesv1beta1.DeveloperAccount).Spec },
+			Validate: func(obj runtime.Object) (admission.Warnings, error) {
+				return nil, r.validateSpec(obj.(*capabilitiesv1beta1.DeveloperAccount))
+			},
+		}).
+		Complete()
+}
@@ -31,6 +31,7 @@ import (
 	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
 	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
 	"github.com/3scale/3scale-operator/pkg/helper"
+	threescalemetrics "github.com/3scale/3scale-operator/pkg/metrics"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 	"github.com/3scale/3scale-operator/version"
 
@@ -363,5 +364,5 @@ func (r *DeveloperUserReconciler) retrieveDevelopAccountCR(developerUser *capabi
 func (r *DeveloperUserReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&capabilitiesv1beta1.DeveloperUser{}).
-		Complete(r)
+		Complete(threescalemetrics.NewInstrumentedReconciler("DeveloperUser", &capabilitiesv1beta1.DeveloperUser{}, mgr.GetClient(), r))
 }
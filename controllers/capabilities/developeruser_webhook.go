@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+)
+
+// +kubebuilder:webhook:path=/validate-capabilities-3scale-net-v1beta1-developeruser,mutating=false,failurePolicy=fail,sideEffects=None,groups=capabilities.3scale.net,resources=developerusers,verbs=create;update,versions=v1beta1,name=vdeveloperuser.capabilities.3scale.net,admissionReviewVersions=v1
+
+func (r *DeveloperUserReconciler) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&capabilitiesv1beta1.DeveloperUser{}).
+		WithValidator(&controllerhelper.SpecValidator{
+			GroupKind: capabilitiesv1beta1.GroupVersion.WithKind(capabilitiesv1beta1.DeveloperUserKind).GroupKind(),
+			Spec:      func(obj runtime.Object) interface{} { return obj.(*capabilitiesv1beta1.DeveloperUser).Spec },
+			Validate: func(obj runtime.Object) (admission.Warnings, error) {
+				return r.validateAdmission(obj.(*capabilitiesv1beta1.DeveloperUser))
+			},
+		}).
+		Complete()
+}
+
+// validateAdmission validates the spec, then checks the parent account exists in the same provider account
+// and no other user of the parent account has the same username
+func (r *DeveloperUserReconciler) validateAdmission(userCR *capabilitiesv1beta1.DeveloperUser) (admission.Warnings, error) {
+	logger := r.Logger().WithValues("developeruser", client.ObjectKeyFromObject(userCR))
+
+	err := r.validateSpec(userCR)
+	if err != nil {
+		return nil, err
+	}
+
+	specFldPath := field.NewPath("spec")
+	parentAccountFldPath := specFldPath.Child("developerAccountRef")
+
+	devAccountCR := &capabilitiesv1beta1.DeveloperAccount{}
+	err = r.Client().Get(r.Context(), types.NamespacedName{Name: userCR.Spec.DeveloperAccountRef.Name, Namespace: userCR.Namespace}, devAccountCR)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		return nil, admissionError(field.ErrorList{
+			field.Invalid(parentAccountFldPath, userCR.Spec.DeveloperAccountRef, "parent account resource not found"),
+		})
+	}
+
+	providerAccount, err := controllerhelper.LookupProviderAccount(r.Client(), userCR.Namespace, userCR.Spec.ProviderAccountRef, logger)
+	if err != nil {
+		return providerAccountWarning(err), nil
+	}
+
+	if !sameProviderAccount(r.Client(), userCR.Namespace, devAccountCR.Spec.ProviderAccountRef, providerAccount.AdminURLStr, logger) {
+		return nil, admissionError(field.ErrorList{
+			field.Invalid(parentAccountFldPath, userCR.Spec.DeveloperAccountRef, "parent account resource does not belong to the same provider account"),
+		})
+	}
+
+	userList := &capabilitiesv1beta1.DeveloperUserList{}
+	err = r.Client().List(r.Context(), userList, client.InNamespace(userCR.Namespace))
+	if err != nil {
+		return nil, err
+	}
+	for idx := range userList.Items {
+		other := &userList.Items[idx]
+		if other.Name == userCR.Name || other.Spec.DeveloperAccountRef.Name != userCR.Spec.DeveloperAccountRef.Name || other.Spec.Username != userCR.Spec.Username {
+			continue
+		}
+		return nil, admissionError(field.ErrorList{
+			field.Invalid(specFldPath.Child("username"), userCR.Spec.Username, fmt.Sprintf("username already used by DeveloperUser %s of the same parent account", other.Name)),
+		})
+	}
+
+	return nil, nil
+}
@@ -37,6 +37,7 @@ import (
 	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
 	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
 	"github.com/3scale/3scale-operator/pkg/helper"
+	threescalemetrics "github.com/3scale/3scale-operator/pkg/metrics"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 	"github.com/3scale/3scale-operator/version"
 	"github.com/getkin/kin-openapi/openapi3"
@@ -154,7 +155,7 @@ func (r *OpenAPIReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&capabilitiesv1beta1.Product{}).
 		Owns(&capabilitiesv1beta1.Backend{}).
 		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(secretToOpenAPIEventMapper.Map), builder.WatchesOption(builder.WithPredicates(oasSecretLabelSelectorPredicate))).
-		Complete(r)
+		Complete(threescalemetrics.NewInstrumentedReconciler("OpenAPI", &capabilitiesv1beta1.OpenAPI{}, mgr.GetClient(), r))
 }
 
 func (r *OpenAPIReconciler) reconcileSpec(openapiCR *capabilitiesv1beta1.OpenAPI) (*OpenAPIStatusReconciler, ctrl.Result, error) {
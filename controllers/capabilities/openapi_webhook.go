@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+)
+
+// +kubebuilder:webhook:path=/validate-capabilities-3scale-net-v1beta1-openapi,mutating=false,failurePolicy=fail,sideEffects=None,groups=capabilities.3scale.net,resources=openapis,verbs=create;update,versions=v1beta1,name=vopenapi.capabilities.3scale.net,admissionReviewVersions=v1
+
+func (r *OpenAPIReconciler) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&capabilitiesv1beta1.OpenAPI{}).
+		WithValidator(&controllerhelper.SpecValidator{
+			GroupKind: capabilitiesv1beta1.GroupVersion.WithKind(capabilitiesv1beta1.OpenAPIKind).GroupKind(),
+			Spec:      func(obj runtime.Object) interface{} { return obj.(*capabilitiesv1beta1.OpenAPI).Spec },
+			Validate: func(obj runtime.Object) (admission.Warnings, error) {
+				return r.validateAdmission(obj.(*capabilitiesv1beta1.OpenAPI))
+			},
+		}).
+		Complete()
+}
+
+// validateAdmission validates the defaulted spec and warns when the OpenAPI document secret does not exist
+func (r *OpenAPIReconciler) validateAdmission(resource *capabilitiesv1beta1.OpenAPI) (admission.Warnings, error) {
+	openapi := resource.DeepCopy()
+	openapi.SetDefaults(r.Logger())
+
+	err := r.validateSpec(openapi)
+	if err != nil {
+		return nil, err
+	}
+
+	secretRef := openapi.Spec.OpenAPIRef.SecretRef
+	if secretRef == nil {
+		return nil, nil
+	}
+
+	secretKey := types.NamespacedName{Name: secretRef.Name, Namespace: secretRef.Namespace}
+	err = r.Client().Get(r.Context(), secretKey, &corev1.Secret{})
+	return secretWarning(field.NewPath("spec").Child("openapiRef").Child("secretRef"), secretRef.Name, err), nil
+}
@@ -27,13 +27,17 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
 	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
 	"github.com/3scale/3scale-operator/pkg/helper"
+	threescalemetrics "github.com/3scale/3scale-operator/pkg/metrics"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 	"github.com/3scale/3scale-operator/version"
 )
@@ -181,6 +185,12 @@ func (r *ProductReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{}, reconcileErr
 	}
 
+	err = r.reconcileProductDashboards(product)
+	if err != nil {
+		reqLogger.Error(err, "Failed to reconcile product dashboards")
+		return ctrl.Result{}, err
+	}
+
 	reqLogger.Info("END", "error", reconcileErr)
 	return ctrl.Result{}, reconcileErr
 }
@@ -249,6 +259,22 @@ func (r *ProductReconciler) checkExternalRefs(resource *capabilitiesv1beta1.Prod
 		return fmt.Errorf("checking backend usage references: %w", err)
 	}
 
+	errors = append(errors, r.checkBackendRefs(resource, backendList)...)
+
+	if len(errors) == 0 {
+		return nil
+	}
+
+	return &helper.SpecFieldError{
+		ErrorType:      helper.OrphanError,
+		FieldErrorList: errors,
+	}
+}
+
+// checkBackendRefs checks the backend usages, and the backend metrics referenced by the application plans, exist in the backend list
+func (r *ProductReconciler) checkBackendRefs(resource *capabilitiesv1beta1.Product, backendList []capabilitiesv1beta1.Backend) field.ErrorList {
+	errors := field.ErrorList{}
+
 	backendUsageErrors := r.checkBackendUsages(resource, backendList)
 	errors = append(errors, backendUsageErrors...)
 
@@ -260,14 +286,7 @@ func (r *ProductReconciler) checkExternalRefs(resource *capabilitiesv1beta1.Prod
 	pricingRulesBackendMetricRefErrors := checkAppPricingRulesExternalRefs(resource, backendUsageList)
 	errors = append(errors, pricingRulesBackendMetricRefErrors...)
 
-	if len(errors) == 0 {
-		return nil
-	}
-
-	return &helper.SpecFieldError{
-		ErrorType:      helper.OrphanError,
-		FieldErrorList: errors,
-	}
+	return errors
 }
 
 func (r *ProductReconciler) checkBackendUsages(resource *capabilitiesv1beta1.Product, backendList []capabilitiesv1beta1.Backend) field.ErrorList {
@@ -416,7 +435,14 @@ func (r *ProductReconciler) removeProductFrom3scale(product *capabilitiesv1beta1
 }
 
 func (r *ProductReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	apimanagerToProductEventMapper := &APIManagerToProductEventMapper{
+		Context:   r.Context(),
+		K8sClient: r.Client(),
+		Logger:    r.Logger().WithName("apimanagerToProductEventMapper"),
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&capabilitiesv1beta1.Product{}).
-		Complete(r)
+		Watches(&appsv1alpha1.APIManager{}, handler.EnqueueRequestsFromMapFunc(apimanagerToProductEventMapper.Map), builder.WatchesOption(builder.WithPredicates(apimanagerDashboardsChangedPredicate))).
+		Complete(threescalemetrics.NewInstrumentedReconciler("Product", &capabilitiesv1beta1.Product{}, mgr.GetClient(), r))
 }
@@ -0,0 +1,310 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	grafanav1alpha1 "github.com/grafana-operator/grafana-operator/v4/api/integreatly/v1alpha1"
+	grafanav1beta1 "github.com/grafana-operator/grafana-operator/v5/api/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/operator"
+	"github.com/3scale/3scale-operator/pkg/common"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+)
+
+const (
+	productDashboardLabelKey = "capabilities.3scale.net/product"
+)
+
+func productDashboardName(product *capabilitiesv1beta1.Product) string {
+	return fmt.Sprintf("product-%s", product.Name)
+}
+
+// reconcileProductDashboards reconciles the traffic dashboards of the product when
+// monitoring is enabled in an APIManager of the product namespace.
+// Dashboards are owned by the product and garbage collected along with it
+func (r *ProductReconciler) reconcileProductDashboards(product *capabilitiesv1beta1.Product) error {
+	apimanager, err := r.monitoringAPIManager(product.Namespace)
+	if err != nil {
+		return err
+	}
+
+	// Service metrics are labeled with the product ID, unknown until the product is synchronized
+	enabled := apimanager != nil && product.Status.ID != nil &&
+		!apimanager.IsGrafanaDashboardDisabled(productDashboardName(product))
+
+	v5Available, err := r.HasGrafanaV5Dashboards()
+	if err != nil {
+		return err
+	}
+	if v5Available {
+		dashboard := productGrafanaV5Dashboard(product)
+		if apimanager != nil {
+			operator.GrafanaDashboardPlacement(apimanager).ApplyV5(dashboard)
+		}
+		err = r.reconcileProductDashboard(product, &grafanav1beta1.GrafanaDashboard{}, dashboard, enabled)
+		if err != nil {
+			return err
+		}
+	}
+
+	v4Available, err := r.HasGrafanaV4Dashboards()
+	if err != nil {
+		return err
+	}
+	if v4Available {
+		dashboard := productGrafanaV4Dashboard(product)
+		if apimanager != nil {
+			operator.GrafanaDashboardPlacement(apimanager).ApplyV4(dashboard)
+		}
+		err = r.reconcileProductDashboard(product, &grafanav1alpha1.GrafanaDashboard{}, dashboard, enabled)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *ProductReconciler) reconcileProductDashboard(product *capabilitiesv1beta1.Product, obj, dashboard common.KubernetesObject, enabled bool) error {
+	if !enabled {
+		common.TagObjectToDelete(dashboard)
+	} else {
+		err := r.SetControllerOwnerReference(product, dashboard)
+		if err != nil {
+			return err
+		}
+	}
+
+	return r.ReconcileResource(obj, dashboard, reconcilers.GrafanaDashboardLabelsMutator(reconcilers.GenericGrafanaDashboardsMutator))
+}
+
+// monitoringAPIManager returns the APIManager of the namespace with monitoring enabled, if any.
+// Its grafana options place the product dashboards
+func (r *ProductReconciler) monitoringAPIManager(namespace string) (*appsv1alpha1.APIManager, error) {
+	apimanagerList := &appsv1alpha1.APIManagerList{}
+	err := r.Client().List(r.Context(), apimanagerList, client.InNamespace(namespace))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list APIManagers: %w", err)
+	}
+
+	for idx := range apimanagerList.Items {
+		if apimanagerList.Items[idx].IsMonitoringEnabled() {
+			return &apimanagerList.Items[idx], nil
+		}
+	}
+
+	return nil, nil
+}
+
+func productGrafanaV5Dashboard(product *capabilitiesv1beta1.Product) *grafanav1beta1.GrafanaDashboard {
+	return &grafanav1beta1.GrafanaDashboard{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      productDashboardName(product),
+			Namespace: product.Namespace,
+			Labels:    productDashboardLabels(product),
+		},
+		Spec: grafanav1beta1.GrafanaDashboardSpec{
+			InstanceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"apim-management": "grafana",
+				},
+			},
+			Json: productDashboardJSON(product),
+		},
+	}
+}
+
+func productGrafanaV4Dashboard(product *capabilitiesv1beta1.Product) *grafanav1alpha1.GrafanaDashboard {
+	return &grafanav1alpha1.GrafanaDashboard{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      productDashboardName(product),
+			Namespace: product.Namespace,
+			Labels:    productDashboardLabels(product),
+		},
+		Spec: grafanav1alpha1.GrafanaDashboardSpec{
+			Json: productDashboardJSON(product),
+		},
+	}
+}
+
+func productDashboardLabels(product *capabilitiesv1beta1.Product) map[string]string {
+	return map[string]string{
+		"app":                    "3scale-api-management",
+		"monitoring-key":         common.MonitoringKey,
+		productDashboardLabelKey: product.Name,
+	}
+}
+
+// productDashboardJSON builds the dashboard from the APIcast extended metrics of the product.
+// APIcast does not expose per mapping rule or per metric counters, the usage breakdown
+// is linked to the 3scale analytics of the product
+func productDashboardJSON(product *capabilitiesv1beta1.Product) string {
+	var serviceID int64
+	if product.Status.ID != nil {
+		serviceID = *product.Status.ID
+	}
+	selector := fmt.Sprintf(`namespace="%s", pod=~"apicast-$env.*", service_id="%d"`, product.Namespace, serviceID)
+
+	timeseries := []struct {
+		title, unit string
+		targets     []map[string]string
+	}{
+		{"Request rate", "reqps", []map[string]string{
+			{"expr": fmt.Sprintf(`sum(rate(total_response_time_seconds_count{%s}[1m]))`, selector), "legendFormat": "requests"},
+		}},
+		{"Error rate", "percentunit", []map[string]string{
+			{"expr": fmt.Sprintf(`sum(rate(upstream_status{%[1]s, status=~"5.*"}[1m])) / sum(rate(upstream_status{%[1]s}[1m]))`, selector), "legendFormat": "5xx"},
+		}},
+		{"Status codes", "reqps", []map[string]string{
+			{"expr": fmt.Sprintf(`sum(rate(upstream_status{%s}[1m])) by (status)`, selector), "legendFormat": "{{status}}"},
+		}},
+		{"Total latency", "s", latencyTargets("total_response_time_seconds_bucket", selector)},
+		{"Upstream latency", "s", latencyTargets("upstream_response_time_seconds_bucket", selector)},
+	}
+
+	panels := []map[string]interface{}{}
+	id := 1
+	for idx, panel := range timeseries {
+		panels = append(panels, map[string]interface{}{
+			"id":         id,
+			"type":       "timeseries",
+			"title":      panel.title,
+			"datasource": "${datasource}",
+			"gridPos":    map[string]int{"h": 8, "w": 8, "x": (idx % 3) * 8, "y": (idx / 3) * 8},
+			"fieldConfig": map[string]interface{}{
+				"defaults": map[string]string{"unit": panel.unit},
+			},
+			"targets": panel.targets,
+		})
+		id++
+	}
+
+	y := ((len(timeseries) + 2) / 3) * 8
+	panels = append(panels,
+		map[string]interface{}{
+			"id":      id,
+			"type":    "text",
+			"title":   "Mapping rules",
+			"gridPos": map[string]int{"h": 10, "w": 12, "x": 0, "y": y},
+			"options": map[string]string{"mode": "markdown", "content": productMappingRulesMarkdown(product)},
+		},
+		map[string]interface{}{
+			"id":      id + 1,
+			"type":    "text",
+			"title":   "Metrics and methods",
+			"gridPos": map[string]int{"h": 10, "w": 12, "x": 12, "y": y},
+			"options": map[string]string{"mode": "markdown", "content": productMetricsMarkdown(product)},
+		},
+	)
+
+	links := []map[string]interface{}{}
+	if product.Status.ProviderAccountHost != "" && product.Status.ID != nil {
+		links = append(links, map[string]interface{}{
+			"title":       "3scale analytics",
+			"type":        "link",
+			"url":         fmt.Sprintf("%s/apiconfig/services/%d/stats/usage", strings.TrimSuffix(product.Status.ProviderAccountHost, "/"), serviceID),
+			"targetBlank": true,
+		})
+	}
+
+	dashboard := map[string]interface{}{
+		"title":         fmt.Sprintf("%s / Product / %s", product.Namespace, product.Spec.Name),
+		"editable":      true,
+		"schemaVersion": 36,
+		"tags":          []string{"3scale", "product"},
+		"time":          map[string]string{"from": "now-6h", "to": "now"},
+		"refresh":       "1m",
+		"links":         links,
+		"templating": map[string]interface{}{
+			"list": []map[string]interface{}{
+				{"name": "datasource", "type": "datasource", "query": "prometheus", "label": "Data source"},
+				{
+					"name":    "env",
+					"type":    "custom",
+					"label":   "Environment",
+					"query":   "production,staging",
+					"current": map[string]string{"text": "production", "value": "production"},
+					"options": []map[string]interface{}{
+						{"text": "production", "value": "production", "selected": true},
+						{"text": "staging", "value": "staging", "selected": false},
+					},
+				},
+			},
+		},
+		"panels": panels,
+	}
+
+	dashboardJSON, err := json.MarshalIndent(dashboard, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	return string(dashboardJSON)
+}
+
+func latencyTargets(metric, selector string) []map[string]string {
+	targets := []map[string]string{}
+	for _, percentile := range []string{"50", "90", "99"} {
+		targets = append(targets, map[string]string{
+			"expr":         fmt.Sprintf(`histogram_quantile(0.%s, sum(rate(%s{%s}[1m])) by (le))`, percentile, metric, selector),
+			"legendFormat": fmt.Sprintf("p%s", percentile),
+		})
+	}
+	return targets
+}
+
+func productMappingRulesMarkdown(product *capabilitiesv1beta1.Product) string {
+	if len(product.Spec.MappingRules) == 0 {
+		return "No mapping rules defined"
+	}
+
+	var b strings.Builder
+	b.WriteString("| Method | Pattern | Metric | Increment | Last |\n|---|---|---|---|---|\n")
+	for _, rule := range product.Spec.MappingRules {
+		last := false
+		if rule.Last != nil {
+			last = *rule.Last
+		}
+		fmt.Fprintf(&b, "| %s | `%s` | %s | %d | %t |\n", rule.HTTPMethod, markdownEscape(rule.Pattern), rule.MetricMethodRef, rule.Increment, last)
+	}
+	return b.String()
+}
+
+func productMetricsMarkdown(product *capabilitiesv1beta1.Product) string {
+	// mapping rules incrementing each metric or method
+	mappingRules := map[string]int{}
+	for _, rule := range product.Spec.MappingRules {
+		mappingRules[rule.MetricMethodRef]++
+	}
+
+	var b strings.Builder
+	b.WriteString("| System name | Name | Kind | Unit | Mapping rules |\n|---|---|---|---|---|\n")
+	for _, systemName := range sortedKeys(product.Spec.Metrics) {
+		metric := product.Spec.Metrics[systemName]
+		fmt.Fprintf(&b, "| %s | %s | metric | %s | %d |\n", systemName, markdownEscape(metric.Name), markdownEscape(metric.Unit), mappingRules[systemName])
+	}
+	for _, systemName := range sortedKeys(product.Spec.Methods) {
+		method := product.Spec.Methods[systemName]
+		fmt.Fprintf(&b, "| %s | %s | method | hits | %d |\n", systemName, markdownEscape(method.Name), mappingRules[systemName])
+	}
+	return b.String()
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func markdownEscape(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
@@ -0,0 +1,133 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	grafanav1beta1 "github.com/grafana-operator/grafana-operator/v5/api/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+)
+
+func getProductDashboardProduct() *capabilitiesv1beta1.Product {
+	var productID int64 = 3
+	return &capabilitiesv1beta1.Product{
+		ObjectMeta: metav1.ObjectMeta{Name: "petstore", Namespace: "test", UID: "1234"},
+		Spec: capabilitiesv1beta1.ProductSpec{
+			Name:       "Pet Store",
+			SystemName: "petstore",
+			MappingRules: []capabilitiesv1beta1.MappingRuleSpec{
+				{HTTPMethod: "GET", Pattern: "/pets", MetricMethodRef: "list_pets", Increment: 1},
+				{HTTPMethod: "POST", Pattern: "/pets", MetricMethodRef: "hits", Increment: 2},
+			},
+			Metrics: map[string]capabilitiesv1beta1.MetricSpec{
+				"hits": {Name: "Hits", Unit: "hit"},
+			},
+			Methods: map[string]capabilitiesv1beta1.MethodSpec{
+				"list_pets": {Name: "List pets"},
+			},
+		},
+		Status: capabilitiesv1beta1.ProductStatus{
+			ID:                  &productID,
+			ProviderAccountHost: "https://3scale-admin.example.com",
+		},
+	}
+}
+
+func TestProductDashboardJSON(t *testing.T) {
+	dashboardJSON := productDashboardJSON(getProductDashboardProduct())
+
+	for _, expected := range []string{
+		`service_id=\"3\"`,
+		"total_response_time_seconds_count",
+		"histogram_quantile(0.99",
+		"| GET | `/pets` | list_pets | 1 | false |",
+		"| hits | Hits | metric | hit | 1 |",
+		"| list_pets | List pets | method | hits | 1 |",
+		"https://3scale-admin.example.com/apiconfig/services/3/stats/usage",
+	} {
+		if !strings.Contains(dashboardJSON, expected) {
+			t.Errorf("dashboard does not contain %q", expected)
+		}
+	}
+}
+
+func TestProductReconcilerReconcileProductDashboards(t *testing.T) {
+	cases := []struct {
+		testName          string
+		monitoringEnabled bool
+		exists            bool
+	}{
+		{"monitoringDisabled", false, false},
+		{"monitoringEnabled", true, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			s := scheme.Scheme
+			if err := capabilitiesv1beta1.AddToScheme(s); err != nil {
+				subT.Fatal(err)
+			}
+			if err := appsv1alpha1.AddToScheme(s); err != nil {
+				subT.Fatal(err)
+			}
+			if err := grafanav1beta1.AddToScheme(s); err != nil {
+				subT.Fatal(err)
+			}
+
+			product := getProductDashboardProduct()
+			apimanager := getApiManger()
+			apimanager.Spec.Monitoring = &appsv1alpha1.MonitoringSpec{Enabled: tc.monitoringEnabled}
+			objs := []runtime.Object{product, apimanager}
+			if !tc.exists {
+				// dashboard left from a previous reconciliation must be removed
+				dashboard := productGrafanaV5Dashboard(product)
+				objs = append(objs, dashboard)
+			}
+
+			cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objs...).Build()
+			clientset := fakeclientset.NewSimpleClientset()
+			clientset.Resources = []*metav1.APIResourceList{{
+				GroupVersion: grafanav1beta1.GroupVersion.String(),
+				APIResources: []metav1.APIResource{
+					{Name: "grafanadashboards", Namespaced: true, Kind: "GrafanaDashboard"},
+				},
+			}}
+			log := logf.Log.WithName("product dashboard test")
+			baseReconciler := reconcilers.NewBaseReconciler(context.TODO(), cl, s, cl, log, clientset.Discovery(), record.NewFakeRecorder(100))
+			r := &ProductReconciler{BaseReconciler: baseReconciler}
+
+			err := r.reconcileProductDashboards(product)
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			dashboard := &grafanav1beta1.GrafanaDashboard{}
+			err = cl.Get(context.TODO(), types.NamespacedName{Name: "product-petstore", Namespace: "test"}, dashboard)
+			if !tc.exists {
+				if !errors.IsNotFound(err) {
+					subT.Fatalf("dashboard should not exist: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if len(dashboard.OwnerReferences) != 1 || dashboard.OwnerReferences[0].Name != product.Name {
+				subT.Fatalf("dashboard should be owned by the product: %v", dashboard.OwnerReferences)
+			}
+		})
+	}
+}
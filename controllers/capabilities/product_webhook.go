@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+)
+
+// +kubebuilder:webhook:path=/validate-capabilities-3scale-net-v1beta1-product,mutating=false,failurePolicy=fail,sideEffects=None,groups=capabilities.3scale.net,resources=products,verbs=create;update,versions=v1beta1,name=vproduct.capabilities.3scale.net,admissionReviewVersions=v1
+
+func (r *ProductReconciler) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&capabilitiesv1beta1.Product{}).
+		WithValidator(&controllerhelper.SpecValidator{
+			GroupKind: capabilitiesv1beta1.GroupVersion.WithKind(capabilitiesv1beta1.ProductKind).GroupKind(),
+			Spec:      func(obj runtime.Object) interface{} { return obj.(*capabilitiesv1beta1.Product).Spec },
+			Validate: func(obj runtime.Object) (admission.Warnings, error) {
+				return r.validateAdmission(obj.(*capabilitiesv1beta1.Product))
+			},
+		}).
+		Complete()
+}
+
+// validateAdmission validates the defaulted spec, then checks the backend usages and the system name
+// against the Backend and Product resources of the same provider account
+func (r *ProductReconciler) validateAdmission(resource *capabilitiesv1beta1.Product) (admission.Warnings, error) {
+	logger := r.Logger().WithValues("product", client.ObjectKeyFromObject(resource))
+
+	product := resource.DeepCopy()
+	product.SetDefaults(logger)
+
+	err := r.validateSpec(product)
+	if err != nil {
+		return nil, err
+	}
+
+	providerAccount, err := controllerhelper.LookupProviderAccount(r.Client(), product.Namespace, product.Spec.ProviderAccountRef, logger)
+	if err != nil {
+		return providerAccountWarning(err), nil
+	}
+
+	errors := field.ErrorList{}
+
+	productList := &capabilitiesv1beta1.ProductList{}
+	err = r.Client().List(r.Context(), productList, client.InNamespace(product.Namespace))
+	if err != nil {
+		return nil, err
+	}
+	for idx := range productList.Items {
+		other := productList.Items[idx].DeepCopy()
+		other.SetDefaults(logger)
+		if other.Name == product.Name || other.Spec.SystemName != product.Spec.SystemName {
+			continue
+		}
+		if sameProviderAccount(r.Client(), other.Namespace, other.Spec.ProviderAccountRef, providerAccount.AdminURLStr, logger) {
+			errors = append(errors, duplicateSystemNameError(field.NewPath("spec").Child("systemName"), product.Spec.SystemName, capabilitiesv1beta1.ProductKind, other.Name)...)
+			break
+		}
+	}
+
+	// Backends not synchronized yet are valid references, unlike when reconciling
+	backendList := &capabilitiesv1beta1.BackendList{}
+	err = r.Client().List(r.Context(), backendList, client.InNamespace(product.Namespace))
+	if err != nil {
+		return nil, err
+	}
+	backends := []capabilitiesv1beta1.Backend{}
+	for idx := range backendList.Items {
+		backend := backendList.Items[idx].DeepCopy()
+		backend.SetDefaults(logger)
+		if sameProviderAccount(r.Client(), backend.Namespace, backend.Spec.ProviderAccountRef, providerAccount.AdminURLStr, logger) {
+			backends = append(backends, *backend)
+		}
+	}
+	errors = append(errors, r.checkBackendRefs(product, backends)...)
+
+	return nil, admissionError(errors)
+}
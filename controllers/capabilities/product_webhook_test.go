@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+)
+
+func TestProductValidateAdmission(t *testing.T) {
+	s := scheme.Scheme
+	if err := capabilitiesv1beta1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	providerAccountRef := &corev1.LocalObjectReference{Name: "test"}
+	newProduct := func(name, systemName string, backendUsages map[string]capabilitiesv1beta1.BackendUsageSpec) *capabilitiesv1beta1.Product {
+		return &capabilitiesv1beta1.Product{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"},
+			Spec: capabilitiesv1beta1.ProductSpec{
+				Name:               name,
+				SystemName:         systemName,
+				BackendUsages:      backendUsages,
+				ProviderAccountRef: providerAccountRef,
+			},
+		}
+	}
+	// backends not synchronized yet are valid references
+	backend := &capabilitiesv1beta1.Backend{
+		ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "test"},
+		Spec: capabilitiesv1beta1.BackendSpec{
+			Name:               "Backend",
+			SystemName:         "backend",
+			PrivateBaseURL:     "https://api.example.com",
+			ProviderAccountRef: providerAccountRef,
+		},
+	}
+	existing := newProduct("petstore", "petstore", nil)
+
+	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(getProviderAccountRefSecret(), backend, existing).Build()
+	r := &ProductReconciler{
+		BaseReconciler: reconcilers.NewBaseReconciler(context.TODO(), cl, s, cl, logf.Log.WithName("product webhook test"), fakeclientset.NewSimpleClientset().Discovery(), record.NewFakeRecorder(10)),
+	}
+
+	cases := []struct {
+		testName      string
+		product       *capabilitiesv1beta1.Product
+		expectedError string
+	}{
+		{"valid", newProduct("pets", "pets", map[string]capabilitiesv1beta1.BackendUsageSpec{"backend": {Path: "/"}}), ""},
+		{"existingUpdate", existing, ""},
+		{"duplicateSystemName", newProduct("other", "petstore", nil), "system name already used by Product petstore"},
+		{"unknownBackend", newProduct("pets", "pets", map[string]capabilitiesv1beta1.BackendUsageSpec{"unknown": {Path: "/"}}), "backend usage does not have valid backend reference"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			_, err := r.validateAdmission(tc.product)
+			if tc.expectedError == "" {
+				if err != nil {
+					subT.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !helper.IsOrphanSpecError(err) || !strings.Contains(err.Error(), tc.expectedError) {
+				subT.Errorf("expected error %q, got %v", tc.expectedError, err)
+			}
+		})
+	}
+
+	product := newProduct("pets", "pets", nil)
+	product.Spec.MappingRules = []capabilitiesv1beta1.MappingRuleSpec{
+		{HTTPMethod: "GET", Pattern: "/pets/{id", MetricMethodRef: "hits", Increment: 1},
+	}
+	_, err := r.validateAdmission(product)
+	if !helper.IsInvalidSpecError(err) {
+		t.Errorf("invalid mapping rule pattern should be rejected, got %v", err)
+	}
+
+	// provider account cannot be looked up, references are not checked
+	product = newProduct("pets", "pets", map[string]capabilitiesv1beta1.BackendUsageSpec{"unknown": {Path: "/"}})
+	product.Spec.ProviderAccountRef = &corev1.LocalObjectReference{Name: "missing"}
+	warnings, err := r.validateAdmission(product)
+	if err != nil || len(warnings) != 1 {
+		t.Errorf("expected a warning and no error, got %v %v", warnings, err)
+	}
+}
@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+)
+
+// +kubebuilder:webhook:path=/validate-capabilities-3scale-net-v1beta1-proxyconfigpromote,mutating=false,failurePolicy=fail,sideEffects=None,groups=capabilities.3scale.net,resources=proxyconfigpromotes,verbs=create;update,versions=v1beta1,name=vproxyconfigpromote.capabilities.3scale.net,admissionReviewVersions=v1
+
+func (r *ProxyConfigPromoteReconciler) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&capabilitiesv1beta1.ProxyConfigPromote{}).
+		WithValidator(&controllerhelper.SpecValidator{
+			GroupKind: capabilitiesv1beta1.GroupVersion.WithKind("ProxyConfigPromote").GroupKind(),
+			Spec:      func(obj runtime.Object) interface{} { return obj.(*capabilitiesv1beta1.ProxyConfigPromote).Spec },
+			Validate: func(obj runtime.Object) (admission.Warnings, error) {
+				return r.validateAdmission(obj.(*capabilitiesv1beta1.ProxyConfigPromote))
+			},
+		}).
+		Complete()
+}
+
+// validateAdmission checks the referenced Product exists and is not invalid
+func (r *ProxyConfigPromoteReconciler) validateAdmission(proxyConfigPromote *capabilitiesv1beta1.ProxyConfigPromote) (admission.Warnings, error) {
+	productFldPath := field.NewPath("spec").Child("productCRName")
+
+	product := &capabilitiesv1beta1.Product{}
+	err := r.Client().Get(r.Context(), types.NamespacedName{Name: proxyConfigPromote.Spec.ProductCRName, Namespace: proxyConfigPromote.Namespace}, product)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		return nil, admissionError(field.ErrorList{
+			field.Invalid(productFldPath, proxyConfigPromote.Spec.ProductCRName, "product resource not found"),
+		})
+	}
+
+	if product.Status.Conditions.IsTrueFor(capabilitiesv1beta1.ProductInvalidConditionType) {
+		return nil, admissionError(field.ErrorList{
+			field.Invalid(productFldPath, proxyConfigPromote.Spec.ProductCRName, "product CR is in an invalid state"),
+		})
+	}
+
+	return nil, nil
+}
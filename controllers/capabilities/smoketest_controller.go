@@ -0,0 +1,523 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/operator"
+	"github.com/3scale/3scale-operator/pkg/apispkg/common"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	threescalemetrics "github.com/3scale/3scale-operator/pkg/metrics"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+	"github.com/3scale/3scale-operator/version"
+)
+
+const (
+	// smokeTestRetryInterval is the delay before retrying a run that could not be started
+	smokeTestRetryInterval = time.Minute
+	// smokeTestUsageRetryInterval is the delay between reads of the usage reported by backend
+	smokeTestUsageRetryInterval = 15 * time.Second
+)
+
+// SmokeTestReconciler reconciles a SmokeTest object
+type SmokeTestReconciler struct {
+	*reconcilers.BaseReconciler
+}
+
+// smokeTestTarget is the product the smoke test requests are sent to
+type smokeTestTarget struct {
+	productID          int64
+	providerAccount    *controllerhelper.ProviderAccount
+	insecureSkipVerify bool
+	endpoints          map[capabilitiesv1beta1.SmokeTestEnvironment]string
+	credentials        smokeTestCredentials
+}
+
+// +kubebuilder:rbac:groups=capabilities.3scale.net,resources=smoketests,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=capabilities.3scale.net,resources=smoketests/status,verbs=get;update;patch
+
+func (r *SmokeTestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := r.Logger().WithValues("smoketest", req.NamespacedName)
+	reqLogger.Info("Reconcile SmokeTest", "Operator version", version.Version)
+
+	smokeTest := &capabilitiesv1beta1.SmokeTest{}
+	err := r.Client().Get(r.Context(), req.NamespacedName, smokeTest)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			reqLogger.Info("resource not found. Ignoring since object must have been deleted")
+			threescalemetrics.DeleteSmokeTestMetrics(req.Namespace, req.Name)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Ignore deleted resources, the Job is garbage collected
+	if smokeTest.GetDeletionTimestamp() != nil {
+		reqLogger.Info("SmokeTest marked to be deleted")
+		return ctrl.Result{}, nil
+	}
+
+	job := &batchv1.Job{}
+	err = r.Client().Get(r.Context(), types.NamespacedName{Name: smokeTestJobName(smokeTest), Namespace: smokeTest.Namespace}, job)
+	if err != nil && !errors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+
+	if errors.IsNotFound(err) {
+		return r.reconcileNextRun(smokeTest, reqLogger)
+	}
+
+	return r.reconcileRun(smokeTest, job, reqLogger)
+}
+
+// reconcileNextRun starts a run when the interval since the last run elapsed or the spec changed
+func (r *SmokeTestReconciler) reconcileNextRun(smokeTest *capabilitiesv1beta1.SmokeTest, reqLogger logr.Logger) (ctrl.Result, error) {
+	now := time.Now()
+	nextRun := smokeTestNextRunTime(smokeTest)
+	if now.Before(nextRun) {
+		reqLogger.V(1).Info("waiting for the next run", "nextRun", nextRun)
+		return ctrl.Result{RequeueAfter: nextRun.Sub(now)}, nil
+	}
+
+	target, err := r.smokeTestTarget(smokeTest)
+	if err != nil {
+		reqLogger.Info("smoke test could not be started", "reason", err.Error())
+		return r.updateStatus(smokeTest, smokeTestSetupFailedStatus(&smokeTest.Status, err), smokeTestRetryInterval)
+	}
+
+	options := &smokeTestJobOptions{
+		Image:              operator.ApicastImageURL(),
+		Generation:         smokeTest.Generation,
+		Endpoints:          target.endpoints,
+		Credentials:        target.credentials,
+		InsecureSkipVerify: target.insecureSkipVerify,
+	}
+
+	if smokeTest.IsUsageVerified() {
+		// hits reported before the run, the usage API granularity is one hour
+		since := now.UTC().Truncate(time.Hour)
+		hits, err := productHits(r.Context(), controllerhelper.PortaHTTPClient(target.insecureSkipVerify), target.providerAccount, target.productID, since, now)
+		if err != nil {
+			reqLogger.Info("smoke test could not be started", "reason", err.Error())
+			return r.updateStatus(smokeTest, smokeTestSetupFailedStatus(&smokeTest.Status, err), smokeTestRetryInterval)
+		}
+		options.BaselineHits = &hits
+		options.UsageSince = since.Format(time.RFC3339)
+	}
+
+	job := smokeTestJob(smokeTest, options)
+	err = r.SetControllerOwnerReference(smokeTest, job)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	err = r.Client().Create(r.Context(), job)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to create smoke test job: %w", err)
+	}
+	reqLogger.Info("smoke test job created", "job", job.Name)
+
+	newStatus := smokeTest.Status.DeepCopy()
+	newStatus.ProductID = &target.productID
+	newStatus.Conditions.SetCondition(common.Condition{
+		Type:    capabilitiesv1beta1.SmokeTestRunningConditionType,
+		Status:  corev1.ConditionTrue,
+		Message: fmt.Sprintf("Job %s is running", job.Name),
+	})
+
+	return r.updateStatus(smokeTest, newStatus, 0)
+}
+
+// reconcileRun records the results of a completed run and removes its Job
+func (r *SmokeTestReconciler) reconcileRun(smokeTest *capabilitiesv1beta1.SmokeTest, job *batchv1.Job, reqLogger logr.Logger) (ctrl.Result, error) {
+	if job.GetDeletionTimestamp() != nil {
+		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+	}
+
+	// Results of a previous spec cannot be matched with the requests, run again
+	if job.Annotations[smokeTestGenerationAnnotation] != strconv.FormatInt(smokeTest.Generation, 10) {
+		reqLogger.Info("spec changed during the run, discarding the results", "job", job.Name)
+		return ctrl.Result{Requeue: true}, r.deleteJob(job)
+	}
+
+	completionTime, jobFailure, finished := smokeTestJobFinished(job)
+	if !finished {
+		// The Job status changes are watched
+		return ctrl.Result{}, nil
+	}
+
+	message, err := r.smokeTestJobMessage(job)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	run := capabilitiesv1beta1.SmokeTestRunStatus{
+		SmokeTestRunSummary: capabilitiesv1beta1.SmokeTestRunSummary{
+			StartTime:      job.CreationTimestamp,
+			CompletionTime: completionTime,
+		},
+		Results: parseSmokeTestResults(smokeTest, message),
+	}
+
+	reason := smokeTestResultsFailureReason(run.Results)
+	if jobFailure != "" {
+		reason = fmt.Sprintf("smoke test job failed: %s", jobFailure)
+	}
+
+	if reason == "" && job.Annotations[smokeTestBaselineHitsAnnotation] != "" {
+		reportedHits, usageReason, err := r.verifyUsage(smokeTest, job, run.Results)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		// backend reports the usage asynchronously
+		if usageReason != "" && time.Since(completionTime.Time) < smokeTestUsageGracePeriod {
+			reqLogger.V(1).Info("waiting for backend to report the usage", "reportedHits", reportedHits)
+			return ctrl.Result{RequeueAfter: smokeTestUsageRetryInterval}, nil
+		}
+		run.ReportedHits = &reportedHits
+		reason = usageReason
+	}
+
+	run.Passed = reason == ""
+	run.Reason = reason
+
+	previouslyPassed := smokeTest.Status.LastRun != nil && smokeTest.Status.LastRun.Passed
+	result, err := r.updateStatus(smokeTest, smokeTestRunStatus(&smokeTest.Status, run, smokeTest.Generation, smokeTest.HistoryLimit()), 0)
+	if err != nil || result.Requeue {
+		return result, err
+	}
+
+	r.recordRunMetrics(smokeTest, &run)
+	if !run.Passed {
+		r.EventRecorder().Eventf(smokeTest, corev1.EventTypeWarning, "SmokeTestFailed", "%s", run.Reason)
+	} else if !previouslyPassed {
+		r.EventRecorder().Eventf(smokeTest, corev1.EventTypeNormal, "SmokeTestPassed", "All requests passed")
+	}
+	reqLogger.Info("smoke test run completed", "passed", run.Passed, "reason", run.Reason)
+
+	err = r.deleteJob(job)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: time.Until(smokeTestNextRunTime(smokeTest))}, nil
+}
+
+// verifyUsage compares the hits reported by backend since the run started with the successful requests
+func (r *SmokeTestReconciler) verifyUsage(smokeTest *capabilitiesv1beta1.SmokeTest, job *batchv1.Job, results []capabilitiesv1beta1.SmokeTestRequestResult) (int64, string, error) {
+	baselineHits, err := strconv.ParseInt(job.Annotations[smokeTestBaselineHitsAnnotation], 10, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid %s annotation: %w", smokeTestBaselineHitsAnnotation, err)
+	}
+	since, err := time.Parse(time.RFC3339, job.Annotations[smokeTestUsageSinceAnnotation])
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid %s annotation: %w", smokeTestUsageSinceAnnotation, err)
+	}
+
+	product, providerAccount, err := r.smokeTestProduct(smokeTest)
+	if err != nil {
+		return 0, err.Error(), nil
+	}
+
+	insecureSkipVerify := controllerhelper.GetInsecureSkipVerifyAnnotation(smokeTest.GetAnnotations())
+	hits, err := productHits(r.Context(), controllerhelper.PortaHTTPClient(insecureSkipVerify), providerAccount, *product.Status.ID, since, time.Now())
+	if err != nil {
+		return 0, err.Error(), nil
+	}
+
+	reportedHits := hits - baselineHits
+	expectedHits := smokeTestSuccessfulRequests(results)
+	if reportedHits < expectedHits {
+		return reportedHits, fmt.Sprintf("backend reported %d hits, expected at least %d", reportedHits, expectedHits), nil
+	}
+
+	return reportedHits, "", nil
+}
+
+// smokeTestProduct returns the synchronized product and its provider account
+func (r *SmokeTestReconciler) smokeTestProduct(smokeTest *capabilitiesv1beta1.SmokeTest) (*capabilitiesv1beta1.Product, *controllerhelper.ProviderAccount, error) {
+	product := &capabilitiesv1beta1.Product{}
+	err := r.Client().Get(r.Context(), types.NamespacedName{Name: smokeTest.Spec.ProductCRName, Namespace: smokeTest.Namespace}, product)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil, fmt.Errorf("product %s not found", smokeTest.Spec.ProductCRName)
+		}
+		return nil, nil, err
+	}
+
+	if product.Status.ID == nil || !product.Status.Conditions.IsTrueFor(capabilitiesv1beta1.ProductSyncedConditionType) {
+		return nil, nil, fmt.Errorf("product %s is not synchronized", smokeTest.Spec.ProductCRName)
+	}
+
+	providerAccount, err := controllerhelper.LookupProviderAccount(r.Client(), smokeTest.Namespace, product.Spec.ProviderAccountRef, r.Logger())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return product, providerAccount, nil
+}
+
+// smokeTestTarget reads the APIcast endpoints and the credentials location of the product
+func (r *SmokeTestReconciler) smokeTestTarget(smokeTest *capabilitiesv1beta1.SmokeTest) (*smokeTestTarget, error) {
+	product, providerAccount, err := r.smokeTestProduct(smokeTest)
+	if err != nil {
+		return nil, err
+	}
+
+	insecureSkipVerify := controllerhelper.GetInsecureSkipVerifyAnnotation(smokeTest.GetAnnotations())
+	threescaleAPIClient, err := controllerhelper.PortaClient(providerAccount, insecureSkipVerify)
+	if err != nil {
+		return nil, err
+	}
+
+	proxy, err := threescaleAPIClient.ReadProxy(strconv.FormatInt(*product.Status.ID, 10))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the product proxy: %w", err)
+	}
+
+	target := &smokeTestTarget{
+		productID:          *product.Status.ID,
+		providerAccount:    providerAccount,
+		insecureSkipVerify: insecureSkipVerify,
+		endpoints: map[capabilitiesv1beta1.SmokeTestEnvironment]string{
+			capabilitiesv1beta1.SmokeTestEnvironmentStaging:    proxy.SandboxEndpoint,
+			capabilitiesv1beta1.SmokeTestEnvironmentProduction: proxy.Endpoint,
+		},
+	}
+	for _, environment := range smokeTest.EnvironmentsOrDefault() {
+		if target.endpoints[environment] == "" {
+			return nil, fmt.Errorf("product %s has no %s endpoint", smokeTest.Spec.ProductCRName, environment)
+		}
+	}
+
+	authSecret := &corev1.Secret{}
+	err = r.Client().Get(r.Context(), types.NamespacedName{Name: smokeTest.Spec.AuthSecretRef.Name, Namespace: smokeTest.Namespace}, authSecret)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, fmt.Errorf("auth secret %s not found", smokeTest.Spec.AuthSecretRef.Name)
+		}
+		return nil, err
+	}
+
+	target.credentials, err = smokeTestCredentialsFromProxy(proxy.CredentialsLocation, proxy.AuthUserKey, proxy.AuthAppID, proxy.AuthAppKey, authSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	return target, nil
+}
+
+func smokeTestCredentialsFromProxy(location, userKeyParam, appIDParam, appKeyParam string, authSecret *corev1.Secret) (smokeTestCredentials, error) {
+	credentials := smokeTestCredentials{Location: location}
+	if credentials.Location == "" {
+		credentials.Location = smokeTestCredentialsQuery
+	}
+
+	switch {
+	case len(authSecret.Data[UserKey]) > 0:
+		credentials.UserKeyParam = userKeyParam
+		if credentials.UserKeyParam == "" {
+			credentials.UserKeyParam = "user_key"
+		}
+	case len(authSecret.Data[ApplicationID]) > 0 && len(authSecret.Data[ApplicationKey]) > 0:
+		credentials.AppIDParam = appIDParam
+		if credentials.AppIDParam == "" {
+			credentials.AppIDParam = "app_id"
+		}
+		credentials.AppKeyParam = appKeyParam
+		if credentials.AppKeyParam == "" {
+			credentials.AppKeyParam = "app_key"
+		}
+	default:
+		return credentials, fmt.Errorf("auth secret %s must contain %s, or %s and %s", authSecret.Name, UserKey, ApplicationID, ApplicationKey)
+	}
+
+	return credentials, nil
+}
+
+// smokeTestJobMessage reads the results the Job pod wrote to its termination log
+func (r *SmokeTestReconciler) smokeTestJobMessage(job *batchv1.Job) (string, error) {
+	podList := &corev1.PodList{}
+	err := r.Client().List(r.Context(), podList, client.InNamespace(job.Namespace), client.MatchingLabels{"job-name": job.Name})
+	if err != nil {
+		return "", fmt.Errorf("failed to list smoke test pods: %w", err)
+	}
+
+	for _, pod := range podList.Items {
+		if !metav1.IsControlledBy(&pod, job) {
+			continue
+		}
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			if containerStatus.Name == smokeTestContainerName && containerStatus.State.Terminated != nil {
+				return containerStatus.State.Terminated.Message, nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+func (r *SmokeTestReconciler) deleteJob(job *batchv1.Job) error {
+	err := r.Client().Delete(r.Context(), job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete smoke test job: %w", err)
+	}
+	return nil
+}
+
+func (r *SmokeTestReconciler) recordRunMetrics(smokeTest *capabilitiesv1beta1.SmokeTest, run *capabilitiesv1beta1.SmokeTestRunStatus) {
+	result := "passed"
+	passed := 1.0
+	if !run.Passed {
+		result = "failed"
+		passed = 0
+	}
+	threescalemetrics.SmokeTestRuns.WithLabelValues(smokeTest.Namespace, smokeTest.Name, result).Inc()
+	threescalemetrics.SmokeTestLastRunPassed.WithLabelValues(smokeTest.Namespace, smokeTest.Name).Set(passed)
+	threescalemetrics.SmokeTestLastRunTimestamp.WithLabelValues(smokeTest.Namespace, smokeTest.Name).Set(float64(run.StartTime.Unix()))
+	for _, requestResult := range run.Results {
+		if requestResult.StatusCode == 0 {
+			continue
+		}
+		threescalemetrics.SmokeTestRequestDuration.WithLabelValues(smokeTest.Namespace, smokeTest.Name, string(requestResult.Environment)).
+			Observe(float64(requestResult.LatencyMilliseconds) / 1000)
+	}
+}
+
+func (r *SmokeTestReconciler) updateStatus(smokeTest *capabilitiesv1beta1.SmokeTest, newStatus *capabilitiesv1beta1.SmokeTestStatus, requeueAfter time.Duration) (ctrl.Result, error) {
+	logger := r.Logger().WithValues("smoketest", client.ObjectKeyFromObject(smokeTest))
+	if smokeTest.Status.Equals(newStatus, logger) {
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	smokeTest.Status = *newStatus
+	err := r.Client().Status().Update(r.Context(), smokeTest)
+	if err != nil {
+		// Ignore conflicts, resource might just be outdated.
+		if errors.IsConflict(err) {
+			logger.Info("Failed to update status: resource might just be outdated")
+			return ctrl.Result{Requeue: true}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to update status: %w", err)
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// smokeTestNextRunTime is the start of the last run plus the interval.
+// A spec change or the lack of previous runs schedule a run right away
+func smokeTestNextRunTime(smokeTest *capabilitiesv1beta1.SmokeTest) time.Time {
+	if smokeTest.Status.LastRun == nil || smokeTest.Status.ObservedGeneration != smokeTest.Generation {
+		return time.Time{}
+	}
+	return smokeTest.Status.LastRun.StartTime.Add(time.Duration(smokeTest.IntervalSeconds()) * time.Second)
+}
+
+// smokeTestJobFinished returns the completion time and, for failed Jobs, the failure message
+func smokeTestJobFinished(job *batchv1.Job) (*metav1.Time, string, bool) {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		completionTime := condition.LastTransitionTime
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return &completionTime, "", true
+		case batchv1.JobFailed:
+			message := condition.Message
+			if message == "" {
+				message = condition.Reason
+			}
+			return &completionTime, message, true
+		}
+	}
+	return nil, "", false
+}
+
+// smokeTestRunStatus records a completed run in the status
+func smokeTestRunStatus(status *capabilitiesv1beta1.SmokeTestStatus, run capabilitiesv1beta1.SmokeTestRunStatus, generation int64, historyLimit int32) *capabilitiesv1beta1.SmokeTestStatus {
+	newStatus := status.DeepCopy()
+	newStatus.ObservedGeneration = generation
+	newStatus.LastRun = run.DeepCopy()
+
+	newStatus.History = append([]capabilitiesv1beta1.SmokeTestRunSummary{run.SmokeTestRunSummary}, newStatus.History...)
+	if len(newStatus.History) > int(historyLimit) {
+		newStatus.History = newStatus.History[:historyLimit]
+	}
+
+	readyCondition := common.Condition{
+		Type:   capabilitiesv1beta1.SmokeTestReadyConditionType,
+		Status: corev1.ConditionTrue,
+	}
+	failedCondition := common.Condition{
+		Type:   capabilitiesv1beta1.SmokeTestFailedConditionType,
+		Status: corev1.ConditionFalse,
+	}
+	if !run.Passed {
+		newStatus.LastFailureReason = run.Reason
+		startTime := run.StartTime
+		newStatus.LastFailureTime = &startTime
+		readyCondition.Status = corev1.ConditionFalse
+		failedCondition.Status = corev1.ConditionTrue
+		failedCondition.Message = run.Reason
+	}
+
+	newStatus.Conditions.SetCondition(readyCondition)
+	newStatus.Conditions.SetCondition(failedCondition)
+	newStatus.Conditions.SetCondition(common.Condition{
+		Type:   capabilitiesv1beta1.SmokeTestRunningConditionType,
+		Status: corev1.ConditionFalse,
+	})
+
+	return newStatus
+}
+
+// smokeTestSetupFailedStatus reports a run that could not be started, i.e. the product is not synchronized
+func smokeTestSetupFailedStatus(status *capabilitiesv1beta1.SmokeTestStatus, err error) *capabilitiesv1beta1.SmokeTestStatus {
+	newStatus := status.DeepCopy()
+	newStatus.Conditions.SetCondition(common.Condition{
+		Type:   capabilitiesv1beta1.SmokeTestReadyConditionType,
+		Status: corev1.ConditionFalse,
+	})
+	newStatus.Conditions.SetCondition(common.Condition{
+		Type:    capabilitiesv1beta1.SmokeTestFailedConditionType,
+		Status:  corev1.ConditionTrue,
+		Reason:  common.ConditionReason("RunNotStarted"),
+		Message: err.Error(),
+	})
+	return newStatus
+}
+
+func (r *SmokeTestReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&capabilitiesv1beta1.SmokeTest{}).
+		Owns(&batchv1.Job{}).
+		Complete(threescalemetrics.NewInstrumentedReconciler("SmokeTest", &capabilitiesv1beta1.SmokeTest{}, mgr.GetClient(), r))
+}
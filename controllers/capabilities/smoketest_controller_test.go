@@ -0,0 +1,126 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+)
+
+func TestSmokeTestRunStatus(t *testing.T) {
+	startTime := metav1.NewTime(time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC))
+	status := &capabilitiesv1beta1.SmokeTestStatus{}
+	for idx := 0; idx < 3; idx++ {
+		run := capabilitiesv1beta1.SmokeTestRunStatus{
+			SmokeTestRunSummary: capabilitiesv1beta1.SmokeTestRunSummary{
+				StartTime: metav1.NewTime(startTime.Add(time.Duration(idx) * time.Hour)),
+				Passed:    idx != 1,
+			},
+		}
+		if !run.Passed {
+			run.Reason = "staging GET /: expected 200, got 503"
+		}
+		status = smokeTestRunStatus(status, run, 1, 2)
+	}
+
+	if len(status.History) != 2 {
+		t.Fatalf("history should be limited to 2 runs: %v", status.History)
+	}
+	if !status.History[0].Passed || status.History[1].Passed {
+		t.Errorf("history should be sorted from the most recent run: %v", status.History)
+	}
+	if status.LastFailureReason != "staging GET /: expected 200, got 503" || !status.LastFailureTime.Equal(&status.History[1].StartTime) {
+		t.Errorf("unexpected last failure %q at %v", status.LastFailureReason, status.LastFailureTime)
+	}
+	if !status.Conditions.IsTrueFor(capabilitiesv1beta1.SmokeTestReadyConditionType) || !status.Conditions.IsFalseFor(capabilitiesv1beta1.SmokeTestFailedConditionType) {
+		t.Errorf("last run passed, unexpected conditions %v", status.Conditions)
+	}
+}
+
+func TestSmokeTestReconcilerCompletedRun(t *testing.T) {
+	s := scheme.Scheme
+	if err := capabilitiesv1beta1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	verifyUsage := false
+	smokeTest := getSmokeTest()
+	smokeTest.Spec.VerifyUsage = &verifyUsage
+	smokeTest.Spec.Environments = []capabilitiesv1beta1.SmokeTestEnvironment{capabilitiesv1beta1.SmokeTestEnvironmentStaging}
+
+	job := smokeTestJob(smokeTest, &smokeTestJobOptions{Image: "apicast:latest", Generation: smokeTest.Generation})
+	job.UID = "9012"
+	job.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Minute))
+	job.Status.Conditions = []batchv1.JobCondition{
+		{Type: batchv1.JobComplete, Status: corev1.ConditionTrue, LastTransitionTime: metav1.Now()},
+	}
+	controller := true
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            job.Name + "-x7k2p",
+			Namespace:       job.Namespace,
+			Labels:          map[string]string{"job-name": job.Name},
+			OwnerReferences: []metav1.OwnerReference{{APIVersion: "batch/v1", Kind: "Job", Name: job.Name, UID: job.UID, Controller: &controller}},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: smokeTestContainerName,
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					Message: "staging 0 200 0.050\nstaging 1 500 0.120\n",
+				}},
+			}},
+		},
+	}
+
+	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(smokeTest, job, pod).
+		WithStatusSubresource(&capabilitiesv1beta1.SmokeTest{}).Build()
+	log := logf.Log.WithName("smoketest test")
+	recorder := record.NewFakeRecorder(10)
+	r := &SmokeTestReconciler{
+		BaseReconciler: reconcilers.NewBaseReconciler(context.TODO(), cl, s, cl, log, fakeclientset.NewSimpleClientset().Discovery(), recorder),
+	}
+
+	result, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Name: smokeTest.Name, Namespace: smokeTest.Namespace}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RequeueAfter <= 0 {
+		t.Errorf("next run should be scheduled: %v", result)
+	}
+
+	updated := &capabilitiesv1beta1.SmokeTest{}
+	if err := cl.Get(context.TODO(), types.NamespacedName{Name: smokeTest.Name, Namespace: smokeTest.Namespace}, updated); err != nil {
+		t.Fatal(err)
+	}
+	if updated.Status.LastRun == nil || updated.Status.LastRun.Passed || len(updated.Status.LastRun.Results) != 2 {
+		t.Fatalf("unexpected last run %+v", updated.Status.LastRun)
+	}
+	if updated.Status.LastFailureReason != "staging POST /pets?verbose=true: expected 201, got 500" {
+		t.Errorf("unexpected failure reason %q", updated.Status.LastFailureReason)
+	}
+	if updated.Status.ObservedGeneration != smokeTest.Generation || !updated.Status.Conditions.IsTrueFor(capabilitiesv1beta1.SmokeTestFailedConditionType) {
+		t.Errorf("unexpected status %+v", updated.Status)
+	}
+	if len(recorder.Events) != 1 {
+		t.Errorf("expected a failure event, got %d events", len(recorder.Events))
+	}
+
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: job.Name, Namespace: job.Namespace}, &batchv1.Job{})
+	if !errors.IsNotFound(err) {
+		t.Errorf("completed job should be deleted: %v", err)
+	}
+}
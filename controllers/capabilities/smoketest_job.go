@@ -0,0 +1,287 @@
+package controllers
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/helper"
+)
+
+const (
+	smokeTestLabelKey = "capabilities.3scale.net/smoketest"
+	// smokeTestGenerationAnnotation is the SmokeTest generation the Job was built from
+	smokeTestGenerationAnnotation = "capabilities.3scale.net/smoketest-generation"
+	// smokeTestBaselineHitsAnnotation is the number of hits reported by backend when the Job was created
+	smokeTestBaselineHitsAnnotation = "capabilities.3scale.net/smoketest-baseline-hits"
+	// smokeTestUsageSinceAnnotation is the start of the usage window of the run
+	smokeTestUsageSinceAnnotation = "capabilities.3scale.net/smoketest-usage-since"
+
+	smokeTestContainerName = "smoketest"
+	// smokeTestRequestTimeoutSeconds bounds every request sent by the Job
+	smokeTestRequestTimeoutSeconds = 30
+
+	smokeTestCredentialsQuery         = "query"
+	smokeTestCredentialsHeaders       = "headers"
+	smokeTestCredentialsAuthorization = "authorization"
+)
+
+// smokeTestScriptHeader defines the run function. Each request appends
+// "<environment> <request index> <status code> <total time>" to the termination log,
+// read by the operator once the Job completes
+const smokeTestScriptHeader = `set -u
+RESULTS=/dev/termination-log
+: > "${RESULTS}"
+run() {
+  ENVIRONMENT=$1
+  INDEX=$2
+  shift 2
+  OUT=$(curl --silent%s --output /dev/null --max-time %d --write-out '%%{http_code} %%{time_total}' "$@") || true
+  echo "${ENVIRONMENT} ${INDEX} ${OUT:-000 0}" >> "${RESULTS}"
+}
+`
+
+// smokeTestCredentials locates the application credentials in the requests, as configured in the product
+type smokeTestCredentials struct {
+	// Location is one of query, headers or authorization
+	Location string
+	// UserKeyParam is set for user key authentication
+	UserKeyParam string
+	// AppIDParam and AppKeyParam are set for application id/key authentication
+	AppIDParam  string
+	AppKeyParam string
+}
+
+type smokeTestJobOptions struct {
+	Image        string
+	Generation   int64
+	Endpoints    map[capabilitiesv1beta1.SmokeTestEnvironment]string
+	Credentials  smokeTestCredentials
+	BaselineHits *int64
+	UsageSince   string
+	// InsecureSkipVerify skips the verification of the APIcast certificates
+	InsecureSkipVerify bool
+}
+
+func smokeTestJobName(smokeTest *capabilitiesv1beta1.SmokeTest) string {
+	name := fmt.Sprintf("smoketest-%s", smokeTest.Name)
+	// job-name label of the pods is limited to 63 characters
+	if len(name) > 63 {
+		name = strings.TrimRight(name[:63], "-.")
+	}
+	return name
+}
+
+func smokeTestLabels(smokeTest *capabilitiesv1beta1.SmokeTest) map[string]string {
+	return map[string]string{
+		"app":             "3scale-api-management",
+		smokeTestLabelKey: smokeTest.Name,
+	}
+}
+
+func smokeTestJob(smokeTest *capabilitiesv1beta1.SmokeTest, options *smokeTestJobOptions) *batchv1.Job {
+	annotations := map[string]string{
+		smokeTestGenerationAnnotation: strconv.FormatInt(options.Generation, 10),
+	}
+	if options.BaselineHits != nil {
+		annotations[smokeTestBaselineHitsAnnotation] = strconv.FormatInt(*options.BaselineHits, 10)
+		annotations[smokeTestUsageSinceAnnotation] = options.UsageSince
+	}
+
+	authSecretName := smokeTest.Spec.AuthSecretRef.Name
+	env := []corev1.EnvVar{
+		helper.EnvVarFromSecretOptional("USER_KEY", authSecretName, UserKey),
+		helper.EnvVarFromSecretOptional("APP_ID", authSecretName, ApplicationID),
+		helper.EnvVarFromSecretOptional("APP_KEY", authSecretName, ApplicationKey),
+	}
+
+	// every request is bounded by curl, the deadline covers pod scheduling and image pulls
+	requestCount := len(smokeTest.EnvironmentsOrDefault()) * len(smokeTest.Spec.Requests)
+	activeDeadlineSeconds := int64(300 + requestCount*smokeTestRequestTimeoutSeconds)
+
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        smokeTestJobName(smokeTest),
+			Namespace:   smokeTest.Namespace,
+			Labels:      smokeTestLabels(smokeTest),
+			Annotations: annotations,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          &[]int32{0}[0],
+			ActiveDeadlineSeconds: &activeDeadlineSeconds,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: smokeTestLabels(smokeTest),
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:    smokeTestContainerName,
+							Image:   options.Image,
+							Command: []string{"/bin/sh", "-c", smokeTestScript(smokeTest, options.Endpoints, options.Credentials, options.InsecureSkipVerify)},
+							Env:     env,
+						},
+					},
+					RestartPolicy: corev1.RestartPolicyNever,
+				},
+			},
+		},
+	}
+}
+
+// smokeTestScript sends every request of the spec to every environment.
+// Credentials are read from the environment of the container, never written in the script
+func smokeTestScript(smokeTest *capabilitiesv1beta1.SmokeTest, endpoints map[capabilitiesv1beta1.SmokeTestEnvironment]string, credentials smokeTestCredentials, insecureSkipVerify bool) string {
+	curlOptions := ""
+	if insecureSkipVerify {
+		curlOptions = " --insecure"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, smokeTestScriptHeader, curlOptions, smokeTestRequestTimeoutSeconds)
+
+	for _, environment := range smokeTest.EnvironmentsOrDefault() {
+		endpoint := strings.TrimSuffix(endpoints[environment], "/")
+		for idx, request := range smokeTest.Spec.Requests {
+			args := []string{"run", string(environment), strconv.Itoa(idx), "--request", shellQuote(request.MethodOrDefault())}
+
+			for _, name := range sortedKeys(request.Headers) {
+				args = append(args, "--header", shellQuote(fmt.Sprintf("%s: %s", name, request.Headers[name])))
+			}
+			if request.Body != nil {
+				args = append(args, "--data-binary", shellQuote(*request.Body))
+			}
+
+			requestURL := shellQuote(endpoint + request.Path)
+			switch credentials.Location {
+			case smokeTestCredentialsHeaders:
+				if credentials.UserKeyParam != "" {
+					args = append(args, "--header", shellQuote(credentials.UserKeyParam+": ")+`"${USER_KEY}"`)
+				} else {
+					args = append(args,
+						"--header", shellQuote(credentials.AppIDParam+": ")+`"${APP_ID}"`,
+						"--header", shellQuote(credentials.AppKeyParam+": ")+`"${APP_KEY}"`)
+				}
+			case smokeTestCredentialsAuthorization:
+				if credentials.UserKeyParam != "" {
+					args = append(args, "--user", `"${USER_KEY}:"`)
+				} else {
+					args = append(args, "--user", `"${APP_ID}:${APP_KEY}"`)
+				}
+			default:
+				separator := "?"
+				if strings.Contains(request.Path, "?") {
+					separator = "&"
+				}
+				if credentials.UserKeyParam != "" {
+					requestURL += shellQuote(separator+credentials.UserKeyParam+"=") + `"${USER_KEY}"`
+				} else {
+					requestURL += shellQuote(separator+credentials.AppIDParam+"=") + `"${APP_ID}"` +
+						shellQuote("&"+credentials.AppKeyParam+"=") + `"${APP_KEY}"`
+				}
+			}
+
+			args = append(args, requestURL)
+			b.WriteString(strings.Join(args, " "))
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String()
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// parseSmokeTestResults reads the results written by the Job to the termination log.
+// Requests without a result line, i.e. the Job was killed, are reported with a 0 status code
+func parseSmokeTestResults(smokeTest *capabilitiesv1beta1.SmokeTest, message string) []capabilitiesv1beta1.SmokeTestRequestResult {
+	type resultKey struct {
+		environment string
+		index       int
+	}
+	type resultValue struct {
+		statusCode int32
+		latency    int64
+	}
+
+	parsed := map[resultKey]resultValue{}
+	scanner := bufio.NewScanner(strings.NewReader(message))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 4 {
+			continue
+		}
+		index, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue
+		}
+		statusCode, err := strconv.ParseInt(fields[2], 10, 32)
+		if err != nil {
+			continue
+		}
+		seconds, err := strconv.ParseFloat(fields[3], 64)
+		if err != nil {
+			continue
+		}
+		parsed[resultKey{fields[0], index}] = resultValue{int32(statusCode), int64(math.Round(seconds * 1000))}
+	}
+
+	results := []capabilitiesv1beta1.SmokeTestRequestResult{}
+	for _, environment := range smokeTest.EnvironmentsOrDefault() {
+		for idx, request := range smokeTest.Spec.Requests {
+			value := parsed[resultKey{string(environment), idx}]
+			results = append(results, capabilitiesv1beta1.SmokeTestRequestResult{
+				Environment:         environment,
+				Method:              request.MethodOrDefault(),
+				Path:                request.Path,
+				StatusCode:          value.statusCode,
+				ExpectedStatusCode:  request.ExpectedStatusCodeOrDefault(),
+				LatencyMilliseconds: value.latency,
+			})
+		}
+	}
+
+	return results
+}
+
+// smokeTestResultsFailureReason returns the mismatched status codes, empty when all requests passed
+func smokeTestResultsFailureReason(results []capabilitiesv1beta1.SmokeTestRequestResult) string {
+	failures := []string{}
+	for _, result := range results {
+		if result.StatusCode == result.ExpectedStatusCode {
+			continue
+		}
+		got := strconv.Itoa(int(result.StatusCode))
+		if result.StatusCode == 0 {
+			got = "no response"
+		}
+		failures = append(failures, fmt.Sprintf("%s %s %s: expected %d, got %s", result.Environment, result.Method, result.Path, result.ExpectedStatusCode, got))
+	}
+	sort.Strings(failures)
+	return strings.Join(failures, "; ")
+}
+
+// smokeTestSuccessfulRequests counts the requests answered with a 2xx status code, reported to backend by APIcast
+func smokeTestSuccessfulRequests(results []capabilitiesv1beta1.SmokeTestRequestResult) int64 {
+	var count int64
+	for _, result := range results {
+		if result.StatusCode >= 200 && result.StatusCode < 300 {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,165 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+)
+
+func getSmokeTest() *capabilitiesv1beta1.SmokeTest {
+	post := "POST"
+	body := `{"name": "o'brien"}`
+	created := int32(201)
+	return &capabilitiesv1beta1.SmokeTest{
+		ObjectMeta: metav1.ObjectMeta{Name: "petstore", Namespace: "test", UID: "5678", Generation: 2},
+		Spec: capabilitiesv1beta1.SmokeTestSpec{
+			ProductCRName: "petstore",
+			AuthSecretRef: &corev1.LocalObjectReference{Name: "petstore-auth"},
+			Requests: []capabilitiesv1beta1.SmokeTestRequestSpec{
+				{Path: "/pets"},
+				{Method: &post, Path: "/pets?verbose=true", Headers: map[string]string{"Content-Type": "application/json"}, Body: &body, ExpectedStatusCode: &created},
+			},
+		},
+	}
+}
+
+func TestSmokeTestScript(t *testing.T) {
+	smokeTest := getSmokeTest()
+	endpoints := map[capabilitiesv1beta1.SmokeTestEnvironment]string{
+		capabilitiesv1beta1.SmokeTestEnvironmentStaging:    "https://petstore-staging.example.com/",
+		capabilitiesv1beta1.SmokeTestEnvironmentProduction: "https://petstore.example.com",
+	}
+
+	cases := []struct {
+		testName    string
+		credentials smokeTestCredentials
+		insecure    bool
+		expected    []string
+	}{
+		{"userKeyQuery", smokeTestCredentials{Location: smokeTestCredentialsQuery, UserKeyParam: "user_key"}, false, []string{
+			`run staging 0 --request 'GET' 'https://petstore-staging.example.com/pets''?user_key='"${USER_KEY}"`,
+			`run production 1 --request 'POST' --header 'Content-Type: application/json' --data-binary '{"name": "o'\''brien"}' 'https://petstore.example.com/pets?verbose=true''&user_key='"${USER_KEY}"`,
+		}},
+		{"appIDHeaders", smokeTestCredentials{Location: smokeTestCredentialsHeaders, AppIDParam: "app_id", AppKeyParam: "app_key"}, true, []string{
+			"curl --silent --insecure",
+			`run staging 0 --request 'GET' --header 'app_id: '"${APP_ID}" --header 'app_key: '"${APP_KEY}" 'https://petstore-staging.example.com/pets'`,
+		}},
+		{"userKeyAuthorization", smokeTestCredentials{Location: smokeTestCredentialsAuthorization, UserKeyParam: "user_key"}, false, []string{
+			`run production 0 --request 'GET' --user "${USER_KEY}:" 'https://petstore.example.com/pets'`,
+		}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			script := smokeTestScript(smokeTest, endpoints, tc.credentials, tc.insecure)
+			for _, expected := range tc.expected {
+				if !strings.Contains(script, expected) {
+					subT.Errorf("script does not contain %q:\n%s", expected, script)
+				}
+			}
+			if !tc.insecure && strings.Contains(script, "--insecure") {
+				subT.Errorf("script should verify the certificates:\n%s", script)
+			}
+		})
+	}
+}
+
+func TestParseSmokeTestResults(t *testing.T) {
+	smokeTest := getSmokeTest()
+	smokeTest.Spec.Environments = []capabilitiesv1beta1.SmokeTestEnvironment{capabilitiesv1beta1.SmokeTestEnvironmentProduction}
+
+	// second request timed out, the Job was killed before writing the last line
+	results := parseSmokeTestResults(smokeTest, "production 0 200 0.1234\nproduction 1 000 30.001\ngarbage\n")
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %v", results)
+	}
+	if results[0].StatusCode != 200 || results[0].LatencyMilliseconds != 123 || results[0].Method != "GET" {
+		t.Errorf("unexpected result %+v", results[0])
+	}
+	if results[1].StatusCode != 0 || results[1].ExpectedStatusCode != 201 {
+		t.Errorf("unexpected result %+v", results[1])
+	}
+
+	reason := smokeTestResultsFailureReason(results)
+	if reason != "production POST /pets?verbose=true: expected 201, got no response" {
+		t.Errorf("unexpected failure reason %q", reason)
+	}
+	if smokeTestSuccessfulRequests(results) != 1 {
+		t.Errorf("expected 1 successful request, got %d", smokeTestSuccessfulRequests(results))
+	}
+
+	results[1].StatusCode = 201
+	if reason := smokeTestResultsFailureReason(results); reason != "" {
+		t.Errorf("expected no failure, got %q", reason)
+	}
+}
+
+func TestSmokeTestJob(t *testing.T) {
+	smokeTest := getSmokeTest()
+	smokeTest.Name = strings.Repeat("a", 70)
+	baselineHits := int64(42)
+
+	job := smokeTestJob(smokeTest, &smokeTestJobOptions{
+		Image:        "apicast:latest",
+		Generation:   smokeTest.Generation,
+		Credentials:  smokeTestCredentials{Location: smokeTestCredentialsQuery, UserKeyParam: "user_key"},
+		BaselineHits: &baselineHits,
+		UsageSince:   "2026-10-18T10:00:00Z",
+	})
+
+	if len(job.Name) > 63 {
+		t.Errorf("job name %q exceeds 63 characters", job.Name)
+	}
+	if job.Annotations[smokeTestGenerationAnnotation] != "2" || job.Annotations[smokeTestBaselineHitsAnnotation] != "42" {
+		t.Errorf("unexpected annotations %v", job.Annotations)
+	}
+	if *job.Spec.BackoffLimit != 0 {
+		t.Errorf("failed runs should not be retried")
+	}
+	env := job.Spec.Template.Spec.Containers[0].Env
+	if len(env) != 3 || env[0].ValueFrom.SecretKeyRef.Name != "petstore-auth" {
+		t.Errorf("credentials should be read from the auth secret: %v", env)
+	}
+}
+
+func TestProductHits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/stats/services/3/usage.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		query := req.URL.Query()
+		if query.Get("access_token") != "token" || query.Get("metric_name") != "hits" || query.Get("since") != "2026-10-18 10:00:00" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"metric": {"system_name": "hits"}, "total": 17, "values": [10, 7]}`))
+	}))
+	defer server.Close()
+
+	providerAccount := &controllerhelper.ProviderAccount{AdminURLStr: server.URL, Token: "token"}
+	since := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+
+	hits, err := productHits(context.TODO(), server.Client(), providerAccount, 3, since, since.Add(90*time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hits != 17 {
+		t.Errorf("expected 17 hits, got %d", hits)
+	}
+
+	_, err = productHits(context.TODO(), server.Client(), providerAccount, 4, since, since.Add(time.Minute))
+	if err == nil {
+		t.Error("expected an error for an unknown product")
+	}
+}
@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+)
+
+const (
+	// smokeTestUsageTimeFormat is the time format of the 3scale analytics API
+	smokeTestUsageTimeFormat = "2006-01-02 15:04:05"
+	// smokeTestUsageGracePeriod is the time backend is given to report the usage of a completed run
+	smokeTestUsageGracePeriod = 2 * time.Minute
+)
+
+// productHits reads the hits reported by backend for the product from since until now, using the
+// 3scale analytics API. The window must start at the beginning of an hour, the granularity of the API
+func productHits(ctx context.Context, httpClient *http.Client, providerAccount *controllerhelper.ProviderAccount, productID int64, since, until time.Time) (int64, error) {
+	params := url.Values{}
+	params.Set("access_token", providerAccount.Token)
+	params.Set("metric_name", "hits")
+	params.Set("since", since.UTC().Format(smokeTestUsageTimeFormat))
+	params.Set("until", until.UTC().Format(smokeTestUsageTimeFormat))
+	params.Set("granularity", "hour")
+	params.Set("timezone", "UTC")
+	params.Set("skip_change", "true")
+
+	usageURL := fmt.Sprintf("%s/stats/services/%d/usage.json?%s", strings.TrimSuffix(providerAccount.AdminURLStr, "/"), productID, params.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, usageURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read the product usage: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("failed to read the product usage: %s", resp.Status)
+	}
+
+	usage := struct {
+		Total int64 `json:"total"`
+	}{}
+	err = json.NewDecoder(resp.Body).Decode(&usage)
+	if err != nil {
+		return 0, fmt.Errorf("failed to decode the product usage: %w", err)
+	}
+
+	return usage.Total, nil
+}
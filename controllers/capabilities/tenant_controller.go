@@ -31,17 +31,20 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
-	capabilitiesv1alpha1 "github.com/3scale/3scale-operator/apis/capabilities/v1alpha1"
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
 	"github.com/3scale/3scale-operator/pkg/helper"
+	threescalemetrics "github.com/3scale/3scale-operator/pkg/metrics"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 	"github.com/3scale/3scale-operator/version"
 	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
 )
 
 const (
-	// tenant deletion state
+	// tenant account states
+	approvedState             = "approved"
+	suspendedState            = "suspended"
 	scheduledForDeletionState = "scheduled_for_deletion"
 
 	// tenant finalizer
@@ -78,7 +81,7 @@ func (r *TenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	reqLogger.Info("Reconcile Tenant", "Operator version", version.Version)
 
 	// Fetch the Tenant instance
-	tenantCR := &capabilitiesv1alpha1.Tenant{}
+	tenantCR := &capabilitiesv1beta1.Tenant{}
 	err := r.Client().Get(context.TODO(), req.NamespacedName, tenantCR)
 	if err != nil {
 		if k8serrors.IsNotFound(err) {
@@ -100,10 +103,13 @@ func (r *TenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		reqLogger.V(1).Info(string(jsonData))
 	}
 
+	// The reconcilers update the status in place, the current status tells whether it has to be saved
+	currentStatus := tenantCR.Status.DeepCopy()
+
 	// Setup porta client
-	portaClient, err := r.setupPortaClient(tenantCR, reqLogger)
+	portaClient, masterAPI, err := r.setupPortaClient(tenantCR, reqLogger)
 	if err != nil {
-		_, statusReconcilerError := r.reconcileStatus(tenantCR, err)
+		_, statusReconcilerError := r.reconcileStatus(tenantCR, currentStatus, err)
 		if statusReconcilerError != nil {
 			return helper.ReconcileErrorHandler(err, reqLogger), nil
 		}
@@ -118,8 +124,10 @@ func (r *TenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 			return ctrl.Result{}, err
 		}
 
-		// delete tenantCR if tenant is present in 3scale
-		if existingTenant != nil {
+		// delete tenantCR if tenant is present in 3scale, unless it is retained
+		if existingTenant != nil && tenantCR.DeletionPolicy() == capabilitiesv1beta1.TenantDeletionPolicyRetain {
+			reqLogger.Info("Removing tenant CR - tenant is retained", "tenantID", existingTenant.Signup.Account.ID)
+		} else if existingTenant != nil {
 			// do not attempt to delete tenant that is already scheduled for deletion
 			if existingTenant.Signup.Account.State != scheduledForDeletionState {
 				err := portaClient.DeleteTenant(tenantCR.Status.TenantId)
@@ -161,9 +169,9 @@ func (r *TenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	}
 
 	// Validate and update spec if required
-	internalReconciler := NewTenantThreescaleReconciler(r.BaseReconciler, tenantCR, portaClient, reqLogger)
+	internalReconciler := NewTenantThreescaleReconciler(r.BaseReconciler, tenantCR, portaClient, masterAPI, reqLogger)
 	specReconcileErr := internalReconciler.Run()
-	statusIsEqual, statusReconcilerError := r.reconcileStatus(tenantCR, specReconcileErr)
+	statusIsEqual, statusReconcilerError := r.reconcileStatus(tenantCR, currentStatus, specReconcileErr)
 	if statusReconcilerError != nil {
 		return helper.ReconcileErrorHandler(statusReconcilerError, reqLogger), nil
 	}
@@ -181,8 +189,8 @@ func (r *TenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	return ctrl.Result{}, nil
 }
 
-func (r *TenantReconciler) reconcileStatus(tenantCR *capabilitiesv1alpha1.Tenant, reconcileError error) (bool, error) {
-	statusReconciler := NewTenantStatusReconciler(r.BaseReconciler, tenantCR, reconcileError)
+func (r *TenantReconciler) reconcileStatus(tenantCR *capabilitiesv1beta1.Tenant, currentStatus *capabilitiesv1beta1.TenantStatus, reconcileError error) (bool, error) {
+	statusReconciler := NewTenantStatusReconciler(r.BaseReconciler, tenantCR, currentStatus, reconcileError)
 	statusEqual, err := statusReconciler.Reconcile()
 	if err != nil {
 		return statusEqual, err
@@ -191,7 +199,7 @@ func (r *TenantReconciler) reconcileStatus(tenantCR *capabilitiesv1alpha1.Tenant
 	return statusEqual, nil
 }
 
-func (r *TenantReconciler) reconcileMetadata(tenantCR *capabilitiesv1alpha1.Tenant) bool {
+func (r *TenantReconciler) reconcileMetadata(tenantCR *capabilitiesv1beta1.Tenant) bool {
 	changed := false
 	// If the tenant.Status.TenantID is found and the annotation is not found - create
 	// If the tenant.Status.TenantID is found and the annotation is found but, the value of annotation is different to the status.TenantID - update
@@ -212,7 +220,7 @@ func (r *TenantReconciler) reconcileMetadata(tenantCR *capabilitiesv1alpha1.Tena
 	return changed
 }
 
-func (r *TenantReconciler) fetchMasterCredentials(tenantR *capabilitiesv1alpha1.Tenant) (string, error) {
+func (r *TenantReconciler) fetchMasterCredentials(tenantR *capabilitiesv1beta1.Tenant) (string, error) {
 	masterCredentialsSecret := &corev1.Secret{}
 
 	err := r.Client().Get(context.TODO(), tenantR.MasterSecretKey(), masterCredentialsSecret)
@@ -231,24 +239,30 @@ func (r *TenantReconciler) fetchMasterCredentials(tenantR *capabilitiesv1alpha1.
 	return bytes.NewBuffer(masterAccessTokenByteArray).String(), nil
 }
 
-func (r *TenantReconciler) setupPortaClient(tenantCR *capabilitiesv1alpha1.Tenant, logger logr.Logger) (*threescaleapi.ThreeScaleClient, error) {
+func (r *TenantReconciler) setupPortaClient(tenantCR *capabilitiesv1beta1.Tenant, logger logr.Logger) (*threescaleapi.ThreeScaleClient, *tenantMasterAPI, error) {
 	masterAccessToken, err := r.fetchMasterCredentials(tenantCR)
 	if err != nil {
 		logger.Error(err, "Error fetching master credentials secret")
-		return nil, err
+		return nil, nil, err
 	}
 
 	insecureSkipVerify := controllerhelper.GetInsecureSkipVerifyAnnotation(tenantCR.GetAnnotations())
 	portaClient, err := controllerhelper.PortaClientFromURLString(tenantCR.Spec.SystemMasterUrl, masterAccessToken, insecureSkipVerify)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	masterAPI := &tenantMasterAPI{
+		httpClient: controllerhelper.PortaHTTPClient(insecureSkipVerify),
+		masterURL:  tenantCR.Spec.SystemMasterUrl,
+		token:      masterAccessToken,
 	}
 
-	return portaClient, nil
+	return portaClient, masterAPI, nil
 }
 
 func (r *TenantReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&capabilitiesv1alpha1.Tenant{}).
-		Complete(r)
+		For(&capabilitiesv1beta1.Tenant{}).
+		Complete(threescalemetrics.NewInstrumentedReconciler("Tenant", &capabilitiesv1beta1.Tenant{}, mgr.GetClient(), r))
 }
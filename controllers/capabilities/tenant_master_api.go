@@ -0,0 +1,47 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// tenantResumeEndpoint resumes a tenant scheduled for deletion
+	tenantResumeEndpoint = "/master/api/providers/%d/resume.json"
+)
+
+// tenantMasterAPI calls the 3scale master API endpoints not covered by porta_client
+type tenantMasterAPI struct {
+	httpClient *http.Client
+	masterURL  string
+	token      string
+}
+
+// resumeTenant cancels the deletion of a tenant scheduled for deletion, the tenant is approved again
+func (m *tenantMasterAPI) resumeTenant(ctx context.Context, tenantID int64) error {
+	params := url.Values{}
+	params.Set("access_token", m.token)
+
+	resumeURL := strings.TrimSuffix(m.masterURL, "/") + fmt.Sprintf(tenantResumeEndpoint, tenantID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, resumeURL, strings.NewReader(params.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to resume the tenant: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to resume the tenant: %s", resp.Status)
+	}
+
+	return nil
+}
@@ -1,7 +1,7 @@
 package controllers
 
 import (
-	capabilitiesv1alpha1 "github.com/3scale/3scale-operator/apis/capabilities/v1alpha1"
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 
 	"github.com/3scale/3scale-operator/pkg/apispkg/common"
@@ -11,15 +11,17 @@ import (
 
 type TenantStatusReconciler struct {
 	*reconcilers.BaseReconciler
-	tenantResource *capabilitiesv1alpha1.Tenant
+	tenantResource *capabilitiesv1beta1.Tenant
+	currentStatus  *capabilitiesv1beta1.TenantStatus
 	reconcileError error
 	logger         logr.Logger
 }
 
-func NewTenantStatusReconciler(b *reconcilers.BaseReconciler, tenantResource *capabilitiesv1alpha1.Tenant, reconcileError error) *TenantStatusReconciler {
+func NewTenantStatusReconciler(b *reconcilers.BaseReconciler, tenantResource *capabilitiesv1beta1.Tenant, currentStatus *capabilitiesv1beta1.TenantStatus, reconcileError error) *TenantStatusReconciler {
 	return &TenantStatusReconciler{
 		BaseReconciler: b,
 		tenantResource: tenantResource,
+		currentStatus:  currentStatus,
 		reconcileError: reconcileError,
 		logger:         b.Logger().WithValues("Status Reconciler", tenantResource.Name),
 	}
@@ -31,7 +33,7 @@ func (s *TenantStatusReconciler) Reconcile() (bool, error) {
 	equalStatus := true
 	// Check for changes to the status
 	newStatus := s.calculateStatus()
-	equalStatus = s.tenantResource.Status.StatusEqual(&newStatus, s.logger)
+	equalStatus = s.currentStatus.StatusEqual(&newStatus, s.logger)
 
 	if !equalStatus {
 		s.logger.Info("updating tenant status")
@@ -46,7 +48,7 @@ func (s *TenantStatusReconciler) Reconcile() (bool, error) {
 	return equalStatus, nil
 }
 
-func (s *TenantStatusReconciler) calculateStatus() capabilitiesv1alpha1.TenantStatus {
+func (s *TenantStatusReconciler) calculateStatus() capabilitiesv1beta1.TenantStatus {
 	status := s.tenantResource.Status
 	status.Conditions = s.tenantResource.Status.Conditions.Copy()
 	status.Conditions.SetCondition(s.readyCondition())
@@ -56,7 +58,7 @@ func (s *TenantStatusReconciler) calculateStatus() capabilitiesv1alpha1.TenantSt
 
 func (s *TenantStatusReconciler) readyCondition() common.Condition {
 	condition := common.Condition{
-		Type:   capabilitiesv1alpha1.TenantReadyConditionType,
+		Type:   capabilitiesv1beta1.TenantReadyConditionType,
 		Status: corev1.ConditionFalse,
 	}
 
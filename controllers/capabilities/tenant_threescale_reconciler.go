@@ -11,9 +11,10 @@ import (
 	porta_client_pkg "github.com/3scale/3scale-porta-go-client/client"
 	"github.com/go-logr/logr"
 	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	apiv1alpha1 "github.com/3scale/3scale-operator/apis/capabilities/v1alpha1"
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
 	apispkghelper "github.com/3scale/3scale-operator/pkg/apispkg/helper"
 	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
 	"github.com/3scale/3scale-operator/pkg/helper"
@@ -23,18 +24,20 @@ import (
 // TenantThreescaleReconciler reconciles a Tenant object
 type TenantThreescaleReconciler struct {
 	*reconcilers.BaseReconciler
-	tenantR     *apiv1alpha1.Tenant
+	tenantR     *capabilitiesv1beta1.Tenant
 	portaClient *porta_client_pkg.ThreeScaleClient
+	masterAPI   *tenantMasterAPI
 	logger      logr.Logger
 }
 
 // NewTenantThreescaleReconciler constructs InternalReconciler object
-func NewTenantThreescaleReconciler(b *reconcilers.BaseReconciler, tenantR *apiv1alpha1.Tenant,
-	portaClient *porta_client_pkg.ThreeScaleClient, log logr.Logger) *TenantThreescaleReconciler {
+func NewTenantThreescaleReconciler(b *reconcilers.BaseReconciler, tenantR *capabilitiesv1beta1.Tenant,
+	portaClient *porta_client_pkg.ThreeScaleClient, masterAPI *tenantMasterAPI, log logr.Logger) *TenantThreescaleReconciler {
 	return &TenantThreescaleReconciler{
 		BaseReconciler: b,
 		tenantR:        tenantR,
 		portaClient:    portaClient,
+		masterAPI:      masterAPI,
 		logger:         log,
 	}
 }
@@ -42,10 +45,12 @@ func NewTenantThreescaleReconciler(b *reconcilers.BaseReconciler, tenantR *apiv1
 // Run tenant reconciliation logic
 // Facts to reconcile:
 // - Have 3scale Tenant Account
+// - Have the desired lifecycle state
 // - Have active admin user
-// - Have secret with tenant's access_token
+// - Have the desired provider plan
+// - Have secret with tenant's access_token and admin domain
 func (r *TenantThreescaleReconciler) Run() error {
-	updateRequired, err := r.reconcileTenant()
+	tenantDef, updateRequired, err := r.reconcileTenant()
 	if err != nil {
 		return err
 	}
@@ -54,33 +59,54 @@ func (r *TenantThreescaleReconciler) Run() error {
 		return nil
 	}
 
+	err = r.reconcileState(tenantDef)
+	r.reconcileStatusAccount(tenantDef)
+	if err != nil {
+		return err
+	}
+
+	// tenants scheduled for deletion cannot be updated
+	if tenantDef.Signup.Account.State == scheduledForDeletionState {
+		return nil
+	}
+
+	err = r.SetUpdateTenantInfo(tenantDef)
+	r.reconcileStatusAccount(tenantDef)
+	if err != nil {
+		return err
+	}
+
 	err = r.reconcileAdminUser()
 	if err != nil {
 		return err
 	}
 
-	return nil
+	err = r.reconcileProviderPlan(tenantDef.Signup.Account.ID)
+	if err != nil {
+		return err
+	}
+
+	return r.reconcileAdminURLSecret(tenantDef)
 }
 
 // This method makes sure that tenant exists, otherwise it will create one
 // On method completion:
 // * tenant will exist
-// * tenant's attributes will be updated if required
-func (r *TenantThreescaleReconciler) reconcileTenant() (bool, error) {
+func (r *TenantThreescaleReconciler) reconcileTenant() (*porta_client_pkg.Tenant, bool, error) {
 	tenantID, err := r.retrieveTenantID()
 	if err != nil {
-		return false, errors.New("failed to convert tenantID annotation to int64")
+		return nil, false, errors.New("failed to convert tenantID annotation to int64")
 	}
 
 	tenantDef, err := controllerhelper.FetchTenant(tenantID, r.portaClient)
 	if err != nil {
-		return false, err
+		return nil, false, err
 	}
 
 	if tenantDef == nil {
 		tenantDef, err = r.createTenant()
 		if err != nil {
-			return false, err
+			return nil, false, err
 		}
 
 		// Early save access token as it is only available on the response of the
@@ -88,11 +114,11 @@ func (r *TenantThreescaleReconciler) reconcileTenant() (bool, error) {
 
 		err = r.reconcileAccessTokenSecret(tenantDef)
 		if err != nil {
-			return false, err
+			return nil, false, err
 		}
 
 		// Early update status with the new tenantID
-		newStatus := &apiv1alpha1.TenantStatus{
+		newStatus := &capabilitiesv1beta1.TenantStatus{
 			// reset adminID. It could keep old stale value
 			AdminId:  0,
 			TenantId: tenantDef.Signup.Account.ID,
@@ -100,21 +126,166 @@ func (r *TenantThreescaleReconciler) reconcileTenant() (bool, error) {
 
 		updated, err := r.reconcileStatusIDs(newStatus)
 		if err != nil {
-			return false, err
+			return nil, false, err
 		}
 
 		// If updated - update the status and requeue
 		if updated {
-			return true, nil
+			return tenantDef, true, nil
 		}
 	}
 
-	err = r.SetUpdateTenantInfo(tenantDef)
+	return tenantDef, false, nil
+}
+
+// This method makes sure the tenant account is in the desired lifecycle state.
+// Pending and rejected tenants are left to the 3scale approval process
+func (r *TenantThreescaleReconciler) reconcileState(tenant *porta_client_pkg.Tenant) error {
+	// Unset state leaves the tenant account as it is, i.e. suspended or scheduled for deletion outside the operator
+	if r.tenantR.Spec.State == nil {
+		return nil
+	}
+
+	account := &tenant.Signup.Account
+	desiredState := *r.tenantR.Spec.State
+
+	if desiredState == capabilitiesv1beta1.TenantStateScheduledForDeletion {
+		if account.State == scheduledForDeletionState {
+			return nil
+		}
+		r.logger.Info("Scheduling tenant for deletion", "tenantID", account.ID)
+		err := r.portaClient.DeleteTenant(account.ID)
+		if err != nil {
+			return fmt.Errorf("failed to schedule the tenant for deletion: %w", err)
+		}
+		r.EventRecorder().Eventf(r.tenantR, v1.EventTypeNormal, "ScheduledForDeletion", "Tenant %d scheduled for deletion", account.ID)
+		account.State = scheduledForDeletionState
+		return nil
+	}
+
+	if account.State == scheduledForDeletionState {
+		r.logger.Info("Resuming tenant scheduled for deletion", "tenantID", account.ID)
+		err := r.masterAPI.resumeTenant(r.Context(), account.ID)
+		if err != nil {
+			return err
+		}
+		r.EventRecorder().Eventf(r.tenantR, v1.EventTypeNormal, "Resumed", "Tenant %d deletion cancelled", account.ID)
+		account.State = approvedState
+	}
+
+	stateEvent := ""
+	if desiredState == capabilitiesv1beta1.TenantStateSuspended && account.State == approvedState {
+		stateEvent = "suspend"
+	}
+	if desiredState == capabilitiesv1beta1.TenantStateActive && account.State == suspendedState {
+		stateEvent = "resume"
+	}
+	if stateEvent == "" {
+		return nil
+	}
+
+	r.logger.Info("Changing tenant state", "tenantID", account.ID, "event", stateEvent)
+	updatedTenant, err := r.portaClient.UpdateTenant(account.ID, porta_client_pkg.Params{"state_event": stateEvent})
 	if err != nil {
-		return false, err
+		return fmt.Errorf("failed to %s the tenant: %w", stateEvent, err)
 	}
+	*tenant = *updatedTenant
 
-	return false, nil
+	return nil
+}
+
+// This method makes sure the tenant has the desired provider plan,
+// the plan of the tenant application of the master account
+func (r *TenantThreescaleReconciler) reconcileProviderPlan(tenantID int64) error {
+	applications, err := r.portaClient.ListApplications(tenantID)
+	if err != nil {
+		return err
+	}
+
+	if len(applications.Applications) == 0 {
+		if r.tenantR.Spec.ProviderPlan == nil {
+			return nil
+		}
+		return &helper.WaitError{
+			Err: fmt.Errorf("tenant %d has no application of the master account", tenantID),
+		}
+	}
+	application := &applications.Applications[0].Application
+
+	plans, err := r.portaClient.ListApplicationPlansByProduct(application.ServiceID)
+	if err != nil {
+		return err
+	}
+
+	desiredPlan := r.tenantR.Spec.ProviderPlan
+	var currentPlan, newPlan *porta_client_pkg.ApplicationPlanItem
+	for idx := range plans.Plans {
+		plan := &plans.Plans[idx].Element
+		if plan.ID == application.PlanID {
+			currentPlan = plan
+		}
+		if desiredPlan != nil && plan.SystemName == *desiredPlan {
+			newPlan = plan
+		}
+	}
+
+	if desiredPlan != nil && newPlan == nil {
+		return &helper.SpecFieldError{
+			ErrorType:      helper.InvalidError,
+			FieldErrorList: field.ErrorList{field.Invalid(field.NewPath("spec").Child("providerPlan"), *desiredPlan, "application plan not found in the master account")},
+		}
+	}
+
+	if newPlan != nil && newPlan.ID != application.PlanID {
+		r.logger.Info("Changing tenant provider plan", "tenantID", tenantID, "plan", newPlan.SystemName)
+		_, err := r.portaClient.ChangeApplicationPlan(tenantID, application.ID, newPlan.ID)
+		if err != nil {
+			return fmt.Errorf("failed to change the provider plan: %w", err)
+		}
+		currentPlan = newPlan
+	}
+
+	r.tenantR.Status.ProviderPlan = ""
+	if currentPlan != nil {
+		r.tenantR.Status.ProviderPlan = currentPlan.SystemName
+	}
+
+	return nil
+}
+
+// This method makes sure the secret with tenant's access_token has the current admin domain.
+// The secret is only created with the tenant, when the access_token is available
+func (r *TenantThreescaleReconciler) reconcileAdminURLSecret(tenantDef *porta_client_pkg.Tenant) error {
+	adminURL, err := controllerhelper.URLFromDomain(tenantDef.Signup.Account.AdminDomain)
+	if err != nil {
+		return err
+	}
+
+	secret := &v1.Secret{}
+	err = r.Client().Get(r.Context(), r.tenantR.TenantSecretKey(), secret)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	if string(secret.Data[TenantAdminDomainKeySecretField]) == adminURL.String() {
+		return nil
+	}
+
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[TenantAdminDomainKeySecretField] = []byte(adminURL.String())
+	return r.UpdateResource(secret)
+}
+
+// reconcileStatusAccount reports the tenant account state and domains
+func (r *TenantThreescaleReconciler) reconcileStatusAccount(tenantDef *porta_client_pkg.Tenant) {
+	r.tenantR.Status.AccountState = tenantDef.Signup.Account.State
+	r.tenantR.Status.AdminDomain = tenantDef.Signup.Account.AdminDomain
+	r.tenantR.Status.DeveloperDomain = tenantDef.Signup.Account.Domain
 }
 
 // This method makes sure admin user:
@@ -157,7 +328,7 @@ func (r *TenantThreescaleReconciler) reconcileAdminUser() error {
 		return err
 	}
 
-	newStatus := &apiv1alpha1.TenantStatus{
+	newStatus := &capabilitiesv1beta1.TenantStatus{
 		AdminId:  *adminUser.Element.ID,
 		TenantId: tenantID,
 	}
@@ -295,7 +466,7 @@ func (r *TenantThreescaleReconciler) syncAdminUser(tenantID int64, adminUser *po
 }
 
 // Returns whether the status should be updated or not and the error
-func (r *TenantThreescaleReconciler) reconcileStatusIDs(desiredStatus *apiv1alpha1.TenantStatus) (bool, error) {
+func (r *TenantThreescaleReconciler) reconcileStatusIDs(desiredStatus *capabilitiesv1beta1.TenantStatus) (bool, error) {
 	if desiredStatus.TenantId != r.tenantR.Status.TenantId {
 		r.tenantR.Status.TenantId = desiredStatus.TenantId
 		return true, nil
@@ -379,6 +550,18 @@ func (r *TenantThreescaleReconciler) SetUpdateTenantInfo(tenant *porta_client_pk
 		}
 	}
 
+	if r.tenantR.Spec.AdminDomain != nil {
+		if tenant.Signup.Account.AdminDomain != *r.tenantR.Spec.AdminDomain {
+			params["admin_domain"] = *r.tenantR.Spec.AdminDomain
+		}
+	}
+
+	if r.tenantR.Spec.DeveloperDomain != nil {
+		if tenant.Signup.Account.Domain != *r.tenantR.Spec.DeveloperDomain {
+			params["domain"] = *r.tenantR.Spec.DeveloperDomain
+		}
+	}
+
 	if !helper.ManagedByOperatorAnnotationExists(tenant.Signup.Account.Annotations) {
 		for k, v := range helper.ManagedByOperatorAnnotation() {
 			params[k] = v
@@ -387,13 +570,14 @@ func (r *TenantThreescaleReconciler) SetUpdateTenantInfo(tenant *porta_client_pk
 
 	if len(params) > 0 {
 		r.logger.Info("Set/Update Optional parameters for tenant", "OrganizationName", r.tenantR.Spec.OrganizationName, "Username", r.tenantR.Spec.Username)
-		_, err := r.portaClient.UpdateTenant(
+		updatedTenant, err := r.portaClient.UpdateTenant(
 			tenant.Signup.Account.ID,
 			params,
 		)
 		if err != nil {
 			return err
 		}
+		*tenant = *updatedTenant
 	}
 
 	return nil
@@ -0,0 +1,218 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+)
+
+// tenantMasterServer mocks the master API for tenant 3, whose application 10 of the master service 2 has plan 20
+func tenantMasterServer(t *testing.T, requests *[]string) *httptest.Server {
+	writeJSON := func(w http.ResponseWriter, obj interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(obj); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if err := req.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		*requests = append(*requests, req.Method+" "+req.URL.Path+" "+req.Form.Get("state_event")+req.Form.Get("plan_id"))
+
+		switch req.Method + " " + req.URL.Path {
+		case "PUT /master/api/providers/3.json":
+			tenant := &threescaleapi.Tenant{}
+			tenant.Signup.Account.ID = 3
+			tenant.Signup.Account.AdminDomain = "example-admin.example.com"
+			tenant.Signup.Account.State = map[string]string{"suspend": suspendedState, "resume": approvedState}[req.Form.Get("state_event")]
+			writeJSON(w, tenant)
+		case "DELETE /master/api/providers/3.json", "PUT /master/api/providers/3/resume.json":
+			w.WriteHeader(http.StatusOK)
+		case "GET /admin/api/accounts/3/applications.json":
+			writeJSON(w, &threescaleapi.ApplicationList{Applications: []threescaleapi.ApplicationElem{
+				{Application: threescaleapi.Application{ID: 10, ServiceID: 2, PlanID: 20}},
+			}})
+		case "GET /admin/api/services/2/application_plans.json":
+			writeJSON(w, &threescaleapi.ApplicationPlanJSONList{Plans: []threescaleapi.ApplicationPlan{
+				{Element: threescaleapi.ApplicationPlanItem{ID: 20, SystemName: "basic"}},
+				{Element: threescaleapi.ApplicationPlanItem{ID: 21, SystemName: "enterprise"}},
+			}})
+		case "PUT /admin/api/accounts/3/applications/10/change_plan.json":
+			writeJSON(w, &threescaleapi.ApplicationElem{Application: threescaleapi.Application{ID: 10, ServiceID: 2, PlanID: 21}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func newTestTenantThreescaleReconciler(t *testing.T, server *httptest.Server, tenantCR *capabilitiesv1beta1.Tenant, objects ...runtime.Object) *TenantThreescaleReconciler {
+	portaClient, err := controllerhelper.PortaClientFromURLString(server.URL, "token", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	masterAPI := &tenantMasterAPI{httpClient: server.Client(), masterURL: server.URL, token: "token"}
+	return NewTenantThreescaleReconciler(getBaseReconciler(objects...), tenantCR, portaClient, masterAPI, logf.Log.WithName("tenant test"))
+}
+
+func newTestTenant() *capabilitiesv1beta1.Tenant {
+	return &capabilitiesv1beta1.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "test"},
+		Spec: capabilitiesv1beta1.TenantSpec{
+			TenantSecretRef: corev1.SecretReference{Name: "example-tenant", Namespace: "test"},
+		},
+		Status: capabilitiesv1beta1.TenantStatus{TenantId: 3},
+	}
+}
+
+func TestTenantThreescaleReconcilerState(t *testing.T) {
+	active := capabilitiesv1beta1.TenantStateActive
+	suspended := capabilitiesv1beta1.TenantStateSuspended
+	scheduledForDeletion := capabilitiesv1beta1.TenantStateScheduledForDeletion
+
+	cases := []struct {
+		testName         string
+		desiredState     *capabilitiesv1beta1.TenantState
+		currentState     string
+		expectedRequests []string
+		expectedState    string
+	}{
+		{"unset keeps active", nil, approvedState, nil, approvedState},
+		{"unset keeps suspended", nil, suspendedState, nil, suspendedState},
+		{"unset keeps scheduled for deletion", nil, scheduledForDeletionState, nil, scheduledForDeletionState},
+		{"active", &active, approvedState, nil, approvedState},
+		{"suspend", &suspended, approvedState, []string{"PUT /master/api/providers/3.json suspend"}, suspendedState},
+		{"resume suspended", &active, suspendedState, []string{"PUT /master/api/providers/3.json resume"}, approvedState},
+		{"schedule for deletion", &scheduledForDeletion, approvedState, []string{"DELETE /master/api/providers/3.json "}, scheduledForDeletionState},
+		{"already scheduled for deletion", &scheduledForDeletion, scheduledForDeletionState, nil, scheduledForDeletionState},
+		{"resume scheduled for deletion", &active, scheduledForDeletionState, []string{"PUT /master/api/providers/3/resume.json "}, approvedState},
+		{"suspend scheduled for deletion", &suspended, scheduledForDeletionState, []string{"PUT /master/api/providers/3/resume.json ", "PUT /master/api/providers/3.json suspend"}, suspendedState},
+		{"pending left to the approval process", &active, "pending", nil, "pending"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			var requests []string
+			server := tenantMasterServer(subT, &requests)
+			defer server.Close()
+
+			tenantCR := newTestTenant()
+			tenantCR.Spec.State = tc.desiredState
+			tenantDef := &threescaleapi.Tenant{}
+			tenantDef.Signup.Account.ID = 3
+			tenantDef.Signup.Account.State = tc.currentState
+
+			r := newTestTenantThreescaleReconciler(subT, server, tenantCR)
+			if err := r.reconcileState(tenantDef); err != nil {
+				subT.Fatal(err)
+			}
+			if !reflect.DeepEqual(requests, tc.expectedRequests) {
+				subT.Errorf("expected requests %v, got %v", tc.expectedRequests, requests)
+			}
+			if tenantDef.Signup.Account.State != tc.expectedState {
+				subT.Errorf("expected state %s, got %s", tc.expectedState, tenantDef.Signup.Account.State)
+			}
+		})
+	}
+}
+
+func TestTenantThreescaleReconcilerProviderPlan(t *testing.T) {
+	cases := []struct {
+		testName         string
+		providerPlan     *string
+		expectedRequests int
+		expectedPlan     string
+	}{
+		{"not set", nil, 2, "basic"},
+		{"current plan", &[]string{"basic"}[0], 2, "basic"},
+		{"change plan", &[]string{"enterprise"}[0], 3, "enterprise"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			var requests []string
+			server := tenantMasterServer(subT, &requests)
+			defer server.Close()
+
+			tenantCR := newTestTenant()
+			tenantCR.Spec.ProviderPlan = tc.providerPlan
+
+			r := newTestTenantThreescaleReconciler(subT, server, tenantCR)
+			if err := r.reconcileProviderPlan(3); err != nil {
+				subT.Fatal(err)
+			}
+			if len(requests) != tc.expectedRequests {
+				subT.Errorf("expected %d requests, got %v", tc.expectedRequests, requests)
+			}
+			if tc.expectedRequests == 3 && requests[2] != "PUT /admin/api/accounts/3/applications/10/change_plan.json 21" {
+				subT.Errorf("unexpected plan change request %s", requests[2])
+			}
+			if tenantCR.Status.ProviderPlan != tc.expectedPlan {
+				subT.Errorf("expected plan %s in the status, got %s", tc.expectedPlan, tenantCR.Status.ProviderPlan)
+			}
+		})
+	}
+
+	var requests []string
+	server := tenantMasterServer(t, &requests)
+	defer server.Close()
+
+	tenantCR := newTestTenant()
+	tenantCR.Spec.ProviderPlan = &[]string{"unknown"}[0]
+	r := newTestTenantThreescaleReconciler(t, server, tenantCR)
+	if err := r.reconcileProviderPlan(3); !helper.IsInvalidSpecError(err) {
+		t.Errorf("expected an invalid spec error for an unknown plan, got %v", err)
+	}
+}
+
+func TestTenantThreescaleReconcilerAdminURLSecret(t *testing.T) {
+	var requests []string
+	server := tenantMasterServer(t, &requests)
+	defer server.Close()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-tenant", Namespace: "test"},
+		Data: map[string][]byte{
+			TenantAccessTokenSecretField:    []byte("token"),
+			TenantAdminDomainKeySecretField: []byte("https://example-admin.old.example.com"),
+		},
+	}
+	tenantCR := newTestTenant()
+	r := newTestTenantThreescaleReconciler(t, server, tenantCR, secret)
+
+	tenantDef := &threescaleapi.Tenant{}
+	tenantDef.Signup.Account.AdminDomain = "example-admin.example.com"
+	if err := r.reconcileAdminURLSecret(tenantDef); err != nil {
+		t.Fatal(err)
+	}
+
+	updated := &corev1.Secret{}
+	if err := r.Client().Get(r.Context(), tenantCR.TenantSecretKey(), updated); err != nil {
+		t.Fatal(err)
+	}
+	if string(updated.Data[TenantAdminDomainKeySecretField]) != "https://example-admin.example.com" {
+		t.Errorf("admin URL not updated: %s", updated.Data[TenantAdminDomainKeySecretField])
+	}
+	if string(updated.Data[TenantAccessTokenSecretField]) != "token" {
+		t.Errorf("access token modified: %s", updated.Data[TenantAccessTokenSecretField])
+	}
+
+	// the secret is only created with the tenant
+	r = newTestTenantThreescaleReconciler(t, server, newTestTenant())
+	if err := r.reconcileAdminURLSecret(tenantDef); err != nil {
+		t.Errorf("unexpected error without secret: %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+package controllers
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+)
+
+// +kubebuilder:webhook:path=/validate-capabilities-3scale-net-v1beta1-tenant,mutating=false,failurePolicy=fail,sideEffects=None,groups=capabilities.3scale.net,resources=tenants,verbs=create;update,versions=v1beta1,name=vtenant.capabilities.3scale.net,admissionReviewVersions=v1
+
+// SetupWebhookWithManager rejects invalid Tenant resources. v1alpha1 resources are validated once converted,
+// the builder also serves the /convert endpoint converting between v1alpha1 and the v1beta1 storage version
+func (r *TenantReconciler) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&capabilitiesv1beta1.Tenant{}).
+		WithValidator(&controllerhelper.SpecValidator{
+			GroupKind: capabilitiesv1beta1.GroupVersion.WithKind(capabilitiesv1beta1.TenantKind).GroupKind(),
+			Spec:      func(obj runtime.Object) interface{} { return obj.(*capabilitiesv1beta1.Tenant).Spec },
+			Validate: func(obj runtime.Object) (admission.Warnings, error) {
+				return r.validateAdmission(obj.(*capabilitiesv1beta1.Tenant))
+			},
+		}).
+		Complete()
+}
+
+// validateAdmission validates the spec, then checks no other Tenant of the same master account has the same
+// organization name or admin domain. Warns when the master credentials secret does not exist
+func (r *TenantReconciler) validateAdmission(tenantCR *capabilitiesv1beta1.Tenant) (admission.Warnings, error) {
+	fieldErrors := tenantCR.Validate()
+	if len(fieldErrors) > 0 {
+		return nil, &helper.SpecFieldError{
+			ErrorType:      helper.InvalidError,
+			FieldErrorList: fieldErrors,
+		}
+	}
+
+	specFldPath := field.NewPath("spec")
+
+	tenantList := &capabilitiesv1beta1.TenantList{}
+	err := r.Client().List(r.Context(), tenantList, client.InNamespace(tenantCR.Namespace))
+	if err != nil {
+		return nil, err
+	}
+	for idx := range tenantList.Items {
+		other := &tenantList.Items[idx]
+		if other.Name == tenantCR.Name || other.Spec.SystemMasterUrl != tenantCR.Spec.SystemMasterUrl {
+			continue
+		}
+		if other.Spec.OrganizationName == tenantCR.Spec.OrganizationName {
+			return nil, admissionError(field.ErrorList{
+				field.Invalid(specFldPath.Child("organizationName"), tenantCR.Spec.OrganizationName, fmt.Sprintf("organization name already used by Tenant %s of the same master account", other.Name)),
+			})
+		}
+		if tenantCR.Spec.AdminDomain != nil && other.Spec.AdminDomain != nil && *other.Spec.AdminDomain == *tenantCR.Spec.AdminDomain {
+			return nil, admissionError(field.ErrorList{
+				field.Invalid(specFldPath.Child("adminDomain"), *tenantCR.Spec.AdminDomain, fmt.Sprintf("admin domain already used by Tenant %s of the same master account", other.Name)),
+			})
+		}
+	}
+
+	err = r.Client().Get(r.Context(), tenantCR.MasterSecretKey(), &corev1.Secret{})
+	return secretWarning(specFldPath.Child("masterCredentialsRef"), tenantCR.Spec.MasterCredentialsRef.Name, err), nil
+}
@@ -0,0 +1,69 @@
+package controllers
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+)
+
+// Validating webhooks reject invalid specs, duplicated system names and references to missing
+// custom resources. References to secrets are only reported as warnings, secrets are often
+// created by other tools after the custom resources
+
+// providerAccountWarning is returned when the provider account of the resource cannot be looked up,
+// references to other resources of the same provider account are not checked
+func providerAccountWarning(err error) admission.Warnings {
+	return admission.Warnings{fmt.Sprintf("references to other 3scale resources not validated: %v", err)}
+}
+
+// secretWarning reports a secret referenced by the resource that cannot be read, nil when err is nil
+func secretWarning(fldPath *field.Path, name string, err error) admission.Warnings {
+	if err == nil {
+		return nil
+	}
+
+	if apierrors.IsNotFound(err) {
+		return admission.Warnings{fmt.Sprintf("%s: secret %q not found", fldPath.String(), name)}
+	}
+
+	return admission.Warnings{fmt.Sprintf("%s: secret %q not validated: %v", fldPath.String(), name, err)}
+}
+
+// sameProviderAccount returns true when the providerAccountRef resolves to the given provider account.
+// Resources whose provider account cannot be looked up are ignored
+func sameProviderAccount(cl client.Client, ns string, providerAccountRef *corev1.LocalObjectReference, providerAccountURLStr string, logger logr.Logger) bool {
+	providerAccount, err := controllerhelper.LookupProviderAccount(cl, ns, providerAccountRef, logger)
+	if err != nil {
+		logger.V(1).Info("provider account lookup failed", "error", err)
+		return false
+	}
+
+	return providerAccount.AdminURLStr == providerAccountURLStr
+}
+
+// duplicateSystemNameError reports a system name already used by another resource of the same provider account
+func duplicateSystemNameError(fldPath *field.Path, systemName, kind, otherName string) field.ErrorList {
+	return field.ErrorList{
+		field.Invalid(fldPath, systemName, fmt.Sprintf("system name already used by %s %s of the same provider account", kind, otherName)),
+	}
+}
+
+// admissionError wraps the field errors found by the webhooks, nil when the list is empty
+func admissionError(errors field.ErrorList) error {
+	if len(errors) == 0 {
+		return nil
+	}
+
+	return &helper.SpecFieldError{
+		ErrorType:      helper.OrphanError,
+		FieldErrorList: errors,
+	}
+}
@@ -40,6 +40,7 @@ import (
 	"github.com/3scale/3scale-operator/pkg/helper"
 
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
+	"github.com/3scale/3scale-operator/pkg/upgrade"
 	"github.com/3scale/3scale-operator/version"
 )
 
@@ -156,6 +157,18 @@ func (r *SubscriptionReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		rhtComponentVersion = val
 	}
 
+	// Requirements are version constraint expressions, reject the ones the preflights cannot evaluate
+	err = validateRequirements(map[string]string{
+		helper.RHTThreescaleMysqlRequirements:        mysqlRequirement,
+		helper.RHTThreescalePostgresRequirements:     postgresRequirement,
+		helper.RHTThreescaleSystemRedisRequirements:  systemredisRequirement,
+		helper.RHTThreescaleBackendRedisRequirements: backendredisRequirement,
+	})
+	if err != nil {
+		logger.Error(err, "invalid requirements in the CSV")
+		return ctrl.Result{}, err
+	}
+
 	requirementsConfigMap := &corev1.ConfigMap{
 		ObjectMeta: v1.ObjectMeta{
 			Name:      helper.OperatorRequirementsConfigMapName,
@@ -252,10 +265,16 @@ func (r *SubscriptionReconciler) reconcileOperatorCondtions(coreosLabel string)
 
 	// Check if all APIManagers have the requirements confirmed
 	apimRequirementsConfirmed := true
+	var unmetRequirements []string
 	for _, apim := range apimList.Items {
 		requirementsAlreadyConfirmed := apim.RequirementsConfirmed(requirementsConfigMapResourceVersion)
 		if !requirementsAlreadyConfirmed {
 			apimRequirementsConfirmed = false
+			// The preflights condition explains which constraint failed
+			preflightsCondition := apim.Status.Conditions.GetCondition(appsv1alpha1.APIManagerPreflightsConditionType)
+			if preflightsCondition != nil && preflightsCondition.IsFalse() {
+				unmetRequirements = append(unmetRequirements, fmt.Sprintf("%s/%s: %s", apim.Namespace, apim.Name, preflightsCondition.Message))
+			}
 		}
 	}
 
@@ -266,6 +285,29 @@ func (r *SubscriptionReconciler) reconcileOperatorCondtions(coreosLabel string)
 	}
 	isOlmApprovedUpgrade := r.isOlmApprovedUpgradeScenarioDetected(operatorConditionsList)
 
+	// Block the incoming upgrade while any APIManager sets a field removed by the incoming version
+	if isOlmApprovedUpgrade {
+		removedFields, err := removedFieldsInUse(apimList, version.ThreescaleVersionMajorMinor(), rhtComponentVersion)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if len(removedFields) > 0 {
+			operatorCondition, err := r.retrieveBlockingOperatorUpgradeBlockingCondition(operatorConditionsList)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			emptyListOfConditions := &operatorConditions.OperatorConditionSpec{}
+			updatedConditions := append(emptyListOfConditions.Conditions, getUpgradableCondition("False", "DeprecatedFieldsInUse", removedFieldsMessage(rhtComponentVersion, removedFields)))
+			operatorCondition.Spec.Conditions = updatedConditions
+			operatorCondition.Spec.Overrides = emptyListOfConditions.Overrides
+			err = r.Client().Update(context.TODO(), operatorCondition)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{RequeueAfter: time.Minute * 1}, nil
+		}
+	}
+
 	// If all APIManagers have confirmed the requirements, and the currently installed operator version is not the latest one coming from the CSV, it means
 	// the requirements were checked for incoming upgrade, therefore an override should be created to allow for upgrade to proceed whenever it's auto-approved or approved by user
 	if apimRequirementsConfirmed && isOlmApprovedUpgrade {
@@ -308,7 +350,7 @@ func (r *SubscriptionReconciler) reconcileOperatorCondtions(coreosLabel string)
 			return ctrl.Result{}, err
 		}
 		emptyListOfConditions := &operatorConditions.OperatorConditionSpec{}
-		updatedConditions := append(emptyListOfConditions.Conditions, getUpgradableCondition("False", "UpgradeRejected", "Requirements are not confirmed yet by all 3scale instances that are managed by the operator"))
+		updatedConditions := append(emptyListOfConditions.Conditions, getUpgradableCondition("False", "UpgradeRejected", upgradeRejectedMessage(unmetRequirements)))
 		operatorCondition.Spec.Conditions = updatedConditions
 		operatorCondition.Spec.Overrides = emptyListOfConditions.Overrides
 		err = r.Client().Update(context.TODO(), operatorCondition)
@@ -337,6 +379,53 @@ func (r *SubscriptionReconciler) reconcileOperatorCondtions(coreosLabel string)
 	return ctrl.Result{}, nil
 }
 
+// removedFieldsInUse returns the fields of the APIManagers the upgrade to the incoming version removes
+func removedFieldsInUse(apimList *appsv1alpha1.APIManagerList, currentVersion, incomingVersion string) ([]upgrade.DeprecatedFieldUsage, error) {
+	if incomingVersion == "" || incomingVersion == currentVersion {
+		return nil, nil
+	}
+
+	var result []upgrade.DeprecatedFieldUsage
+	for idx := range apimList.Items {
+		removedFields, err := upgrade.RemovedFieldsInUse(&apimList.Items[idx], upgrade.APIManagerDeprecations, currentVersion, incomingVersion)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, removedFields...)
+	}
+
+	return result, nil
+}
+
+func removedFieldsMessage(incomingVersion string, removedFields []upgrade.DeprecatedFieldUsage) string {
+	usages := make([]string, 0, len(removedFields))
+	for _, removedField := range removedFields {
+		usages = append(usages, removedField.String())
+	}
+	return fmt.Sprintf("Upgrade to %s removes APIManager fields still in use - %s", incomingVersion, strings.Join(usages, "; "))
+}
+
+func upgradeRejectedMessage(unmetRequirements []string) string {
+	message := "Requirements are not confirmed yet by all 3scale instances that are managed by the operator"
+	if len(unmetRequirements) > 0 {
+		message = fmt.Sprintf("%s - %s", message, strings.Join(unmetRequirements, "; "))
+	}
+	return message
+}
+
+// validateRequirements verifies the requirements are valid version constraint expressions
+func validateRequirements(requirements map[string]string) error {
+	for key, requirement := range requirements {
+		if requirement == "" {
+			continue
+		}
+		if _, err := helper.ParseVersionConstraint(requirement); err != nil {
+			return fmt.Errorf("requirement %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
 func getUpgradableCondition(trueOrFalse, reason, message string) v1.Condition {
 	condition := v1.Condition{
 		Type:               operatorConditions.Upgradeable,
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	appsv1beta1 "github.com/3scale/3scale-operator/apis/apps/v1beta1"
 	capabilitiesv1alpha1 "github.com/3scale/3scale-operator/apis/capabilities/v1alpha1"
 	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
 	appscontroller "github.com/3scale/3scale-operator/controllers/apps"
@@ -58,6 +59,7 @@ import (
 
 	subcontroller "github.com/3scale/3scale-operator/controllers/subscription"
 	"github.com/3scale/3scale-operator/pkg/helper"
+	threescalemetrics "github.com/3scale/3scale-operator/pkg/metrics"
 	operatorsv2 "github.com/operator-framework/api/pkg/operators/v2"
 	// +kubebuilder:scaffold:imports
 )
@@ -77,6 +79,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(appsv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(appsv1beta1.AddToScheme(scheme))
 	utilruntime.Must(operatorsv2.AddToScheme(scheme))
 	utilruntime.Must(operatorsv1alpha1.AddToScheme(scheme))
 	utilruntime.Must(capabilitiesv1alpha1.AddToScheme(scheme))
@@ -402,7 +405,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	registerThreescaleMetricsIntoControllerRuntimeMetricsRegistry()
+	registerThreescaleMetricsIntoControllerRuntimeMetricsRegistry(mgr.GetClient())
 
 	discoveryProxyConfigPromote, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
 	if err != nil {
@@ -454,6 +457,61 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "Application")
 		os.Exit(1)
 	}
+	discoverySmokeTest, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create discovery client")
+		os.Exit(1)
+	}
+
+	if err = (&capabilitiescontroller.SmokeTestReconciler{
+		BaseReconciler: reconcilers.NewBaseReconciler(
+			context.Background(), mgr.GetClient(), mgr.GetScheme(), mgr.GetAPIReader(),
+			ctrl.Log.WithName("controllers").WithName("SmokeTest"),
+			discoverySmokeTest,
+			mgr.GetEventRecorderFor("SmokeTest")),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "SmokeTest")
+		os.Exit(1)
+	}
+
+	// Webhooks need the serving certificates injected by OLM, disable them when running the operator locally
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		discoveryWebhooks, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+		if err != nil {
+			setupLog.Error(err, "unable to create discovery client")
+			os.Exit(1)
+		}
+
+		webhookBaseReconciler := reconcilers.NewBaseReconciler(
+			context.Background(), mgr.GetClient(), mgr.GetScheme(), mgr.GetAPIReader(),
+			ctrl.Log.WithName("webhooks"),
+			discoveryWebhooks,
+			mgr.GetEventRecorderFor("webhooks"))
+
+		webhooks := []struct {
+			name  string
+			setup func(ctrl.Manager) error
+		}{
+			{"APIManager", (&appscontroller.APIManagerReconciler{BaseReconciler: webhookBaseReconciler}).SetupWebhookWithManager},
+			{"Tenant", (&capabilitiescontroller.TenantReconciler{BaseReconciler: webhookBaseReconciler}).SetupWebhookWithManager},
+			{"Backend", (&capabilitiescontroller.BackendReconciler{BaseReconciler: webhookBaseReconciler}).SetupWebhookWithManager},
+			{"Product", (&capabilitiescontroller.ProductReconciler{BaseReconciler: webhookBaseReconciler}).SetupWebhookWithManager},
+			{"OpenAPI", (&capabilitiescontroller.OpenAPIReconciler{BaseReconciler: webhookBaseReconciler}).SetupWebhookWithManager},
+			{"ActiveDoc", (&capabilitiescontroller.ActiveDocReconciler{BaseReconciler: webhookBaseReconciler}).SetupWebhookWithManager},
+			{"DeveloperAccount", (&capabilitiescontroller.DeveloperAccountReconciler{BaseReconciler: webhookBaseReconciler}).SetupWebhookWithManager},
+			{"DeveloperUser", (&capabilitiescontroller.DeveloperUserReconciler{BaseReconciler: webhookBaseReconciler}).SetupWebhookWithManager},
+			{"CustomPolicyDefinition", (&capabilitiescontroller.CustomPolicyDefinitionReconciler{BaseReconciler: webhookBaseReconciler}).SetupWebhookWithManager},
+			{"ProxyConfigPromote", (&capabilitiescontroller.ProxyConfigPromoteReconciler{BaseReconciler: webhookBaseReconciler}).SetupWebhookWithManager},
+			{"Application", (&capabilitiescontroller.ApplicationReconciler{BaseReconciler: webhookBaseReconciler}).SetupWebhookWithManager},
+			{"ApplicationAuth", (&capabilitiescontroller.ApplicationAuthReconciler{BaseReconciler: webhookBaseReconciler}).SetupWebhookWithManager},
+		}
+		for _, webhook := range webhooks {
+			if err = webhook.setup(mgr); err != nil {
+				setupLog.Error(err, "unable to create webhook", "webhook", webhook.name)
+				os.Exit(1)
+			}
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	setupLog.Info("starting manager")
@@ -469,8 +527,9 @@ func printVersion() {
 	setupLog.Info(fmt.Sprintf("Go OS/Arch: %s/%s", runtime.GOOS, runtime.GOARCH))
 }
 
-func registerThreescaleMetricsIntoControllerRuntimeMetricsRegistry() {
+func registerThreescaleMetricsIntoControllerRuntimeMetricsRegistry(reader client.Reader) {
 	register3scaleVersionInfoMetric()
+	registerOperatorMetrics(reader)
 }
 
 func register3scaleVersionInfoMetric() {
@@ -487,3 +546,24 @@ func register3scaleVersionInfoMetric() {
 	// Register custom metrics with the global prometheus registry
 	controllerruntimemetrics.Registry.MustRegister(threeScaleVersionInfo)
 }
+
+func registerOperatorMetrics(reader client.Reader) {
+	controllerruntimemetrics.Registry.MustRegister(threescalemetrics.Collectors()...)
+
+	conditionsCollector := threescalemetrics.NewConditionsCollector(reader, map[string]client.ObjectList{
+		"APIManager":             &appsv1alpha1.APIManagerList{},
+		"Tenant":                 &capabilitiesv1beta1.TenantList{},
+		"Backend":                &capabilitiesv1beta1.BackendList{},
+		"Product":                &capabilitiesv1beta1.ProductList{},
+		"OpenAPI":                &capabilitiesv1beta1.OpenAPIList{},
+		"ActiveDoc":              &capabilitiesv1beta1.ActiveDocList{},
+		"CustomPolicyDefinition": &capabilitiesv1beta1.CustomPolicyDefinitionList{},
+		"DeveloperAccount":       &capabilitiesv1beta1.DeveloperAccountList{},
+		"DeveloperUser":          &capabilitiesv1beta1.DeveloperUserList{},
+		"ProxyConfigPromote":     &capabilitiesv1beta1.ProxyConfigPromoteList{},
+		"Application":            &capabilitiesv1beta1.ApplicationList{},
+		"ApplicationAuth":        &capabilitiesv1beta1.ApplicationAuthList{},
+		"SmokeTest":              &capabilitiesv1beta1.SmokeTestList{},
+	}, ctrl.Log.WithName("metrics"))
+	controllerruntimemetrics.Registry.MustRegister(conditionsCollector)
+}
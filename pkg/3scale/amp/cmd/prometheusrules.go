@@ -18,12 +18,18 @@ import (
 
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
 
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/prometheusrules"
 )
 
 var prometheusRulesNamespace string
 
+// File with the alert overrides, same format as the APIManager spec.monitoring.alertOverrides field
+var prometheusRulesAlertOverridesFile string
+
 // Compatibility with Openshift <4.9
 var compatPre49 bool
 
@@ -65,16 +71,45 @@ func runPrometheusRulesCommand(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("Factory %s not found", prName)
 	}
 
+	alertOverrides, err := readAlertOverrides(prometheusRulesAlertOverridesFile)
+	if err != nil {
+		return err
+	}
+
 	prometheusRulesObj := factory.PrometheusRule(compatPre49, prometheusRulesNamespace)
+	prometheusrules.ApplyAlertOverrides(prometheusRulesObj, alertOverrides)
 
 	serializer := json.NewSerializerWithOptions(json.DefaultMetaFactory, nil, nil,
 		json.SerializerOptions{Yaml: true, Pretty: true, Strict: true})
 	return serializer.Encode(prometheusRulesObj, os.Stdout)
 }
 
+func readAlertOverrides(path string) ([]appsv1alpha1.AlertOverrideSpec, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	alertOverrides := []appsv1alpha1.AlertOverrideSpec{}
+	if err := yaml.UnmarshalStrict(data, &alertOverrides); err != nil {
+		return nil, fmt.Errorf("Failed parsing alert overrides file %s: %w", path, err)
+	}
+
+	if fieldErrors := prometheusrules.ValidateAlertOverrides(field.NewPath("alertOverrides"), alertOverrides); len(fieldErrors) > 0 {
+		return nil, fieldErrors.ToAggregate()
+	}
+
+	return alertOverrides, nil
+}
+
 func init() {
 	prometheusRulesCmd.PersistentFlags().StringVar(&prometheusRulesNamespace, "namespace", "", "Namespace to be used when generating the prometheus rules")
 	prometheusRulesCmd.PersistentFlags().BoolVar(&compatPre49, "compat", false, "Generate rules compatible with Openshift releases prior to 4.9")
+	prometheusRulesCmd.PersistentFlags().StringVar(&prometheusRulesAlertOverridesFile, "alert-overrides", "", "YAML file with the list of alert overrides to apply")
 	prometheusRulesCmd.MarkFlagRequired("namespace")
 	rootCmd.AddCommand(prometheusRulesCmd)
 }
@@ -26,7 +26,10 @@ const (
 	ApicastProductionName              = "apicast-production"
 	ApicastProductionInitContainerName = "system-master-svc"
 
-	CustomPoliciesMountBasePath               = "/opt/app-root/src/policies"
+	CustomPoliciesMountBasePath = "/opt/app-root/src/policies"
+	// CustomPolicyImagePath is the directory of the policy files in custom policy images
+	CustomPolicyImagePath                     = "/policy"
+	CustomPolicyInitMountPath                 = "/mnt/policy"
 	CustomPoliciesAnnotationNameSegmentPrefix = "apicast-policy-volume"
 	CustomPoliciesAnnotationPartialKey        = "apps.3scale.net/" + CustomPoliciesAnnotationNameSegmentPrefix
 
@@ -52,11 +55,12 @@ const (
 )
 
 const (
-	APIcastEnvironmentCMAnnotation             = "apimanager.apps.3scale.net/env-configmap-hash"
-	HttpsCertSecretResverAnnotationPrefix      = "apimanager.apps.3scale.net/https-cert-secret-resource-version-"
-	OpenTelemetrySecretResverAnnotationPrefix  = "apimanager.apps.3scale.net/opentelemetry-secret-resource-version-"
-	CustomEnvSecretResverAnnotationPrefix      = "apimanager.apps.3scale.net/customenv-secret-resource-version-"
-	CustomPoliciesSecretResverAnnotationPrefix = "apimanager.apps.3scale.net/custompolicy-secret-resource-version-"
+	APIcastEnvironmentCMAnnotation              = "apimanager.apps.3scale.net/env-configmap-hash"
+	HttpsCertSecretResverAnnotationPrefix       = "apimanager.apps.3scale.net/https-cert-secret-resource-version-"
+	OpenTelemetrySecretResverAnnotationPrefix   = "apimanager.apps.3scale.net/opentelemetry-secret-resource-version-"
+	CustomEnvSecretResverAnnotationPrefix       = "apimanager.apps.3scale.net/customenv-secret-resource-version-"
+	CustomPoliciesSecretResverAnnotationPrefix  = "apimanager.apps.3scale.net/custompolicy-secret-resource-version-"
+	CustomPoliciesConfigMapHashAnnotationPrefix = "apimanager.apps.3scale.net/custompolicy-configmap-hash-"
 )
 
 type Apicast struct {
@@ -144,6 +148,7 @@ func (apicast *Apicast) StagingDeployment(ctx context.Context, k8sclient client.
 					Tolerations:        apicast.Options.StagingTolerations,
 					ServiceAccountName: "amp",
 					Volumes:            apicast.stagingVolumes(),
+					InitContainers:     customPolicyInitContainers(apicast.Options.StagingCustomPolicies),
 					Containers: []v1.Container{
 						{
 							Ports:           apicast.stagingContainerPorts(),
@@ -224,7 +229,7 @@ func (apicast *Apicast) ProductionDeployment(ctx context.Context, k8sclient clie
 					Tolerations:        apicast.Options.ProductionTolerations,
 					ServiceAccountName: "amp",
 					Volumes:            apicast.productionVolumes(),
-					InitContainers: []v1.Container{
+					InitContainers: append([]v1.Container{
 						{
 							Name:    ApicastProductionInitContainerName,
 							Image:   containerImage,
@@ -236,7 +241,7 @@ func (apicast *Apicast) ProductionDeployment(ctx context.Context, k8sclient clie
 								},
 							},
 						},
-					},
+					}, customPolicyInitContainers(apicast.Options.ProductionCustomPolicies)...),
 					Containers: []v1.Container{
 						{
 							Ports:           apicast.productionContainerPorts(),
@@ -294,8 +299,8 @@ func (apicast *Apicast) buildApicastStagingEnv() []v1.EnvVar {
 	result := []v1.EnvVar{}
 	result = append(result, apicast.buildApicastCommonEnv()...)
 	result = append(result,
-		helper.EnvVarFromValue("APICAST_CONFIGURATION_LOADER", "lazy"),
-		helper.EnvVarFromValue("APICAST_CONFIGURATION_CACHE", "0"),
+		helper.EnvVarFromValue("APICAST_CONFIGURATION_LOADER", valueOrDefault(apicast.Options.StagingTuning.ConfigurationLoader, "lazy")),
+		helper.EnvVarFromValue("APICAST_CONFIGURATION_CACHE", valueOrDefault(apicast.Options.StagingTuning.ConfigurationCache, "0")),
 		helper.EnvVarFromValue("THREESCALE_DEPLOYMENT_ENV", "staging"),
 	)
 	if apicast.Options.StagingLogLevel != nil {
@@ -365,6 +370,8 @@ func (apicast *Apicast) buildApicastStagingEnv() []v1.EnvVar {
 		result = append(result, helper.EnvVarFromValue("OPENTELEMETRY_CONFIG", apicast.Options.StagingOpentelemetry.ConfigFile))
 	}
 
+	result = append(result, apicast.Options.StagingTuning.EnvVars()...)
+
 	return result
 }
 
@@ -372,8 +379,8 @@ func (apicast *Apicast) buildApicastProductionEnv() []v1.EnvVar {
 	result := []v1.EnvVar{}
 	result = append(result, apicast.buildApicastCommonEnv()...)
 	result = append(result,
-		helper.EnvVarFromValue("APICAST_CONFIGURATION_LOADER", "boot"),
-		helper.EnvVarFromValue("APICAST_CONFIGURATION_CACHE", "300"),
+		helper.EnvVarFromValue("APICAST_CONFIGURATION_LOADER", valueOrDefault(apicast.Options.ProductionTuning.ConfigurationLoader, "boot")),
+		helper.EnvVarFromValue("APICAST_CONFIGURATION_CACHE", valueOrDefault(apicast.Options.ProductionTuning.ConfigurationCache, "300")),
 		helper.EnvVarFromValue("THREESCALE_DEPLOYMENT_ENV", "production"),
 	)
 	if apicast.Options.ProductionWorkers != nil {
@@ -446,6 +453,8 @@ func (apicast *Apicast) buildApicastProductionEnv() []v1.EnvVar {
 		result = append(result, helper.EnvVarFromValue("OPENTELEMETRY_CONFIG", apicast.Options.ProductionOpentelemetry.ConfigFile))
 	}
 
+	result = append(result, apicast.Options.ProductionTuning.EnvVars()...)
+
 	return result
 }
 
@@ -616,12 +625,8 @@ func (apicast *Apicast) productionVolumes() []v1.Volume {
 
 	for _, customPolicy := range apicast.Options.ProductionCustomPolicies {
 		volumes = append(volumes, v1.Volume{
-			Name: customPolicy.VolumeName(),
-			VolumeSource: v1.VolumeSource{
-				Secret: &v1.SecretVolumeSource{
-					SecretName: customPolicy.Secret.Name,
-				},
-			},
+			Name:         customPolicy.VolumeName(),
+			VolumeSource: customPolicy.VolumeSource(),
 		})
 	}
 
@@ -684,12 +689,8 @@ func (apicast *Apicast) stagingVolumes() []v1.Volume {
 
 	for _, customPolicy := range apicast.Options.StagingCustomPolicies {
 		volumes = append(volumes, v1.Volume{
-			Name: customPolicy.VolumeName(),
-			VolumeSource: v1.VolumeSource{
-				Secret: &v1.SecretVolumeSource{
-					SecretName: customPolicy.Secret.Name,
-				},
-			},
+			Name:         customPolicy.VolumeName(),
+			VolumeSource: customPolicy.VolumeSource(),
 		})
 	}
 
@@ -785,6 +786,28 @@ func (apicast *Apicast) stagingDeploymentAnnotations() map[string]string {
 	return annotations
 }
 
+// watchedConfigMapAnnotations returns the hash of the custom policy ConfigMaps watched by 3scale,
+// so the deployment rolls out when their content changes
+func watchedConfigMapAnnotations(customPolicies []CustomPolicy) map[string]string {
+	annotations := map[string]string{}
+	for _, customPolicy := range customPolicies {
+		if !helper.IsConfigMapWatchedBy3scale(customPolicy.ConfigMap) {
+			continue
+		}
+
+		data := map[string][]byte{}
+		for key, value := range customPolicy.ConfigMap.Data {
+			data[key] = []byte(value)
+		}
+		for key, value := range customPolicy.ConfigMap.BinaryData {
+			data[key] = value
+		}
+		annotations[CustomPoliciesConfigMapHashAnnotationPrefix+customPolicy.ConfigMap.Name] = HashSecret(data)
+	}
+
+	return annotations
+}
+
 func (apicast *Apicast) productionContainerPorts() []v1.ContainerPort {
 	ports := []v1.ContainerPort{
 		v1.ContainerPort{ContainerPort: 8080, Protocol: v1.ProtocolTCP},
@@ -851,13 +874,17 @@ func (apicast *Apicast) stagingPodAnnotations(watchedSecretAnnotations map[strin
 	annotations := map[string]string{
 		"prometheus.io/scrape":         "true",
 		"prometheus.io/port":           "9421",
-		APIcastEnvironmentCMAnnotation: apicast.envConfigMapHash(),
+		APIcastEnvironmentCMAnnotation: apicast.envConfigMapHash(apicast.Options.StagingTuning),
 	}
 
 	for key, val := range watchedSecretAnnotations {
 		annotations[key] = val
 	}
 
+	for key, val := range watchedConfigMapAnnotations(apicast.Options.StagingCustomPolicies) {
+		annotations[key] = val
+	}
+
 	for key, val := range apicast.Options.StagingPodTemplateAnnotations {
 		annotations[key] = val
 	}
@@ -869,13 +896,17 @@ func (apicast *Apicast) productionPodAnnotations(watchedSecretAnnotations map[st
 	annotations := map[string]string{
 		"prometheus.io/scrape":         "true",
 		"prometheus.io/port":           "9421",
-		APIcastEnvironmentCMAnnotation: apicast.envConfigMapHash(),
+		APIcastEnvironmentCMAnnotation: apicast.envConfigMapHash(apicast.Options.ProductionTuning),
 	}
 
 	for key, val := range watchedSecretAnnotations {
 		annotations[key] = val
 	}
 
+	for key, val := range watchedConfigMapAnnotations(apicast.Options.ProductionCustomPolicies) {
+		annotations[key] = val
+	}
+
 	for key, val := range apicast.Options.ProductionPodTemplateAnnotations {
 		annotations[key] = val
 	}
@@ -914,12 +945,41 @@ func ApicastEnvVolumeNamesFromAnnotations(annotations map[string]string) []strin
 
 // APIcast environment hash
 // When any of the fields used to compute the hash change, the hash will change and the apicast deployment will rollout
-func (apicast *Apicast) envConfigMapHash() string {
+func (apicast *Apicast) envConfigMapHash(tuning APIcastTuningOptions) string {
 
 	h := fnv.New32a()
 	h.Write([]byte(apicast.Options.ManagementAPI))
 	h.Write([]byte(apicast.Options.OpenSSLVerify))
 	h.Write([]byte(apicast.Options.ResponseCodes))
+	// Only the tuning values set in the APIManager, so the hash does not change when none is set
+	envVars := tuning.EnvVars()
+	if tuning.ConfigurationLoader != nil {
+		envVars = append(envVars, helper.EnvVarFromValue("APICAST_CONFIGURATION_LOADER", *tuning.ConfigurationLoader))
+	}
+	if tuning.ConfigurationCache != nil {
+		envVars = append(envVars, helper.EnvVarFromValue("APICAST_CONFIGURATION_CACHE", *tuning.ConfigurationCache))
+	}
+	for _, envVar := range envVars {
+		h.Write([]byte(envVar.Name + "=" + envVar.Value))
+	}
 	val := h.Sum32()
 	return fmt.Sprint(val)
 }
+
+func valueOrDefault(value *string, def string) string {
+	if value == nil {
+		return def
+	}
+	return *value
+}
+
+// customPolicyInitContainers returns the containers copying the image sourced custom policies to their volumes
+func customPolicyInitContainers(customPolicies []CustomPolicy) []v1.Container {
+	var containers []v1.Container
+	for _, customPolicy := range customPolicies {
+		if customPolicy.Image != "" {
+			containers = append(containers, customPolicy.InitContainer())
+		}
+	}
+	return containers
+}
@@ -11,10 +11,47 @@ import (
 	"github.com/3scale/3scale-operator/pkg/helper"
 )
 
+// CustomPolicy is loaded from one of Secret, ConfigMap or Image
 type CustomPolicy struct {
-	Name    string
-	Version string
-	Secret  *v1.Secret
+	Name      string
+	Version   string
+	Secret    *v1.Secret
+	ConfigMap *v1.ConfigMap
+	Image     string
+}
+
+// VolumeSource returns the source of the custom policy volume.
+// Image policies are copied to an empty dir volume by an init container
+func (c CustomPolicy) VolumeSource() v1.VolumeSource {
+	switch {
+	case c.ConfigMap != nil:
+		return v1.VolumeSource{
+			ConfigMap: &v1.ConfigMapVolumeSource{
+				LocalObjectReference: v1.LocalObjectReference{Name: c.ConfigMap.Name},
+			},
+		}
+	case c.Image != "":
+		return v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}
+	default:
+		return v1.VolumeSource{
+			Secret: &v1.SecretVolumeSource{
+				SecretName: c.Secret.Name,
+			},
+		}
+	}
+}
+
+// InitContainer returns the container copying the policy directory of the image to the policy volume.
+// It should only be used when c.Image is set
+func (c CustomPolicy) InitContainer() v1.Container {
+	return v1.Container{
+		Name:    c.VolumeName(),
+		Image:   c.Image,
+		Command: []string{"cp", "-R", CustomPolicyImagePath + "/.", CustomPolicyInitMountPath},
+		VolumeMounts: []v1.VolumeMount{
+			{Name: c.VolumeName(), MountPath: CustomPolicyInitMountPath},
+		},
+	}
 }
 
 func (c CustomPolicy) VolumeName() string {
@@ -64,6 +101,44 @@ func (c APIcastTracingConfig) AnnotationKey() string {
 	return fmt.Sprintf("%s-%x", APIcastTracingConfigAnnotationPartialKey, md5.Sum([]byte(c.VolumeName())))
 }
 
+// APIcastTuningOptions holds the APICAST_* environment values set in the APIManager.
+// Nil values are not rendered, leaving APIcast defaults
+type APIcastTuningOptions struct {
+	ConfigurationLoader     *string
+	ConfigurationCache      *string
+	PathRouting             *string
+	PathRoutingOnly         *string
+	CacheMaxTime            *string
+	CacheStatusCodes        *string
+	UpstreamRetryCases      *string
+	HTTPKeepaliveTimeout    *string
+	BatcherSharedMemorySize *string
+	AccessLogFile           *string
+}
+
+// EnvVars returns the tuning environment variables, sorted by name
+func (t APIcastTuningOptions) EnvVars() []v1.EnvVar {
+	result := []v1.EnvVar{}
+	for _, option := range []struct {
+		name  string
+		value *string
+	}{
+		{"APICAST_ACCESS_LOG_FILE", t.AccessLogFile},
+		{"APICAST_CACHE_MAX_TIME", t.CacheMaxTime},
+		{"APICAST_CACHE_STATUS_CODES", t.CacheStatusCodes},
+		{"APICAST_PATH_ROUTING", t.PathRouting},
+		{"APICAST_PATH_ROUTING_ONLY", t.PathRoutingOnly},
+		{"APICAST_POLICY_BATCHER_SHARED_MEMORY_SIZE", t.BatcherSharedMemorySize},
+		{"APICAST_UPSTREAM_RETRY_CASES", t.UpstreamRetryCases},
+		{"HTTP_KEEPALIVE_TIMEOUT", t.HTTPKeepaliveTimeout},
+	} {
+		if option.value != nil {
+			result = append(result, helper.EnvVarFromValue(option.name, *option.value))
+		}
+	}
+	return result
+}
+
 type ApicastOptions struct {
 	ManagementAPI                       string `validate:"required"`
 	OpenSSLVerify                       string `validate:"required"`
@@ -129,6 +204,9 @@ type ApicastOptions struct {
 
 	ProductionServiceCacheSize *int32
 	StagingServiceCacheSize    *int32
+
+	ProductionTuning APIcastTuningOptions `validate:"-"`
+	StagingTuning    APIcastTuningOptions `validate:"-"`
 }
 
 func NewApicastOptions() *ApicastOptions {
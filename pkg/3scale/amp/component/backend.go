@@ -117,6 +117,7 @@ func (backend *Backend) WorkerDeployment(containerImage string) *k8sappsv1.Deplo
 							Args:            []string{"bin/3scale_backend_worker", "run"},
 							Env:             backend.buildBackendWorkerEnv(),
 							Resources:       backend.Options.WorkerResourceRequirements,
+							VolumeMounts:    backend.Options.OpenTelemetry.VolumeMounts(),
 							ImagePullPolicy: v1.PullIfNotPresent,
 							Ports:           backend.workerPorts(),
 							LivenessProbe: &v1.Probe{
@@ -135,6 +136,7 @@ func (backend *Backend) WorkerDeployment(containerImage string) *k8sappsv1.Deplo
 							},
 						},
 					},
+					Volumes:                   backend.Options.OpenTelemetry.Volumes(),
 					ServiceAccountName:        "amp",
 					PriorityClassName:         backend.Options.PriorityClassNameWorker,
 					TopologySpreadConstraints: backend.Options.TopologySpreadConstraintsWorker,
@@ -200,6 +202,7 @@ func (backend *Backend) CronDeployment(containerImage string) *k8sappsv1.Deploym
 							Args:            []string{"touch /tmp/healthy && backend-cron"},
 							Env:             backend.buildBackendCronEnv(),
 							Resources:       backend.Options.CronResourceRequirements,
+							VolumeMounts:    backend.Options.OpenTelemetry.VolumeMounts(),
 							ImagePullPolicy: v1.PullIfNotPresent,
 							LivenessProbe: &v1.Probe{
 								ProbeHandler: v1.ProbeHandler{
@@ -212,6 +215,7 @@ func (backend *Backend) CronDeployment(containerImage string) *k8sappsv1.Deploym
 							},
 						},
 					},
+					Volumes:                   backend.Options.OpenTelemetry.Volumes(),
 					ServiceAccountName:        "amp",
 					PriorityClassName:         backend.Options.PriorityClassNameCron,
 					TopologySpreadConstraints: backend.Options.TopologySpreadConstraintsCron,
@@ -259,12 +263,13 @@ func (backend *Backend) ListenerDeployment(containerImage string) *k8sappsv1.Dep
 					Tolerations: backend.Options.ListenerTolerations,
 					Containers: []v1.Container{
 						{
-							Name:      BackendListenerName,
-							Image:     containerImage,
-							Args:      []string{"bin/3scale_backend", "start", "-e", "production", "-p", "3000", "-x", "/dev/stdout"},
-							Ports:     backend.listenerPorts(),
-							Env:       backend.buildBackendListenerEnv(),
-							Resources: backend.Options.ListenerResourceRequirements,
+							Name:         BackendListenerName,
+							Image:        containerImage,
+							Args:         []string{"bin/3scale_backend", "start", "-e", "production", "-p", "3000", "-x", "/dev/stdout"},
+							Ports:        backend.listenerPorts(),
+							Env:          backend.buildBackendListenerEnv(),
+							Resources:    backend.Options.ListenerResourceRequirements,
+							VolumeMounts: backend.Options.OpenTelemetry.VolumeMounts(),
 							LivenessProbe: &v1.Probe{
 								ProbeHandler: v1.ProbeHandler{TCPSocket: &v1.TCPSocketAction{
 									Port: intstr.IntOrString{
@@ -293,6 +298,7 @@ func (backend *Backend) ListenerDeployment(containerImage string) *k8sappsv1.Dep
 							ImagePullPolicy: v1.PullIfNotPresent,
 						},
 					},
+					Volumes:                   backend.Options.OpenTelemetry.Volumes(),
 					ServiceAccountName:        "amp",
 					PriorityClassName:         backend.Options.PriorityClassNameListener,
 					TopologySpreadConstraints: backend.Options.TopologySpreadConstraintsListener,
@@ -397,6 +403,7 @@ func (backend *Backend) buildBackendWorkerEnv() []v1.EnvVar {
 			v1.EnvVar{Name: "CONFIG_WORKER_PROMETHEUS_METRICS_ENABLED", Value: "true"},
 		)
 	}
+	result = append(result, backend.Options.OpenTelemetry.EnvVars(BackendOpenTelemetryEnabledEnvVarName, BackendWorkerName)...)
 
 	return result
 }
@@ -404,6 +411,7 @@ func (backend *Backend) buildBackendWorkerEnv() []v1.EnvVar {
 func (backend *Backend) buildBackendCronEnv() []v1.EnvVar {
 	result := []v1.EnvVar{}
 	result = append(result, backend.buildBackendCommonEnv()...)
+	result = append(result, backend.Options.OpenTelemetry.EnvVars(BackendOpenTelemetryEnabledEnvVarName, BackendCronName)...)
 	return result
 }
 
@@ -411,7 +419,7 @@ func (backend *Backend) buildBackendListenerEnv() []v1.EnvVar {
 	result := []v1.EnvVar{}
 	result = append(result, backend.buildBackendCommonEnv()...)
 	result = append(result,
-		helper.EnvVarFromValue("PUMA_WORKERS", "16"),
+		helper.EnvVarFromValue("PUMA_WORKERS", strconv.Itoa(int(backend.Options.ListenerWorkers))),
 		helper.EnvVarFromSecret("CONFIG_INTERNAL_API_USER", BackendSecretInternalApiSecretName, BackendSecretInternalApiUsernameFieldName),
 		helper.EnvVarFromSecret("CONFIG_INTERNAL_API_PASSWORD", BackendSecretInternalApiSecretName, BackendSecretInternalApiPasswordFieldName),
 	)
@@ -422,6 +430,7 @@ func (backend *Backend) buildBackendListenerEnv() []v1.EnvVar {
 			v1.EnvVar{Name: "CONFIG_LISTENER_PROMETHEUS_METRICS_ENABLED", Value: "true"},
 		)
 	}
+	result = append(result, backend.Options.OpenTelemetry.EnvVars(BackendOpenTelemetryEnabledEnvVarName, BackendListenerName)...)
 	return result
 }
 
@@ -17,6 +17,7 @@ type BackendOptions struct {
 	ListenerReplicas             int32
 	WorkerReplicas               int32
 	CronReplicas                 int32
+	ListenerWorkers              int32             `validate:"required"`
 	SystemBackendUsername        string            `validate:"required"`
 	SystemBackendPassword        string            `validate:"required"`
 	TenantName                   string            `validate:"required"`
@@ -49,6 +50,8 @@ type BackendOptions struct {
 	WorkerPodTemplateAnnotations   map[string]string `validate:"-"`
 	CronPodTemplateAnnotations     map[string]string `validate:"-"`
 
+	OpenTelemetry *OpenTelemetryOptions `validate:"-"`
+
 	// Used for monitoring objects
 	// Those objects are namespaced. However, objects includes labels, rules and expressions
 	// that need namespace filtering because they are "global" once imported
@@ -56,6 +59,8 @@ type BackendOptions struct {
 	Namespace string `validate:"required"`
 }
 
+const DefaultBackendListenerWorkers int32 = 16
+
 func NewBackendOptions() *BackendOptions {
 	return &BackendOptions{}
 }
@@ -0,0 +1,69 @@
+package component
+
+import (
+	"fmt"
+
+	"github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	CertificatesMonitoringName = "threescale-certificates"
+)
+
+// CertificatesPrometheusRules alerts on the certificate expiry metrics exposed by the operator.
+// The operator metrics are only visible to rules of the operator namespace, so the rule is shipped
+// next to the operator ServiceMonitor instead of being reconciled in the APIManager namespace
+func CertificatesPrometheusRules(appLabel string) *monitoringv1.PrometheusRule {
+	expiry := "threescale_operator_certificate_expiry_timestamp_seconds"
+	warningDays := "threescale_operator_certificate_expiry_warning_days"
+	return &monitoringv1.PrometheusRule{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       monitoringv1.PrometheusRuleKind,
+			APIVersion: fmt.Sprintf("%s/%s", monitoring.GroupName, monitoringv1.Version),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: CertificatesMonitoringName,
+			Labels: map[string]string{
+				"prometheus": "application-monitoring",
+				"role":       "alert-rules",
+				"app":        appLabel,
+			},
+		},
+		Spec: monitoringv1.PrometheusRuleSpec{
+			Groups: []monitoringv1.RuleGroup{
+				{
+					Name: "certificates.rules",
+					Rules: []monitoringv1.Rule{
+						{
+							Alert: "ThreescaleCertificateExpiring",
+							Annotations: map[string]string{
+								"summary":     "Certificate {{ $labels.certificate }} of {{ $labels.apimanager_namespace }}/{{ $labels.apimanager }} is about to expire",
+								"description": "Certificate {{ $labels.certificate }} of APIManager {{ $labels.apimanager_namespace }}/{{ $labels.apimanager }} expires in {{ printf \"%.0f\" $value }} days, within the APIManager expiry warning days",
+							},
+							Expr: intstr.FromString(fmt.Sprintf(`(%s - time()) / 86400 < on(apimanager_namespace, apimanager) group_left() %s`, expiry, warningDays)),
+							For:  "10m",
+							Labels: map[string]string{
+								"severity": "warning",
+							},
+						},
+						{
+							Alert: "ThreescaleCertificateExpired",
+							Annotations: map[string]string{
+								"summary":     "Certificate {{ $labels.certificate }} of {{ $labels.apimanager_namespace }}/{{ $labels.apimanager }} has expired",
+								"description": "Certificate {{ $labels.certificate }} of APIManager {{ $labels.apimanager_namespace }}/{{ $labels.apimanager }} has expired, TLS connections using it fail",
+							},
+							Expr: intstr.FromString(fmt.Sprintf(`%s - time() < 0`, expiry)),
+							For:  "1m",
+							Labels: map[string]string{
+								"severity": "critical",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
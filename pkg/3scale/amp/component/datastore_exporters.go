@@ -0,0 +1,239 @@
+package component
+
+import (
+	"fmt"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+)
+
+const (
+	BackendRedisStorageExporterName = "backend-redis-storage-exporter"
+	BackendRedisQueuesExporterName  = "backend-redis-queues-exporter"
+	SystemRedisExporterName         = "system-redis-exporter"
+	SystemDatabaseExporterName      = "system-database-exporter"
+	ZyncDatabaseExporterName        = "zync-database-exporter"
+
+	// ZyncDatabaseExporterContainerName is the exporter sidecar of the internal zync-database
+	ZyncDatabaseExporterContainerName = "postgres-exporter"
+
+	DatastoreExportersSecretName    = "threescale-datastore-exporters"
+	DatastoreExporterHashAnnotation = "apps.3scale.net/datastore-exporter-hash"
+
+	RedisExporterMetricsPort      = 9121
+	MySQLExporterMetricsPort      = 9104
+	PostgreSQLExporterMetricsPort = 9187
+
+	mysqlExporterPasswordEnvName = "MYSQLD_EXPORTER_PASSWORD"
+)
+
+// DatastoreExporterNames are the names of all the standalone exporters
+var DatastoreExporterNames = []string{
+	BackendRedisStorageExporterName,
+	BackendRedisQueuesExporterName,
+	SystemRedisExporterName,
+	SystemDatabaseExporterName,
+	ZyncDatabaseExporterName,
+}
+
+type DatastoreExporters struct {
+	Options *DatastoreExportersOptions
+}
+
+func NewDatastoreExporters(options *DatastoreExportersOptions) *DatastoreExporters {
+	return &DatastoreExporters{Options: options}
+}
+
+// Secret holds the connection settings of every exporter, keyed by exporter name,
+// read from the secrets of the datastores
+func (d *DatastoreExporters) Secret() *v1.Secret {
+	return &v1.Secret{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   DatastoreExportersSecretName,
+			Labels: d.labels(DatastoreExporterOptions{}),
+		},
+		StringData: d.secretData(),
+		Type:       v1.SecretTypeOpaque,
+	}
+}
+
+func (d *DatastoreExporters) secretData() map[string]string {
+	data := map[string]string{}
+	for _, exporter := range d.Options.Exporters {
+		data[exporter.Name] = exporter.ConnectionString
+	}
+	return data
+}
+
+// Deployment runs the exporter of a datastore not managed by the operator
+func (d *DatastoreExporters) Deployment(exporter DatastoreExporterOptions, containerImage string) *k8sappsv1.Deployment {
+	var replicas int32 = 1
+
+	return &k8sappsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{APIVersion: reconcilers.DeploymentAPIVersion, Kind: reconcilers.DeploymentKind},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   exporter.Name,
+			Labels: d.labels(exporter),
+		},
+		Spec: k8sappsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					reconcilers.DeploymentLabelSelector: exporter.Name,
+				},
+			},
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: d.podTemplateLabels(exporter),
+					Annotations: map[string]string{
+						DatastoreExporterHashAnnotation: HashSecret(map[string][]byte{exporter.Name: []byte(exporter.ConnectionString)}),
+					},
+				},
+				Spec: v1.PodSpec{
+					ServiceAccountName: "amp", //TODO make this configurable via flag
+					Containers:         []v1.Container{d.container(exporter, containerImage)},
+				},
+			},
+		},
+	}
+}
+
+func (d *DatastoreExporters) container(exporter DatastoreExporterOptions, containerImage string) v1.Container {
+	container := v1.Container{
+		Name:            exporter.Name,
+		Image:           containerImage,
+		Resources:       d.Options.ContainerResourceRequirements,
+		ImagePullPolicy: v1.PullIfNotPresent,
+	}
+
+	var port int32
+	switch exporter.Type {
+	case DatastoreExporterTypeRedis:
+		port = RedisExporterMetricsPort
+		container.Env = []v1.EnvVar{
+			helper.EnvVarFromSecret("REDIS_ADDR", DatastoreExportersSecretName, exporter.Name),
+		}
+	case DatastoreExporterTypeMySQL:
+		port = MySQLExporterMetricsPort
+		container.Args = []string{
+			fmt.Sprintf("--mysqld.address=%s", exporter.MySQLAddress),
+			fmt.Sprintf("--mysqld.username=%s", exporter.MySQLUser),
+		}
+		container.Env = []v1.EnvVar{
+			helper.EnvVarFromSecret(mysqlExporterPasswordEnvName, DatastoreExportersSecretName, exporter.Name),
+		}
+	case DatastoreExporterTypePostgreSQL:
+		port = PostgreSQLExporterMetricsPort
+		container.Env = []v1.EnvVar{
+			helper.EnvVarFromSecret("DATA_SOURCE_NAME", DatastoreExportersSecretName, exporter.Name),
+		}
+	}
+
+	container.Ports = []v1.ContainerPort{
+		{Name: "metrics", ContainerPort: port, Protocol: v1.ProtocolTCP},
+	}
+	container.ReadinessProbe = &v1.Probe{
+		ProbeHandler: v1.ProbeHandler{
+			TCPSocket: &v1.TCPSocketAction{Port: intstr.FromInt32(port)},
+		},
+		InitialDelaySeconds: 5,
+		PeriodSeconds:       10,
+	}
+
+	return container
+}
+
+func (d *DatastoreExporters) PodMonitor(exporter DatastoreExporterOptions) *monitoringv1.PodMonitor {
+	return &monitoringv1.PodMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   exporter.Name,
+			Labels: d.labels(exporter),
+		},
+		Spec: monitoringv1.PodMonitorSpec{
+			PodMetricsEndpoints: []monitoringv1.PodMetricsEndpoint{{
+				Port:   "metrics",
+				Path:   "/metrics",
+				Scheme: "http",
+			}},
+			Selector: metav1.LabelSelector{
+				MatchLabels: map[string]string{reconcilers.DeploymentLabelSelector: exporter.Name},
+			},
+		},
+	}
+}
+
+// ZyncDatabasePodMonitor scrapes the exporter sidecar of the internal zync-database
+func (d *DatastoreExporters) ZyncDatabasePodMonitor() *monitoringv1.PodMonitor {
+	return &monitoringv1.PodMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: ZyncDatabaseExporterName,
+			Labels: d.labels(DatastoreExporterOptions{
+				Datastore: ZyncDatabaseDeploymentName,
+				Type:      DatastoreExporterTypePostgreSQL,
+			}),
+		},
+		Spec: monitoringv1.PodMonitorSpec{
+			PodMetricsEndpoints: []monitoringv1.PodMetricsEndpoint{{
+				Port:   "metrics",
+				Path:   "/metrics",
+				Scheme: "http",
+			}},
+			Selector: metav1.LabelSelector{
+				MatchLabels: map[string]string{reconcilers.DeploymentLabelSelector: ZyncDatabaseDeploymentName},
+			},
+		},
+	}
+}
+
+func (d *DatastoreExporters) labels(exporter DatastoreExporterOptions) map[string]string {
+	labels := make(map[string]string)
+
+	for key, value := range d.Options.CommonLabels {
+		labels[key] = value
+	}
+
+	labels["threescale_component"] = "datastore-exporter"
+	if exporter.Datastore != "" {
+		labels["threescale_component_element"] = exporter.Datastore
+		labels["threescale_datastore_type"] = exporter.Type
+	}
+	return labels
+}
+
+func (d *DatastoreExporters) podTemplateLabels(exporter DatastoreExporterOptions) map[string]string {
+	labels := helper.MeteringLabels(exporter.Name, helper.ApplicationType)
+
+	for k, v := range d.labels(exporter) {
+		labels[k] = v
+	}
+
+	labels[reconcilers.DeploymentLabelSelector] = exporter.Name
+
+	return labels
+}
+
+// ZyncDatabaseExporterContainer is the postgres_exporter sidecar of the internal zync-database.
+// It connects through localhost with the zync database credentials
+func ZyncDatabaseExporterContainer(containerImage string, resources v1.ResourceRequirements) v1.Container {
+	return v1.Container{
+		Name:  ZyncDatabaseExporterContainerName,
+		Image: containerImage,
+		Env: []v1.EnvVar{
+			{Name: "DATA_SOURCE_URI", Value: "localhost:5432/zync_production?sslmode=disable"},
+			{Name: "DATA_SOURCE_USER", Value: "zync"},
+			helper.EnvVarFromSecret("DATA_SOURCE_PASS", ZyncSecretName, ZyncSecretDatabasePasswordFieldName),
+		},
+		Ports: []v1.ContainerPort{
+			{Name: "metrics", ContainerPort: PostgreSQLExporterMetricsPort, Protocol: v1.ProtocolTCP},
+		},
+		Resources:       resources,
+		ImagePullPolicy: v1.PullIfNotPresent,
+	}
+}
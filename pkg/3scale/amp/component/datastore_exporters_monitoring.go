@@ -0,0 +1,239 @@
+package component
+
+import (
+	"encoding/json"
+	"fmt"
+
+	grafanav1alpha1 "github.com/grafana-operator/grafana-operator/v4/api/integreatly/v1alpha1"
+	grafanav1beta1 "github.com/grafana-operator/grafana-operator/v5/api/v1beta1"
+	"github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/3scale/3scale-operator/pkg/common"
+)
+
+const (
+	DatastoresMonitoringName = "threescale-datastores"
+)
+
+func (d *DatastoreExporters) GrafanaV5Dashboard() *grafanav1beta1.GrafanaDashboard {
+	return &grafanav1beta1.GrafanaDashboard{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   DatastoresMonitoringName,
+			Labels: d.monitoringLabels(),
+		},
+		Spec: grafanav1beta1.GrafanaDashboardSpec{
+			InstanceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"apim-management": "grafana",
+				},
+			},
+			Json: d.grafanaDashboardJSON(),
+		},
+	}
+}
+
+func (d *DatastoreExporters) GrafanaV4Dashboard() *grafanav1alpha1.GrafanaDashboard {
+	return &grafanav1alpha1.GrafanaDashboard{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   DatastoresMonitoringName,
+			Labels: d.monitoringLabels(),
+		},
+		Spec: grafanav1alpha1.GrafanaDashboardSpec{
+			Json: d.grafanaDashboardJSON(),
+		},
+	}
+}
+
+type datastoreDashboardPanel struct {
+	title, unit string
+	exprs       []string
+}
+
+func (d *DatastoreExporters) grafanaDashboardJSON() string {
+	ns := d.Options.Namespace
+	sections := []struct {
+		title  string
+		panels []datastoreDashboardPanel
+	}{
+		{"Redis", []datastoreDashboardPanel{
+			{"Up", "none", []string{fmt.Sprintf(`redis_up{namespace="%s"}`, ns)}},
+			{"Memory used", "percent", []string{fmt.Sprintf(`100 * redis_memory_used_bytes{namespace="%[1]s"} / (redis_memory_max_bytes{namespace="%[1]s"} > 0)`, ns)}},
+			{"Connected clients", "none", []string{fmt.Sprintf(`redis_connected_clients{namespace="%s"}`, ns)}},
+			{"Commands", "ops", []string{fmt.Sprintf(`sum by (job) (rate(redis_commands_processed_total{namespace="%s"}[5m]))`, ns)}},
+			{"Rejected connections", "ops", []string{fmt.Sprintf(`rate(redis_rejected_connections_total{namespace="%s"}[5m])`, ns)}},
+			{"Connected replicas", "none", []string{fmt.Sprintf(`redis_connected_slaves{namespace="%s"}`, ns)}},
+		}},
+		{"MySQL", []datastoreDashboardPanel{
+			{"Up", "none", []string{fmt.Sprintf(`mysql_up{namespace="%s"}`, ns)}},
+			{"Connections", "percent", []string{fmt.Sprintf(`100 * mysql_global_status_threads_connected{namespace="%[1]s"} / mysql_global_variables_max_connections{namespace="%[1]s"}`, ns)}},
+			{"Queries", "ops", []string{fmt.Sprintf(`rate(mysql_global_status_queries{namespace="%s"}[5m])`, ns)}},
+			{"Slow queries", "ops", []string{fmt.Sprintf(`rate(mysql_global_status_slow_queries{namespace="%s"}[5m])`, ns)}},
+			{"Replication lag", "s", []string{fmt.Sprintf(`mysql_slave_status_seconds_behind_master{namespace="%s"}`, ns)}},
+		}},
+		{"PostgreSQL", []datastoreDashboardPanel{
+			{"Up", "none", []string{fmt.Sprintf(`pg_up{namespace="%s"}`, ns)}},
+			{"Connections", "percent", []string{fmt.Sprintf(`100 * sum by (job) (pg_stat_activity_count{namespace="%[1]s"}) / on (job) max by (job) (pg_settings_max_connections{namespace="%[1]s"})`, ns)}},
+			{"Transactions", "ops", []string{
+				fmt.Sprintf(`sum by (job) (rate(pg_stat_database_xact_commit{namespace="%s"}[5m]))`, ns),
+				fmt.Sprintf(`sum by (job) (rate(pg_stat_database_xact_rollback{namespace="%s"}[5m]))`, ns),
+			}},
+			{"Longest transaction", "s", []string{fmt.Sprintf(`max by (job) (pg_stat_activity_max_tx_duration{namespace="%s"})`, ns)}},
+			{"Replication lag", "s", []string{fmt.Sprintf(`pg_replication_lag_seconds{namespace="%s"}`, ns)}},
+		}},
+	}
+
+	panels := []map[string]interface{}{}
+	id, y := 1, 0
+	for _, section := range sections {
+		panels = append(panels, map[string]interface{}{
+			"id":      id,
+			"type":    "row",
+			"title":   section.title,
+			"gridPos": map[string]int{"h": 1, "w": 24, "x": 0, "y": y},
+		})
+		id++
+		y++
+		for idx, panel := range section.panels {
+			targets := []map[string]string{}
+			for _, expr := range panel.exprs {
+				targets = append(targets, map[string]string{"expr": expr, "legendFormat": "{{job}}"})
+			}
+			panels = append(panels, map[string]interface{}{
+				"id":         id,
+				"type":       "timeseries",
+				"title":      panel.title,
+				"datasource": "${datasource}",
+				"gridPos":    map[string]int{"h": 8, "w": 8, "x": (idx % 3) * 8, "y": y + (idx/3)*8},
+				"fieldConfig": map[string]interface{}{
+					"defaults": map[string]string{"unit": panel.unit},
+				},
+				"targets": targets,
+			})
+			id++
+		}
+		y += ((len(section.panels) + 2) / 3) * 8
+	}
+
+	dashboard := map[string]interface{}{
+		"title":         fmt.Sprintf("%s / Datastores", ns),
+		"editable":      true,
+		"schemaVersion": 36,
+		"tags":          []string{"3scale", "datastores"},
+		"time":          map[string]string{"from": "now-6h", "to": "now"},
+		"refresh":       "1m",
+		"templating": map[string]interface{}{
+			"list": []map[string]string{
+				{"name": "datasource", "type": "datasource", "query": "prometheus", "label": "Data source"},
+			},
+		},
+		"panels": panels,
+	}
+
+	dashboardJSON, err := json.MarshalIndent(dashboard, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	return string(dashboardJSON)
+}
+
+func (d *DatastoreExporters) PrometheusRules() *monitoringv1.PrometheusRule {
+	ns := d.Options.Namespace
+	return &monitoringv1.PrometheusRule{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       monitoringv1.PrometheusRuleKind,
+			APIVersion: fmt.Sprintf("%s/%s", monitoring.GroupName, monitoringv1.Version),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   DatastoresMonitoringName,
+			Labels: d.prometheusRulesMonitoringLabels(),
+		},
+		Spec: monitoringv1.PrometheusRuleSpec{
+			Groups: []monitoringv1.RuleGroup{
+				{
+					Name: fmt.Sprintf("%s/datastores.rules", ns),
+					Rules: []monitoringv1.Rule{
+						datastoreAlert("ThreescaleRedisDown", "critical", "1m",
+							"Redis {{ $labels.job }} on {{ $labels.namespace }} is DOWN",
+							fmt.Sprintf(`redis_up{namespace="%s"} == 0`, ns)),
+						datastoreAlert("ThreescaleRedisMemoryHigh", "warning", "5m",
+							"Redis {{ $labels.job }} on {{ $labels.namespace }} uses more than 90% of its max memory",
+							fmt.Sprintf(`100 * redis_memory_used_bytes{namespace="%[1]s"} / (redis_memory_max_bytes{namespace="%[1]s"} > 0) > 90`, ns)),
+						datastoreAlert("ThreescaleRedisRejectedConnections", "warning", "1m",
+							"Redis {{ $labels.job }} on {{ $labels.namespace }} is rejecting connections",
+							fmt.Sprintf(`increase(redis_rejected_connections_total{namespace="%s"}[5m]) > 0`, ns)),
+						datastoreAlert("ThreescaleRedisReplicationBroken", "warning", "5m",
+							"Redis replica {{ $labels.job }} on {{ $labels.namespace }} lost the link with its master",
+							fmt.Sprintf(`redis_master_link_up{namespace="%s"} == 0`, ns)),
+						datastoreAlert("ThreescaleMySQLDown", "critical", "1m",
+							"MySQL {{ $labels.job }} on {{ $labels.namespace }} is DOWN",
+							fmt.Sprintf(`mysql_up{namespace="%s"} == 0`, ns)),
+						datastoreAlert("ThreescaleMySQLConnectionsHigh", "warning", "5m",
+							"MySQL {{ $labels.job }} on {{ $labels.namespace }} uses more than 80% of its max connections",
+							fmt.Sprintf(`100 * max_over_time(mysql_global_status_threads_connected{namespace="%[1]s"}[1m]) / mysql_global_variables_max_connections{namespace="%[1]s"} > 80`, ns)),
+						datastoreAlert("ThreescaleMySQLSlowQueries", "warning", "2m",
+							"MySQL {{ $labels.job }} on {{ $labels.namespace }} is running slow queries",
+							fmt.Sprintf(`increase(mysql_global_status_slow_queries{namespace="%s"}[1m]) > 0`, ns)),
+						datastoreAlert("ThreescaleMySQLReplicationNotRunning", "critical", "1m",
+							"MySQL replica {{ $labels.job }} on {{ $labels.namespace }} is not replicating",
+							fmt.Sprintf(`mysql_slave_status_slave_sql_running{namespace="%[1]s"} * mysql_slave_status_slave_io_running{namespace="%[1]s"} == 0`, ns)),
+						datastoreAlert("ThreescalePostgreSQLDown", "critical", "1m",
+							"PostgreSQL {{ $labels.job }} on {{ $labels.namespace }} is DOWN",
+							fmt.Sprintf(`pg_up{namespace="%s"} == 0`, ns)),
+						datastoreAlert("ThreescalePostgreSQLConnectionsHigh", "warning", "5m",
+							"PostgreSQL {{ $labels.job }} on {{ $labels.namespace }} uses more than 80% of its max connections",
+							fmt.Sprintf(`100 * sum by (namespace, job) (pg_stat_activity_count{namespace="%[1]s"}) / on (namespace, job) max by (namespace, job) (pg_settings_max_connections{namespace="%[1]s"}) > 80`, ns)),
+						datastoreAlert("ThreescalePostgreSQLReplicationLagHigh", "warning", "5m",
+							"PostgreSQL replica {{ $labels.job }} on {{ $labels.namespace }} is more than 30 seconds behind its primary",
+							fmt.Sprintf(`pg_replication_lag_seconds{namespace="%s"} > 30`, ns)),
+						datastoreAlert("ThreescalePostgreSQLLongRunningTransaction", "warning", "1m",
+							"PostgreSQL {{ $labels.job }} on {{ $labels.namespace }} has a transaction running for more than 5 minutes",
+							fmt.Sprintf(`max by (namespace, job) (pg_stat_activity_max_tx_duration{namespace="%s",state="active"}) > 300`, ns)),
+					},
+				},
+			},
+		},
+	}
+}
+
+func datastoreAlert(name, severity, duration, summary, expr string) monitoringv1.Rule {
+	return monitoringv1.Rule{
+		Alert: name,
+		Annotations: map[string]string{
+			"summary":     summary,
+			"description": summary,
+		},
+		Expr: intstr.FromString(expr),
+		For:  duration,
+		Labels: map[string]string{
+			"severity": severity,
+		},
+	}
+}
+
+func (d *DatastoreExporters) monitoringLabels() map[string]string {
+	labels := make(map[string]string)
+
+	for key, value := range d.Options.CommonLabels {
+		labels[key] = value
+	}
+
+	labels["threescale_component"] = "datastore-exporter"
+	labels["monitoring-key"] = common.MonitoringKey
+	return labels
+}
+
+func (d *DatastoreExporters) prometheusRulesMonitoringLabels() map[string]string {
+	labels := make(map[string]string)
+
+	for key, value := range d.Options.CommonLabels {
+		labels[key] = value
+	}
+
+	labels["threescale_component"] = "datastore-exporter"
+	labels["prometheus"] = "application-monitoring"
+	labels["role"] = "alert-rules"
+	return labels
+}
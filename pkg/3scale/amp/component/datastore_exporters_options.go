@@ -0,0 +1,68 @@
+package component
+
+import (
+	"github.com/go-playground/validator/v10"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	DatastoreExporterTypeRedis      = "redis"
+	DatastoreExporterTypeMySQL      = "mysql"
+	DatastoreExporterTypePostgreSQL = "postgresql"
+)
+
+// DatastoreExporterOptions describes the exporter of a single datastore
+type DatastoreExporterOptions struct {
+	// Name of the exporter Deployment and PodMonitor
+	Name string `validate:"required"`
+	// Datastore is the monitored datastore, i.e. backend-redis
+	Datastore string `validate:"required"`
+	Type      string `validate:"oneof=redis mysql postgresql"`
+
+	// ConnectionString is the connection URL of the redis and postgresql exporters,
+	// the password of the mysql exporter
+	ConnectionString string `validate:"required"`
+
+	// MySQL connection settings, parsed from the system-database secret URL
+	MySQLAddress string `validate:"-"`
+	MySQLUser    string `validate:"-"`
+}
+
+type DatastoreExportersOptions struct {
+	Exporters []DatastoreExporterOptions `validate:"dive"`
+	// ZyncDatabaseExporter is set when the exporter runs as a sidecar of the zync-database Deployment
+	ZyncDatabaseExporter bool
+
+	ContainerResourceRequirements v1.ResourceRequirements `validate:"-"`
+
+	CommonLabels map[string]string `validate:"required"`
+
+	// Used for monitoring objects
+	// Those objects are namespaced. However, objects includes labels, rules and expressions
+	// that need namespace filtering because they are "global" once imported
+	// to the prometheus or grafana services.
+	Namespace string `validate:"required"`
+}
+
+func NewDatastoreExportersOptions() *DatastoreExportersOptions {
+	return &DatastoreExportersOptions{}
+}
+
+func (d *DatastoreExportersOptions) Validate() error {
+	validate := validator.New()
+	return validate.Struct(d)
+}
+
+func DefaultDatastoreExporterContainerResourceRequirements() v1.ResourceRequirements {
+	return v1.ResourceRequirements{
+		Limits: v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse("100m"),
+			v1.ResourceMemory: resource.MustParse("64Mi"),
+		},
+		Requests: v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse("10m"),
+			v1.ResourceMemory: resource.MustParse("32Mi"),
+		},
+	}
+}
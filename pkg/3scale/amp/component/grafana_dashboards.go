@@ -0,0 +1,139 @@
+package component
+
+import (
+	"encoding/json"
+
+	grafanav1alpha1 "github.com/grafana-operator/grafana-operator/v4/api/integreatly/v1alpha1"
+	grafanav1beta1 "github.com/grafana-operator/grafana-operator/v5/api/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/3scale/3scale-operator/pkg/common"
+)
+
+const (
+	// GrafanaUserDashboardLabelKey identifies the user supplied dashboards
+	GrafanaUserDashboardLabelKey = "apps.3scale.net/grafana-dashboard"
+
+	// GrafanaDashboardDatasourceInputName is the datasource input of exported dashboards
+	GrafanaDashboardDatasourceInputName = "DS_PROMETHEUS"
+)
+
+// GrafanaDashboardPlacement places the dashboards in the Grafana instances.
+// Empty fields keep the defaults of the dashboards
+type GrafanaDashboardPlacement struct {
+	InstanceSelector map[string]string
+	Folder           string
+	Datasource       string
+}
+
+func (p *GrafanaDashboardPlacement) ApplyV5(dashboard *grafanav1beta1.GrafanaDashboard) {
+	if len(p.InstanceSelector) > 0 {
+		dashboard.Spec.InstanceSelector = &metav1.LabelSelector{MatchLabels: p.InstanceSelector}
+	}
+	if p.Folder != "" {
+		dashboard.Spec.FolderTitle = p.Folder
+	}
+	if p.Datasource != "" {
+		dashboard.Spec.Json = grafanaDashboardJSONWithDatasource(dashboard.Spec.Json, p.Datasource)
+		dashboard.Spec.Datasources = []grafanav1beta1.GrafanaDashboardDatasource{
+			{InputName: GrafanaDashboardDatasourceInputName, DatasourceName: p.Datasource},
+		}
+	}
+}
+
+func (p *GrafanaDashboardPlacement) ApplyV4(dashboard *grafanav1alpha1.GrafanaDashboard) {
+	// grafana-operator v4 instances select the dashboards by label
+	if len(p.InstanceSelector) > 0 {
+		labels := dashboard.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		for key, value := range p.InstanceSelector {
+			labels[key] = value
+		}
+		dashboard.SetLabels(labels)
+	}
+	if p.Folder != "" {
+		dashboard.Spec.CustomFolderName = p.Folder
+	}
+	if p.Datasource != "" {
+		dashboard.Spec.Json = grafanaDashboardJSONWithDatasource(dashboard.Spec.Json, p.Datasource)
+		dashboard.Spec.Datasources = []grafanav1alpha1.GrafanaDashboardDatasource{
+			{InputName: GrafanaDashboardDatasourceInputName, DatasourceName: p.Datasource},
+		}
+	}
+}
+
+// grafanaDashboardJSONWithDatasource selects the datasource in the datasource variable of the dashboard.
+// The dashboard is returned unchanged when it cannot be parsed
+func grafanaDashboardJSONWithDatasource(dashboardJSON, datasource string) string {
+	if dashboardJSON == "" {
+		return dashboardJSON
+	}
+
+	dashboard := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(dashboardJSON), &dashboard); err != nil {
+		return dashboardJSON
+	}
+
+	templating, ok := dashboard["templating"].(map[string]interface{})
+	if !ok {
+		return dashboardJSON
+	}
+	variables, ok := templating["list"].([]interface{})
+	if !ok {
+		return dashboardJSON
+	}
+
+	for _, variable := range variables {
+		variableMap, ok := variable.(map[string]interface{})
+		if !ok || variableMap["type"] != "datasource" {
+			continue
+		}
+		variableMap["current"] = map[string]interface{}{"text": datasource, "value": datasource}
+	}
+
+	result, err := json.MarshalIndent(dashboard, "", "  ")
+	if err != nil {
+		return dashboardJSON
+	}
+	return string(result)
+}
+
+func UserGrafanaV5Dashboard(name string, configMapRef v1.ConfigMapKeySelector, appLabel string) *grafanav1beta1.GrafanaDashboard {
+	return &grafanav1beta1.GrafanaDashboard{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: userGrafanaDashboardLabels(appLabel),
+		},
+		Spec: grafanav1beta1.GrafanaDashboardSpec{
+			InstanceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"apim-management": "grafana",
+				},
+			},
+			ConfigMapRef: &configMapRef,
+		},
+	}
+}
+
+func UserGrafanaV4Dashboard(name string, configMapRef v1.ConfigMapKeySelector, appLabel string) *grafanav1alpha1.GrafanaDashboard {
+	return &grafanav1alpha1.GrafanaDashboard{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: userGrafanaDashboardLabels(appLabel),
+		},
+		Spec: grafanav1alpha1.GrafanaDashboardSpec{
+			ConfigMapRef: &configMapRef,
+		},
+	}
+}
+
+func userGrafanaDashboardLabels(appLabel string) map[string]string {
+	return map[string]string{
+		"monitoring-key":             common.MonitoringKey,
+		"app":                        appLabel,
+		GrafanaUserDashboardLabelKey: "user",
+	}
+}
@@ -28,6 +28,30 @@ func ZyncPostgreSQLImageURL() string {
 	return "quay.io/sclorg/postgresql-13-c8s"
 }
 
+func SystemPgBouncerImageURL() string {
+	return "docker.io/edoburu/pgbouncer:v1.23.1-p3"
+}
+
+func SystemPgBouncerExporterImageURL() string {
+	return "quay.io/prometheuscommunity/pgbouncer-exporter:v0.9.0"
+}
+
+func RedisExporterImageURL() string {
+	return "quay.io/oliver006/redis_exporter:v1.62.0"
+}
+
+func MySQLExporterImageURL() string {
+	return "quay.io/prometheus/mysqld-exporter:v0.15.1"
+}
+
+func PostgreSQLExporterImageURL() string {
+	return "quay.io/prometheuscommunity/postgres-exporter:v0.15.0"
+}
+
+func SystemStorageMigrationImageURL() string {
+	return "docker.io/amazon/aws-cli:2.17.20"
+}
+
 func OCCLIImageURL() string {
 	return "quay.io/openshift/origin-cli:4.7"
 }
@@ -0,0 +1,240 @@
+package component
+
+import (
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	ApicastHTTPPort         = 8080
+	ApicastManagementPort   = 8090
+	ApicastMetricsPort      = 9421
+	BackendListenerPort     = 3000
+	SystemAppProviderPort   = 3000
+	SystemAppDeveloperPort  = 3001
+	SystemAppMasterPort     = 3002
+	SystemMemcachedPort     = 11211
+	SystemSearchdPort       = 9306
+	ZyncPort                = 8080
+	ZyncDatabasePort        = 5432
+	namespaceNameLabel      = "kubernetes.io/metadata.name"
+	NetworkPolicyAPIVersion = "networking.k8s.io/v1"
+	NetworkPolicyKind       = "NetworkPolicy"
+)
+
+// NetworkPolicies builds one ingress NetworkPolicy per 3scale component.
+// Only the flows documented between components, from the ingress
+// controller and from the monitoring stack are allowed. Redis and
+// system database are external, so only the zync database gets a policy.
+type NetworkPolicies struct {
+	Options *NetworkPoliciesOptions
+}
+
+func NewNetworkPolicies(options *NetworkPoliciesOptions) *NetworkPolicies {
+	return &NetworkPolicies{Options: options}
+}
+
+func (n *NetworkPolicies) ApicastStagingNetworkPolicy() *networkingv1.NetworkPolicy {
+	gatewayPorts := n.apicastGatewayPorts(n.Options.ApicastStagingHTTPSPort)
+
+	rules := []networkingv1.NetworkPolicyIngressRule{n.ingressControllerRule(gatewayPorts...)}
+	rules = append(rules, n.allowedNamespacesRules(gatewayPorts...)...)
+	// system-app reads the policies registry from the management API
+	rules = append(rules, podsRule(tcpPorts(ApicastManagementPort), SystemAppDeploymentName))
+	rules = append(rules, n.monitoringRule(ApicastMetricsPort))
+
+	return n.networkPolicy(ApicastStagingName, rules)
+}
+
+func (n *NetworkPolicies) ApicastProductionNetworkPolicy() *networkingv1.NetworkPolicy {
+	gatewayPorts := n.apicastGatewayPorts(n.Options.ApicastProductionHTTPSPort)
+
+	rules := []networkingv1.NetworkPolicyIngressRule{n.ingressControllerRule(gatewayPorts...)}
+	rules = append(rules, n.allowedNamespacesRules(gatewayPorts...)...)
+	rules = append(rules, n.monitoringRule(ApicastMetricsPort))
+
+	return n.networkPolicy(ApicastProductionName, rules)
+}
+
+func (n *NetworkPolicies) BackendListenerNetworkPolicy() *networkingv1.NetworkPolicy {
+	ports := tcpPorts(BackendListenerPort)
+
+	rules := []networkingv1.NetworkPolicyIngressRule{n.ingressControllerRule(ports...)}
+	rules = append(rules, n.allowedNamespacesRules(ports...)...)
+	// apicast reports traffic, system uses the backend internal API
+	rules = append(rules, podsRule(ports,
+		ApicastProductionName, ApicastStagingName, SystemAppDeploymentName, SystemSidekiqName))
+	rules = append(rules, n.monitoringRule(BackendListenerMetricsPort))
+
+	return n.networkPolicy(BackendListenerName, rules)
+}
+
+func (n *NetworkPolicies) BackendWorkerNetworkPolicy() *networkingv1.NetworkPolicy {
+	return n.networkPolicy(BackendWorkerName, []networkingv1.NetworkPolicyIngressRule{
+		n.monitoringRule(BackendWorkerMetricsPort),
+	})
+}
+
+// BackendCronNetworkPolicy denies all ingress traffic
+func (n *NetworkPolicies) BackendCronNetworkPolicy() *networkingv1.NetworkPolicy {
+	return n.networkPolicy(BackendCronName, []networkingv1.NetworkPolicyIngressRule{})
+}
+
+func (n *NetworkPolicies) SystemAppNetworkPolicy() *networkingv1.NetworkPolicy {
+	exposedPorts := tcpPorts(SystemAppProviderPort, SystemAppDeveloperPort, SystemAppMasterPort)
+
+	rules := []networkingv1.NetworkPolicyIngressRule{n.ingressControllerRule(exposedPorts...)}
+	rules = append(rules, n.allowedNamespacesRules(exposedPorts...)...)
+	// apicast fetches the proxy configuration, backend-worker sends the events hook,
+	// zync-que syncs routes and system-sidekiq waits on the master status endpoint
+	rules = append(rules, podsRule(tcpPorts(SystemAppProviderPort, SystemAppMasterPort),
+		ApicastProductionName, ApicastStagingName, BackendWorkerName, ZyncQueDeploymentName, SystemSidekiqName))
+	rules = append(rules, n.monitoringRule(
+		SystemAppMasterContainerPrometheusPort,
+		SystemAppProviderContainerPrometheusPort,
+		SystemAppDeveloperContainerPrometheusPort,
+	))
+
+	return n.networkPolicy(SystemAppDeploymentName, rules)
+}
+
+func (n *NetworkPolicies) SystemSidekiqNetworkPolicy() *networkingv1.NetworkPolicy {
+	return n.networkPolicy(SystemSidekiqName, []networkingv1.NetworkPolicyIngressRule{
+		n.monitoringRule(SystemSidekiqMetricsPort),
+	})
+}
+
+func (n *NetworkPolicies) SystemMemcachedNetworkPolicy() *networkingv1.NetworkPolicy {
+	return n.networkPolicy(SystemMemcachedDeploymentName, []networkingv1.NetworkPolicyIngressRule{
+		podsRule(tcpPorts(SystemMemcachedPort), SystemAppDeploymentName, SystemSidekiqName),
+	})
+}
+
+func (n *NetworkPolicies) SystemSearchdNetworkPolicy() *networkingv1.NetworkPolicy {
+	return n.networkPolicy(SystemSearchdDeploymentName, []networkingv1.NetworkPolicyIngressRule{
+		podsRule(tcpPorts(SystemSearchdPort), SystemAppDeploymentName, SystemSidekiqName),
+	})
+}
+
+func (n *NetworkPolicies) SystemPgBouncerNetworkPolicy() *networkingv1.NetworkPolicy {
+	return n.networkPolicy(SystemPgBouncerDeploymentName, []networkingv1.NetworkPolicyIngressRule{
+		podsRule(tcpPorts(SystemPgBouncerPort), SystemAppDeploymentName, SystemSidekiqName),
+		n.monitoringRule(SystemPgBouncerMetricsPort),
+	})
+}
+
+func (n *NetworkPolicies) ZyncNetworkPolicy() *networkingv1.NetworkPolicy {
+	return n.networkPolicy(ZyncName, []networkingv1.NetworkPolicyIngressRule{
+		podsRule(tcpPorts(ZyncPort), SystemAppDeploymentName, SystemSidekiqName),
+		n.monitoringRule(ZyncMetricsPort),
+	})
+}
+
+func (n *NetworkPolicies) ZyncQueNetworkPolicy() *networkingv1.NetworkPolicy {
+	return n.networkPolicy(ZyncQueDeploymentName, []networkingv1.NetworkPolicyIngressRule{
+		n.monitoringRule(ZyncQueMetricsPort),
+	})
+}
+
+func (n *NetworkPolicies) ZyncDatabaseNetworkPolicy() *networkingv1.NetworkPolicy {
+	rules := []networkingv1.NetworkPolicyIngressRule{
+		podsRule(tcpPorts(ZyncDatabasePort), ZyncName, ZyncQueDeploymentName),
+	}
+	if n.Options.ZyncDatabaseExporter {
+		rules = append(rules, n.monitoringRule(PostgreSQLExporterMetricsPort))
+	}
+
+	return n.networkPolicy(ZyncDatabaseDeploymentName, rules)
+}
+
+func (n *NetworkPolicies) networkPolicy(deploymentName string, rules []networkingv1.NetworkPolicyIngressRule) *networkingv1.NetworkPolicy {
+	return &networkingv1.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{APIVersion: NetworkPolicyAPIVersion, Kind: NetworkPolicyKind},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   deploymentName,
+			Labels: n.Options.CommonLabels,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{reconcilers.DeploymentLabelSelector: deploymentName},
+			},
+			Ingress:     rules,
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		},
+	}
+}
+
+func (n *NetworkPolicies) apicastGatewayPorts(httpsPort *int32) []networkingv1.NetworkPolicyPort {
+	if httpsPort != nil {
+		return tcpPorts(ApicastHTTPPort, *httpsPort)
+	}
+	return tcpPorts(ApicastHTTPPort)
+}
+
+func (n *NetworkPolicies) ingressControllerRule(ports ...networkingv1.NetworkPolicyPort) networkingv1.NetworkPolicyIngressRule {
+	selector := n.Options.IngressControllerNamespaceSelector
+	return networkingv1.NetworkPolicyIngressRule{
+		Ports: ports,
+		From:  []networkingv1.NetworkPolicyPeer{{NamespaceSelector: &selector}},
+	}
+}
+
+func (n *NetworkPolicies) monitoringRule(ports ...int32) networkingv1.NetworkPolicyIngressRule {
+	selector := n.Options.MonitoringNamespaceSelector
+	return networkingv1.NetworkPolicyIngressRule{
+		Ports: tcpPorts(ports...),
+		From:  []networkingv1.NetworkPolicyPeer{{NamespaceSelector: &selector}},
+	}
+}
+
+func (n *NetworkPolicies) allowedNamespacesRules(ports ...networkingv1.NetworkPolicyPort) []networkingv1.NetworkPolicyIngressRule {
+	if len(n.Options.AllowedNamespaces) == 0 {
+		return nil
+	}
+
+	return []networkingv1.NetworkPolicyIngressRule{
+		{
+			Ports: ports,
+			From: []networkingv1.NetworkPolicyPeer{
+				{
+					NamespaceSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{
+								Key:      namespaceNameLabel,
+								Operator: metav1.LabelSelectorOpIn,
+								Values:   n.Options.AllowedNamespaces,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func podsRule(ports []networkingv1.NetworkPolicyPort, deploymentNames ...string) networkingv1.NetworkPolicyIngressRule {
+	peers := make([]networkingv1.NetworkPolicyPeer, 0, len(deploymentNames))
+	for _, name := range deploymentNames {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{reconcilers.DeploymentLabelSelector: name},
+			},
+		})
+	}
+
+	return networkingv1.NetworkPolicyIngressRule{Ports: ports, From: peers}
+}
+
+func tcpPorts(ports ...int32) []networkingv1.NetworkPolicyPort {
+	result := make([]networkingv1.NetworkPolicyPort, 0, len(ports))
+	for _, port := range ports {
+		protocol := v1.ProtocolTCP
+		portValue := intstr.FromInt32(port)
+		result = append(result, networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &portValue})
+	}
+	return result
+}
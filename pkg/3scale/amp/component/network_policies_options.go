@@ -0,0 +1,41 @@
+package component
+
+import (
+	"github.com/go-playground/validator/v10"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type NetworkPoliciesOptions struct {
+	CommonLabels map[string]string `validate:"required"`
+
+	IngressControllerNamespaceSelector metav1.LabelSelector `validate:"-"`
+	MonitoringNamespaceSelector        metav1.LabelSelector `validate:"-"`
+	AllowedNamespaces                  []string             `validate:"-"`
+
+	ApicastProductionHTTPSPort *int32 `validate:"-"`
+	ApicastStagingHTTPSPort    *int32 `validate:"-"`
+
+	// ZyncDatabaseExporter is set when the zync-database Deployment runs the postgres exporter sidecar
+	ZyncDatabaseExporter bool `validate:"-"`
+}
+
+func NewNetworkPoliciesOptions() *NetworkPoliciesOptions {
+	return &NetworkPoliciesOptions{}
+}
+
+func (n *NetworkPoliciesOptions) Validate() error {
+	validate := validator.New()
+	return validate.Struct(n)
+}
+
+func DefaultIngressControllerNamespaceSelector() metav1.LabelSelector {
+	return metav1.LabelSelector{
+		MatchLabels: map[string]string{"network.openshift.io/policy-group": "ingress"},
+	}
+}
+
+func DefaultMonitoringNamespaceSelector() metav1.LabelSelector {
+	return metav1.LabelSelector{
+		MatchLabels: map[string]string{"network.openshift.io/policy-group": "monitoring"},
+	}
+}
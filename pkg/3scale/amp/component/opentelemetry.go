@@ -0,0 +1,141 @@
+package component
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/3scale/3scale-operator/pkg/helper"
+)
+
+const (
+	OpenTelemetryTLSVolumeName = "otel-tls"
+	OpenTelemetryTLSMountPath  = "/var/run/secrets/otel-tls"
+
+	OpenTelemetryTLSCAFieldName   = "ca.crt"
+	OpenTelemetryTLSCertFieldName = "tls.crt"
+	OpenTelemetryTLSKeyFieldName  = "tls.key"
+
+	OpenTelemetryDefaultSamplingRatio = "1"
+
+	// The instrumentation of each component is turned on by its own switch. Once on, the
+	// OpenTelemetry Ruby SDK of the component reads the exporter settings from the OTEL_* env vars
+	SystemOpenTelemetryEnabledEnvVarName  = "OPENTELEMETRY_ENABLED"
+	BackendOpenTelemetryEnabledEnvVarName = "CONFIG_OPENTELEMETRY_ENABLED"
+	ZyncOpenTelemetryEnabledEnvVarName    = "OPENTELEMETRY_ENABLED"
+)
+
+// OpenTelemetryEnvVarNames are the env vars rendered by the OpenTelemetry instrumentation
+var OpenTelemetryEnvVarNames = []string{
+	SystemOpenTelemetryEnabledEnvVarName,
+	BackendOpenTelemetryEnabledEnvVarName,
+	"OTEL_SERVICE_NAME",
+	"OTEL_TRACES_EXPORTER",
+	"OTEL_EXPORTER_OTLP_ENDPOINT",
+	"OTEL_TRACES_SAMPLER",
+	"OTEL_TRACES_SAMPLER_ARG",
+	"OTEL_RESOURCE_ATTRIBUTES",
+	"OTEL_EXPORTER_OTLP_CERTIFICATE",
+	"OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE",
+	"OTEL_EXPORTER_OTLP_CLIENT_KEY",
+}
+
+// OpenTelemetryOptions configures the OTLP exporter of the system, backend and zync components.
+// A nil value disables the instrumentation
+type OpenTelemetryOptions struct {
+	Endpoint           string
+	SamplingRatio      string
+	ResourceAttributes map[string]string
+	// TLSSecretName references the secret with the CA and, when ClientCertificate is set, the client certificate
+	TLSSecretName     *string
+	ClientCertificate bool
+}
+
+// EnvVars returns the env var turning on the instrumentation of the component and the
+// OpenTelemetry SDK env vars of the container exporting spans as serviceName
+func (o *OpenTelemetryOptions) EnvVars(enabledEnvVarName, serviceName string) []v1.EnvVar {
+	if o == nil {
+		return nil
+	}
+
+	samplingRatio := o.SamplingRatio
+	if samplingRatio == "" {
+		samplingRatio = OpenTelemetryDefaultSamplingRatio
+	}
+
+	result := []v1.EnvVar{
+		helper.EnvVarFromValue(enabledEnvVarName, "true"),
+		helper.EnvVarFromValue("OTEL_SERVICE_NAME", serviceName),
+		helper.EnvVarFromValue("OTEL_TRACES_EXPORTER", "otlp"),
+		helper.EnvVarFromValue("OTEL_EXPORTER_OTLP_ENDPOINT", o.Endpoint),
+		helper.EnvVarFromValue("OTEL_TRACES_SAMPLER", "parentbased_traceidratio"),
+		helper.EnvVarFromValue("OTEL_TRACES_SAMPLER_ARG", samplingRatio),
+	}
+
+	if len(o.ResourceAttributes) > 0 {
+		result = append(result, helper.EnvVarFromValue("OTEL_RESOURCE_ATTRIBUTES", o.resourceAttributes()))
+	}
+
+	if o.TLSSecretName != nil {
+		result = append(result, helper.EnvVarFromValue("OTEL_EXPORTER_OTLP_CERTIFICATE", path.Join(OpenTelemetryTLSMountPath, OpenTelemetryTLSCAFieldName)))
+		if o.ClientCertificate {
+			result = append(result,
+				helper.EnvVarFromValue("OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE", path.Join(OpenTelemetryTLSMountPath, OpenTelemetryTLSCertFieldName)),
+				helper.EnvVarFromValue("OTEL_EXPORTER_OTLP_CLIENT_KEY", path.Join(OpenTelemetryTLSMountPath, OpenTelemetryTLSKeyFieldName)),
+			)
+		}
+	}
+
+	return result
+}
+
+// resourceAttributes renders the attributes sorted by key, so the pod template is stable
+func (o *OpenTelemetryOptions) resourceAttributes() string {
+	keys := make([]string, 0, len(o.ResourceAttributes))
+	for key := range o.ResourceAttributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	attributes := make([]string, 0, len(keys))
+	for _, key := range keys {
+		attributes = append(attributes, fmt.Sprintf("%s=%s", key, o.ResourceAttributes[key]))
+	}
+	return strings.Join(attributes, ",")
+}
+
+// Volumes returns the volume with the TLS files, if any
+func (o *OpenTelemetryOptions) Volumes() []v1.Volume {
+	if o == nil || o.TLSSecretName == nil {
+		return nil
+	}
+
+	return []v1.Volume{
+		{
+			Name: OpenTelemetryTLSVolumeName,
+			VolumeSource: v1.VolumeSource{
+				Secret: &v1.SecretVolumeSource{
+					SecretName: *o.TLSSecretName,
+				},
+			},
+		},
+	}
+}
+
+// VolumeMounts returns the mount of the TLS files, if any
+func (o *OpenTelemetryOptions) VolumeMounts() []v1.VolumeMount {
+	if o == nil || o.TLSSecretName == nil {
+		return nil
+	}
+
+	return []v1.VolumeMount{
+		{
+			Name:      OpenTelemetryTLSVolumeName,
+			MountPath: OpenTelemetryTLSMountPath,
+			ReadOnly:  true,
+		},
+	}
+}
@@ -0,0 +1,146 @@
+package component
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// SizingProfile holds the default sizing of the 3scale components
+type SizingProfile struct {
+	// ResourcesPercentage scales the default resource requirements of every container.
+	// Zero removes the resource requirements
+	ResourcesPercentage int64
+	// Replicas of the Deployments, indexed by Deployment name. Deployments
+	// not listed run a single replica
+	Replicas map[string]int32
+	// HPA replicas bounds
+	HpaMinReplicas int32
+	HpaMaxReplicas int32
+	// APICAST_WORKERS of the apicast production gateway
+	ApicastProductionWorkers int32
+	// PUMA_WORKERS of the backend listener
+	BackendListenerWorkers int32
+	// PUMA_WORKERS of the system-app containers
+	SystemAppWorkers int32
+	// Sidekiq concurrency (RAILS_MAX_THREADS) of system-sidekiq
+	SystemSidekiqConcurrency int32
+}
+
+var sizingProfiles = map[string]*SizingProfile{
+	"evaluation": {
+		ResourcesPercentage:      0,
+		HpaMinReplicas:           1,
+		HpaMaxReplicas:           1,
+		ApicastProductionWorkers: 1,
+		BackendListenerWorkers:   2,
+		SystemAppWorkers:         1,
+		SystemSidekiqConcurrency: 5,
+	},
+	"small": {
+		ResourcesPercentage:      50,
+		HpaMinReplicas:           1,
+		HpaMaxReplicas:           3,
+		ApicastProductionWorkers: 1,
+		BackendListenerWorkers:   8,
+		SystemAppWorkers:         1,
+		SystemSidekiqConcurrency: 10,
+	},
+	"medium": {
+		ResourcesPercentage: 100,
+		Replicas: map[string]int32{
+			ApicastProductionName:   2,
+			BackendListenerName:     2,
+			BackendWorkerName:       2,
+			SystemAppDeploymentName: 2,
+			SystemSidekiqName:       2,
+		},
+		HpaMinReplicas:           2,
+		HpaMaxReplicas:           5,
+		ApicastProductionWorkers: 1,
+		BackendListenerWorkers:   DefaultBackendListenerWorkers,
+		SystemAppWorkers:         2,
+		SystemSidekiqConcurrency: DefaultSystemSidekiqConcurrency,
+	},
+	"large": {
+		ResourcesPercentage: 200,
+		Replicas: map[string]int32{
+			ApicastProductionName:   3,
+			BackendListenerName:     3,
+			BackendWorkerName:       3,
+			SystemAppDeploymentName: 3,
+			SystemSidekiqName:       2,
+			ZyncName:                2,
+			ZyncQueDeploymentName:   2,
+		},
+		HpaMinReplicas:           3,
+		HpaMaxReplicas:           10,
+		ApicastProductionWorkers: 2,
+		BackendListenerWorkers:   32,
+		SystemAppWorkers:         3,
+		SystemSidekiqConcurrency: DefaultSystemSidekiqConcurrency,
+	},
+	"xlarge": {
+		ResourcesPercentage: 400,
+		Replicas: map[string]int32{
+			ApicastProductionName:   5,
+			BackendListenerName:     5,
+			BackendWorkerName:       5,
+			SystemAppDeploymentName: 4,
+			SystemSidekiqName:       3,
+			ZyncName:                2,
+			ZyncQueDeploymentName:   2,
+		},
+		HpaMinReplicas:           5,
+		HpaMaxReplicas:           20,
+		ApicastProductionWorkers: 4,
+		BackendListenerWorkers:   64,
+		SystemAppWorkers:         4,
+		SystemSidekiqConcurrency: DefaultSystemSidekiqConcurrency,
+	},
+}
+
+// GetSizingProfile returns the sizing profile with the given name, nil when it does not exist
+func GetSizingProfile(name string) *SizingProfile {
+	return sizingProfiles[name]
+}
+
+// DeploymentReplicas returns the replicas of the given Deployment
+func (p *SizingProfile) DeploymentReplicas(deploymentName string) int32 {
+	if replicas, ok := p.Replicas[deploymentName]; ok {
+		return replicas
+	}
+	return 1
+}
+
+// ResourceRequirements scales the given default resource requirements
+func (p *SizingProfile) ResourceRequirements(defaults v1.ResourceRequirements) v1.ResourceRequirements {
+	if p.ResourcesPercentage == 0 {
+		return v1.ResourceRequirements{}
+	}
+
+	return v1.ResourceRequirements{
+		Limits:   p.scaleResourceList(defaults.Limits),
+		Requests: p.scaleResourceList(defaults.Requests),
+	}
+}
+
+func (p *SizingProfile) scaleResourceList(list v1.ResourceList) v1.ResourceList {
+	if list == nil {
+		return nil
+	}
+
+	result := v1.ResourceList{}
+	for name, quantity := range list {
+		if p.ResourcesPercentage == 100 {
+			result[name] = quantity.DeepCopy()
+			continue
+		}
+		if name == v1.ResourceCPU {
+			result[name] = *resource.NewMilliQuantity(quantity.MilliValue()*p.ResourcesPercentage/100, resource.DecimalSI)
+		} else {
+			result[name] = *resource.NewQuantity(quantity.Value()*p.ResourcesPercentage/100, quantity.Format)
+		}
+	}
+
+	return result
+}
@@ -93,6 +93,8 @@ const (
 	SystemSecretSystemSMTPAuthenticationFieldName    = "authentication"
 	SystemSecretSystemSMTPOpenSSLVerifyModeFieldName = "openssl.verify.mode"
 	SystemSecretSystemSMTPFromAddressFieldName       = "from_address"
+	SystemSecretSystemSMTPStartTLSFieldName          = "enable_starttls"
+	SystemSecretSystemSMTPTLSFieldName               = "tls"
 )
 
 const (
@@ -123,6 +125,11 @@ const (
 	SystemAppDeveloperContainerMetricsPortName    = "dev-metrics"
 )
 
+const (
+	SystemAppWorkersEnvVarName        = "PUMA_WORKERS"
+	SystemSidekiqConcurrencyArgPrefix = "RAILS_MAX_THREADS="
+)
+
 const (
 	SystemDatabaseSecretResverAnnotationPrefix = "apimanager.apps.3scale.net/systemdatabase-secret-resource-version-"
 )
@@ -168,6 +175,14 @@ func (system *System) getSystemSMTPEnvsFromSMTPSecret() []v1.EnvVar {
 		helper.EnvVarFromSecret("SMTP_OPENSSL_VERIFY_MODE", SystemSecretSystemSMTPSecretName, SystemSecretSystemSMTPOpenSSLVerifyModeFieldName),
 	}
 
+	// Only set by the smtp spec
+	if system.Options.SmtpSecretOptions.StartTLS != nil {
+		result = append(result,
+			helper.EnvVarFromSecretOptional("SMTP_STARTTLS", SystemSecretSystemSMTPSecretName, SystemSecretSystemSMTPStartTLSFieldName),
+			helper.EnvVarFromSecretOptional("SMTP_TLS", SystemSecretSystemSMTPSecretName, SystemSecretSystemSMTPTLSFieldName),
+		)
+	}
+
 	if system.Options.SmtpSecretOptions.FromAddress != nil &&
 		*system.Options.SmtpSecretOptions.FromAddress != "" {
 		result = append(result, helper.EnvVarFromSecret("NOREPLY_EMAIL", SystemSecretSystemSMTPSecretName, SystemSecretSystemSMTPFromAddressFieldName))
@@ -254,25 +269,7 @@ func (system *System) buildSystemBaseEnv() []v1.EnvVar {
 	result = append(result, systemBackendInternalAPIUser, systemBackendInternalAPIPass)
 
 	if system.Options.S3FileStorageOptions != nil {
-		result = append(result,
-			helper.EnvVarFromSecret(apps.AwsBucket, system.Options.S3FileStorageOptions.ConfigurationSecretName, apps.AwsBucket),
-			helper.EnvVarFromSecret(apps.AwsRegion, system.Options.S3FileStorageOptions.ConfigurationSecretName, apps.AwsRegion),
-			helper.EnvVarFromSecretOptional(apps.AwsProtocol, system.Options.S3FileStorageOptions.ConfigurationSecretName, apps.AwsProtocol),
-			helper.EnvVarFromSecretOptional(apps.AwsHostname, system.Options.S3FileStorageOptions.ConfigurationSecretName, apps.AwsHostname),
-			helper.EnvVarFromSecretOptional(apps.AwsPathStyle, system.Options.S3FileStorageOptions.ConfigurationSecretName, apps.AwsPathStyle),
-		)
-
-		if system.Options.S3FileStorageOptions.STSEnabled {
-			result = append(result,
-				helper.EnvVarFromSecret(apps.AwsRoleArn, system.Options.S3FileStorageOptions.ConfigurationSecretName, apps.AwsRoleArn),
-				helper.EnvVarFromSecret(apps.AwsWebIdentityTokenFile, system.Options.S3FileStorageOptions.ConfigurationSecretName, apps.AwsWebIdentityTokenFile),
-			)
-		} else {
-			result = append(result,
-				helper.EnvVarFromSecret(apps.AwsAccessKeyID, system.Options.S3FileStorageOptions.ConfigurationSecretName, apps.AwsAccessKeyID),
-				helper.EnvVarFromSecret(apps.AwsSecretAccessKey, system.Options.S3FileStorageOptions.ConfigurationSecretName, apps.AwsSecretAccessKey),
-			)
-		}
+		result = append(result, s3FileStorageEnvVars(system.Options.S3FileStorageOptions)...)
 	}
 
 	return result
@@ -281,48 +278,71 @@ func (system *System) buildSystemBaseEnv() []v1.EnvVar {
 func (system *System) buildAppEnv() []v1.EnvVar {
 	result := []v1.EnvVar{}
 	result = append(result, helper.EnvVarFromSecret(SystemSecretSystemAppUserSessionTTLFieldName, SystemSecretSystemAppSecretName, SystemSecretSystemAppUserSessionTTLFieldName))
+	if system.Options.AppWorkers != nil {
+		result = append(result, helper.EnvVarFromValue(SystemAppWorkersEnvVarName, strconv.Itoa(int(*system.Options.AppWorkers))))
+	}
 	return result
 }
 
 func (system *System) buildAppMasterContainerEnv() []v1.EnvVar {
-	result := system.buildSystemBaseEnv()
+	result := system.connectionPoolerDatabaseURL(system.buildSystemBaseEnv())
 	if system.Options.AppMetrics {
 		result = append(result, helper.EnvVarFromValue(SystemAppPrometheusExporterPortEnvVarName, strconv.Itoa(SystemAppMasterContainerPrometheusPort)))
 	}
 	result = append(result, system.buildAppEnv()...)
+	result = append(result, system.Options.OpenTelemetry.EnvVars(SystemOpenTelemetryEnabledEnvVarName, "system-master")...)
 
 	return result
 }
 
 func (system *System) buildAppProviderContainerEnv() []v1.EnvVar {
-	result := system.buildSystemBaseEnv()
+	result := system.connectionPoolerDatabaseURL(system.buildSystemBaseEnv())
 	if system.Options.AppMetrics {
 		result = append(result, helper.EnvVarFromValue(SystemAppPrometheusExporterPortEnvVarName, strconv.Itoa(SystemAppProviderContainerPrometheusPort)))
 	}
 	result = append(result, system.buildAppEnv()...)
+	result = append(result, system.Options.OpenTelemetry.EnvVars(SystemOpenTelemetryEnabledEnvVarName, "system-provider")...)
 
 	return result
 }
 
 func (system *System) buildAppDeveloperContainerEnv() []v1.EnvVar {
-	result := system.buildSystemBaseEnv()
+	result := system.connectionPoolerDatabaseURL(system.buildSystemBaseEnv())
 	if system.Options.AppMetrics {
 		result = append(result, helper.EnvVarFromValue(SystemAppPrometheusExporterPortEnvVarName, strconv.Itoa(SystemAppDeveloperContainerPrometheusPort)))
 	}
 	result = append(result, system.buildAppEnv()...)
+	result = append(result, system.Options.OpenTelemetry.EnvVars(SystemOpenTelemetryEnabledEnvVarName, "system-developer")...)
 
 	return result
 }
 
 func (system *System) buildSystemSidekiqContainerEnv() []v1.EnvVar {
-	result := system.buildSystemBaseEnv()
+	result := system.connectionPoolerDatabaseURL(system.buildSystemBaseEnv())
 	if system.Options.SideKiqMetrics {
 		result = append(result, helper.EnvVarFromValue(SystemSidekiqPrometheusExporterPortEnvVarName, strconv.Itoa(SystemSidekiqMetricsPort)))
 	}
+	result = append(result, system.Options.OpenTelemetry.EnvVars(SystemOpenTelemetryEnabledEnvVarName, "system-sidekiq")...)
 
 	return result
 }
 
+// connectionPoolerDatabaseURL points DATABASE_URL to the PgBouncer pooler when enabled.
+// The app hook jobs keep the direct connection, migrations rely on session level locks
+func (system *System) connectionPoolerDatabaseURL(env []v1.EnvVar) []v1.EnvVar {
+	if !system.Options.SystemDatabaseConnectionPoolerEnabled {
+		return env
+	}
+
+	for idx := range env {
+		if env[idx].Name == "DATABASE_URL" {
+			env[idx] = helper.EnvVarFromSecret("DATABASE_URL", SystemPgBouncerSecretName, SystemPgBouncerSecretDatabaseURLFieldName)
+		}
+	}
+
+	return env
+}
+
 func (system *System) buildSystemAppPreHookEnv() []v1.EnvVar {
 	result := []v1.EnvVar{}
 	baseEnv := system.buildSystemBaseEnv()
@@ -568,25 +588,11 @@ func (system *System) appPodVolumes() []v1.Volume {
 	}
 
 	if system.Options.S3FileStorageOptions != nil && system.Options.S3FileStorageOptions.STSEnabled {
-		s3CredsProjectedVolume := v1.Volume{
-			Name: S3StsCredentialsSecretName,
-			VolumeSource: v1.VolumeSource{
-				Projected: &v1.ProjectedVolumeSource{
-					Sources: []v1.VolumeProjection{
-						v1.VolumeProjection{
-							ServiceAccountToken: &v1.ServiceAccountTokenProjection{
-								Audience:          system.Options.S3FileStorageOptions.STSAudience,
-								ExpirationSeconds: &[]int64{3600}[0],
-								Path:              system.Options.S3FileStorageOptions.STSTokenMountRelativePath,
-							},
-						},
-					},
-				},
-			},
-		}
-		res = append(res, s3CredsProjectedVolume)
+		res = append(res, s3CredentialsProjectedVolume(system.Options.S3FileStorageOptions))
 	}
 
+	res = append(res, system.Options.OpenTelemetry.Volumes()...)
+
 	return res
 }
 
@@ -960,24 +966,9 @@ func (system *System) SidekiqPodVolumes() []v1.Volume {
 		res = append(res, systemWritableTlsVolume)
 	}
 	if system.Options.S3FileStorageOptions != nil && system.Options.S3FileStorageOptions.STSEnabled {
-		s3CredsProjectedVolume := v1.Volume{
-			Name: S3StsCredentialsSecretName,
-			VolumeSource: v1.VolumeSource{
-				Projected: &v1.ProjectedVolumeSource{
-					Sources: []v1.VolumeProjection{
-						v1.VolumeProjection{
-							ServiceAccountToken: &v1.ServiceAccountTokenProjection{
-								Audience:          system.Options.S3FileStorageOptions.STSAudience,
-								ExpirationSeconds: &[]int64{3600}[0],
-								Path:              system.Options.S3FileStorageOptions.STSTokenMountRelativePath,
-							},
-						},
-					},
-				},
-			},
-		}
-		res = append(res, s3CredsProjectedVolume)
+		res = append(res, s3CredentialsProjectedVolume(system.Options.S3FileStorageOptions))
 	}
+	res = append(res, system.Options.OpenTelemetry.Volumes()...)
 	return res
 }
 
@@ -1028,7 +1019,7 @@ func (system *System) SidekiqDeployment(ctx context.Context, k8sclient client.Cl
 						{
 							Name:            SystemSidekiqName,
 							Image:           containerImage,
-							Args:            []string{"rake", "sidekiq:worker", "RAILS_MAX_THREADS=25"},
+							Args:            []string{"rake", "sidekiq:worker", SystemSidekiqConcurrencyArgPrefix + strconv.Itoa(int(system.Options.SidekiqConcurrency))},
 							Env:             system.buildSystemSidekiqContainerEnv(),
 							Resources:       *system.Options.SidekiqContainerResourceRequirements,
 							VolumeMounts:    system.sidekiqContainerVolumeMounts(),
@@ -1061,7 +1052,7 @@ func (system *System) systemStorageVolumeMount(readOnly bool) v1.VolumeMount {
 	return v1.VolumeMount{
 		Name:      SystemFileStoragePVCName,
 		ReadOnly:  readOnly,
-		MountPath: "/opt/system/public/system",
+		MountPath: systemStorageMountPath,
 	}
 }
 
@@ -1081,10 +1072,57 @@ func (system *System) systemTlsVolumeMount() v1.VolumeMount {
 }
 
 func (system *System) s3CredsProjectedVolumeMount() v1.VolumeMount {
+	return s3CredentialsProjectedVolumeMount(system.Options.S3FileStorageOptions)
+}
+
+func s3FileStorageEnvVars(options *S3FileStorageOptions) []v1.EnvVar {
+	result := []v1.EnvVar{
+		helper.EnvVarFromSecret(apps.AwsBucket, options.ConfigurationSecretName, apps.AwsBucket),
+		helper.EnvVarFromSecret(apps.AwsRegion, options.ConfigurationSecretName, apps.AwsRegion),
+		helper.EnvVarFromSecretOptional(apps.AwsProtocol, options.ConfigurationSecretName, apps.AwsProtocol),
+		helper.EnvVarFromSecretOptional(apps.AwsHostname, options.ConfigurationSecretName, apps.AwsHostname),
+		helper.EnvVarFromSecretOptional(apps.AwsPathStyle, options.ConfigurationSecretName, apps.AwsPathStyle),
+	}
+
+	if options.STSEnabled {
+		result = append(result,
+			helper.EnvVarFromSecret(apps.AwsRoleArn, options.ConfigurationSecretName, apps.AwsRoleArn),
+			helper.EnvVarFromSecret(apps.AwsWebIdentityTokenFile, options.ConfigurationSecretName, apps.AwsWebIdentityTokenFile),
+		)
+	} else {
+		result = append(result,
+			helper.EnvVarFromSecret(apps.AwsAccessKeyID, options.ConfigurationSecretName, apps.AwsAccessKeyID),
+			helper.EnvVarFromSecret(apps.AwsSecretAccessKey, options.ConfigurationSecretName, apps.AwsSecretAccessKey),
+		)
+	}
+
+	return result
+}
+
+func s3CredentialsProjectedVolume(options *S3FileStorageOptions) v1.Volume {
+	return v1.Volume{
+		Name: S3StsCredentialsSecretName,
+		VolumeSource: v1.VolumeSource{
+			Projected: &v1.ProjectedVolumeSource{
+				Sources: []v1.VolumeProjection{
+					v1.VolumeProjection{
+						ServiceAccountToken: &v1.ServiceAccountTokenProjection{
+							Audience:          options.STSAudience,
+							ExpirationSeconds: &[]int64{3600}[0],
+							Path:              options.STSTokenMountRelativePath,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func s3CredentialsProjectedVolumeMount(options *S3FileStorageOptions) v1.VolumeMount {
 	return v1.VolumeMount{
 		Name:      S3StsCredentialsSecretName,
 		ReadOnly:  true,
-		MountPath: system.Options.S3FileStorageOptions.STSTokenMountPath,
+		MountPath: options.STSTokenMountPath,
 	}
 }
 
@@ -1104,6 +1142,8 @@ func (system *System) appCommonContainerVolumeMounts(systemStorageReadonly bool)
 		res = append(res, system.systemTlsVolumeMount())
 	}
 
+	res = append(res, system.Options.OpenTelemetry.VolumeMounts()...)
+
 	return res
 }
 
@@ -1139,6 +1179,7 @@ func (system *System) sidekiqContainerVolumeMounts() []v1.VolumeMount {
 	if system.Options.S3FileStorageOptions != nil && system.Options.S3FileStorageOptions.STSEnabled {
 		res = append(res, system.s3CredsProjectedVolumeMount())
 	}
+	res = append(res, system.Options.OpenTelemetry.VolumeMounts()...)
 
 	return res
 }
@@ -1290,6 +1331,12 @@ func (system *System) SMTPSecret() *v1.Secret {
 		},
 	}
 
+	if system.Options.SmtpSecretOptions.StartTLS != nil {
+		res.StringData[SystemSecretSystemSMTPStartTLSFieldName] = *system.Options.SmtpSecretOptions.StartTLS
+	}
+	if system.Options.SmtpSecretOptions.TLS != nil {
+		res.StringData[SystemSecretSystemSMTPTLSFieldName] = *system.Options.SmtpSecretOptions.TLS
+	}
 	if system.Options.SmtpSecretOptions.FromAddress != nil {
 		res.StringData[SystemSecretSystemSMTPFromAddressFieldName] = *system.Options.SmtpSecretOptions.FromAddress
 	}
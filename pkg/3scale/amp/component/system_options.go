@@ -26,6 +26,9 @@ type SystemSMTPSecretOptions struct {
 	Port              *string `validate:"required"`
 	Username          *string `validate:"required"`
 	FromAddress       *string
+	// Set from the smtp spec only
+	StartTLS *string
+	TLS      *string
 }
 
 type PVCFileStorageOptions struct {
@@ -54,6 +57,10 @@ type SystemOptions struct {
 	AppReplicas     int32
 	SidekiqReplicas int32
 
+	// AppWorkers sets PUMA_WORKERS of the system-app containers, nil keeps the image default
+	AppWorkers         *int32
+	SidekiqConcurrency int32
+
 	AdminAccessToken    string  `validate:"required"`
 	AdminPassword       string  `validate:"required"`
 	AdminUsername       string  `validate:"required"`
@@ -90,6 +97,10 @@ type SystemOptions struct {
 	AppMetrics               bool
 	SystemDbTLSEnabled       bool
 
+	SystemDatabaseConnectionPoolerEnabled bool
+
+	OpenTelemetry *OpenTelemetryOptions `validate:"-"`
+
 	IncludeOracleOptionalSettings bool
 
 	ZyncEnabled bool
@@ -286,6 +297,8 @@ func DefaultSidekiqReplicas() *int32 {
 	return &defaultReplicas
 }
 
+const DefaultSystemSidekiqConcurrency int32 = 25
+
 func DefaultSharedStorageResources() resource.Quantity {
 	return resource.MustParse("100Mi")
 }
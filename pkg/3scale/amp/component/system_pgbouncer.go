@@ -0,0 +1,269 @@
+package component
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	SystemPgBouncerDeploymentName = "system-pgbouncer"
+	SystemPgBouncerServiceName    = "system-pgbouncer"
+	SystemPgBouncerSecretName     = "system-pgbouncer"
+	SystemPgBouncerPort           = 6432
+	SystemPgBouncerMetricsPort    = 9127
+
+	SystemPgBouncerSecretConfigFieldName           = "pgbouncer.ini"
+	SystemPgBouncerSecretUserListFieldName         = "userlist.txt"
+	SystemPgBouncerSecretDatabaseURLFieldName      = "DATABASE_URL"
+	SystemPgBouncerSecretExporterURLFieldName      = "EXPORTER_CONNECTION_STRING"
+	SystemPgBouncerConfigHashAnnotation            = "apps.3scale.net/pgbouncer-config-hash"
+	systemPgBouncerConfigVolumeName                = "pgbouncer-config"
+	systemPgBouncerConfigMountPath                 = "/etc/pgbouncer"
+	systemPgBouncerExporterConnectionStringEnvName = "PGBOUNCER_EXPORTER_CONNECTION_STRING"
+)
+
+type SystemPgBouncer struct {
+	Options *SystemPgBouncerOptions
+}
+
+func NewSystemPgBouncer(options *SystemPgBouncerOptions) *SystemPgBouncer {
+	return &SystemPgBouncer{Options: options}
+}
+
+// ConfigSecret holds the PgBouncer configuration, generated from the
+// system-database secret, and the DATABASE_URL the system components
+// use to connect through the pooler
+func (p *SystemPgBouncer) ConfigSecret() *v1.Secret {
+	return &v1.Secret{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   SystemPgBouncerSecretName,
+			Labels: p.Options.Labels,
+		},
+		StringData: p.configSecretData(),
+		Type:       v1.SecretTypeOpaque,
+	}
+}
+
+func (p *SystemPgBouncer) configSecretData() map[string]string {
+	return map[string]string{
+		SystemPgBouncerSecretConfigFieldName:      p.config(),
+		SystemPgBouncerSecretUserListFieldName:    p.userList(),
+		SystemPgBouncerSecretDatabaseURLFieldName: p.databaseURL(),
+		SystemPgBouncerSecretExporterURLFieldName: p.exporterConnectionString(),
+	}
+}
+
+func (p *SystemPgBouncer) config() string {
+	var sb strings.Builder
+	sb.WriteString("[databases]\n")
+	fmt.Fprintf(&sb, "%s = host=%s port=%s dbname=%s\n\n", p.Options.DatabaseName, p.Options.DatabaseHost, p.Options.DatabasePort, p.Options.DatabaseName)
+	sb.WriteString("[pgbouncer]\n")
+	sb.WriteString("listen_addr = 0.0.0.0\n")
+	fmt.Fprintf(&sb, "listen_port = %d\n", SystemPgBouncerPort)
+	sb.WriteString("auth_type = scram-sha-256\n")
+	fmt.Fprintf(&sb, "auth_file = %s/%s\n", systemPgBouncerConfigMountPath, SystemPgBouncerSecretUserListFieldName)
+	fmt.Fprintf(&sb, "stats_users = %s\n", p.Options.DatabaseUser)
+	fmt.Fprintf(&sb, "pool_mode = %s\n", p.Options.PoolMode)
+	fmt.Fprintf(&sb, "default_pool_size = %d\n", p.Options.DefaultPoolSize)
+	fmt.Fprintf(&sb, "max_client_conn = %d\n", p.Options.MaxClientConnections)
+	sb.WriteString("ignore_startup_parameters = extra_float_digits\n")
+	if p.Options.DatabaseSSLMode != "" {
+		fmt.Fprintf(&sb, "server_tls_sslmode = %s\n", p.Options.DatabaseSSLMode)
+	}
+	return sb.String()
+}
+
+func (p *SystemPgBouncer) userList() string {
+	quote := func(s string) string { return `"` + strings.ReplaceAll(s, `"`, `""`) + `"` }
+	return fmt.Sprintf("%s %s\n", quote(p.Options.DatabaseUser), quote(p.Options.DatabasePassword))
+}
+
+func (p *SystemPgBouncer) databaseURL() string {
+	u := url.URL{
+		Scheme:   "postgresql",
+		User:     url.UserPassword(p.Options.DatabaseUser, p.Options.DatabasePassword),
+		Host:     net.JoinHostPort(SystemPgBouncerServiceName, fmt.Sprint(SystemPgBouncerPort)),
+		Path:     "/" + p.Options.DatabaseName,
+		RawQuery: p.Options.DatabaseURLQuery,
+	}
+	return u.String()
+}
+
+func (p *SystemPgBouncer) exporterConnectionString() string {
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(p.Options.DatabaseUser, p.Options.DatabasePassword),
+		Host:     net.JoinHostPort("localhost", fmt.Sprint(SystemPgBouncerPort)),
+		Path:     "/pgbouncer",
+		RawQuery: "sslmode=disable",
+	}
+	return u.String()
+}
+
+func (p *SystemPgBouncer) Deployment(containerImage, exporterImage string) *k8sappsv1.Deployment {
+	annotations := map[string]string{
+		SystemPgBouncerConfigHashAnnotation: HashSecret(helper.GetSecretDataFromStringData(p.configSecretData())),
+	}
+	for k, v := range p.Options.PodTemplateAnnotations {
+		annotations[k] = v
+	}
+
+	return &k8sappsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{APIVersion: reconcilers.DeploymentAPIVersion, Kind: reconcilers.DeploymentKind},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   SystemPgBouncerDeploymentName,
+			Labels: p.Options.Labels,
+		},
+		Spec: k8sappsv1.DeploymentSpec{
+			Replicas: &p.Options.Replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					reconcilers.DeploymentLabelSelector: SystemPgBouncerDeploymentName,
+				},
+			},
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      p.Options.PodTemplateLabels,
+					Annotations: annotations,
+				},
+				Spec: v1.PodSpec{
+					Affinity:           p.Options.Affinity,
+					Tolerations:        p.Options.Tolerations,
+					ServiceAccountName: "amp", //TODO make this configurable via flag
+					Volumes: []v1.Volume{
+						{
+							Name: systemPgBouncerConfigVolumeName,
+							VolumeSource: v1.VolumeSource{
+								Secret: &v1.SecretVolumeSource{
+									SecretName: SystemPgBouncerSecretName,
+									Items: []v1.KeyToPath{
+										{Key: SystemPgBouncerSecretConfigFieldName, Path: SystemPgBouncerSecretConfigFieldName},
+										{Key: SystemPgBouncerSecretUserListFieldName, Path: SystemPgBouncerSecretUserListFieldName},
+									},
+								},
+							},
+						},
+					},
+					Containers: []v1.Container{
+						{
+							Name:    SystemPgBouncerDeploymentName,
+							Image:   containerImage,
+							Command: []string{"pgbouncer", fmt.Sprintf("%s/%s", systemPgBouncerConfigMountPath, SystemPgBouncerSecretConfigFieldName)},
+							Ports: []v1.ContainerPort{
+								{Name: "pgbouncer", ContainerPort: SystemPgBouncerPort, Protocol: v1.ProtocolTCP},
+							},
+							VolumeMounts: []v1.VolumeMount{
+								{Name: systemPgBouncerConfigVolumeName, MountPath: systemPgBouncerConfigMountPath, ReadOnly: true},
+							},
+							Resources: p.Options.ContainerResourceRequirements,
+							LivenessProbe: &v1.Probe{
+								ProbeHandler: v1.ProbeHandler{
+									TCPSocket: &v1.TCPSocketAction{Port: intstr.FromInt32(SystemPgBouncerPort)},
+								},
+								InitialDelaySeconds: 10,
+								PeriodSeconds:       10,
+							},
+							ReadinessProbe: &v1.Probe{
+								ProbeHandler: v1.ProbeHandler{
+									TCPSocket: &v1.TCPSocketAction{Port: intstr.FromInt32(SystemPgBouncerPort)},
+								},
+								InitialDelaySeconds: 5,
+								PeriodSeconds:       10,
+							},
+							ImagePullPolicy: v1.PullIfNotPresent,
+						},
+						{
+							Name:  "pgbouncer-exporter",
+							Image: exporterImage,
+							Args:  []string{fmt.Sprintf("--pgBouncer.connectionString=$(%s)", systemPgBouncerExporterConnectionStringEnvName)},
+							Env: []v1.EnvVar{
+								helper.EnvVarFromSecret(systemPgBouncerExporterConnectionStringEnvName, SystemPgBouncerSecretName, SystemPgBouncerSecretExporterURLFieldName),
+							},
+							Ports: []v1.ContainerPort{
+								{Name: "metrics", ContainerPort: SystemPgBouncerMetricsPort, Protocol: v1.ProtocolTCP},
+							},
+							ImagePullPolicy: v1.PullIfNotPresent,
+						},
+					},
+					PriorityClassName:         p.Options.PriorityClassName,
+					TopologySpreadConstraints: p.Options.TopologySpreadConstraints,
+				},
+			},
+		},
+	}
+}
+
+func (p *SystemPgBouncer) Service() *v1.Service {
+	return &v1.Service{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Service",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   SystemPgBouncerServiceName,
+			Labels: p.Options.Labels,
+		},
+		Spec: v1.ServiceSpec{
+			Ports: []v1.ServicePort{
+				{
+					Name:       "pgbouncer",
+					Protocol:   v1.ProtocolTCP,
+					Port:       SystemPgBouncerPort,
+					TargetPort: intstr.FromInt32(SystemPgBouncerPort),
+				},
+			},
+			Selector: map[string]string{reconcilers.DeploymentLabelSelector: SystemPgBouncerDeploymentName},
+		},
+	}
+}
+
+func (p *SystemPgBouncer) PodDisruptionBudget() *policyv1.PodDisruptionBudget {
+	return &policyv1.PodDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PodDisruptionBudget",
+			APIVersion: "policy/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   SystemPgBouncerDeploymentName,
+			Labels: p.Options.Labels,
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{reconcilers.DeploymentLabelSelector: SystemPgBouncerDeploymentName},
+			},
+			MaxUnavailable: &intstr.IntOrString{IntVal: PDB_MAX_UNAVAILABLE_POD_NUMBER},
+		},
+	}
+}
+
+func (p *SystemPgBouncer) PodMonitor() *monitoringv1.PodMonitor {
+	return &monitoringv1.PodMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   SystemPgBouncerDeploymentName,
+			Labels: p.Options.Labels,
+		},
+		Spec: monitoringv1.PodMonitorSpec{
+			PodMetricsEndpoints: []monitoringv1.PodMetricsEndpoint{{
+				Port:   "metrics",
+				Path:   "/metrics",
+				Scheme: "http",
+			}},
+			Selector: metav1.LabelSelector{
+				MatchLabels: map[string]string{reconcilers.DeploymentLabelSelector: SystemPgBouncerDeploymentName},
+			},
+		},
+	}
+}
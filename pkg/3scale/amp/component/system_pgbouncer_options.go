@@ -0,0 +1,71 @@
+package component
+
+import (
+	"github.com/go-playground/validator/v10"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+type SystemPgBouncerOptions struct {
+	ContainerResourceRequirements v1.ResourceRequirements `validate:"-"`
+
+	Replicas int32
+
+	Affinity    *v1.Affinity    `validate:"-"`
+	Tolerations []v1.Toleration `validate:"-"`
+
+	Labels            map[string]string `validate:"required"`
+	PodTemplateLabels map[string]string `validate:"required"`
+
+	PriorityClassName         string                        `validate:"-"`
+	TopologySpreadConstraints []v1.TopologySpreadConstraint `validate:"-"`
+	PodTemplateAnnotations    map[string]string             `validate:"-"`
+
+	PoolMode             string `validate:"oneof=session transaction"`
+	DefaultPoolSize      int32  `validate:"min=1"`
+	MaxClientConnections int32  `validate:"min=1"`
+
+	// Upstream database settings, parsed from the system-database secret URL
+	DatabaseHost     string `validate:"required"`
+	DatabasePort     string `validate:"required"`
+	DatabaseName     string `validate:"required"`
+	DatabaseUser     string `validate:"required"`
+	DatabasePassword string `validate:"required"`
+	DatabaseSSLMode  string `validate:"-"`
+	// URL query parameters kept in the DATABASE_URL pointing to the pooler
+	DatabaseURLQuery string `validate:"-"`
+}
+
+func NewSystemPgBouncerOptions() *SystemPgBouncerOptions {
+	return &SystemPgBouncerOptions{}
+}
+
+func (s *SystemPgBouncerOptions) Validate() error {
+	validate := validator.New()
+	return validate.Struct(s)
+}
+
+func DefaultSystemPgBouncerPoolMode() string {
+	return "session"
+}
+
+func DefaultSystemPgBouncerDefaultPoolSize() int32 {
+	return 20
+}
+
+func DefaultSystemPgBouncerMaxClientConnections() int32 {
+	return 1000
+}
+
+func DefaultSystemPgBouncerContainerResourceRequirements() v1.ResourceRequirements {
+	return v1.ResourceRequirements{
+		Limits: v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse("500m"),
+			v1.ResourceMemory: resource.MustParse("128Mi"),
+		},
+		Requests: v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse("50m"),
+			v1.ResourceMemory: resource.MustParse("32Mi"),
+		},
+	}
+}
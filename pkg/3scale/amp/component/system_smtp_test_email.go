@@ -0,0 +1,117 @@
+package component
+
+import (
+	"github.com/go-playground/validator/v10"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/3scale/3scale-operator/pkg/helper"
+)
+
+const (
+	SystemSMTPTestEmailJobName = "system-smtp-test-email"
+	// SystemSMTPTestEmailRecipientAnnotation tracks the recipient the Job sends the probe email to
+	SystemSMTPTestEmailRecipientAnnotation = "apps.3scale.net/smtp-test-email-recipient"
+)
+
+// systemSMTPTestEmailScript sends a probe email through the system-smtp configuration.
+// The domain is sent in the EHLO command and the authentication is mapped to the SASL mechanism
+const systemSMTPTestEmailScript = `set -e
+SCHEME=smtp
+if [ "${SMTP_TLS}" = "true" ]; then
+  SCHEME=smtps
+fi
+set -- --silent --show-error --url "${SCHEME}://${SMTP_ADDRESS}:${SMTP_PORT:-25}/${SMTP_DOMAIN}" --mail-from "${NOREPLY_EMAIL}" --mail-rcpt "${TEST_EMAIL_RECIPIENT}" --upload-file /tmp/probe.eml
+if [ "${SMTP_STARTTLS}" = "true" ]; then
+  set -- "$@" --ssl-reqd
+fi
+if [ -n "${SMTP_USER_NAME}" ]; then
+  set -- "$@" --user "${SMTP_USER_NAME}:${SMTP_PASSWORD}"
+fi
+case "${SMTP_AUTHENTICATION}" in
+  plain) set -- "$@" --login-options AUTH=PLAIN ;;
+  login) set -- "$@" --login-options AUTH=LOGIN ;;
+  cram_md5) set -- "$@" --login-options AUTH=CRAM-MD5 ;;
+esac
+if [ "${SMTP_OPENSSL_VERIFY_MODE}" = "none" ]; then
+  set -- "$@" --insecure
+fi
+printf 'From: %s\r\nTo: %s\r\nSubject: 3scale SMTP test email\r\n\r\nThis email was sent by the 3scale operator to verify the SMTP configuration.\r\n' \
+  "${NOREPLY_EMAIL}" "${TEST_EMAIL_RECIPIENT}" > /tmp/probe.eml
+curl "$@"
+echo "test email sent to ${TEST_EMAIL_RECIPIENT}"
+`
+
+type SystemSMTPTestEmailOptions struct {
+	Recipient string            `validate:"required,email"`
+	Labels    map[string]string `validate:"required"`
+}
+
+func NewSystemSMTPTestEmailOptions() *SystemSMTPTestEmailOptions {
+	return &SystemSMTPTestEmailOptions{}
+}
+
+func (s *SystemSMTPTestEmailOptions) Validate() error {
+	validate := validator.New()
+	return validate.Struct(s)
+}
+
+// SystemSMTPTestEmail sends a probe email through the system SMTP configuration
+type SystemSMTPTestEmail struct {
+	Options *SystemSMTPTestEmailOptions
+}
+
+func NewSystemSMTPTestEmail(options *SystemSMTPTestEmailOptions) *SystemSMTPTestEmail {
+	return &SystemSMTPTestEmail{Options: options}
+}
+
+func (t *SystemSMTPTestEmail) Job(containerImage string) *batchv1.Job {
+	env := []v1.EnvVar{
+		helper.EnvVarFromSecret("SMTP_ADDRESS", SystemSecretSystemSMTPSecretName, SystemSecretSystemSMTPAddressFieldName),
+		helper.EnvVarFromSecret("SMTP_PORT", SystemSecretSystemSMTPSecretName, SystemSecretSystemSMTPPortFieldName),
+		helper.EnvVarFromSecret("SMTP_USER_NAME", SystemSecretSystemSMTPSecretName, SystemSecretSystemSMTPUserNameFieldName),
+		helper.EnvVarFromSecret("SMTP_PASSWORD", SystemSecretSystemSMTPSecretName, SystemSecretSystemSMTPPasswordFieldName),
+		helper.EnvVarFromSecret("SMTP_DOMAIN", SystemSecretSystemSMTPSecretName, SystemSecretSystemSMTPDomainFieldName),
+		helper.EnvVarFromSecret("SMTP_AUTHENTICATION", SystemSecretSystemSMTPSecretName, SystemSecretSystemSMTPAuthenticationFieldName),
+		helper.EnvVarFromSecret("SMTP_OPENSSL_VERIFY_MODE", SystemSecretSystemSMTPSecretName, SystemSecretSystemSMTPOpenSSLVerifyModeFieldName),
+		helper.EnvVarFromSecretOptional("SMTP_STARTTLS", SystemSecretSystemSMTPSecretName, SystemSecretSystemSMTPStartTLSFieldName),
+		helper.EnvVarFromSecretOptional("SMTP_TLS", SystemSecretSystemSMTPSecretName, SystemSecretSystemSMTPTLSFieldName),
+		helper.EnvVarFromSecretOptional("NOREPLY_EMAIL", SystemSecretSystemSMTPSecretName, SystemSecretSystemSMTPFromAddressFieldName),
+		helper.EnvVarFromValue("TEST_EMAIL_RECIPIENT", t.Options.Recipient),
+	}
+
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   SystemSMTPTestEmailJobName,
+			Labels: t.Options.Labels,
+			Annotations: map[string]string{
+				SystemSMTPTestEmailRecipientAnnotation: t.Options.Recipient,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &[]int32{0}[0],
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: t.Options.Labels,
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name:    SystemSMTPTestEmailJobName,
+							Image:   containerImage,
+							Command: []string{"/bin/sh", "-c", systemSMTPTestEmailScript},
+							Env:     env,
+						},
+					},
+					RestartPolicy:      v1.RestartPolicyNever,
+					ServiceAccountName: "amp",
+				},
+			},
+		},
+	}
+}
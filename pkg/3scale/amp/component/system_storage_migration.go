@@ -0,0 +1,133 @@
+package component
+
+import (
+	"strconv"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	SystemStorageMigrationJobName      = "system-storage-migration"
+	SystemStorageMigrationFinalJobName = "system-storage-migration-final"
+	systemStorageMountPath             = "/opt/system/public/system"
+)
+
+// systemStorageMigrationScript copies the system-storage PVC contents into the
+// S3 bucket keeping the relative paths, then fails when any file of the PVC has
+// no object with the same key in the bucket. The final pass runs once System
+// writes to the bucket: it only copies the files missing in the bucket, so the
+// objects System wrote meanwhile are not overwritten
+const systemStorageMigrationScript = `set -e
+export AWS_CONFIG_FILE=/tmp/aws-config
+export AWS_DEFAULT_REGION="${AWS_REGION}"
+export LC_ALL=C
+ENDPOINT=""
+if [ -n "${AWS_HOSTNAME}" ]; then
+  ENDPOINT="--endpoint-url ${AWS_PROTOCOL:-https}://${AWS_HOSTNAME}"
+fi
+if [ "${AWS_PATH_STYLE}" = "true" ]; then
+  aws configure set default.s3.addressing_style path
+fi
+list_bucket_keys() {
+  aws ${ENDPOINT} s3api list-objects-v2 --bucket "${AWS_BUCKET}" --query 'Contents[].[Key]' --output text | grep -vx None | sort
+}
+cd ` + systemStorageMountPath + `
+find . -type f | sed 's|^\./||' | sort > /tmp/local-keys
+if [ "${FINAL_PASS}" = "true" ]; then
+  list_bucket_keys > /tmp/bucket-keys
+  comm -23 /tmp/local-keys /tmp/bucket-keys | while IFS= read -r key; do
+    aws ${ENDPOINT} s3 cp "./${key}" "s3://${AWS_BUCKET}/${key}" --only-show-errors
+  done
+else
+  aws ${ENDPOINT} s3 sync . "s3://${AWS_BUCKET}/" --only-show-errors
+fi
+list_bucket_keys > /tmp/bucket-keys
+comm -23 /tmp/local-keys /tmp/bucket-keys > /tmp/missing-keys
+echo "system-storage files: $(wc -l < /tmp/local-keys), missing in the bucket: $(wc -l < /tmp/missing-keys)"
+if [ -s /tmp/missing-keys ]; then
+  head -n 20 /tmp/missing-keys >&2
+  echo "verification failed: system-storage files missing in the bucket" >&2
+  exit 1
+fi
+`
+
+// SystemStorageMigration copies the system file storage from the
+// system-storage PVC to the S3 bucket when the file storage is switched
+type SystemStorageMigration struct {
+	Options *SystemStorageMigrationOptions
+}
+
+func NewSystemStorageMigration(options *SystemStorageMigrationOptions) *SystemStorageMigration {
+	return &SystemStorageMigration{Options: options}
+}
+
+// Job copies the system-storage PVC contents while System still uses the PVC
+func (m *SystemStorageMigration) Job(containerImage string) *batchv1.Job {
+	return m.job(SystemStorageMigrationJobName, containerImage, false)
+}
+
+// FinalJob copies the files System wrote to the PVC until it switched to S3
+func (m *SystemStorageMigration) FinalJob(containerImage string) *batchv1.Job {
+	return m.job(SystemStorageMigrationFinalJobName, containerImage, true)
+}
+
+func (m *SystemStorageMigration) job(name, containerImage string, finalPass bool) *batchv1.Job {
+	volumes := []v1.Volume{
+		{
+			Name: SystemFileStoragePVCName,
+			VolumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+					ClaimName: SystemFileStoragePVCName,
+					ReadOnly:  true,
+				},
+			},
+		},
+	}
+	volumeMounts := []v1.VolumeMount{
+		{
+			Name:      SystemFileStoragePVCName,
+			ReadOnly:  true,
+			MountPath: systemStorageMountPath,
+		},
+	}
+
+	if m.Options.S3FileStorageOptions.STSEnabled {
+		volumes = append(volumes, s3CredentialsProjectedVolume(m.Options.S3FileStorageOptions))
+		volumeMounts = append(volumeMounts, s3CredentialsProjectedVolumeMount(m.Options.S3FileStorageOptions))
+	}
+
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: m.Options.Labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &[]int32{3}[0],
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: m.Options.Labels,
+				},
+				Spec: v1.PodSpec{
+					Volumes: volumes,
+					Containers: []v1.Container{
+						{
+							Name:         SystemStorageMigrationJobName,
+							Image:        containerImage,
+							Command:      []string{"/bin/sh", "-c", systemStorageMigrationScript},
+							Env:          append(s3FileStorageEnvVars(m.Options.S3FileStorageOptions), v1.EnvVar{Name: "FINAL_PASS", Value: strconv.FormatBool(finalPass)}),
+							VolumeMounts: volumeMounts,
+						},
+					},
+					RestartPolicy:      v1.RestartPolicyNever,
+					ServiceAccountName: "amp",
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,19 @@
+package component
+
+import (
+	"github.com/go-playground/validator/v10"
+)
+
+type SystemStorageMigrationOptions struct {
+	S3FileStorageOptions *S3FileStorageOptions `validate:"required"`
+	Labels               map[string]string     `validate:"required"`
+}
+
+func NewSystemStorageMigrationOptions() *SystemStorageMigrationOptions {
+	return &SystemStorageMigrationOptions{}
+}
+
+func (s *SystemStorageMigrationOptions) Validate() error {
+	validate := validator.New()
+	return validate.Struct(s)
+}
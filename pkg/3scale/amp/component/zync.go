@@ -230,7 +230,7 @@ func (zync *Zync) Deployment(ctx context.Context, k8sclient client.Client, conta
 							Name:  ZyncName,
 							Image: containerImage,
 							Ports: zync.zyncPorts(),
-							Env:   zync.commonZyncEnvVars(),
+							Env:   append(zync.commonZyncEnvVars(), zync.Options.OpenTelemetry.EnvVars(ZyncOpenTelemetryEnabledEnvVarName, ZyncName)...),
 							LivenessProbe: &v1.Probe{
 								ProbeHandler: v1.ProbeHandler{
 									HTTPGet: &v1.HTTPGetAction{
@@ -408,7 +408,7 @@ func (zync *Zync) QueDeployment(ctx context.Context, k8sclient client.Client, co
 								},
 							},
 							Resources:    zync.Options.QueContainerResourceRequirements,
-							Env:          zync.commonZyncEnvVars(),
+							Env:          append(zync.commonZyncEnvVars(), zync.Options.OpenTelemetry.EnvVars(ZyncOpenTelemetryEnabledEnvVarName, ZyncQueDeploymentName)...),
 							VolumeMounts: zync.zyncVolumeMount(),
 						},
 					},
@@ -424,7 +424,7 @@ func (zync *Zync) QueDeployment(ctx context.Context, k8sclient client.Client, co
 func (zync *Zync) DatabaseDeployment(containerImage string) *k8sappsv1.Deployment {
 	var zyncDatabaseReplicas int32 = 1
 
-	return &k8sappsv1.Deployment{
+	deployment := &k8sappsv1.Deployment{
 		TypeMeta: metav1.TypeMeta{APIVersion: reconcilers.DeploymentAPIVersion, Kind: reconcilers.DeploymentKind},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:   ZyncDatabaseDeploymentName,
@@ -525,6 +525,13 @@ func (zync *Zync) DatabaseDeployment(containerImage string) *k8sappsv1.Deploymen
 			},
 		},
 	}
+
+	if zync.Options.DatabaseExporterImage != "" {
+		deployment.Spec.Template.Spec.Containers = append(deployment.Spec.Template.Spec.Containers,
+			ZyncDatabaseExporterContainer(zync.Options.DatabaseExporterImage, zync.Options.DatabaseExporterContainerResourceRequirements))
+	}
+
+	return deployment
 }
 
 func (zync *Zync) Service() *v1.Service {
@@ -721,20 +728,22 @@ func (zync *Zync) zyncInit(containerImage string) []v1.Container {
 }
 
 func (zync *Zync) zyncVolumeMount() []v1.VolumeMount {
+	res := []v1.VolumeMount{}
 	if zync.Options.ZyncDbTLSEnabled {
-		return []v1.VolumeMount{
-			{
-				Name:      "writable-tls", // Reuse the same volume in the main container if needed
-				MountPath: "/tls",
-				ReadOnly:  true,
-			},
-		}
-	} else {
-		return []v1.VolumeMount{}
+		res = append(res, v1.VolumeMount{
+			Name:      "writable-tls", // Reuse the same volume in the main container if needed
+			MountPath: "/tls",
+			ReadOnly:  true,
+		})
 	}
+	return append(res, zync.Options.OpenTelemetry.VolumeMounts()...)
 }
 
 func (zync *Zync) zyncVolume() []v1.Volume {
+	return append(zync.databaseTLSVolumes(), zync.Options.OpenTelemetry.Volumes()...)
+}
+
+func (zync *Zync) databaseTLSVolumes() []v1.Volume {
 	if zync.Options.ZyncDbTLSEnabled {
 		return []v1.Volume{
 			{
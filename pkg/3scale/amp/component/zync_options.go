@@ -27,6 +27,10 @@ type ZyncOptions struct {
 	DatabaseSslCert                       string
 	DatabaseSslKey                        string
 	ZyncDbTLSEnabled                      bool
+	OpenTelemetry                         *OpenTelemetryOptions `validate:"-"`
+	// DatabaseExporterImage adds the postgres_exporter sidecar to the zync-database when set
+	DatabaseExporterImage                         string                  `validate:"-"`
+	DatabaseExporterContainerResourceRequirements v1.ResourceRequirements `validate:"-"`
 
 	ZyncAffinity            *v1.Affinity    `validate:"-"`
 	ZyncTolerations         []v1.Toleration `validate:"-"`
@@ -57,6 +57,19 @@ func (a *AmpImagesOptionsProvider) GetAmpImagesOptions() (*component.AmpImagesOp
 		a.ampImagesOptions.SystemSearchdImage = *a.apimanager.Spec.System.SearchdSpec.Image
 	}
 
+	a.ampImagesOptions.SystemPgBouncerImage = SystemPgBouncerImageURL()
+	if a.apimanager.IsSystemDatabaseConnectionPoolerEnabled() && a.apimanager.Spec.System.DatabaseSpec.PostgreSQL.ConnectionPooler.Image != nil {
+		a.ampImagesOptions.SystemPgBouncerImage = *a.apimanager.Spec.System.DatabaseSpec.PostgreSQL.ConnectionPooler.Image
+	}
+
+	a.ampImagesOptions.SystemPgBouncerExporterImage = SystemPgBouncerExporterImageURL()
+
+	a.ampImagesOptions.RedisExporterImage = RedisExporterImageURL()
+	a.ampImagesOptions.MySQLExporterImage = MySQLExporterImageURL()
+	a.ampImagesOptions.PostgreSQLExporterImage = PostgreSQLExporterImageURL()
+
+	a.ampImagesOptions.SystemStorageMigrationImage = SystemStorageMigrationImageURL()
+
 	a.ampImagesOptions.ImagePullSecrets = component.AmpImagesDefaultImagePullSecrets()
 	if a.apimanager.Spec.ImagePullSecrets != nil {
 		a.ampImagesOptions.ImagePullSecrets = a.apimanager.Spec.ImagePullSecrets
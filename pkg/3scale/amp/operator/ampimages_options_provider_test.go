@@ -21,20 +21,27 @@ const (
 	zyncPostgresqlImage  = "postgresql-10:mytag"
 	systemMemcachedImage = "memcached:mytag"
 	systemSearchdImage   = "quay.io/3scale/searchd:mytag"
+	systemPgBouncerImage = "pgbouncer:mytag"
 )
 
 func defaultAmpImageOptions() *component.AmpImagesOptions {
 	return &component.AmpImagesOptions{
-		AppLabel:                    appLabel,
-		AmpRelease:                  version.ThreescaleVersionMajorMinor(),
-		ApicastImage:                ApicastImageURL(),
-		BackendImage:                BackendImageURL(),
-		SystemImage:                 SystemImageURL(),
-		ZyncImage:                   ZyncImageURL(),
-		ZyncDatabasePostgreSQLImage: component.ZyncPostgreSQLImageURL(),
-		SystemMemcachedImage:        SystemMemcachedImageURL(),
-		SystemSearchdImage:          SystemSearchdImageURL(),
-		ImagePullSecrets:            component.AmpImagesDefaultImagePullSecrets(),
+		AppLabel:                     appLabel,
+		AmpRelease:                   version.ThreescaleVersionMajorMinor(),
+		ApicastImage:                 ApicastImageURL(),
+		BackendImage:                 BackendImageURL(),
+		SystemImage:                  SystemImageURL(),
+		ZyncImage:                    ZyncImageURL(),
+		ZyncDatabasePostgreSQLImage:  component.ZyncPostgreSQLImageURL(),
+		SystemMemcachedImage:         SystemMemcachedImageURL(),
+		SystemSearchdImage:           SystemSearchdImageURL(),
+		SystemPgBouncerImage:         SystemPgBouncerImageURL(),
+		SystemPgBouncerExporterImage: SystemPgBouncerExporterImageURL(),
+		RedisExporterImage:           RedisExporterImageURL(),
+		MySQLExporterImage:           MySQLExporterImageURL(),
+		PostgreSQLExporterImage:      PostgreSQLExporterImageURL(),
+		SystemStorageMigrationImage:  SystemStorageMigrationImageURL(),
+		ImagePullSecrets:             component.AmpImagesDefaultImagePullSecrets(),
 	}
 }
 
@@ -53,6 +60,7 @@ func TestGetAmpImagesOptionsProvider(t *testing.T) {
 	tmpZyncPostgresqlImage := zyncPostgresqlImage
 	tmpSystemMemcachedImage := systemMemcachedImage
 	tmpSystemSearchdImage := systemSearchdImage
+	tmpSystemPgBouncerImage := systemPgBouncerImage
 
 	cases := []struct {
 		name                   string
@@ -153,6 +161,23 @@ func TestGetAmpImagesOptionsProvider(t *testing.T) {
 				return opts
 			},
 		},
+		{
+			"systemPgBouncerImage",
+			func() *appsv1alpha1.APIManager {
+				apimanager := basicApimanager()
+				apimanager.Spec.System.DatabaseSpec = &appsv1alpha1.SystemDatabaseSpec{
+					PostgreSQL: &appsv1alpha1.SystemPostgreSQLSpec{
+						ConnectionPooler: &appsv1alpha1.SystemDatabaseConnectionPoolerSpec{Enabled: true, Image: &tmpSystemPgBouncerImage},
+					},
+				}
+				return apimanager
+			},
+			func() *component.AmpImagesOptions {
+				opts := defaultAmpImageOptions()
+				opts.SystemPgBouncerImage = tmpSystemPgBouncerImage
+				return opts
+			},
+		},
 		{
 			"custom image pull secrets",
 			func() *appsv1alpha1.APIManager {
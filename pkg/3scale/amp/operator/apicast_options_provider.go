@@ -49,7 +49,7 @@ func (a *ApicastOptionsProvider) GetApicastOptions() (*component.ApicastOptions,
 	a.apicastOptions.StagingPodTemplateLabels = a.stagingPodTemplateLabels()
 	a.apicastOptions.ProductionPodTemplateLabels = a.productionPodTemplateLabels()
 	a.apicastOptions.Namespace = a.apimanager.Namespace
-	a.apicastOptions.ProductionWorkers = a.apimanager.Spec.Apicast.ProductionSpec.Workers
+	a.apicastOptions.ProductionWorkers = a.productionWorkers()
 	a.apicastOptions.ProductionLogLevel = a.apimanager.Spec.Apicast.ProductionSpec.LogLevel
 	a.apicastOptions.StagingLogLevel = a.apimanager.Spec.Apicast.StagingSpec.LogLevel
 
@@ -81,6 +81,8 @@ func (a *ApicastOptionsProvider) GetApicastOptions() (*component.ApicastOptions,
 	a.apicastOptions.ProductionServiceCacheSize = a.apimanager.Spec.Apicast.ProductionSpec.ServiceCacheSize
 	a.apicastOptions.StagingServiceCacheSize = a.apimanager.Spec.Apicast.StagingSpec.ServiceCacheSize
 
+	a.setTuningOptions()
+
 	a.setResourceRequirementsOptions()
 	a.setNodeAffinityAndTolerationsOptions()
 	a.setReplicas()
@@ -126,22 +128,68 @@ func (a *ApicastOptionsProvider) GetApicastOptions() (*component.ApicastOptions,
 	return a.apicastOptions, nil
 }
 
-func (a *ApicastOptionsProvider) setResourceRequirementsOptions() {
-	if *a.apimanager.Spec.ResourceRequirementsEnabled {
-		if a.apimanager.Spec.Apicast.ProductionSpec.Hpa {
-			a.apicastOptions.ProductionResourceRequirements = component.DefaultHPAProductionResourceRequirements()
-		} else {
-			a.apicastOptions.ProductionResourceRequirements = component.DefaultProductionResourceRequirements()
+func (a *ApicastOptionsProvider) setTuningOptions() {
+	productionSpec := a.apimanager.Spec.Apicast.ProductionSpec
+	a.apicastOptions.ProductionTuning = apicastTuningOptions(
+		productionSpec.ConfigurationLoadMode, productionSpec.CacheConfigurationSeconds,
+		productionSpec.PathRoutingEnabled, productionSpec.PathRoutingOnly,
+		productionSpec.CacheMaxTime, productionSpec.CacheStatusCodes, productionSpec.UpstreamRetryCases,
+		productionSpec.HTTPKeepaliveTimeout, productionSpec.BatcherSharedMemorySize, productionSpec.AccessLogFile,
+	)
+
+	stagingSpec := a.apimanager.Spec.Apicast.StagingSpec
+	a.apicastOptions.StagingTuning = apicastTuningOptions(
+		stagingSpec.ConfigurationLoadMode, stagingSpec.CacheConfigurationSeconds,
+		stagingSpec.PathRoutingEnabled, stagingSpec.PathRoutingOnly,
+		stagingSpec.CacheMaxTime, stagingSpec.CacheStatusCodes, stagingSpec.UpstreamRetryCases,
+		stagingSpec.HTTPKeepaliveTimeout, stagingSpec.BatcherSharedMemorySize, stagingSpec.AccessLogFile,
+	)
+}
+
+func apicastTuningOptions(loadMode *string, cacheSeconds *int64, pathRouting, pathRoutingOnly *bool,
+	cacheMaxTime, cacheStatusCodes, upstreamRetryCases *string, keepaliveTimeout *int32,
+	batcherSharedMemorySize, accessLogFile *string) component.APIcastTuningOptions {
+	boolValue := func(value *bool) *string {
+		if value == nil {
+			return nil
 		}
-		a.apicastOptions.StagingResourceRequirements = component.DefaultStagingResourceRequirements()
-	} else {
-		a.apicastOptions.ProductionResourceRequirements = v1.ResourceRequirements{}
-		a.apicastOptions.StagingResourceRequirements = v1.ResourceRequirements{}
+		result := strconv.FormatBool(*value)
+		return &result
+	}
+
+	tuning := component.APIcastTuningOptions{
+		ConfigurationLoader:     loadMode,
+		PathRouting:             boolValue(pathRouting),
+		PathRoutingOnly:         boolValue(pathRoutingOnly),
+		CacheMaxTime:            cacheMaxTime,
+		CacheStatusCodes:        cacheStatusCodes,
+		UpstreamRetryCases:      upstreamRetryCases,
+		BatcherSharedMemorySize: batcherSharedMemorySize,
+		AccessLogFile:           accessLogFile,
+	}
+	if cacheSeconds != nil {
+		tmp := strconv.FormatInt(*cacheSeconds, 10)
+		tuning.ConfigurationCache = &tmp
 	}
+	if keepaliveTimeout != nil {
+		tmp := strconv.Itoa(int(*keepaliveTimeout))
+		tuning.HTTPKeepaliveTimeout = &tmp
+	}
+
+	return tuning
+}
+
+func (a *ApicastOptionsProvider) setResourceRequirementsOptions() {
+	productionResourceRequirements := component.DefaultProductionResourceRequirements()
+	if a.apimanager.Spec.Apicast.ProductionSpec.Hpa {
+		productionResourceRequirements = component.DefaultHPAProductionResourceRequirements()
+	}
+	a.apicastOptions.ProductionResourceRequirements = profileResourceRequirements(a.apimanager, productionResourceRequirements)
+	a.apicastOptions.StagingResourceRequirements = profileResourceRequirements(a.apimanager, component.DefaultStagingResourceRequirements())
 
 	// Deployment-level ResourceRequirements CR fields have priority over
-	// spec.resourceRequirementsEnabled, overwriting that setting when they are
-	// defined
+	// spec.resourceRequirementsEnabled and spec.profile, overwriting those settings
+	// when they are defined
 	if a.apimanager.Spec.Apicast.ProductionSpec.Resources != nil {
 		a.apicastOptions.ProductionResourceRequirements = *a.apimanager.Spec.Apicast.ProductionSpec.Resources
 
@@ -161,19 +209,30 @@ func (a *ApicastOptionsProvider) setNodeAffinityAndTolerationsOptions() {
 }
 
 func (a *ApicastOptionsProvider) setReplicas() {
-	a.apicastOptions.ProductionReplicas = 1
+	a.apicastOptions.ProductionReplicas = profileReplicas(a.apimanager, component.ApicastProductionName)
 	if a.apimanager.Spec.Apicast.ProductionSpec.Replicas != nil {
 		if !a.apimanager.Spec.Apicast.ProductionSpec.Hpa {
 			a.apicastOptions.ProductionReplicas = int32(*a.apimanager.Spec.Apicast.ProductionSpec.Replicas)
 		}
 	}
 
-	a.apicastOptions.StagingReplicas = 1
+	a.apicastOptions.StagingReplicas = profileReplicas(a.apimanager, component.ApicastStagingName)
 	if a.apimanager.Spec.Apicast.StagingSpec.Replicas != nil {
 		a.apicastOptions.StagingReplicas = int32(*a.apimanager.Spec.Apicast.StagingSpec.Replicas)
 	}
 }
 
+func (a *ApicastOptionsProvider) productionWorkers() *int32 {
+	if a.apimanager.Spec.Apicast.ProductionSpec.Workers != nil {
+		return a.apimanager.Spec.Apicast.ProductionSpec.Workers
+	}
+	if profile := SizingProfile(a.apimanager); profile != nil {
+		workers := profile.ApicastProductionWorkers
+		return &workers
+	}
+	return nil
+}
+
 func (a *ApicastOptionsProvider) commonLabels() map[string]string {
 	return map[string]string{
 		"app":                  *a.apimanager.Spec.AppLabel,
@@ -227,14 +286,7 @@ func (a *ApicastOptionsProvider) productionPodTemplateLabels() map[string]string
 
 func (a *ApicastOptionsProvider) setCustomPolicies() error {
 	for idx, customPolicySpec := range a.apimanager.Spec.Apicast.ProductionSpec.CustomPolicies {
-		// CR Validation ensures secret name is not nil
-
-		namespacedName := types.NamespacedName{
-			Name:      customPolicySpec.SecretRef.Name, // CR Validation ensures not nil
-			Namespace: a.apimanager.Namespace,
-		}
-
-		secret, err := a.validateCustomPolicySecret(context.TODO(), customPolicySpec.SecretRef.Name, namespacedName)
+		customPolicy, err := a.customPolicy(customPolicySpec)
 		if err != nil {
 			fldErr := field.ErrorList{}
 			customPoliciesIdxFldPath := field.NewPath("spec").
@@ -245,23 +297,11 @@ func (a *ApicastOptionsProvider) setCustomPolicies() error {
 			return fldErr.ToAggregate()
 		}
 
-		a.apicastOptions.ProductionCustomPolicies = append(a.apicastOptions.ProductionCustomPolicies, component.CustomPolicy{
-			Name:    customPolicySpec.Name,
-			Version: customPolicySpec.Version,
-			Secret:  secret,
-		})
+		a.apicastOptions.ProductionCustomPolicies = append(a.apicastOptions.ProductionCustomPolicies, *customPolicy)
 	}
 
-	// TODO(eastizle): DRY!!
 	for idx, customPolicySpec := range a.apimanager.Spec.Apicast.StagingSpec.CustomPolicies {
-		// CR Validation ensures secret name is not nil
-
-		namespacedName := types.NamespacedName{
-			Name:      customPolicySpec.SecretRef.Name, // CR Validation ensures not nil
-			Namespace: a.apimanager.Namespace,
-		}
-
-		secret, err := a.validateCustomPolicySecret(context.TODO(), customPolicySpec.SecretRef.Name, namespacedName)
+		customPolicy, err := a.customPolicy(customPolicySpec)
 		if err != nil {
 			fldErr := field.ErrorList{}
 			customPoliciesIdxFldPath := field.NewPath("spec").
@@ -272,16 +312,49 @@ func (a *ApicastOptionsProvider) setCustomPolicies() error {
 			return fldErr.ToAggregate()
 		}
 
-		a.apicastOptions.StagingCustomPolicies = append(a.apicastOptions.StagingCustomPolicies, component.CustomPolicy{
-			Name:    customPolicySpec.Name,
-			Version: customPolicySpec.Version,
-			Secret:  secret,
-		})
+		a.apicastOptions.StagingCustomPolicies = append(a.apicastOptions.StagingCustomPolicies, *customPolicy)
 	}
 
 	return nil
 }
 
+// customPolicy builds the custom policy from its source. CR Validation ensures exactly one source is set
+func (a *ApicastOptionsProvider) customPolicy(customPolicySpec appsv1alpha1.CustomPolicySpec) (*component.CustomPolicy, error) {
+	customPolicy := &component.CustomPolicy{
+		Name:    customPolicySpec.Name,
+		Version: customPolicySpec.PolicyVersion(),
+	}
+
+	switch {
+	case customPolicySpec.SecretRef != nil:
+		namespacedName := types.NamespacedName{
+			Name:      customPolicySpec.SecretRef.Name,
+			Namespace: a.apimanager.Namespace,
+		}
+
+		secret, err := a.validateCustomPolicySecret(context.TODO(), customPolicySpec.SecretRef.Name, namespacedName)
+		if err != nil {
+			return nil, err
+		}
+		customPolicy.Secret = secret
+	case customPolicySpec.ConfigMapRef != nil:
+		namespacedName := types.NamespacedName{
+			Name:      customPolicySpec.ConfigMapRef.Name,
+			Namespace: a.apimanager.Namespace,
+		}
+
+		configMap, err := a.validateCustomPolicyConfigMap(context.TODO(), namespacedName)
+		if err != nil {
+			return nil, err
+		}
+		customPolicy.ConfigMap = configMap
+	case customPolicySpec.Image != nil:
+		customPolicy.Image = *customPolicySpec.Image
+	}
+
+	return customPolicy, nil
+}
+
 func (a *ApicastOptionsProvider) validateCustomPolicySecret(ctx context.Context, name string, nn types.NamespacedName) (*v1.Secret, error) {
 	secret := &v1.Secret{}
 	err := a.client.Get(ctx, nn, secret)
@@ -304,6 +377,23 @@ func (a *ApicastOptionsProvider) validateCustomPolicySecret(ctx context.Context,
 	return secret, nil
 }
 
+func (a *ApicastOptionsProvider) validateCustomPolicyConfigMap(ctx context.Context, nn types.NamespacedName) (*v1.ConfigMap, error) {
+	configMap := &v1.ConfigMap{}
+	err := a.client.Get(ctx, nn, configMap)
+	if err != nil {
+		// NotFoundError is also an error, it is required to exist
+		return nil, err
+	}
+
+	for _, key := range []string{"init.lua", "apicast-policy.json"} {
+		if _, ok := configMap.Data[key]; !ok {
+			return nil, fmt.Errorf("configmap %s is missing required key %s", nn.Name, key)
+		}
+	}
+
+	return configMap, nil
+}
+
 func (a *ApicastOptionsProvider) setTracingConfiguration() error {
 	err := a.setProductionTracingConfiguration()
 	if err != nil {
@@ -303,6 +303,32 @@ func TestGetApicastOptionsProvider(t *testing.T) {
 				return opts
 			},
 		},
+		{"WithTuning",
+			func() *appsv1alpha1.APIManager {
+				apimanager := basicApimanagerTestApicastOptions()
+				apimanager.Spec.Apicast.ProductionSpec.CacheConfigurationSeconds = &[]int64{60}[0]
+				apimanager.Spec.Apicast.ProductionSpec.PathRoutingEnabled = &[]bool{true}[0]
+				apimanager.Spec.Apicast.ProductionSpec.UpstreamRetryCases = &[]string{"error timeout"}[0]
+				apimanager.Spec.Apicast.ProductionSpec.HTTPKeepaliveTimeout = &[]int32{75}[0]
+				apimanager.Spec.Apicast.StagingSpec.ConfigurationLoadMode = &[]string{"boot"}[0]
+				apimanager.Spec.Apicast.StagingSpec.CacheMaxTime = &[]string{"1m"}[0]
+				return apimanager
+			},
+			func() *component.ApicastOptions {
+				opts := defaultApicastOptions()
+				opts.ProductionTuning = component.APIcastTuningOptions{
+					ConfigurationCache:   &[]string{"60"}[0],
+					PathRouting:          &[]string{"true"}[0],
+					UpstreamRetryCases:   &[]string{"error timeout"}[0],
+					HTTPKeepaliveTimeout: &[]string{"75"}[0],
+				}
+				opts.StagingTuning = component.APIcastTuningOptions{
+					ConfigurationLoader: &[]string{"boot"}[0],
+					CacheMaxTime:        &[]string{"1m"}[0],
+				}
+				return opts
+			},
+		},
 		{"WithApicastStagingTelemtryConfigurationWithCustomMountPath",
 			func() *appsv1alpha1.APIManager {
 				apimanager := basicApimanagerTestApicastOptions()
@@ -83,9 +83,11 @@ func (r *ApicastReconciler) Reconcile() (reconcile.Result, error) {
 		apicastHTTPSEnvVarMutator,
 		apicastProxyConfigurationsEnvVarMutator,
 		apicastServiceCacheSizeEnvVarMutator,
+		apicastTuningEnvVarMutator,
 		apicastVolumeMountsMutator,
 		apicastVolumesMutator,
-		apicastCustomPolicyAnnotationsMutator,  // Should be always after volume mutator
+		apicastCustomPolicyInitContainersMutator,
+		apicastCustomPolicyAnnotationsMutator,  // Should be always after volume and init container mutators
 		apicastTracingConfigAnnotationsMutator, // Should be always after volume mutator
 		apicastOpentelemetryConfigAnnotationsMutator,
 		apicastCustomEnvAnnotationsMutator, // Should be always after volume mutator
@@ -97,7 +99,7 @@ func (r *ApicastReconciler) Reconcile() (reconcile.Result, error) {
 		reconcilers.DeploymentPodContainerImageMutator,
 	}
 
-	if r.apiManager.Spec.Apicast.StagingSpec.Replicas != nil {
+	if r.apiManager.Spec.Apicast.StagingSpec.Replicas != nil || r.apiManager.IsProfileSet() {
 		stagingMutators = append(stagingMutators, reconcilers.DeploymentReplicasMutator)
 	}
 
@@ -135,9 +137,11 @@ func (r *ApicastReconciler) Reconcile() (reconcile.Result, error) {
 		apicastHTTPSEnvVarMutator,
 		apicastProxyConfigurationsEnvVarMutator,
 		apicastServiceCacheSizeEnvVarMutator,
+		apicastTuningEnvVarMutator,
 		apicastVolumeMountsMutator,
 		apicastVolumesMutator,
-		apicastCustomPolicyAnnotationsMutator,  // Should be always after volume mutator
+		apicastCustomPolicyInitContainersMutator,
+		apicastCustomPolicyAnnotationsMutator,  // Should be always after volume and init container mutators
 		apicastTracingConfigAnnotationsMutator, // Should be always after volume mutator
 		apicastOpentelemetryConfigAnnotationsMutator,
 		apicastCustomEnvAnnotationsMutator, // Should be always after volume
@@ -150,7 +154,7 @@ func (r *ApicastReconciler) Reconcile() (reconcile.Result, error) {
 		reconcilers.DeploymentPodInitContainerImageMutator,
 	}
 
-	if r.apiManager.Spec.Apicast.ProductionSpec.Replicas != nil {
+	if r.apiManager.Spec.Apicast.ProductionSpec.Replicas != nil || (r.apiManager.IsProfileSet() && !r.apiManager.Spec.Apicast.ProductionSpec.Hpa) {
 		productionMutators = append(productionMutators, reconcilers.DeploymentReplicasMutator)
 	}
 
@@ -352,6 +356,29 @@ func apicastServiceCacheSizeEnvVarMutator(desired, existing *k8sappsv1.Deploymen
 	return reconcilers.DeploymentEnvVarReconciler(desired, existing, "APICAST_SERVICE_CACHE_SIZE"), nil
 }
 
+func apicastTuningEnvVarMutator(desired, existing *k8sappsv1.Deployment) (bool, error) {
+	// Reconcile EnvVars related to the APIcast tuning options
+	var changed bool
+
+	for _, envVar := range []string{
+		"APICAST_CONFIGURATION_LOADER",
+		"APICAST_CONFIGURATION_CACHE",
+		"APICAST_PATH_ROUTING",
+		"APICAST_PATH_ROUTING_ONLY",
+		"APICAST_CACHE_MAX_TIME",
+		"APICAST_CACHE_STATUS_CODES",
+		"APICAST_UPSTREAM_RETRY_CASES",
+		"HTTP_KEEPALIVE_TIMEOUT",
+		"APICAST_POLICY_BATCHER_SHARED_MEMORY_SIZE",
+		"APICAST_ACCESS_LOG_FILE",
+	} {
+		tmpChanged := reconcilers.DeploymentEnvVarReconciler(desired, existing, envVar)
+		changed = changed || tmpChanged
+	}
+
+	return changed, nil
+}
+
 func portsMutator(desired, existing *k8sappsv1.Deployment) (bool, error) {
 	changed := false
 
@@ -549,6 +576,53 @@ func apicastVolumesMutator(desired, existing *k8sappsv1.Deployment) (bool, error
 	return changed, nil
 }
 
+// apicastCustomPolicyInitContainersMutator reconciles the init containers copying image sourced custom policies.
+// Only init containers associated to custom policies are deleted. The operator still allows manually added init containers
+func apicastCustomPolicyInitContainersMutator(desired, existing *k8sappsv1.Deployment) (bool, error) {
+	changed := false
+	existingSpec := &existing.Spec.Template.Spec
+	desiredPolicyVolumeNames := component.ApicastPolicyVolumeNamesFromAnnotations(desired.Annotations)
+
+	// Add desired not in existing, update when not equal
+	for _, desiredContainer := range desired.Spec.Template.Spec.InitContainers {
+		if !helper.ArrayContains(desiredPolicyVolumeNames, desiredContainer.Name) {
+			continue
+		}
+		existingIdx := findContainerByName(existingSpec.InitContainers, desiredContainer.Name)
+		if existingIdx < 0 {
+			existingSpec.InitContainers = append(existingSpec.InitContainers, desiredContainer)
+			changed = true
+		} else if !reflect.DeepEqual(existingSpec.InitContainers[existingIdx], desiredContainer) {
+			existingSpec.InitContainers[existingIdx] = desiredContainer
+			changed = true
+		}
+	}
+
+	// Delete init containers of custom policies that are no longer desired or no longer sourced from an image
+	existingPolicyVolumeNames := component.ApicastPolicyVolumeNamesFromAnnotations(existing.Annotations)
+	for _, policyVolumeName := range existingPolicyVolumeNames {
+		if findContainerByName(desired.Spec.Template.Spec.InitContainers, policyVolumeName) >= 0 {
+			continue
+		}
+		existingIdx := findContainerByName(existingSpec.InitContainers, policyVolumeName)
+		if existingIdx >= 0 {
+			existingSpec.InitContainers = append(existingSpec.InitContainers[:existingIdx], existingSpec.InitContainers[existingIdx+1:]...)
+			changed = true
+		}
+	}
+
+	return changed, nil
+}
+
+func findContainerByName(containers []v1.Container, name string) int {
+	for idx := range containers {
+		if containers[idx].Name == name {
+			return idx
+		}
+	}
+	return -1
+}
+
 func apicastCustomPolicyAnnotationsMutator(desired, existing *k8sappsv1.Deployment) (bool, error) {
 	// It is expected that APIManagerMutator has already added desired annotations to the existing annotations
 	// find existing custom policy annotations not in desired and delete them
@@ -689,7 +763,49 @@ func (r *ApicastReconciler) reconcileApimanagerSecretLabels(ctx context.Context)
 		return false, err
 	}
 
-	return replaceAPIManagerSecretLabels(r.apiManager, secretUIDs), nil
+	configMapUIDs, err := r.getConfigMapUIDs(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	secretLabelsChanged := replaceAPIManagerSecretLabels(r.apiManager, secretUIDs)
+	configMapLabelsChanged := replaceAPIManagerConfigMapLabels(r.apiManager, configMapUIDs)
+
+	return secretLabelsChanged || configMapLabelsChanged, nil
+}
+
+func (r *ApicastReconciler) getConfigMapUIDs(ctx context.Context) (map[string]string, error) {
+	// Custom Policy ConfigMap(s)
+
+	configMapKeys := []client.ObjectKey{}
+
+	customPolicies := append([]appsv1alpha1.CustomPolicySpec{}, r.apiManager.Spec.Apicast.StagingSpec.CustomPolicies...)
+	customPolicies = append(customPolicies, r.apiManager.Spec.Apicast.ProductionSpec.CustomPolicies...)
+	for _, customPolicy := range customPolicies {
+		if customPolicy.ConfigMapRef == nil {
+			continue
+		}
+		configMapKeys = append(configMapKeys, client.ObjectKey{
+			Name:      customPolicy.ConfigMapRef.Name,
+			Namespace: r.apiManager.Namespace,
+		})
+	}
+
+	uidMap := map[string]string{}
+	for idx := range configMapKeys {
+		configMap := &v1.ConfigMap{}
+		configMapKey := configMapKeys[idx]
+		err := r.Client().Get(ctx, configMapKey, configMap)
+		r.Logger().V(1).Info("reading configmap", "objectKey", configMapKey, "error", err)
+		if err != nil {
+			return nil, err
+		}
+
+		watchedByVal := fmt.Sprintf("%t", helper.IsConfigMapWatchedBy3scale(configMap))
+		uidMap[string(configMap.GetUID())] = watchedByVal
+	}
+
+	return uidMap, nil
 }
 
 func (r *ApicastReconciler) getSecretUIDs(ctx context.Context) (map[string]string, error) {
@@ -734,6 +850,9 @@ func (r *ApicastReconciler) getSecretUIDs(ctx context.Context) (map[string]strin
 
 	if r.apiManager.Spec.Apicast.StagingSpec.CustomPolicies != nil {
 		for _, customPolicy := range r.apiManager.Spec.Apicast.StagingSpec.CustomPolicies {
+			if customPolicy.SecretRef == nil {
+				continue
+			}
 			secretKeys = append(secretKeys, client.ObjectKey{
 				Name:      customPolicy.SecretRef.Name,
 				Namespace: r.apiManager.Namespace,
@@ -743,6 +862,9 @@ func (r *ApicastReconciler) getSecretUIDs(ctx context.Context) (map[string]strin
 
 	if r.apiManager.Spec.Apicast.ProductionSpec.CustomPolicies != nil {
 		for _, customPolicy := range r.apiManager.Spec.Apicast.ProductionSpec.CustomPolicies {
+			if customPolicy.SecretRef == nil {
+				continue
+			}
 			secretKeys = append(secretKeys, client.ObjectKey{
 				Name:      customPolicy.SecretRef.Name,
 				Namespace: r.apiManager.Namespace,
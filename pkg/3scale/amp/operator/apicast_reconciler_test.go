@@ -3,6 +3,7 @@ package operator
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"testing"
 
 	grafanav1alpha1 "github.com/grafana-operator/grafana-operator/v4/api/integreatly/v1alpha1"
@@ -1692,3 +1693,207 @@ func multiKeyOtlpSecret() *v1.Secret {
 		},
 	}
 }
+
+func TestApicastTuningEnvVarMutator(t *testing.T) {
+	productionDeployment := func(tuning component.APIcastTuningOptions) *k8sappsv1.Deployment {
+		apicast := component.NewApicast(&component.ApicastOptions{
+			StagingTracingConfig:    &component.APIcastTracingConfig{},
+			ProductionTracingConfig: &component.APIcastTracingConfig{},
+			ProductionTuning:        tuning,
+		})
+		deployment, err := apicast.ProductionDeployment(context.TODO(), fake.NewFakeClient(), "apicast-image")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return deployment
+	}
+
+	existing := productionDeployment(component.APIcastTuningOptions{})
+	desired := productionDeployment(component.APIcastTuningOptions{
+		ConfigurationCache: &[]string{"60"}[0],
+		PathRouting:        &[]string{"true"}[0],
+	})
+
+	if existing.Spec.Template.Annotations[component.APIcastEnvironmentCMAnnotation] == desired.Spec.Template.Annotations[component.APIcastEnvironmentCMAnnotation] {
+		t.Fatal("expected the env config hash to change with the tuning options")
+	}
+
+	changed, err := apicastTuningEnvVarMutator(desired, existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Fatal("expected the tuning env vars to be reconciled")
+	}
+
+	envs := map[string]string{}
+	for _, env := range existing.Spec.Template.Spec.Containers[0].Env {
+		envs[env.Name] = env.Value
+	}
+	if envs["APICAST_CONFIGURATION_CACHE"] != "60" || envs["APICAST_CONFIGURATION_LOADER"] != "boot" || envs["APICAST_PATH_ROUTING"] != "true" {
+		t.Fatalf("unexpected env vars %v", envs)
+	}
+
+	// Removing the tuning options restores the defaults
+	changed, err = apicastTuningEnvVarMutator(productionDeployment(component.APIcastTuningOptions{}), existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Fatal("expected the tuning env vars to be reconciled")
+	}
+	for _, env := range existing.Spec.Template.Spec.Containers[0].Env {
+		if env.Name == "APICAST_PATH_ROUTING" || (env.Name == "APICAST_CONFIGURATION_CACHE" && env.Value != "300") {
+			t.Fatalf("unexpected env var %v", env)
+		}
+	}
+}
+
+func TestApicastCustomPolicyInitContainersMutator(t *testing.T) {
+	imagePolicy := component.CustomPolicy{Name: "image-policy", Version: "1.0", Image: "quay.io/example/policy:1.0"}
+	secretPolicy := component.CustomPolicy{
+		Name:    "secret-policy",
+		Version: "0.1",
+		Secret:  &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "policy-secret"}},
+	}
+
+	productionDeployment := func(customPolicies ...component.CustomPolicy) *k8sappsv1.Deployment {
+		apicast := component.NewApicast(&component.ApicastOptions{
+			StagingTracingConfig:     &component.APIcastTracingConfig{},
+			ProductionTracingConfig:  &component.APIcastTracingConfig{},
+			ProductionCustomPolicies: customPolicies,
+		})
+		deployment, err := apicast.ProductionDeployment(context.TODO(), fake.NewFakeClient(), "apicast-image")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return deployment
+	}
+
+	existing := productionDeployment(secretPolicy)
+	// init containers added manually are kept
+	existing.Spec.Template.Spec.InitContainers = append(existing.Spec.Template.Spec.InitContainers, v1.Container{Name: "manual"})
+
+	desired := productionDeployment(secretPolicy, imagePolicy)
+	if desired.Spec.Template.Spec.Volumes[len(desired.Spec.Template.Spec.Volumes)-1].EmptyDir == nil {
+		t.Fatal("expected image policy to be mounted from an empty dir volume")
+	}
+
+	// Annotations are merged by the deployment annotations mutator before
+	for key, val := range desired.Annotations {
+		existing.Annotations[key] = val
+	}
+
+	changed, err := apicastCustomPolicyInitContainersMutator(desired, existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Fatal("expected the image policy init container to be added")
+	}
+	initContainerNames := func() []string {
+		names := []string{}
+		for _, container := range existing.Spec.Template.Spec.InitContainers {
+			names = append(names, container.Name)
+		}
+		return names
+	}
+	expected := []string{component.ApicastProductionInitContainerName, "manual", imagePolicy.VolumeName()}
+	if !reflect.DeepEqual(initContainerNames(), expected) {
+		t.Fatalf("unexpected init containers %v, want %v", initContainerNames(), expected)
+	}
+
+	// Removing the image policy deletes its init container only
+	desired = productionDeployment(secretPolicy)
+	changed, err = apicastCustomPolicyInitContainersMutator(desired, existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Fatal("expected the image policy init container to be deleted")
+	}
+	expected = []string{component.ApicastProductionInitContainerName, "manual"}
+	if !reflect.DeepEqual(initContainerNames(), expected) {
+		t.Fatalf("unexpected init containers %v, want %v", initContainerNames(), expected)
+	}
+}
+
+func TestApicastCustomPolicyConfigMapAnnotations(t *testing.T) {
+	policyConfigMap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "policy-configmap",
+			Labels: map[string]string{"apimanager.apps.3scale.net/watched-by": "apimanager"},
+		},
+		Data: map[string]string{"init.lua": "v1"},
+	}
+
+	podAnnotations := func(configMap *v1.ConfigMap) map[string]string {
+		apicast := component.NewApicast(&component.ApicastOptions{
+			StagingTracingConfig:    &component.APIcastTracingConfig{},
+			ProductionTracingConfig: &component.APIcastTracingConfig{},
+			ProductionCustomPolicies: []component.CustomPolicy{
+				{Name: "configmap-policy", Version: "0.1", ConfigMap: configMap},
+			},
+		})
+		deployment, err := apicast.ProductionDeployment(context.TODO(), fake.NewFakeClient(), "apicast-image")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return deployment.Spec.Template.Annotations
+	}
+
+	annotationKey := component.CustomPoliciesConfigMapHashAnnotationPrefix + policyConfigMap.Name
+	initialHash, ok := podAnnotations(policyConfigMap)[annotationKey]
+	if !ok {
+		t.Fatalf("expected the %s pod annotation for a watched configmap", annotationKey)
+	}
+
+	policyConfigMap.Data["init.lua"] = "v2"
+	if podAnnotations(policyConfigMap)[annotationKey] == initialHash {
+		t.Fatal("expected the pod annotation to change with the configmap content")
+	}
+
+	policyConfigMap.Labels = nil
+	if _, ok := podAnnotations(policyConfigMap)[annotationKey]; ok {
+		t.Fatal("expected no pod annotation for a configmap not watched")
+	}
+}
+
+func TestApicastReconcilerConfigMapLabels(t *testing.T) {
+	policyConfigMap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "policy-configmap",
+			Namespace: namespace,
+			UID:       "policy-configmap-uid",
+			Labels:    map[string]string{"apimanager.apps.3scale.net/watched-by": "apimanager"},
+		},
+	}
+	apimanager := basicApimanager()
+	apimanager.Spec.Apicast.StagingSpec.CustomPolicies = []appsv1alpha1.CustomPolicySpec{
+		{Name: "configmap-policy", Version: "0.1", ConfigMapRef: &v1.LocalObjectReference{Name: policyConfigMap.Name}},
+	}
+
+	cl := fake.NewFakeClient(policyConfigMap)
+	baseReconciler := reconcilers.NewBaseReconciler(context.TODO(), cl, scheme.Scheme, cl, logf.Log.WithName("operator_test"), nil, record.NewFakeRecorder(10))
+	reconciler := NewApicastReconciler(NewBaseAPIManagerLogicReconciler(baseReconciler, apimanager))
+
+	changed, err := reconciler.reconcileApimanagerSecretLabels(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Fatal("expected the configmap label to be added")
+	}
+	if apimanager.Labels[APIManagerConfigMapLabelPrefix+"policy-configmap-uid"] != "true" {
+		t.Fatalf("unexpected apimanager labels %v", apimanager.Labels)
+	}
+
+	apimanager.Spec.Apicast.StagingSpec.CustomPolicies = nil
+	changed, err = reconciler.reconcileApimanagerSecretLabels(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed || len(apimanager.Labels) != 0 {
+		t.Fatalf("expected the configmap label to be removed, got %v", apimanager.Labels)
+	}
+}
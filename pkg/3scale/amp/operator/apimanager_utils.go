@@ -9,14 +9,20 @@ import (
 )
 
 const (
-	APIManagerSecretLabelPrefix = "secret.apimanager.apps.3scale.net/"
+	APIManagerSecretLabelPrefix    = "secret.apimanager.apps.3scale.net/"
+	APIManagerConfigMapLabelPrefix = "configmap.apimanager.apps.3scale.net/"
 )
 
-func apimanagerSecretLabelKey(uid string) string {
-	return fmt.Sprintf("%s%s", APIManagerSecretLabelPrefix, uid)
+func replaceAPIManagerSecretLabels(apimanager *appsv1alpha1.APIManager, desiredSecretUIDs map[string]string) bool {
+	return replaceAPIManagerLabels(apimanager, APIManagerSecretLabelPrefix, desiredSecretUIDs)
 }
 
-func replaceAPIManagerSecretLabels(apimanager *appsv1alpha1.APIManager, desiredSecretUIDs map[string]string) bool {
+func replaceAPIManagerConfigMapLabels(apimanager *appsv1alpha1.APIManager, desiredConfigMapUIDs map[string]string) bool {
+	return replaceAPIManagerLabels(apimanager, APIManagerConfigMapLabelPrefix, desiredConfigMapUIDs)
+}
+
+// replaceAPIManagerLabels replaces the APIManager labels with the given prefix by one label per object UID
+func replaceAPIManagerLabels(apimanager *appsv1alpha1.APIManager, prefix string, desiredUIDs map[string]string) bool {
 
 	existingLabels := apimanager.GetLabels()
 
@@ -24,24 +30,24 @@ func replaceAPIManagerSecretLabels(apimanager *appsv1alpha1.APIManager, desiredS
 		existingLabels = map[string]string{}
 	}
 
-	existingSecretLabels := map[string]string{}
+	existingPrefixedLabels := map[string]string{}
 
-	// existing Secret UIDs not included in desiredAPIUIDs are deleted
+	// existing UIDs not included in desiredUIDs are deleted
 	for key, value := range existingLabels {
-		if strings.HasPrefix(key, APIManagerSecretLabelPrefix) {
-			existingSecretLabels[key] = value
+		if strings.HasPrefix(key, prefix) {
+			existingPrefixedLabels[key] = value
 			// it is safe to remove keys while looping in range
 			delete(existingLabels, key)
 		}
 	}
 
-	desiredSecretLabels := map[string]string{}
-	for uid, watchedByStatus := range desiredSecretUIDs {
-		desiredSecretLabels[apimanagerSecretLabelKey(uid)] = watchedByStatus
-		existingLabels[apimanagerSecretLabelKey(uid)] = watchedByStatus
+	desiredPrefixedLabels := map[string]string{}
+	for uid, watchedByStatus := range desiredUIDs {
+		desiredPrefixedLabels[fmt.Sprintf("%s%s", prefix, uid)] = watchedByStatus
+		existingLabels[fmt.Sprintf("%s%s", prefix, uid)] = watchedByStatus
 	}
 
 	apimanager.SetLabels(existingLabels)
 
-	return !reflect.DeepEqual(existingSecretLabels, desiredSecretLabels)
+	return !reflect.DeepEqual(existingPrefixedLabels, desiredPrefixedLabels)
 }
@@ -9,7 +9,6 @@ import (
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 	"github.com/3scale/3scale-operator/version"
 
-	v1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -44,6 +43,7 @@ func (o *OperatorBackendOptionsProvider) GetBackendOptions() (*component.Backend
 	o.setResourceRequirementsOptions()
 	o.setNodeAffinityAndTolerationsOptions()
 	o.setReplicas()
+	o.setWorkers()
 	o.setPriorityClassNames()
 	o.setTopologySpreadConstraints()
 	o.setPodTemplateAnnotations()
@@ -60,6 +60,11 @@ func (o *OperatorBackendOptionsProvider) GetBackendOptions() (*component.Backend
 	o.backendOptions.ListenerMetrics = true
 	o.backendOptions.Namespace = o.apimanager.Namespace
 
+	o.backendOptions.OpenTelemetry, err = openTelemetryOptions(o.apimanager.Spec.Backend.OpenTelemetry, o.secretSource)
+	if err != nil {
+		return nil, fmt.Errorf("GetBackendOptions reading opentelemetry options: %w", err)
+	}
+
 	err = o.backendOptions.Validate()
 	if err != nil {
 		return nil, fmt.Errorf("GetBackendOptions validating: %w", err)
@@ -112,27 +117,21 @@ func (o *OperatorBackendOptionsProvider) setSecretBasedOptions() error {
 }
 
 func (o *OperatorBackendOptionsProvider) setResourceRequirementsOptions() {
-	if *o.apimanager.Spec.ResourceRequirementsEnabled {
-		if o.apimanager.Spec.Backend.ListenerSpec.Hpa {
-			o.backendOptions.ListenerResourceRequirements = component.DefaultHPABackendListenerResourceRequirements()
-		} else {
-			o.backendOptions.ListenerResourceRequirements = component.DefaultBackendListenerResourceRequirements()
-		}
-		if o.apimanager.Spec.Backend.WorkerSpec.Hpa {
-			o.backendOptions.WorkerResourceRequirements = component.DefaultHPABackendWorkerResourceRequirements()
-		} else {
-			o.backendOptions.WorkerResourceRequirements = component.DefaultBackendWorkerResourceRequirements()
-		}
-		o.backendOptions.CronResourceRequirements = component.DefaultCronResourceRequirements()
-	} else {
-		o.backendOptions.ListenerResourceRequirements = v1.ResourceRequirements{}
-		o.backendOptions.WorkerResourceRequirements = v1.ResourceRequirements{}
-		o.backendOptions.CronResourceRequirements = v1.ResourceRequirements{}
+	listenerResourceRequirements := component.DefaultBackendListenerResourceRequirements()
+	if o.apimanager.Spec.Backend.ListenerSpec.Hpa {
+		listenerResourceRequirements = component.DefaultHPABackendListenerResourceRequirements()
 	}
+	workerResourceRequirements := component.DefaultBackendWorkerResourceRequirements()
+	if o.apimanager.Spec.Backend.WorkerSpec.Hpa {
+		workerResourceRequirements = component.DefaultHPABackendWorkerResourceRequirements()
+	}
+	o.backendOptions.ListenerResourceRequirements = profileResourceRequirements(o.apimanager, listenerResourceRequirements)
+	o.backendOptions.WorkerResourceRequirements = profileResourceRequirements(o.apimanager, workerResourceRequirements)
+	o.backendOptions.CronResourceRequirements = profileResourceRequirements(o.apimanager, component.DefaultCronResourceRequirements())
 
 	// Deployment-level ResourceRequirements CR fields have priority over
-	// spec.resourceRequirementsEnabled, overwriting that setting when they are
-	// defined
+	// spec.resourceRequirementsEnabled and spec.profile, overwriting those settings
+	// when they are defined
 	if o.apimanager.Spec.Backend.ListenerSpec.Resources != nil {
 		o.backendOptions.ListenerResourceRequirements = *o.apimanager.Spec.Backend.ListenerSpec.Resources
 	}
@@ -154,26 +153,33 @@ func (o *OperatorBackendOptionsProvider) setNodeAffinityAndTolerationsOptions()
 }
 
 func (o *OperatorBackendOptionsProvider) setReplicas() {
-	o.backendOptions.ListenerReplicas = 1
+	o.backendOptions.ListenerReplicas = profileReplicas(o.apimanager, component.BackendListenerName)
 	if o.apimanager.Spec.Backend.ListenerSpec.Replicas != nil {
 		if !o.apimanager.Spec.Backend.ListenerSpec.Hpa {
 			o.backendOptions.ListenerReplicas = int32(*o.apimanager.Spec.Backend.ListenerSpec.Replicas)
 		}
 	}
 
-	o.backendOptions.WorkerReplicas = 1
+	o.backendOptions.WorkerReplicas = profileReplicas(o.apimanager, component.BackendWorkerName)
 	if o.apimanager.Spec.Backend.WorkerSpec.Replicas != nil {
 		if !o.apimanager.Spec.Backend.WorkerSpec.Hpa {
 			o.backendOptions.WorkerReplicas = int32(*o.apimanager.Spec.Backend.WorkerSpec.Replicas)
 		}
 	}
 
-	o.backendOptions.CronReplicas = 1
+	o.backendOptions.CronReplicas = profileReplicas(o.apimanager, component.BackendCronName)
 	if o.apimanager.Spec.Backend.CronSpec.Replicas != nil {
 		o.backendOptions.CronReplicas = int32(*o.apimanager.Spec.Backend.CronSpec.Replicas)
 	}
 }
 
+func (o *OperatorBackendOptionsProvider) setWorkers() {
+	o.backendOptions.ListenerWorkers = component.DefaultBackendListenerWorkers
+	if profile := SizingProfile(o.apimanager); profile != nil {
+		o.backendOptions.ListenerWorkers = profile.BackendListenerWorkers
+	}
+}
+
 func (o *OperatorBackendOptionsProvider) commonLabels() map[string]string {
 	return map[string]string{
 		"app":                  *o.apimanager.Spec.AppLabel,
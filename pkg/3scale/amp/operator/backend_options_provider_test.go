@@ -194,6 +194,7 @@ func defaultBackendOptions(opts *component.BackendOptions) *component.BackendOpt
 		ListenerReplicas:             int32(listenerReplicaCount),
 		WorkerReplicas:               int32(workerReplicaCount),
 		CronReplicas:                 int32(cronReplicaCount),
+		ListenerWorkers:              component.DefaultBackendListenerWorkers,
 		SystemBackendUsername:        component.DefaultSystemBackendUsername(),
 		SystemBackendPassword:        opts.SystemBackendPassword,
 		TenantName:                   tenantName,
@@ -325,6 +326,28 @@ func TestGetBackendOptionsProvider(t *testing.T) {
 				return opts
 			},
 		},
+		{"WithProfile", nil, nil,
+			func() *appsv1alpha1.APIManager {
+				apimanager := basicApimanagerTestBackendOptions()
+				profile := appsv1alpha1.APIManagerProfileLarge
+				apimanager.Spec.Profile = &profile
+				apimanager.Spec.ResourceRequirementsEnabled = &falseValue
+				apimanager.Spec.Backend.CronSpec.Replicas = nil
+				apimanager.Spec.Backend.CronSpec.Resources = testBackendCronCustomResourceRequirements()
+				return apimanager
+			},
+			func(in *component.BackendOptions) *component.BackendOptions {
+				opts := defaultBackendOptions(in)
+				profile := component.GetSizingProfile("large")
+
+				opts.ListenerResourceRequirements = profile.ResourceRequirements(component.DefaultBackendListenerResourceRequirements())
+				opts.WorkerResourceRequirements = profile.ResourceRequirements(component.DefaultBackendWorkerResourceRequirements())
+				opts.CronResourceRequirements = *testBackendCronCustomResourceRequirements()
+				opts.CronReplicas = 1
+				opts.ListenerWorkers = 32
+				return opts
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -6,6 +6,7 @@ import (
 	"github.com/3scale/3scale-operator/pkg/helper"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 	"github.com/3scale/3scale-operator/pkg/upgrade"
+	k8sappsv1 "k8s.io/api/apps/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -32,8 +33,8 @@ func (r *BackendReconciler) Reconcile() (reconcile.Result, error) {
 	}
 
 	// Cron Deployment
-	cronDeploymentMutator := reconcilers.GenericBackendDeploymentMutators()
-	if r.apiManager.Spec.Backend.CronSpec.Replicas != nil {
+	cronDeploymentMutator := append(reconcilers.GenericBackendDeploymentMutators(), openTelemetryMutator)
+	if r.apiManager.Spec.Backend.CronSpec.Replicas != nil || r.apiManager.IsProfileSet() {
 		cronDeploymentMutator = append(cronDeploymentMutator, reconcilers.DeploymentReplicasMutator)
 	}
 
@@ -51,7 +52,7 @@ func (r *BackendReconciler) Reconcile() (reconcile.Result, error) {
 		return reconcile.Result{Requeue: true}, nil
 	}
 
-	listenerDeploymentMutator := reconcilers.GenericBackendDeploymentMutators()
+	listenerDeploymentMutator := append(reconcilers.GenericBackendDeploymentMutators(), openTelemetryMutator)
 	if r.apiManager.IsAsyncDisableAnnotationPresent() {
 		listenerDeploymentMutator = append(listenerDeploymentMutator, reconcilers.DeploymentListenerAsyncDisableArgsMutator)
 		listenerDeploymentMutator = append(listenerDeploymentMutator, reconcilers.DeploymentListenerAsyncDisableEnvMutator)
@@ -59,9 +60,12 @@ func (r *BackendReconciler) Reconcile() (reconcile.Result, error) {
 		listenerDeploymentMutator = append(listenerDeploymentMutator, reconcilers.DeploymentListenerEnvMutator)
 		listenerDeploymentMutator = append(listenerDeploymentMutator, reconcilers.DeploymentListenerArgsMutator)
 	}
-	if r.apiManager.Spec.Backend.ListenerSpec.Replicas != nil {
+	if r.apiManager.Spec.Backend.ListenerSpec.Replicas != nil || (r.apiManager.IsProfileSet() && !r.apiManager.Spec.Backend.ListenerSpec.Hpa) {
 		listenerDeploymentMutator = append(listenerDeploymentMutator, reconcilers.DeploymentReplicasMutator)
 	}
+	if r.apiManager.IsProfileSet() {
+		listenerDeploymentMutator = append(listenerDeploymentMutator, backendListenerWorkersEnvVarMutator)
+	}
 
 	err = r.ReconcileDeployment(backend.ListenerDeployment(ampImages.Options.BackendImage), reconcilers.DeploymentMutator(listenerDeploymentMutator...))
 	if err != nil {
@@ -95,13 +99,13 @@ func (r *BackendReconciler) Reconcile() (reconcile.Result, error) {
 	}
 
 	// Worker Deployment
-	workerDeploymentMutator := reconcilers.GenericBackendDeploymentMutators()
+	workerDeploymentMutator := append(reconcilers.GenericBackendDeploymentMutators(), openTelemetryMutator)
 	if r.apiManager.IsAsyncDisableAnnotationPresent() {
 		workerDeploymentMutator = append(workerDeploymentMutator, reconcilers.DeploymentWorkerDisableAsyncEnvMutator)
 	} else {
 		workerDeploymentMutator = append(workerDeploymentMutator, reconcilers.DeploymentWorkerEnvMutator)
 	}
-	if r.apiManager.Spec.Backend.WorkerSpec.Replicas != nil {
+	if r.apiManager.Spec.Backend.WorkerSpec.Replicas != nil || (r.apiManager.IsProfileSet() && !r.apiManager.Spec.Backend.WorkerSpec.Hpa) {
 		workerDeploymentMutator = append(workerDeploymentMutator, reconcilers.DeploymentReplicasMutator)
 	}
 
@@ -212,6 +216,11 @@ func Backend(apimanager *appsv1alpha1.APIManager, client client.Client) (*compon
 	return component.NewBackend(opts), nil
 }
 
+func backendListenerWorkersEnvVarMutator(desired, existing *k8sappsv1.Deployment) (bool, error) {
+	// Reconcile EnvVar only for "PUMA_WORKERS"
+	return reconcilers.DeploymentEnvVarReconciler(desired, existing, "PUMA_WORKERS"), nil
+}
+
 func containsAsyncDisable(m map[string]string, key, value string) bool {
 	if v, ok := m[key]; ok {
 		return v == value
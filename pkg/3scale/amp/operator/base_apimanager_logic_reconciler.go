@@ -5,6 +5,7 @@ import (
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/prometheusrules"
 	"github.com/3scale/3scale-operator/pkg/common"
 	"github.com/3scale/3scale-operator/pkg/helper"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
@@ -17,6 +18,7 @@ import (
 	hpa "k8s.io/api/autoscaling/v2"
 	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -59,8 +61,30 @@ func (r *BaseAPIManagerLogicReconciler) ReconcilePodDisruptionBudget(desired *po
 	return r.ReconcileResource(&policyv1.PodDisruptionBudget{}, desired, mutatefn)
 }
 
+func (r *BaseAPIManagerLogicReconciler) ReconcileNetworkPolicy(desired *networkingv1.NetworkPolicy, mutatefn reconcilers.MutateFn) error {
+	if !r.apiManager.IsNetworkPoliciesEnabled() {
+		common.TagObjectToDelete(desired)
+	}
+	return r.ReconcileResource(&networkingv1.NetworkPolicy{}, desired, mutatefn)
+}
+
 func (r *BaseAPIManagerLogicReconciler) ReconcileDeployment(desired *k8sappsv1.Deployment, mutatefn reconcilers.MutateFn) error {
-	return r.ReconcileResource(&k8sappsv1.Deployment{}, desired, mutatefn)
+	if common.IsObjectTaggedToDelete(desired) {
+		return r.ReconcileResource(&k8sappsv1.Deployment{}, desired, mutatefn)
+	}
+
+	// Deployments created while in maintenance start with zero replicas
+	if helper.ArrayContains(MaintenancePausedDeployments(r.apiManager), desired.Name) {
+		existing, err := r.maintenanceDeployment(desired.Name)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			maintenanceNewDeployment(desired)
+		}
+	}
+
+	return r.ReconcileResource(&k8sappsv1.Deployment{}, desired, r.maintenanceDeploymentMutator(mutatefn))
 }
 
 func (r *BaseAPIManagerLogicReconciler) ReconcileService(desired *v1.Service, mutateFn reconcilers.MutateFn) error {
@@ -113,18 +137,20 @@ func (r *BaseAPIManagerLogicReconciler) ReconcileGrafanaDashboards(
 	switch d := desired.(type) {
 	case *grafanav1beta1.GrafanaDashboard:
 		if dashboardsAvailable && *r.crdAvailabilityCache.grafanaDashboardCRDV5Available {
-			if !r.apiManager.IsMonitoringEnabled() {
+			if !r.apiManager.IsMonitoringEnabled() || r.apiManager.IsGrafanaDashboardDisabled(d.Name) {
 				common.TagObjectToDelete(d)
 			}
-			return r.ReconcileResource(d, d, mutateFn)
+			GrafanaDashboardPlacement(r.apiManager).ApplyV5(d)
+			return r.ReconcileResource(&grafanav1beta1.GrafanaDashboard{}, d, mutateFn)
 		}
 
 	case *grafanav1alpha1.GrafanaDashboard:
 		if dashboardsAvailable && *r.crdAvailabilityCache.grafanaDashboardCRDV4Available {
-			if !r.apiManager.IsMonitoringEnabled() {
+			if !r.apiManager.IsMonitoringEnabled() || r.apiManager.IsGrafanaDashboardDisabled(d.Name) {
 				common.TagObjectToDelete(d)
 			}
-			return r.ReconcileResource(d, d, mutateFn)
+			GrafanaDashboardPlacement(r.apiManager).ApplyV4(d)
+			return r.ReconcileResource(&grafanav1alpha1.GrafanaDashboard{}, d, mutateFn)
 		}
 
 	default:
@@ -152,7 +178,9 @@ func getName(desired interface{}) string {
 	}
 }
 
-func (r *BaseAPIManagerLogicReconciler) ReconcilePrometheusRules(desired *monitoringv1.PrometheusRule, mutateFn reconcilers.MutateFn) error {
+// ReconcilePrometheusRules reconciles the PrometheusRule with the mutateFn. The overridden alerts and
+// the syncedAlerts are kept in sync regardless of the mutateFn, so it must not be a PrometheusRuleAlertsMutator
+func (r *BaseAPIManagerLogicReconciler) ReconcilePrometheusRules(desired *monitoringv1.PrometheusRule, mutateFn reconcilers.MutateFn, syncedAlerts ...string) error {
 	kindExists, err := r.HasPrometheusRules()
 	if err != nil {
 		return err
@@ -170,7 +198,19 @@ func (r *BaseAPIManagerLogicReconciler) ReconcilePrometheusRules(desired *monito
 	if !r.apiManager.IsPrometheusRulesEnabled() {
 		common.TagObjectToDelete(desired)
 	}
-	return r.ReconcileResource(&monitoringv1.PrometheusRule{}, desired, mutateFn)
+
+	// Overridden alerts are kept in sync regardless of the mutator of the PrometheusRule.
+	// Alerts no longer overridden are restored to their generated definition
+	overrides := r.apiManager.PrometheusRulesAlertOverrides()
+	prometheusrules.ApplyAlertOverrides(desired, overrides)
+	alertNames := append([]string{}, syncedAlerts...)
+	for _, override := range overrides {
+		if !helper.ArrayContains(alertNames, override.Name) {
+			alertNames = append(alertNames, override.Name)
+		}
+	}
+
+	return r.ReconcileResource(&monitoringv1.PrometheusRule{}, desired, reconcilers.PrometheusRuleAlertsMutator(alertNames, mutateFn))
 }
 
 func (r *BaseAPIManagerLogicReconciler) ReconcileServiceMonitor(desired *monitoringv1.ServiceMonitor, mutateFn reconcilers.MutateFn) error {
@@ -195,6 +235,23 @@ func (r *BaseAPIManagerLogicReconciler) ReconcileServiceMonitor(desired *monitor
 }
 
 func (r *BaseAPIManagerLogicReconciler) ReconcileHpa(desired *hpa.HorizontalPodAutoscaler, mutateFn reconcilers.MutateFn) error {
+	// HPA would scale back up the Deployments paused by the maintenance mode
+	if helper.ArrayContains(MaintenancePausedDeployments(r.apiManager), desired.Spec.ScaleTargetRef.Name) {
+		err := r.DeleteResource(desired)
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+
+	// The sizing profile replicas bounds are reconciled, overwriting manual changes
+	if profile := SizingProfile(r.apiManager); profile != nil {
+		minReplicas := profile.HpaMinReplicas
+		desired.Spec.MinReplicas = &minReplicas
+		desired.Spec.MaxReplicas = profile.HpaMaxReplicas
+		mutateFn = reconcilers.HPAReplicasBoundsMutator
+	}
+
 	// Only allow to create Apicast HPA regardless of running async mode or not.
 	if desired.Spec.ScaleTargetRef.Name == component.ApicastProductionName && r.apiManager.Spec.Apicast.ProductionSpec.Hpa {
 		return r.ReconcileResource(&hpa.HorizontalPodAutoscaler{}, desired, mutateFn)
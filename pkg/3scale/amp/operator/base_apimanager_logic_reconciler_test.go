@@ -13,6 +13,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	fakeclientset "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
@@ -506,3 +507,85 @@ func TestBaseAPIManagerLogicReconcilerHasServiceMonitors(t *testing.T) {
 		t.Fatalf("Unexpected exists value received. Expected: %t, got: %t", false, exists)
 	}
 }
+
+func TestBaseAPIManagerLogicReconcilerReconcilePrometheusRulesSyncedAlerts(t *testing.T) {
+	var (
+		namespace = "operator-unittest"
+		log       = logf.Log.WithName("operator_test")
+	)
+
+	ctx := context.TODO()
+	apimanager := &appsv1alpha1.APIManager{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-apimanager", Namespace: namespace},
+		Spec: appsv1alpha1.APIManagerSpec{
+			Monitoring: &appsv1alpha1.MonitoringSpec{
+				Enabled:        true,
+				AlertOverrides: []appsv1alpha1.AlertOverrideSpec{{Name: "Overridden"}},
+			},
+		},
+	}
+
+	s := scheme.Scheme
+	if err := monitoringv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	s.AddKnownTypes(appsv1alpha1.GroupVersion, apimanager)
+
+	cl := fake.NewFakeClient(apimanager)
+	clientset := fakeclientset.NewSimpleClientset()
+	clientset.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: monitoringv1.SchemeGroupVersion.String(),
+			APIResources: []metav1.APIResource{
+				{Name: monitoringv1.PrometheusRuleName, Namespaced: true, Kind: monitoringv1.PrometheusRuleKind},
+			},
+		},
+	}
+	baseReconciler := reconcilers.NewBaseReconciler(ctx, cl, s, fake.NewFakeClient(apimanager), log, clientset.Discovery(), record.NewFakeRecorder(10000))
+	apimanagerLogicReconciler := NewBaseAPIManagerLogicReconciler(baseReconciler, apimanager)
+
+	desired := func() *monitoringv1.PrometheusRule {
+		return &monitoringv1.PrometheusRule{
+			TypeMeta:   metav1.TypeMeta{Kind: monitoringv1.PrometheusRuleKind, APIVersion: monitoringv1.SchemeGroupVersion.String()},
+			ObjectMeta: metav1.ObjectMeta{Name: "rules"},
+			Spec: monitoringv1.PrometheusRuleSpec{
+				Groups: []monitoringv1.RuleGroup{{
+					Name: "group",
+					Rules: []monitoringv1.Rule{
+						{Alert: "Synced", Expr: intstr.FromString("up == 0")},
+						{Alert: "Overridden", Expr: intstr.FromString("up == 0")},
+					},
+				}},
+			},
+		}
+	}
+
+	existing := &monitoringv1.PrometheusRule{}
+	key := client.ObjectKey{Name: "rules", Namespace: namespace}
+
+	// The first pass creates the rule, the second one records the synced alerts
+	for i := 0; i < 2; i++ {
+		err := apimanagerLogicReconciler.ReconcilePrometheusRules(desired(), reconcilers.CreateOnlyMutator, "Synced")
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := cl.Get(ctx, key, existing); err != nil {
+		t.Fatal(err)
+	}
+	if existing.Annotations[reconcilers.OverriddenAlertsAnnotation] != "Overridden,Synced" {
+		t.Fatalf("unexpected synced alerts annotation %v", existing.Annotations)
+	}
+	resourceVersion := existing.ResourceVersion
+
+	err := apimanagerLogicReconciler.ReconcilePrometheusRules(desired(), reconcilers.CreateOnlyMutator, "Synced")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cl.Get(ctx, key, existing); err != nil {
+		t.Fatal(err)
+	}
+	if existing.ResourceVersion != resourceVersion {
+		t.Fatalf("unexpected update of an unchanged PrometheusRule, resource version %s, was %s", existing.ResourceVersion, resourceVersion)
+	}
+}
@@ -0,0 +1,113 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	routev1 "github.com/openshift/api/route/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/helper"
+)
+
+const (
+	CertificateKindSecret = "Secret"
+	CertificateKindRoute  = "Route"
+)
+
+type certificateSecretKey struct {
+	name       string
+	secretName string
+	key        string
+}
+
+// APIManagerCertificates reads the expiry of the TLS certificates referenced by the APIManager:
+// APIcast HTTPS certificates, database TLS certificates and custom route certificates.
+// Missing secrets and data that is not a PEM encoded certificate are skipped
+func APIManagerCertificates(ctx context.Context, apimanager *appsv1alpha1.APIManager, k8sclient client.Client) ([]appsv1alpha1.APIManagerCertificateStatus, error) {
+	secretKeys := []certificateSecretKey{}
+
+	if apimanager.Spec.Apicast != nil && apimanager.Spec.Apicast.ProductionSpec != nil && apimanager.Spec.Apicast.ProductionSpec.HTTPSCertificateSecretRef != nil {
+		secretKeys = append(secretKeys, certificateSecretKey{"apicast-production-https", apimanager.Spec.Apicast.ProductionSpec.HTTPSCertificateSecretRef.Name, v1.TLSCertKey})
+	}
+	if apimanager.Spec.Apicast != nil && apimanager.Spec.Apicast.StagingSpec != nil && apimanager.Spec.Apicast.StagingSpec.HTTPSCertificateSecretRef != nil {
+		secretKeys = append(secretKeys, certificateSecretKey{"apicast-staging-https", apimanager.Spec.Apicast.StagingSpec.HTTPSCertificateSecretRef.Name, v1.TLSCertKey})
+	}
+	if apimanager.IsSystemDatabaseTLSEnabled() {
+		secretKeys = append(secretKeys,
+			certificateSecretKey{"system-database-client", component.SystemSecretSystemDatabaseSecretName, component.SystemSecretSslCert},
+			certificateSecretKey{"system-database-ca", component.SystemSecretSystemDatabaseSecretName, component.SystemSecretSslCa},
+		)
+	}
+	if apimanager.IsZyncEnabled() && apimanager.IsZyncDatabaseTLSEnabled() {
+		secretKeys = append(secretKeys,
+			certificateSecretKey{"zync-database-client", component.ZyncSecretName, component.ZyncSecretSslCert},
+			certificateSecretKey{"zync-database-ca", component.ZyncSecretName, component.ZyncSecretSslCa},
+		)
+	}
+
+	certificates := []appsv1alpha1.APIManagerCertificateStatus{}
+
+	for _, secretKey := range secretKeys {
+		secret := &v1.Secret{}
+		err := k8sclient.Get(ctx, types.NamespacedName{Name: secretKey.secretName, Namespace: apimanager.Namespace}, secret)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get secret %s: %w", secretKey.secretName, err)
+		}
+
+		data, ok := secret.Data[secretKey.key]
+		if !ok || len(data) == 0 {
+			continue
+		}
+		cert, err := helper.ParsePEMCertificate(data)
+		if err != nil {
+			continue
+		}
+		certificates = append(certificates, appsv1alpha1.APIManagerCertificateStatus{
+			Name:       secretKey.name,
+			Kind:       CertificateKindSecret,
+			ObjectName: secretKey.secretName,
+			Subject:    cert.Subject.String(),
+			NotAfter:   metav1.NewTime(cert.NotAfter),
+		})
+	}
+
+	routeList := &routev1.RouteList{}
+	err := k8sclient.List(ctx, routeList, client.InNamespace(apimanager.Namespace))
+	// Routes are not available outside OpenShift
+	if err != nil && !meta.IsNoMatchError(err) {
+		return nil, fmt.Errorf("failed to list routes: %w", err)
+	}
+	for idx := range routeList.Items {
+		route := &routeList.Items[idx]
+		if route.Spec.TLS == nil || route.Spec.TLS.Certificate == "" {
+			continue
+		}
+		cert, err := helper.ParsePEMCertificate([]byte(route.Spec.TLS.Certificate))
+		if err != nil {
+			continue
+		}
+		certificates = append(certificates, appsv1alpha1.APIManagerCertificateStatus{
+			Name:       fmt.Sprintf("route-%s", route.Name),
+			Kind:       CertificateKindRoute,
+			ObjectName: route.Name,
+			Subject:    cert.Subject.String(),
+			NotAfter:   metav1.NewTime(cert.NotAfter),
+		})
+	}
+
+	sort.Slice(certificates, func(i, j int) bool { return certificates[i].Name < certificates[j].Name })
+
+	return certificates, nil
+}
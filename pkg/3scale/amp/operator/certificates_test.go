@@ -0,0 +1,102 @@
+package operator
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	routev1 "github.com/openshift/api/route/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+)
+
+func testCertificatePEM(t *testing.T, commonName string, notAfter time.Time) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    notAfter.AddDate(-1, 0, 0),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestAPIManagerCertificates(t *testing.T) {
+	notAfter := time.Now().AddDate(0, 0, 10).Truncate(time.Second)
+
+	apimanager := basicApimanager()
+	apimanager.Spec.Apicast.ProductionSpec.HTTPSCertificateSecretRef = &v1.LocalObjectReference{Name: "apicast-tls"}
+	// missing secrets are skipped
+	apimanager.Spec.Apicast.StagingSpec.HTTPSCertificateSecretRef = &v1.LocalObjectReference{Name: "missing"}
+	tmpTrueValue := trueValue
+	apimanager.Spec.System.SystemDatabaseTLSEnabled = &tmpTrueValue
+
+	objs := []runtime.Object{
+		GetTestSecret(namespace, "apicast-tls", map[string]string{
+			v1.TLSCertKey: testCertificatePEM(t, "apicast.example.com", notAfter),
+		}),
+		GetTestSecret(namespace, component.SystemSecretSystemDatabaseSecretName, map[string]string{
+			component.SystemSecretSslCa: testCertificatePEM(t, "database-ca", notAfter),
+			// invalid certificates are skipped
+			component.SystemSecretSslCert: "invalid",
+		}),
+		&routev1.Route{
+			ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: namespace},
+			Spec:       routev1.RouteSpec{TLS: &routev1.TLSConfig{Certificate: testCertificatePEM(t, "backend.example.com", notAfter)}},
+		},
+		&routev1.Route{
+			ObjectMeta: metav1.ObjectMeta{Name: "zync-default", Namespace: namespace},
+			Spec:       routev1.RouteSpec{TLS: &routev1.TLSConfig{Termination: routev1.TLSTerminationEdge}},
+		},
+	}
+
+	s := runtime.NewScheme()
+	if err := v1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := routev1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objs...).Build()
+
+	certificates, err := APIManagerCertificates(context.TODO(), apimanager, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []appsv1alpha1.APIManagerCertificateStatus{
+		{Name: "apicast-production-https", Kind: CertificateKindSecret, ObjectName: "apicast-tls", Subject: "CN=apicast.example.com"},
+		{Name: "route-backend", Kind: CertificateKindRoute, ObjectName: "backend", Subject: "CN=backend.example.com"},
+		{Name: "system-database-ca", Kind: CertificateKindSecret, ObjectName: component.SystemSecretSystemDatabaseSecretName, Subject: "CN=database-ca"},
+	}
+	if len(certificates) != len(expected) {
+		t.Fatalf("expected %d certificates, got %v", len(expected), certificates)
+	}
+	for idx := range expected {
+		expected[idx].NotAfter = metav1.NewTime(notAfter)
+		if certificates[idx].Name != expected[idx].Name || certificates[idx].Kind != expected[idx].Kind ||
+			certificates[idx].ObjectName != expected[idx].ObjectName || certificates[idx].Subject != expected[idx].Subject ||
+			!certificates[idx].NotAfter.Equal(&expected[idx].NotAfter) {
+			t.Errorf("expected certificate %v, got %v", expected[idx], certificates[idx])
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package operator
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/helper"
+)
+
+const (
+	datastoreExporterDefaultMySQLPort = "3306"
+)
+
+type DatastoreExportersOptionsProvider struct {
+	apimanager   *appsv1alpha1.APIManager
+	options      *component.DatastoreExportersOptions
+	secretSource *helper.SecretSource
+}
+
+func NewDatastoreExportersOptionsProvider(apimanager *appsv1alpha1.APIManager, namespace string, client client.Client) *DatastoreExportersOptionsProvider {
+	return &DatastoreExportersOptionsProvider{
+		apimanager:   apimanager,
+		options:      component.NewDatastoreExportersOptions(),
+		secretSource: helper.NewSecretSource(client, namespace),
+	}
+}
+
+func (d *DatastoreExportersOptionsProvider) GetOptions() (*component.DatastoreExportersOptions, error) {
+	d.options.CommonLabels = map[string]string{
+		"app": *d.apimanager.Spec.AppLabel,
+	}
+	d.options.Namespace = d.apimanager.Namespace
+	d.options.ContainerResourceRequirements = profileResourceRequirements(d.apimanager, component.DefaultDatastoreExporterContainerResourceRequirements())
+
+	err := d.setRedisExporters()
+	if err != nil {
+		return nil, fmt.Errorf("GetDatastoreExportersOptions reading redis options: %w", err)
+	}
+
+	err = d.setSystemDatabaseExporter()
+	if err != nil {
+		return nil, fmt.Errorf("GetDatastoreExportersOptions reading system database options: %w", err)
+	}
+
+	err = d.setZyncDatabaseExporter()
+	if err != nil {
+		return nil, fmt.Errorf("GetDatastoreExportersOptions reading zync database options: %w", err)
+	}
+
+	err = d.options.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("GetDatastoreExportersOptions validating: %w", err)
+	}
+
+	return d.options, nil
+}
+
+// setRedisExporters adds one exporter per redis instance. Instances behind sentinels
+// are not exported, the exporter connects to a single redis server
+func (d *DatastoreExportersOptionsProvider) setRedisExporters() error {
+	storageURL, err := d.redisURL(component.BackendSecretBackendRedisSecretName, component.BackendSecretBackendRedisStorageURLFieldName, component.BackendSecretBackendRedisStorageSentinelHostsFieldName)
+	if err != nil {
+		return err
+	}
+	if storageURL != nil {
+		d.addExporter(component.BackendRedisStorageExporterName, "backend-redis", component.DatastoreExporterTypeRedis, storageURL.String())
+	}
+
+	queuesURL, err := d.redisURL(component.BackendSecretBackendRedisSecretName, component.BackendSecretBackendRedisQueuesURLFieldName, component.BackendSecretBackendRedisQueuesSentinelHostsFieldName)
+	if err != nil {
+		return err
+	}
+	// Storage and queues usually share the redis server, using different logical databases
+	if queuesURL != nil && (storageURL == nil || queuesURL.Host != storageURL.Host) {
+		d.addExporter(component.BackendRedisQueuesExporterName, "backend-redis", component.DatastoreExporterTypeRedis, queuesURL.String())
+	}
+
+	systemURL, err := d.redisURL(component.SystemSecretSystemRedisSecretName, component.SystemSecretSystemRedisURLFieldName, component.SystemSecretSystemRedisSentinelHosts)
+	if err != nil {
+		return err
+	}
+	if systemURL != nil {
+		d.addExporter(component.SystemRedisExporterName, "system-redis", component.DatastoreExporterTypeRedis, systemURL.String())
+	}
+
+	return nil
+}
+
+// redisURL returns nil when the redis server is behind sentinels
+func (d *DatastoreExportersOptionsProvider) redisURL(secretName, urlFieldName, sentinelHostsFieldName string) (*url.URL, error) {
+	sentinelHosts, err := d.secretSource.FieldValue(secretName, sentinelHostsFieldName, "")
+	if err != nil {
+		return nil, err
+	}
+	if sentinelHosts != "" {
+		return nil, nil
+	}
+
+	rawURL, err := d.secretSource.RequiredFieldValueFromRequiredSecret(secretName, urlFieldName)
+	if err != nil {
+		return nil, err
+	}
+
+	redisURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis URL in secret '%s' field '%s': %w", secretName, urlFieldName, err)
+	}
+	return redisURL, nil
+}
+
+// setSystemDatabaseExporter exports MySQL and PostgreSQL system databases. Oracle is not supported
+func (d *DatastoreExportersOptionsProvider) setSystemDatabaseExporter() error {
+	rawURL, err := d.secretSource.RequiredFieldValueFromRequiredSecret(component.SystemSecretSystemDatabaseSecretName, component.SystemSecretSystemDatabaseURLFieldName)
+	if err != nil {
+		return err
+	}
+
+	databaseURL, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid system database URL: %w", err)
+	}
+
+	switch databaseURL.Scheme {
+	case "mysql2", "mysql":
+		exporter := component.DatastoreExporterOptions{
+			Name:         component.SystemDatabaseExporterName,
+			Datastore:    "system-database",
+			Type:         component.DatastoreExporterTypeMySQL,
+			MySQLAddress: databaseURL.Host,
+		}
+		if databaseURL.Port() == "" {
+			exporter.MySQLAddress = net.JoinHostPort(databaseURL.Hostname(), datastoreExporterDefaultMySQLPort)
+		}
+		if databaseURL.User == nil || databaseURL.User.Username() == "" {
+			return fmt.Errorf("system database URL has no user for the mysql exporter")
+		}
+		exporter.MySQLUser = databaseURL.User.Username()
+		exporter.ConnectionString, _ = databaseURL.User.Password()
+		d.options.Exporters = append(d.options.Exporters, exporter)
+	case "postgresql", "postgres":
+		d.addExporter(component.SystemDatabaseExporterName, "system-database", component.DatastoreExporterTypePostgreSQL, rawURL)
+	}
+
+	return nil
+}
+
+// setZyncDatabaseExporter adds a standalone exporter for external zync databases.
+// Internal zync databases are exported by a sidecar of the zync-database Deployment
+func (d *DatastoreExportersOptionsProvider) setZyncDatabaseExporter() error {
+	if !d.apimanager.IsZyncEnabled() {
+		return nil
+	}
+
+	if !d.apimanager.IsExternal(appsv1alpha1.ZyncDatabase) {
+		d.options.ZyncDatabaseExporter = true
+		return nil
+	}
+
+	databaseURL, err := d.secretSource.RequiredFieldValueFromRequiredSecret(component.ZyncSecretName, component.ZyncSecretDatabaseURLFieldName)
+	if err != nil {
+		return err
+	}
+	d.addExporter(component.ZyncDatabaseExporterName, component.ZyncDatabaseDeploymentName, component.DatastoreExporterTypePostgreSQL, databaseURL)
+
+	return nil
+}
+
+func (d *DatastoreExportersOptionsProvider) addExporter(name, datastore, exporterType, connectionString string) {
+	d.options.Exporters = append(d.options.Exporters, component.DatastoreExporterOptions{
+		Name:             name,
+		Datastore:        datastore,
+		Type:             exporterType,
+		ConnectionString: connectionString,
+	})
+}
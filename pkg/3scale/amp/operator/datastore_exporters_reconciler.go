@@ -0,0 +1,217 @@
+package operator
+
+import (
+	"reflect"
+
+	grafanav1alpha1 "github.com/grafana-operator/grafana-operator/v4/api/integreatly/v1alpha1"
+	grafanav1beta1 "github.com/grafana-operator/grafana-operator/v5/api/v1beta1"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/common"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+)
+
+// DatastoreExportersReconciler reconciles the exporters of the redis and database
+// servers 3scale connects to, along with their monitoring resources
+type DatastoreExportersReconciler struct {
+	*BaseAPIManagerLogicReconciler
+}
+
+func NewDatastoreExportersReconciler(baseAPIManagerLogicReconciler *BaseAPIManagerLogicReconciler) *DatastoreExportersReconciler {
+	return &DatastoreExportersReconciler{
+		BaseAPIManagerLogicReconciler: baseAPIManagerLogicReconciler,
+	}
+}
+
+func (r *DatastoreExportersReconciler) Reconcile() (reconcile.Result, error) {
+	if !r.apiManager.IsDatastoreExportersEnabled() {
+		return reconcile.Result{}, r.deleteExporters(nil)
+	}
+
+	ampImages, err := AmpImages(r.apiManager)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	exporters, err := DatastoreExporters(r.apiManager, r.Client())
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// Secret
+	err = r.ReconcileSecret(exporters.Secret(), reconcilers.DeploymentSecretMutator(reconcilers.SecretStringDataMutator))
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	deploymentMutator := reconcilers.DeploymentMutator(
+		reconcilers.DeploymentContainerResourcesMutator,
+		reconcilers.DeploymentPodTemplateLabelsMutator,
+		reconcilers.DeploymentPodTemplateAnnotationsMutator,
+		datastoreExporterContainerMutator,
+	)
+
+	desiredNames := []string{}
+	for _, exporter := range exporters.Options.Exporters {
+		desiredNames = append(desiredNames, exporter.Name)
+
+		// Deployment
+		err = r.ReconcileDeployment(exporters.Deployment(exporter, datastoreExporterImage(ampImages.Options, exporter.Type)), deploymentMutator)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+
+		// PodMonitor
+		err = r.ReconcilePodMonitor(exporters.PodMonitor(exporter), reconcilers.GenericPodMonitorMutator)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	// Zync database exporter sidecar PodMonitor
+	if exporters.Options.ZyncDatabaseExporter {
+		desiredNames = append(desiredNames, component.ZyncDatabaseExporterName)
+		err = r.ReconcilePodMonitor(exporters.ZyncDatabasePodMonitor(), reconcilers.GenericPodMonitorMutator)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	// Exporters of datastores switched to sentinels or to unsupported databases
+	err = r.deleteExporters(desiredNames)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// GrafanaDashboards
+	err = r.ReconcileGrafanaDashboards(exporters.GrafanaV5Dashboard(), reconcilers.GenericGrafanaDashboardsMutator)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	err = r.ReconcileGrafanaDashboards(exporters.GrafanaV4Dashboard(), reconcilers.GenericGrafanaDashboardsMutator)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// PrometheusRules
+	err = r.ReconcilePrometheusRules(exporters.PrometheusRules(), reconcilers.CreateOnlyMutator)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// deleteExporters removes the exporters not in keep. When keep is nil, the exporters are
+// disabled and the secret and monitoring resources are removed as well.
+// Options are not read because the datastore secrets are not needed to delete the resources
+func (r *DatastoreExportersReconciler) deleteExporters(keep []string) error {
+	for _, name := range component.DatastoreExporterNames {
+		if helper.ArrayContains(keep, name) {
+			continue
+		}
+
+		// The zync database exporter sidecar is removed by the zync reconciler
+		if name != component.ZyncDatabaseExporterName || r.apiManager.IsExternal(appsv1alpha1.ZyncDatabase) {
+			deployment := &k8sappsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			common.TagObjectToDelete(deployment)
+			err := r.ReconcileDeployment(deployment, reconcilers.CreateOnlyMutator)
+			if err != nil {
+				return err
+			}
+		}
+
+		err := r.deletePodMonitor(name)
+		if err != nil {
+			return err
+		}
+	}
+
+	if keep != nil {
+		return nil
+	}
+
+	secret := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: component.DatastoreExportersSecretName}}
+	common.TagObjectToDelete(secret)
+	err := r.ReconcileSecret(secret, reconcilers.CreateOnlyMutator)
+	if err != nil {
+		return err
+	}
+
+	grafanaV5Dashboard := &grafanav1beta1.GrafanaDashboard{ObjectMeta: metav1.ObjectMeta{Name: component.DatastoresMonitoringName}}
+	common.TagObjectToDelete(grafanaV5Dashboard)
+	err = r.ReconcileGrafanaDashboards(grafanaV5Dashboard, reconcilers.DeleteOnlyMutator)
+	if err != nil {
+		return err
+	}
+	grafanaV4Dashboard := &grafanav1alpha1.GrafanaDashboard{ObjectMeta: metav1.ObjectMeta{Name: component.DatastoresMonitoringName}}
+	common.TagObjectToDelete(grafanaV4Dashboard)
+	err = r.ReconcileGrafanaDashboards(grafanaV4Dashboard, reconcilers.DeleteOnlyMutator)
+	if err != nil {
+		return err
+	}
+
+	prometheusRule := &monitoringv1.PrometheusRule{ObjectMeta: metav1.ObjectMeta{Name: component.DatastoresMonitoringName}}
+	common.TagObjectToDelete(prometheusRule)
+	return r.ReconcilePrometheusRules(prometheusRule, reconcilers.DeleteOnlyMutator)
+}
+
+func (r *DatastoreExportersReconciler) deletePodMonitor(name string) error {
+	kindExists, err := r.HasPodMonitors()
+	if err != nil || !kindExists {
+		return err
+	}
+
+	podMonitor := &monitoringv1.PodMonitor{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	common.TagObjectToDelete(podMonitor)
+	return r.ReconcileResource(&monitoringv1.PodMonitor{}, podMonitor, reconcilers.CreateOnlyMutator)
+}
+
+// datastoreExporterContainerMutator replaces the exporter container when the
+// connection settings change, i.e. the system database moved from MySQL to PostgreSQL
+func datastoreExporterContainerMutator(desired, existing *k8sappsv1.Deployment) (bool, error) {
+	desiredContainer := &desired.Spec.Template.Spec.Containers[0]
+	existingContainer := &existing.Spec.Template.Spec.Containers[0]
+
+	if existingContainer.Image == desiredContainer.Image &&
+		reflect.DeepEqual(existingContainer.Args, desiredContainer.Args) &&
+		reflect.DeepEqual(existingContainer.Env, desiredContainer.Env) &&
+		reflect.DeepEqual(existingContainer.Ports, desiredContainer.Ports) {
+		return false, nil
+	}
+
+	existingContainer.Image = desiredContainer.Image
+	existingContainer.Args = desiredContainer.Args
+	existingContainer.Env = desiredContainer.Env
+	existingContainer.Ports = desiredContainer.Ports
+	existingContainer.ReadinessProbe = desiredContainer.ReadinessProbe
+	return true, nil
+}
+
+func datastoreExporterImage(images *component.AmpImagesOptions, exporterType string) string {
+	switch exporterType {
+	case component.DatastoreExporterTypeMySQL:
+		return images.MySQLExporterImage
+	case component.DatastoreExporterTypePostgreSQL:
+		return images.PostgreSQLExporterImage
+	default:
+		return images.RedisExporterImage
+	}
+}
+
+func DatastoreExporters(apimanager *appsv1alpha1.APIManager, client client.Client) (*component.DatastoreExporters, error) {
+	optsProvider := NewDatastoreExportersOptionsProvider(apimanager, apimanager.Namespace, client)
+	opts, err := optsProvider.GetOptions()
+	if err != nil {
+		return nil, err
+	}
+	return component.NewDatastoreExporters(opts), nil
+}
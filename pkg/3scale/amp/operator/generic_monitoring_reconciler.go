@@ -48,6 +48,11 @@ func (r *GenericMonitoringReconciler) Reconcile() (reconcile.Result, error) {
 		return reconcile.Result{}, err
 	}
 
+	err = r.reconcileUserGrafanaDashboards()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
 	prometheusRule := component.KubeStateMetricsPrometheusRules(sumRate, r.apiManager.Namespace, *r.apiManager.Spec.AppLabel)
 	err = r.ReconcilePrometheusRules(prometheusRule, reconcilers.CreateOnlyMutator)
 	if err != nil {
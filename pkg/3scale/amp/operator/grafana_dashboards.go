@@ -0,0 +1,94 @@
+package operator
+
+import (
+	grafanav1alpha1 "github.com/grafana-operator/grafana-operator/v4/api/integreatly/v1alpha1"
+	grafanav1beta1 "github.com/grafana-operator/grafana-operator/v5/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/common"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+)
+
+// GrafanaDashboardPlacement reads the dashboard placement options of the APIManager
+func GrafanaDashboardPlacement(apimanager *appsv1alpha1.APIManager) *component.GrafanaDashboardPlacement {
+	grafanaSpec := apimanager.GrafanaSpec()
+
+	placement := &component.GrafanaDashboardPlacement{
+		InstanceSelector: grafanaSpec.InstanceSelector,
+	}
+	if grafanaSpec.Folder != nil {
+		placement.Folder = *grafanaSpec.Folder
+	}
+	if grafanaSpec.Datasource != nil {
+		placement.Datasource = *grafanaSpec.Datasource
+	}
+	return placement
+}
+
+// reconcileUserGrafanaDashboards installs the dashboards referenced in the APIManager
+// and removes the ones no longer referenced
+func (r *GenericMonitoringReconciler) reconcileUserGrafanaDashboards() error {
+	desiredNames := map[string]bool{}
+	for _, dashboard := range r.apiManager.GrafanaSpec().Dashboards {
+		desiredNames[dashboard.Name] = true
+
+		err := r.ReconcileGrafanaDashboards(component.UserGrafanaV5Dashboard(dashboard.Name, dashboard.ConfigMapRef, *r.apiManager.Spec.AppLabel), reconcilers.GenericGrafanaDashboardsMutator)
+		if err != nil {
+			return err
+		}
+		err = r.ReconcileGrafanaDashboards(component.UserGrafanaV4Dashboard(dashboard.Name, dashboard.ConfigMapRef, *r.apiManager.Spec.AppLabel), reconcilers.GenericGrafanaDashboardsMutator)
+		if err != nil {
+			return err
+		}
+	}
+
+	dashboardsAvailable, err := r.HasGrafanaDashboards()
+	if err != nil || !dashboardsAvailable {
+		return err
+	}
+
+	listOpts := []client.ListOption{
+		client.InNamespace(r.apiManager.Namespace),
+		client.MatchingLabels{component.GrafanaUserDashboardLabelKey: "user"},
+	}
+
+	if *r.crdAvailabilityCache.grafanaDashboardCRDV5Available {
+		dashboardList := &grafanav1beta1.GrafanaDashboardList{}
+		err = r.Client().List(r.Context(), dashboardList, listOpts...)
+		if err != nil {
+			return err
+		}
+		for idx := range dashboardList.Items {
+			if desiredNames[dashboardList.Items[idx].Name] {
+				continue
+			}
+			common.TagObjectToDelete(&dashboardList.Items[idx])
+			err = r.ReconcileResource(&grafanav1beta1.GrafanaDashboard{}, &dashboardList.Items[idx], reconcilers.CreateOnlyMutator)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if *r.crdAvailabilityCache.grafanaDashboardCRDV4Available {
+		dashboardList := &grafanav1alpha1.GrafanaDashboardList{}
+		err = r.Client().List(r.Context(), dashboardList, listOpts...)
+		if err != nil {
+			return err
+		}
+		for idx := range dashboardList.Items {
+			if desiredNames[dashboardList.Items[idx].Name] {
+				continue
+			}
+			common.TagObjectToDelete(&dashboardList.Items[idx])
+			err = r.ReconcileResource(&grafanav1alpha1.GrafanaDashboard{}, &dashboardList.Items[idx], reconcilers.CreateOnlyMutator)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
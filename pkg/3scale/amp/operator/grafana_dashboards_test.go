@@ -0,0 +1,122 @@
+package operator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	grafanav1beta1 "github.com/grafana-operator/grafana-operator/v5/api/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+)
+
+func TestGrafanaDashboardsPlacement(t *testing.T) {
+	s := scheme.Scheme
+	if err := appsv1alpha1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := grafanav1beta1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	folder := "3scale"
+	datasource := "thanos"
+	apimanager := basicApimanager()
+	apimanager.Spec.Monitoring = &appsv1alpha1.MonitoringSpec{
+		Enabled: true,
+		Grafana: &appsv1alpha1.GrafanaSpec{
+			InstanceSelector:   map[string]string{"dashboards": "3scale"},
+			Folder:             &folder,
+			Datasource:         &datasource,
+			DisabledDashboards: []string{"kubernetes-resources-by-pod"},
+			Dashboards: []appsv1alpha1.GrafanaDashboardSpec{
+				{
+					Name: "custom",
+					ConfigMapRef: v1.ConfigMapKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{Name: "my-dashboards"},
+						Key:                  "custom.json",
+					},
+				},
+			},
+		},
+	}
+
+	objs := []runtime.Object{
+		// disabled built-in dashboard must be removed
+		component.KubernetesResourcesByPodGrafanaV5Dashboard("sum_irate", namespace, appLabel),
+		// user dashboard no longer referenced must be removed
+		component.UserGrafanaV5Dashboard("removed", v1.ConfigMapKeySelector{}, appLabel),
+	}
+	for _, obj := range objs {
+		obj.(*grafanav1beta1.GrafanaDashboard).Namespace = namespace
+	}
+
+	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objs...).Build()
+	clientset := fakeclientset.NewSimpleClientset()
+	clientset.Resources = []*metav1.APIResourceList{{
+		GroupVersion: grafanav1beta1.GroupVersion.String(),
+		APIResources: []metav1.APIResource{
+			{Name: "grafanadashboards", Namespaced: true, Kind: "GrafanaDashboard"},
+		},
+	}}
+	log := logf.Log.WithName("operator_test")
+	baseReconciler := reconcilers.NewBaseReconciler(context.TODO(), cl, s, cl, log, clientset.Discovery(), record.NewFakeRecorder(100))
+	reconciler := NewGenericMonitoringReconciler(NewBaseAPIManagerLogicReconciler(baseReconciler, apimanager))
+
+	for _, dashboard := range []*grafanav1beta1.GrafanaDashboard{
+		component.KubernetesResourcesByNamespaceGrafanaV5Dashboard("sum_irate", namespace, appLabel),
+		component.KubernetesResourcesByPodGrafanaV5Dashboard("sum_irate", namespace, appLabel),
+	} {
+		err := reconciler.ReconcileGrafanaDashboards(dashboard, reconcilers.GenericGrafanaDashboardsMutator)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := reconciler.reconcileUserGrafanaDashboards()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"kubernetes-resources-by-pod", "removed"} {
+		err = cl.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, &grafanav1beta1.GrafanaDashboard{})
+		if !errors.IsNotFound(err) {
+			t.Errorf("dashboard %s should not exist: %v", name, err)
+		}
+	}
+
+	builtin := &grafanav1beta1.GrafanaDashboard{}
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: "kubernetes-resources-by-namespace", Namespace: namespace}, builtin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if builtin.Spec.InstanceSelector.MatchLabels["dashboards"] != "3scale" || builtin.Spec.FolderTitle != folder {
+		t.Errorf("unexpected placement: %v %s", builtin.Spec.InstanceSelector, builtin.Spec.FolderTitle)
+	}
+	if !strings.Contains(builtin.Spec.Json, `"value": "thanos"`) {
+		t.Error("datasource variable should select the configured datasource")
+	}
+
+	custom := &grafanav1beta1.GrafanaDashboard{}
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: "custom", Namespace: namespace}, custom)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if custom.Spec.ConfigMapRef == nil || custom.Spec.ConfigMapRef.Name != "my-dashboards" {
+		t.Errorf("unexpected configmap reference: %v", custom.Spec.ConfigMapRef)
+	}
+	if len(custom.Spec.Datasources) != 1 || custom.Spec.Datasources[0].DatasourceName != datasource {
+		t.Errorf("unexpected datasources: %v", custom.Spec.Datasources)
+	}
+}
@@ -32,3 +32,27 @@ func SystemSearchdImageURL() string {
 func ZyncPostgreSQLImageURL() string {
 	return helper.GetEnvVar("RELATED_IMAGE_ZYNC_POSTGRESQL", component.ZyncPostgreSQLImageURL())
 }
+
+func SystemPgBouncerImageURL() string {
+	return helper.GetEnvVar("RELATED_IMAGE_SYSTEM_PGBOUNCER", component.SystemPgBouncerImageURL())
+}
+
+func SystemPgBouncerExporterImageURL() string {
+	return helper.GetEnvVar("RELATED_IMAGE_SYSTEM_PGBOUNCER_EXPORTER", component.SystemPgBouncerExporterImageURL())
+}
+
+func RedisExporterImageURL() string {
+	return helper.GetEnvVar("RELATED_IMAGE_REDIS_EXPORTER", component.RedisExporterImageURL())
+}
+
+func MySQLExporterImageURL() string {
+	return helper.GetEnvVar("RELATED_IMAGE_MYSQL_EXPORTER", component.MySQLExporterImageURL())
+}
+
+func PostgreSQLExporterImageURL() string {
+	return helper.GetEnvVar("RELATED_IMAGE_POSTGRESQL_EXPORTER", component.PostgreSQLExporterImageURL())
+}
+
+func SystemStorageMigrationImageURL() string {
+	return helper.GetEnvVar("RELATED_IMAGE_SYSTEM_STORAGE_MIGRATION", component.SystemStorageMigrationImageURL())
+}
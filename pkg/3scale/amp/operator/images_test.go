@@ -22,6 +22,12 @@ func TestImageURLFromEnv(t *testing.T) {
 		{"SystemMemcachedURL", "RELATED_IMAGE_SYSTEM_MEMCACHED", func() string { return SystemMemcachedImageURL() }},
 		{"SystemSearchdImageURL", "RELATED_IMAGE_SYSTEM_SEARCHD", func() string { return SystemSearchdImageURL() }},
 		{"ZyncPostgreSQLImageURL", "RELATED_IMAGE_ZYNC_POSTGRESQL", func() string { return ZyncPostgreSQLImageURL() }},
+		{"SystemPgBouncerImageURL", "RELATED_IMAGE_SYSTEM_PGBOUNCER", func() string { return SystemPgBouncerImageURL() }},
+		{"SystemPgBouncerExporterImageURL", "RELATED_IMAGE_SYSTEM_PGBOUNCER_EXPORTER", func() string { return SystemPgBouncerExporterImageURL() }},
+		{"RedisExporterImageURL", "RELATED_IMAGE_REDIS_EXPORTER", func() string { return RedisExporterImageURL() }},
+		{"MySQLExporterImageURL", "RELATED_IMAGE_MYSQL_EXPORTER", func() string { return MySQLExporterImageURL() }},
+		{"PostgreSQLExporterImageURL", "RELATED_IMAGE_POSTGRESQL_EXPORTER", func() string { return PostgreSQLExporterImageURL() }},
+		{"SystemStorageMigrationImageURL", "RELATED_IMAGE_SYSTEM_STORAGE_MIGRATION", func() string { return SystemStorageMigrationImageURL() }},
 	}
 
 	for _, tc := range cases {
@@ -51,6 +57,12 @@ func TestImageURLDefault(t *testing.T) {
 		{"SystemMemcachedImageURL", func() string { return SystemMemcachedImageURL() }, func() string { return component.SystemMemcachedImageURL() }},
 		{"SystemSearchdImageURL", func() string { return SystemSearchdImageURL() }, func() string { return component.SystemSearchdImageURL() }},
 		{"ZyncPostgreSQLImageURL", func() string { return ZyncPostgreSQLImageURL() }, func() string { return component.ZyncPostgreSQLImageURL() }},
+		{"SystemPgBouncerImageURL", func() string { return SystemPgBouncerImageURL() }, func() string { return component.SystemPgBouncerImageURL() }},
+		{"SystemPgBouncerExporterImageURL", func() string { return SystemPgBouncerExporterImageURL() }, func() string { return component.SystemPgBouncerExporterImageURL() }},
+		{"RedisExporterImageURL", func() string { return RedisExporterImageURL() }, func() string { return component.RedisExporterImageURL() }},
+		{"MySQLExporterImageURL", func() string { return MySQLExporterImageURL() }, func() string { return component.MySQLExporterImageURL() }},
+		{"PostgreSQLExporterImageURL", func() string { return PostgreSQLExporterImageURL() }, func() string { return component.PostgreSQLExporterImageURL() }},
+		{"SystemStorageMigrationImageURL", func() string { return SystemStorageMigrationImageURL() }, func() string { return component.SystemStorageMigrationImageURL() }},
 	}
 
 	for _, tc := range cases {
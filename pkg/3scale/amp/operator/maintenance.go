@@ -0,0 +1,230 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	k8sappsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/common"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+)
+
+const (
+	// MaintenanceReplicasAnnotation keeps the replicas a Deployment had before
+	// being scaled to zero by the maintenance mode
+	MaintenanceReplicasAnnotation = "apps.3scale.net/maintenance-replicas"
+)
+
+// maintenanceTiers lists the Deployments in the order they are scaled down
+// by the maintenance mode: traffic entry points first, datastores last.
+// They are scaled back up in reverse order
+var maintenanceTiers = [][]string{
+	{component.ApicastStagingName, component.ApicastProductionName},
+	{component.BackendListenerName},
+	{
+		component.SystemAppDeploymentName, component.SystemSidekiqName,
+		component.BackendWorkerName, component.BackendCronName,
+		component.ZyncName, component.ZyncQueDeploymentName,
+	},
+	{
+		component.SystemMemcachedDeploymentName, component.SystemSearchdDeploymentName,
+		component.SystemPgBouncerDeploymentName, component.ZyncDatabaseDeploymentName,
+	},
+}
+
+// MaintenancePausedDeployments returns the Deployments scaled to zero by the
+// maintenance mode of the APIManager
+func MaintenancePausedDeployments(apimanager *appsv1alpha1.APIManager) []string {
+	switch apimanager.MaintenanceMode() {
+	case appsv1alpha1.MaintenanceModeFull:
+		var names []string
+		for _, tier := range maintenanceTiers {
+			names = append(names, tier...)
+		}
+		return names
+	case appsv1alpha1.MaintenanceModeReadOnly:
+		return []string{component.SystemAppDeploymentName, component.SystemSidekiqName}
+	}
+
+	return nil
+}
+
+func maintenanceTier(deploymentName string) int {
+	for idx, tier := range maintenanceTiers {
+		if helper.ArrayContains(tier, deploymentName) {
+			return idx
+		}
+	}
+	return -1
+}
+
+// maintenanceDeploymentMutator scales the Deployments paused by the maintenance mode
+// to zero and restores the original replicas when they are no longer paused.
+// A Deployment is only scaled down once the paused Deployments of previous tiers
+// have no pods left, and only scaled up once the Deployments of next tiers are back
+func (r *BaseAPIManagerLogicReconciler) maintenanceDeploymentMutator(mutateFn reconcilers.MutateFn) reconcilers.MutateFn {
+	return func(existingObj, desiredObj common.KubernetesObject) (bool, error) {
+		existing, ok := existingObj.(*k8sappsv1.Deployment)
+		if !ok {
+			return false, fmt.Errorf("%T is not a *k8sappsv1.Deployment", existingObj)
+		}
+		desired, ok := desiredObj.(*k8sappsv1.Deployment)
+		if !ok {
+			return false, fmt.Errorf("%T is not a *k8sappsv1.Deployment", desiredObj)
+		}
+
+		paused := helper.ArrayContains(MaintenancePausedDeployments(r.apiManager), existing.Name)
+		originalReplicas, inMaintenance := existing.GetAnnotations()[MaintenanceReplicasAnnotation]
+		if !paused && !inMaintenance {
+			return mutateFn(existing, desired)
+		}
+
+		currentReplicas := int32(1)
+		if existing.Spec.Replicas != nil {
+			currentReplicas = *existing.Spec.Replicas
+		}
+
+		targetReplicas := int32(0)
+		switch {
+		case paused && !inMaintenance:
+			drained, err := r.maintenancePreviousTiersDrained(existing.Name)
+			if err != nil {
+				return false, err
+			}
+			if !drained {
+				targetReplicas = currentReplicas
+				break
+			}
+			originalReplicas = strconv.Itoa(int(currentReplicas))
+			inMaintenance = true
+		case !paused && inMaintenance:
+			restored, err := r.maintenanceNextTiersRestored(existing.Name)
+			if err != nil {
+				return false, err
+			}
+			if !restored {
+				break
+			}
+			replicas, err := strconv.ParseInt(originalReplicas, 10, 32)
+			if err != nil {
+				return false, fmt.Errorf("invalid %s annotation in deployment %s: %w", MaintenanceReplicasAnnotation, existing.Name, err)
+			}
+			targetReplicas = int32(replicas)
+			inMaintenance = false
+		}
+
+		desired.Spec.Replicas = &targetReplicas
+		updated, err := mutateFn(existing, desired)
+		if err != nil {
+			return false, err
+		}
+
+		if existing.Spec.Replicas == nil || *existing.Spec.Replicas != targetReplicas {
+			existing.Spec.Replicas = &targetReplicas
+			updated = true
+		}
+
+		annotations := existing.GetAnnotations()
+		if inMaintenance && annotations[MaintenanceReplicasAnnotation] != originalReplicas {
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			annotations[MaintenanceReplicasAnnotation] = originalReplicas
+			existing.SetAnnotations(annotations)
+			updated = true
+		}
+		if _, ok := annotations[MaintenanceReplicasAnnotation]; ok && !inMaintenance {
+			delete(annotations, MaintenanceReplicasAnnotation)
+			existing.SetAnnotations(annotations)
+			updated = true
+		}
+
+		return updated, nil
+	}
+}
+
+// maintenanceNewDeployment sets zero replicas on a Deployment about to be created
+// while paused, keeping the replicas it has to be restored to
+func maintenanceNewDeployment(desired *k8sappsv1.Deployment) {
+	replicas := int32(1)
+	if desired.Spec.Replicas != nil {
+		replicas = *desired.Spec.Replicas
+	}
+	if desired.Annotations == nil {
+		desired.Annotations = map[string]string{}
+	}
+	desired.Annotations[MaintenanceReplicasAnnotation] = strconv.Itoa(int(replicas))
+	zero := int32(0)
+	desired.Spec.Replicas = &zero
+}
+
+// maintenancePreviousTiersDrained returns true when the paused Deployments
+// of the tiers scaled down before the given Deployment have no pods left
+func (r *BaseAPIManagerLogicReconciler) maintenancePreviousTiersDrained(deploymentName string) (bool, error) {
+	pausedDeployments := MaintenancePausedDeployments(r.apiManager)
+	for idx := 0; idx < maintenanceTier(deploymentName); idx++ {
+		for _, name := range maintenanceTiers[idx] {
+			if !helper.ArrayContains(pausedDeployments, name) {
+				continue
+			}
+			deployment, err := r.maintenanceDeployment(name)
+			if err != nil {
+				return false, err
+			}
+			if deployment != nil && (deployment.Spec.Replicas == nil || *deployment.Spec.Replicas != 0 || deployment.Status.Replicas != 0) {
+				return false, nil
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// maintenanceNextTiersRestored returns true when the Deployments of the tiers
+// scaled up before the given Deployment are no longer in maintenance and available
+func (r *BaseAPIManagerLogicReconciler) maintenanceNextTiersRestored(deploymentName string) (bool, error) {
+	tier := maintenanceTier(deploymentName)
+	if tier == -1 {
+		return true, nil
+	}
+
+	for idx := tier + 1; idx < len(maintenanceTiers); idx++ {
+		for _, name := range maintenanceTiers[idx] {
+			deployment, err := r.maintenanceDeployment(name)
+			if err != nil {
+				return false, err
+			}
+			if deployment == nil {
+				continue
+			}
+			if _, ok := deployment.GetAnnotations()[MaintenanceReplicasAnnotation]; ok {
+				return false, nil
+			}
+			if !helper.IsDeploymentAvailable(deployment) {
+				return false, nil
+			}
+		}
+	}
+
+	return true, nil
+}
+
+func (r *BaseAPIManagerLogicReconciler) maintenanceDeployment(name string) (*k8sappsv1.Deployment, error) {
+	deployment := &k8sappsv1.Deployment{}
+	err := r.Client().Get(context.TODO(), client.ObjectKey{Namespace: r.apiManager.Namespace, Name: name}, deployment)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return deployment, nil
+}
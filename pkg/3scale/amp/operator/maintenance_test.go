@@ -0,0 +1,175 @@
+package operator
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+
+	k8sappsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func testMaintenanceDeployment(name string, replicas, statusReplicas int32, annotations map[string]string) *k8sappsv1.Deployment {
+	return &k8sappsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Annotations: annotations},
+		Spec:       k8sappsv1.DeploymentSpec{Replicas: &replicas},
+		Status: k8sappsv1.DeploymentStatus{
+			Replicas: statusReplicas,
+			Conditions: []k8sappsv1.DeploymentCondition{
+				{Type: k8sappsv1.DeploymentAvailable, Status: v1.ConditionTrue},
+			},
+		},
+	}
+}
+
+func testMaintenanceApimanager(mode appsv1alpha1.MaintenanceMode) *appsv1alpha1.APIManager {
+	apimanager := basicApimanager()
+	if mode != "" {
+		apimanager.Spec.Maintenance = &appsv1alpha1.MaintenanceSpec{Mode: mode}
+	}
+	return apimanager
+}
+
+func TestMaintenancePausedDeployments(t *testing.T) {
+	cases := []struct {
+		testName string
+		mode     appsv1alpha1.MaintenanceMode
+		expected []string
+	}{
+		{"disabled", "", nil},
+		{"readOnly", appsv1alpha1.MaintenanceModeReadOnly, []string{component.SystemAppDeploymentName, component.SystemSidekiqName}},
+		{"full", appsv1alpha1.MaintenanceModeFull, []string{
+			component.ApicastStagingName, component.ApicastProductionName,
+			component.BackendListenerName,
+			component.SystemAppDeploymentName, component.SystemSidekiqName,
+			component.BackendWorkerName, component.BackendCronName,
+			component.ZyncName, component.ZyncQueDeploymentName,
+			component.SystemMemcachedDeploymentName, component.SystemSearchdDeploymentName,
+			component.SystemPgBouncerDeploymentName, component.ZyncDatabaseDeploymentName,
+		}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			paused := MaintenancePausedDeployments(testMaintenanceApimanager(tc.mode))
+			if !reflect.DeepEqual(paused, tc.expected) {
+				subT.Fatalf("expected %v, got %v", tc.expected, paused)
+			}
+		})
+	}
+}
+
+func TestMaintenanceReconcileDeployment(t *testing.T) {
+	cases := []struct {
+		testName           string
+		mode               appsv1alpha1.MaintenanceMode
+		deploymentName     string
+		objs               []runtime.Object
+		expectedReplicas   int32
+		expectedAnnotation string
+		expectedAnnotated  bool
+	}{
+		{
+			"scaled down", appsv1alpha1.MaintenanceModeFull, component.ApicastProductionName,
+			[]runtime.Object{testMaintenanceDeployment(component.ApicastProductionName, 2, 2, nil)},
+			0, "2", true,
+		},
+		{
+			"held until previous tiers are drained", appsv1alpha1.MaintenanceModeFull, component.SystemAppDeploymentName,
+			[]runtime.Object{
+				testMaintenanceDeployment(component.SystemAppDeploymentName, 2, 2, nil),
+				testMaintenanceDeployment(component.ApicastStagingName, 0, 1, map[string]string{MaintenanceReplicasAnnotation: "1"}),
+			},
+			2, "", false,
+		},
+		{
+			"scaled down when previous tiers are drained", appsv1alpha1.MaintenanceModeFull, component.SystemAppDeploymentName,
+			[]runtime.Object{
+				testMaintenanceDeployment(component.SystemAppDeploymentName, 2, 2, nil),
+				testMaintenanceDeployment(component.ApicastStagingName, 0, 0, map[string]string{MaintenanceReplicasAnnotation: "1"}),
+			},
+			0, "2", true,
+		},
+		{
+			"not paused in readOnly mode", appsv1alpha1.MaintenanceModeReadOnly, component.ApicastProductionName,
+			[]runtime.Object{testMaintenanceDeployment(component.ApicastProductionName, 2, 2, nil)},
+			2, "", false,
+		},
+		{
+			"restored", "", component.SystemAppDeploymentName,
+			[]runtime.Object{testMaintenanceDeployment(component.SystemAppDeploymentName, 0, 0, map[string]string{MaintenanceReplicasAnnotation: "3"})},
+			3, "", false,
+		},
+		{
+			"held until next tiers are restored", "", component.SystemAppDeploymentName,
+			[]runtime.Object{
+				testMaintenanceDeployment(component.SystemAppDeploymentName, 0, 0, map[string]string{MaintenanceReplicasAnnotation: "3"}),
+				testMaintenanceDeployment(component.SystemMemcachedDeploymentName, 0, 0, map[string]string{MaintenanceReplicasAnnotation: "1"}),
+			},
+			0, "3", true,
+		},
+		{
+			"created while paused", appsv1alpha1.MaintenanceModeFull, component.SystemAppDeploymentName,
+			nil,
+			0, "1", true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			log := logf.Log.WithName("operator_test")
+			ctx := context.TODO()
+			apimanager := testMaintenanceApimanager(tc.mode)
+			s := scheme.Scheme
+			s.AddKnownTypes(appsv1alpha1.GroupVersion, apimanager)
+			err := k8sappsv1.AddToScheme(s)
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			cl := fake.NewFakeClient(tc.objs...)
+			clientAPIReader := fake.NewFakeClient(tc.objs...)
+			clientset := fakeclientset.NewSimpleClientset()
+			recorder := record.NewFakeRecorder(10000)
+
+			baseReconciler := reconcilers.NewBaseReconciler(ctx, cl, s, clientAPIReader, log, clientset.Discovery(), recorder)
+			baseAPIManagerLogicReconciler := NewBaseAPIManagerLogicReconciler(baseReconciler, apimanager)
+
+			oneValue := int32(1)
+			desired := &k8sappsv1.Deployment{
+				TypeMeta:   metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+				ObjectMeta: metav1.ObjectMeta{Name: tc.deploymentName},
+				Spec:       k8sappsv1.DeploymentSpec{Replicas: &oneValue},
+			}
+			err = baseAPIManagerLogicReconciler.ReconcileDeployment(desired, reconcilers.DeploymentMutator())
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			deployment := &k8sappsv1.Deployment{}
+			err = cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: tc.deploymentName}, deployment)
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if deployment.Spec.Replicas == nil || *deployment.Spec.Replicas != tc.expectedReplicas {
+				subT.Fatalf("expected %d replicas, got %v", tc.expectedReplicas, deployment.Spec.Replicas)
+			}
+			annotation, ok := deployment.Annotations[MaintenanceReplicasAnnotation]
+			if ok != tc.expectedAnnotated || annotation != tc.expectedAnnotation {
+				subT.Fatalf("expected annotation %q (present: %t), got %q (present: %t)", tc.expectedAnnotation, tc.expectedAnnotated, annotation, ok)
+			}
+		})
+	}
+}
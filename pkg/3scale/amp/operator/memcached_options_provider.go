@@ -8,8 +8,6 @@ import (
 	"github.com/3scale/3scale-operator/pkg/helper"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 	"github.com/3scale/3scale-operator/version"
-
-	v1 "k8s.io/api/core/v1"
 )
 
 type MemcachedOptionsProvider struct {
@@ -43,15 +41,11 @@ func (m *MemcachedOptionsProvider) GetMemcachedOptions() (*component.MemcachedOp
 }
 
 func (m *MemcachedOptionsProvider) setResourceRequirementsOptions() {
-	if *m.apimanager.Spec.ResourceRequirementsEnabled {
-		m.memcachedOptions.ResourceRequirements = component.DefaultMemcachedResourceRequirements()
-	} else {
-		m.memcachedOptions.ResourceRequirements = v1.ResourceRequirements{}
-	}
+	m.memcachedOptions.ResourceRequirements = profileResourceRequirements(m.apimanager, component.DefaultMemcachedResourceRequirements())
 
 	// Deployment-level ResourceRequirements CR fields have priority over
-	// spec.resourceRequirementsEnabled, overwriting that setting when they are
-	// defined
+	// spec.resourceRequirementsEnabled and spec.profile, overwriting those settings
+	// when they are defined
 	if m.apimanager.Spec.System.MemcachedResources != nil {
 		m.memcachedOptions.ResourceRequirements = *m.apimanager.Spec.System.MemcachedResources
 	}
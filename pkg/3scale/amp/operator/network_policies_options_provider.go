@@ -0,0 +1,76 @@
+package operator
+
+import (
+	"fmt"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+)
+
+type NetworkPoliciesOptionsProvider struct {
+	apimanager             *appsv1alpha1.APIManager
+	networkPoliciesOptions *component.NetworkPoliciesOptions
+}
+
+func NewNetworkPoliciesOptionsProvider(apimanager *appsv1alpha1.APIManager) *NetworkPoliciesOptionsProvider {
+	return &NetworkPoliciesOptionsProvider{
+		apimanager:             apimanager,
+		networkPoliciesOptions: component.NewNetworkPoliciesOptions(),
+	}
+}
+
+func (n *NetworkPoliciesOptionsProvider) GetNetworkPoliciesOptions() (*component.NetworkPoliciesOptions, error) {
+	n.networkPoliciesOptions.CommonLabels = n.commonLabels()
+
+	n.setNamespaceSelectors()
+	n.setApicastHTTPSPorts()
+	n.networkPoliciesOptions.ZyncDatabaseExporter = n.apimanager.IsDatastoreExportersEnabled()
+
+	err := n.networkPoliciesOptions.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("GetNetworkPoliciesOptions validating: %w", err)
+	}
+	return n.networkPoliciesOptions, nil
+}
+
+func (n *NetworkPoliciesOptionsProvider) setNamespaceSelectors() {
+	n.networkPoliciesOptions.IngressControllerNamespaceSelector = component.DefaultIngressControllerNamespaceSelector()
+	n.networkPoliciesOptions.MonitoringNamespaceSelector = component.DefaultMonitoringNamespaceSelector()
+
+	spec := n.apimanager.Spec.NetworkPolicies
+	if spec == nil {
+		return
+	}
+
+	if spec.IngressControllerNamespaceSelector != nil {
+		n.networkPoliciesOptions.IngressControllerNamespaceSelector = *spec.IngressControllerNamespaceSelector
+	}
+
+	if spec.MonitoringNamespaceSelector != nil {
+		n.networkPoliciesOptions.MonitoringNamespaceSelector = *spec.MonitoringNamespaceSelector
+	}
+
+	n.networkPoliciesOptions.AllowedNamespaces = spec.AllowedNamespaces
+}
+
+func (n *NetworkPoliciesOptionsProvider) setApicastHTTPSPorts() {
+	productionSpec := n.apimanager.Spec.Apicast.ProductionSpec
+	n.networkPoliciesOptions.ApicastProductionHTTPSPort = productionSpec.HTTPSPort
+	if productionSpec.HTTPSCertificateSecretRef != nil && productionSpec.HTTPSPort == nil {
+		tmpDefaultPort := appsv1alpha1.DefaultHTTPSPort
+		n.networkPoliciesOptions.ApicastProductionHTTPSPort = &tmpDefaultPort
+	}
+
+	stagingSpec := n.apimanager.Spec.Apicast.StagingSpec
+	n.networkPoliciesOptions.ApicastStagingHTTPSPort = stagingSpec.HTTPSPort
+	if stagingSpec.HTTPSCertificateSecretRef != nil && stagingSpec.HTTPSPort == nil {
+		tmpDefaultPort := appsv1alpha1.DefaultHTTPSPort
+		n.networkPoliciesOptions.ApicastStagingHTTPSPort = &tmpDefaultPort
+	}
+}
+
+func (n *NetworkPoliciesOptionsProvider) commonLabels() map[string]string {
+	return map[string]string{
+		"app": *n.apimanager.Spec.AppLabel,
+	}
+}
@@ -0,0 +1,118 @@
+package operator
+
+import (
+	"reflect"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testNetworkPoliciesIngressControllerSelector() *metav1.LabelSelector {
+	return &metav1.LabelSelector{
+		MatchLabels: map[string]string{"ingress": "true"},
+	}
+}
+
+func testNetworkPoliciesMonitoringSelector() *metav1.LabelSelector {
+	return &metav1.LabelSelector{
+		MatchLabels: map[string]string{"monitoring": "true"},
+	}
+}
+
+func defaultNetworkPoliciesOptions() *component.NetworkPoliciesOptions {
+	return &component.NetworkPoliciesOptions{
+		CommonLabels:                       map[string]string{"app": appLabel},
+		IngressControllerNamespaceSelector: component.DefaultIngressControllerNamespaceSelector(),
+		MonitoringNamespaceSelector:        component.DefaultMonitoringNamespaceSelector(),
+	}
+}
+
+func TestNetworkPoliciesOptionsProvider(t *testing.T) {
+	var customHTTPSPort int32 = 9443
+
+	cases := []struct {
+		testName               string
+		apimanagerFactory      func() *appsv1alpha1.APIManager
+		expectedOptionsFactory func() *component.NetworkPoliciesOptions
+	}{
+		{"Default", basicApimanager, defaultNetworkPoliciesOptions},
+		{"WithNamespaceSelectors",
+			func() *appsv1alpha1.APIManager {
+				apimanager := basicApimanager()
+				apimanager.Spec.NetworkPolicies = &appsv1alpha1.NetworkPoliciesSpec{
+					Enabled:                            true,
+					IngressControllerNamespaceSelector: testNetworkPoliciesIngressControllerSelector(),
+					MonitoringNamespaceSelector:        testNetworkPoliciesMonitoringSelector(),
+				}
+				return apimanager
+			},
+			func() *component.NetworkPoliciesOptions {
+				opts := defaultNetworkPoliciesOptions()
+				opts.IngressControllerNamespaceSelector = *testNetworkPoliciesIngressControllerSelector()
+				opts.MonitoringNamespaceSelector = *testNetworkPoliciesMonitoringSelector()
+				return opts
+			},
+		},
+		{"WithAllowedNamespaces",
+			func() *appsv1alpha1.APIManager {
+				apimanager := basicApimanager()
+				apimanager.Spec.NetworkPolicies = &appsv1alpha1.NetworkPoliciesSpec{
+					Enabled:           true,
+					AllowedNamespaces: []string{"ns1", "ns2"},
+				}
+				return apimanager
+			},
+			func() *component.NetworkPoliciesOptions {
+				opts := defaultNetworkPoliciesOptions()
+				opts.AllowedNamespaces = []string{"ns1", "ns2"}
+				return opts
+			},
+		},
+		{"WithApicastHTTPSPorts",
+			func() *appsv1alpha1.APIManager {
+				apimanager := basicApimanager()
+				apimanager.Spec.Apicast.ProductionSpec.HTTPSPort = &customHTTPSPort
+				apimanager.Spec.Apicast.StagingSpec.HTTPSCertificateSecretRef = &v1.LocalObjectReference{Name: "mysecret"}
+				return apimanager
+			},
+			func() *component.NetworkPoliciesOptions {
+				opts := defaultNetworkPoliciesOptions()
+				defaultHTTPSPort := appsv1alpha1.DefaultHTTPSPort
+				opts.ApicastProductionHTTPSPort = &customHTTPSPort
+				opts.ApicastStagingHTTPSPort = &defaultHTTPSPort
+				return opts
+			},
+		},
+		{"WithDatastoreExporters",
+			func() *appsv1alpha1.APIManager {
+				apimanager := basicApimanager()
+				apimanager.Spec.Monitoring = &appsv1alpha1.MonitoringSpec{Enabled: true}
+				return apimanager
+			},
+			func() *component.NetworkPoliciesOptions {
+				opts := defaultNetworkPoliciesOptions()
+				opts.ZyncDatabaseExporter = true
+				return opts
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			optsProvider := NewNetworkPoliciesOptionsProvider(tc.apimanagerFactory())
+			opts, err := optsProvider.GetNetworkPoliciesOptions()
+			if err != nil {
+				subT.Error(err)
+			}
+			expectedOptions := tc.expectedOptionsFactory()
+			if !reflect.DeepEqual(expectedOptions, opts) {
+				subT.Errorf("Resulting expected options differ: %s", cmp.Diff(expectedOptions, opts))
+			}
+		})
+	}
+}
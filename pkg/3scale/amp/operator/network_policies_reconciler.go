@@ -0,0 +1,84 @@
+package operator
+
+import (
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/common"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type NetworkPoliciesReconciler struct {
+	*BaseAPIManagerLogicReconciler
+}
+
+func NewNetworkPoliciesReconciler(baseAPIManagerLogicReconciler *BaseAPIManagerLogicReconciler) *NetworkPoliciesReconciler {
+	return &NetworkPoliciesReconciler{
+		BaseAPIManagerLogicReconciler: baseAPIManagerLogicReconciler,
+	}
+}
+
+func (r *NetworkPoliciesReconciler) Reconcile() (reconcile.Result, error) {
+	networkPolicies, err := NetworkPolicies(r.apiManager)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	desiredPolicies := []*networkingv1.NetworkPolicy{
+		networkPolicies.ApicastStagingNetworkPolicy(),
+		networkPolicies.ApicastProductionNetworkPolicy(),
+		networkPolicies.BackendListenerNetworkPolicy(),
+		networkPolicies.BackendWorkerNetworkPolicy(),
+		networkPolicies.BackendCronNetworkPolicy(),
+		networkPolicies.SystemAppNetworkPolicy(),
+		networkPolicies.SystemSidekiqNetworkPolicy(),
+		networkPolicies.SystemMemcachedNetworkPolicy(),
+		networkPolicies.SystemSearchdNetworkPolicy(),
+	}
+
+	pgBouncerPolicy := networkPolicies.SystemPgBouncerNetworkPolicy()
+	if !r.apiManager.IsSystemDatabaseConnectionPoolerEnabled() {
+		common.TagObjectToDelete(pgBouncerPolicy)
+	}
+	desiredPolicies = append(desiredPolicies, pgBouncerPolicy)
+
+	zyncPolicies := []*networkingv1.NetworkPolicy{
+		networkPolicies.ZyncNetworkPolicy(),
+		networkPolicies.ZyncQueNetworkPolicy(),
+	}
+	zyncDatabasePolicy := networkPolicies.ZyncDatabaseNetworkPolicy()
+
+	if !r.apiManager.IsZyncEnabled() {
+		for _, policy := range zyncPolicies {
+			common.TagObjectToDelete(policy)
+		}
+		common.TagObjectToDelete(zyncDatabasePolicy)
+	}
+
+	if r.apiManager.IsExternal(appsv1alpha1.ZyncDatabase) {
+		common.TagObjectToDelete(zyncDatabasePolicy)
+	}
+
+	desiredPolicies = append(desiredPolicies, zyncPolicies...)
+	desiredPolicies = append(desiredPolicies, zyncDatabasePolicy)
+
+	for _, policy := range desiredPolicies {
+		err = r.ReconcileNetworkPolicy(policy, reconcilers.GenericNetworkPolicyMutator)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	return reconcile.Result{}, nil
+}
+
+func NetworkPolicies(apimanager *appsv1alpha1.APIManager) (*component.NetworkPolicies, error) {
+	optsProvider := NewNetworkPoliciesOptionsProvider(apimanager)
+	opts, err := optsProvider.GetNetworkPoliciesOptions()
+	if err != nil {
+		return nil, err
+	}
+	return component.NewNetworkPolicies(opts), nil
+}
@@ -0,0 +1,152 @@
+package operator
+
+import (
+	"context"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestNetworkPoliciesReconciler(t *testing.T) {
+	falseValue := false
+
+	cases := []struct {
+		testName          string
+		apimanagerFactory func() *appsv1alpha1.APIManager
+		objName           string
+		exists            bool
+	}{
+		{"disabled", basicApimanager, "backend-listener", false},
+		{"enabled",
+			func() *appsv1alpha1.APIManager {
+				apimanager := basicApimanager()
+				apimanager.Spec.NetworkPolicies = &appsv1alpha1.NetworkPoliciesSpec{Enabled: true}
+				return apimanager
+			},
+			"backend-listener", true,
+		},
+		{"zyncDatabase",
+			func() *appsv1alpha1.APIManager {
+				apimanager := basicApimanager()
+				apimanager.Spec.NetworkPolicies = &appsv1alpha1.NetworkPoliciesSpec{Enabled: true}
+				return apimanager
+			},
+			"zync-database", true,
+		},
+		{"systemPgBouncerDisabled",
+			func() *appsv1alpha1.APIManager {
+				apimanager := basicApimanager()
+				apimanager.Spec.NetworkPolicies = &appsv1alpha1.NetworkPoliciesSpec{Enabled: true}
+				return apimanager
+			},
+			"system-pgbouncer", false,
+		},
+		{"systemPgBouncerEnabled",
+			func() *appsv1alpha1.APIManager {
+				apimanager := testSystemPgBouncerApimanager()
+				apimanager.Spec.NetworkPolicies = &appsv1alpha1.NetworkPoliciesSpec{Enabled: true}
+				return apimanager
+			},
+			"system-pgbouncer", true,
+		},
+		{"zyncDisabled",
+			func() *appsv1alpha1.APIManager {
+				apimanager := basicApimanager()
+				apimanager.Spec.NetworkPolicies = &appsv1alpha1.NetworkPoliciesSpec{Enabled: true}
+				apimanager.Spec.Zync.Enabled = &falseValue
+				return apimanager
+			},
+			"zync", false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			log := logf.Log.WithName("operator_test")
+			ctx := context.TODO()
+			apimanager := tc.apimanagerFactory()
+			s := scheme.Scheme
+			s.AddKnownTypes(appsv1alpha1.GroupVersion, apimanager)
+			err := networkingv1.AddToScheme(s)
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			// Objects to track in the fake client.
+			objs := []runtime.Object{}
+
+			// Create a fake client to mock API calls.
+			cl := fake.NewFakeClient(objs...)
+			clientAPIReader := fake.NewFakeClient(objs...)
+			clientset := fakeclientset.NewSimpleClientset()
+			recorder := record.NewFakeRecorder(10000)
+
+			baseReconciler := reconcilers.NewBaseReconciler(ctx, cl, s, clientAPIReader, log, clientset.Discovery(), recorder)
+			baseAPIManagerLogicReconciler := NewBaseAPIManagerLogicReconciler(baseReconciler, apimanager)
+
+			reconciler := NewNetworkPoliciesReconciler(baseAPIManagerLogicReconciler)
+			_, err = reconciler.Reconcile()
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			namespacedName := types.NamespacedName{Name: tc.objName, Namespace: namespace}
+			err = cl.Get(ctx, namespacedName, &networkingv1.NetworkPolicy{})
+			if tc.exists && err != nil {
+				subT.Errorf("error fetching object %s: %v", tc.objName, err)
+			}
+			if !tc.exists && !errors.IsNotFound(err) {
+				subT.Errorf("object %s should not exist: %v", tc.objName, err)
+			}
+		})
+	}
+}
+
+func TestNetworkPoliciesReconcilerZyncDatabaseExporter(t *testing.T) {
+	ctx := context.TODO()
+	apimanager := basicApimanager()
+	apimanager.Spec.NetworkPolicies = &appsv1alpha1.NetworkPoliciesSpec{Enabled: true}
+	apimanager.Spec.Monitoring = &appsv1alpha1.MonitoringSpec{Enabled: true}
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.GroupVersion, apimanager)
+	err := networkingv1.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cl := fake.NewFakeClient()
+	clientset := fakeclientset.NewSimpleClientset()
+	baseReconciler := reconcilers.NewBaseReconciler(ctx, cl, s, fake.NewFakeClient(), logf.Log.WithName("operator_test"), clientset.Discovery(), record.NewFakeRecorder(10000))
+
+	_, err = NewNetworkPoliciesReconciler(NewBaseAPIManagerLogicReconciler(baseReconciler, apimanager)).Reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policy := &networkingv1.NetworkPolicy{}
+	err = cl.Get(ctx, types.NamespacedName{Name: component.ZyncDatabaseDeploymentName, Namespace: namespace}, policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, rule := range policy.Spec.Ingress {
+		for _, port := range rule.Ports {
+			if port.Port != nil && port.Port.IntValue() == component.PostgreSQLExporterMetricsPort {
+				return
+			}
+		}
+	}
+	t.Fatalf("zync-database policy does not admit the exporter port %d: %v", component.PostgreSQLExporterMetricsPort, policy.Spec.Ingress)
+}
@@ -0,0 +1,65 @@
+package operator
+
+import (
+	k8sappsv1 "k8s.io/api/apps/v1"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+)
+
+// openTelemetryOptions returns the OTLP exporter options of the system, backend and zync components.
+// Returns nil when the instrumentation is disabled
+func openTelemetryOptions(spec *appsv1alpha1.ComponentOpenTelemetrySpec, secretSource *helper.SecretSource) (*component.OpenTelemetryOptions, error) {
+	if !spec.IsEnabled() {
+		return nil, nil
+	}
+
+	options := &component.OpenTelemetryOptions{
+		Endpoint:           spec.Endpoint,
+		ResourceAttributes: spec.ResourceAttributes,
+	}
+
+	if spec.SamplingRatio != nil {
+		options.SamplingRatio = *spec.SamplingRatio
+	}
+
+	if spec.TLSSecretRef != nil {
+		// CR Validation ensures the secret name is not empty
+		secretName := spec.TLSSecretRef.Name
+		_, err := secretSource.RequiredFieldValueFromRequiredSecret(secretName, component.OpenTelemetryTLSCAFieldName)
+		if err != nil {
+			return nil, err
+		}
+
+		cert, err := secretSource.FieldValue(secretName, component.OpenTelemetryTLSCertFieldName, "")
+		if err != nil {
+			return nil, err
+		}
+		key, err := secretSource.FieldValue(secretName, component.OpenTelemetryTLSKeyFieldName, "")
+		if err != nil {
+			return nil, err
+		}
+
+		options.TLSSecretName = &secretName
+		options.ClientCertificate = cert != "" && key != ""
+	}
+
+	return options, nil
+}
+
+// openTelemetryMutator reconciles the OpenTelemetry env vars and the TLS volume
+func openTelemetryMutator(desired, existing *k8sappsv1.Deployment) (bool, error) {
+	update := false
+
+	for _, envVar := range component.OpenTelemetryEnvVarNames {
+		tmpChanged := reconcilers.DeploymentEnvVarReconciler(desired, existing, envVar)
+		update = update || tmpChanged
+	}
+
+	tmpChanged := reconcilers.DeploymentVolumeReconciler(desired, existing, component.OpenTelemetryTLSVolumeName)
+	update = update || tmpChanged
+
+	return update, nil
+}
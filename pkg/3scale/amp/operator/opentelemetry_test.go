@@ -0,0 +1,189 @@
+package operator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/helper"
+)
+
+func TestOpenTelemetryOptions(t *testing.T) {
+	const namespace = "operator-unittest"
+	trueValue := true
+	samplingRatio := "0.25"
+	tlsSecretName := "otel-tls"
+
+	caOnlySecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: tlsSecretName, Namespace: namespace},
+		Data:       map[string][]byte{"ca.crt": []byte("ca")},
+	}
+	clientCertSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: tlsSecretName, Namespace: namespace},
+		Data: map[string][]byte{
+			"ca.crt":  []byte("ca"),
+			"tls.crt": []byte("cert"),
+			"tls.key": []byte("key"),
+		},
+	}
+
+	cases := []struct {
+		testName    string
+		spec        *appsv1alpha1.ComponentOpenTelemetrySpec
+		secret      *v1.Secret
+		expected    *component.OpenTelemetryOptions
+		expectedErr bool
+	}{
+		{"Unset", nil, nil, nil, false},
+		{"Disabled", &appsv1alpha1.ComponentOpenTelemetrySpec{Endpoint: "http://collector:4318"}, nil, nil, false},
+		{"Enabled",
+			&appsv1alpha1.ComponentOpenTelemetrySpec{
+				Enabled:            &trueValue,
+				Endpoint:           "http://collector:4318",
+				SamplingRatio:      &samplingRatio,
+				ResourceAttributes: map[string]string{"deployment.environment": "prod"},
+			}, nil,
+			&component.OpenTelemetryOptions{
+				Endpoint:           "http://collector:4318",
+				SamplingRatio:      samplingRatio,
+				ResourceAttributes: map[string]string{"deployment.environment": "prod"},
+			}, false,
+		},
+		{"TLSCAOnly",
+			&appsv1alpha1.ComponentOpenTelemetrySpec{
+				Enabled:      &trueValue,
+				Endpoint:     "https://collector:4318",
+				TLSSecretRef: &v1.LocalObjectReference{Name: tlsSecretName},
+			}, caOnlySecret,
+			&component.OpenTelemetryOptions{Endpoint: "https://collector:4318", TLSSecretName: &tlsSecretName}, false,
+		},
+		{"TLSClientCertificate",
+			&appsv1alpha1.ComponentOpenTelemetrySpec{
+				Enabled:      &trueValue,
+				Endpoint:     "https://collector:4318",
+				TLSSecretRef: &v1.LocalObjectReference{Name: tlsSecretName},
+			}, clientCertSecret,
+			&component.OpenTelemetryOptions{Endpoint: "https://collector:4318", TLSSecretName: &tlsSecretName, ClientCertificate: true}, false,
+		},
+		{"TLSSecretMissing",
+			&appsv1alpha1.ComponentOpenTelemetrySpec{
+				Enabled:      &trueValue,
+				Endpoint:     "https://collector:4318",
+				TLSSecretRef: &v1.LocalObjectReference{Name: tlsSecretName},
+			}, nil, nil, true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			cl := fake.NewFakeClient()
+			if tc.secret != nil {
+				cl = fake.NewFakeClient(tc.secret)
+			}
+
+			opts, err := openTelemetryOptions(tc.spec, helper.NewSecretSource(cl, namespace))
+			if (err != nil) != tc.expectedErr {
+				subT.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, opts); diff != "" {
+				subT.Fatalf("unexpected options (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOpenTelemetryMutator(t *testing.T) {
+	tlsSecretName := "otel-tls"
+	listenerDeployment := func(openTelemetry *component.OpenTelemetryOptions) *k8sappsv1.Deployment {
+		return component.NewBackend(&component.BackendOptions{
+			ListenerWorkers: component.DefaultBackendListenerWorkers,
+			OpenTelemetry:   openTelemetry,
+		}).ListenerDeployment("backend-image")
+	}
+	openTelemetry := &component.OpenTelemetryOptions{
+		Endpoint:           "https://collector:4318",
+		ResourceAttributes: map[string]string{"b": "2", "a": "1"},
+		TLSSecretName:      &tlsSecretName,
+	}
+
+	existing := listenerDeployment(nil)
+	changed, err := openTelemetryMutator(listenerDeployment(openTelemetry), existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Fatal("expected the opentelemetry configuration to be added")
+	}
+
+	envs := map[string]string{}
+	for _, env := range existing.Spec.Template.Spec.Containers[0].Env {
+		envs[env.Name] = env.Value
+	}
+	expectedEnvs := map[string]string{
+		"CONFIG_OPENTELEMETRY_ENABLED":   "true",
+		"OTEL_SERVICE_NAME":              component.BackendListenerName,
+		"OTEL_TRACES_EXPORTER":           "otlp",
+		"OTEL_EXPORTER_OTLP_ENDPOINT":    "https://collector:4318",
+		"OTEL_TRACES_SAMPLER":            "parentbased_traceidratio",
+		"OTEL_TRACES_SAMPLER_ARG":        "1",
+		"OTEL_RESOURCE_ATTRIBUTES":       "a=1,b=2",
+		"OTEL_EXPORTER_OTLP_CERTIFICATE": "/var/run/secrets/otel-tls/ca.crt",
+	}
+	for name, value := range expectedEnvs {
+		if envs[name] != value {
+			t.Errorf("env var %s = %q, want %q", name, envs[name], value)
+		}
+	}
+	if helper.FindVolumeByName(existing.Spec.Template.Spec.Volumes, component.OpenTelemetryTLSVolumeName) < 0 {
+		t.Fatal("expected the tls volume to be added")
+	}
+	if helper.FindVolumeMountByName(existing.Spec.Template.Spec.Containers[0].VolumeMounts, component.OpenTelemetryTLSVolumeName) < 0 {
+		t.Fatal("expected the tls volume to be mounted")
+	}
+
+	// Disabling the instrumentation removes the env vars and the volume
+	changed, err = openTelemetryMutator(listenerDeployment(nil), existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Fatal("expected the opentelemetry configuration to be removed")
+	}
+	for _, env := range existing.Spec.Template.Spec.Containers[0].Env {
+		if helper.ArrayContains(component.OpenTelemetryEnvVarNames, env.Name) {
+			t.Errorf("unexpected env var %s", env.Name)
+		}
+	}
+	if len(existing.Spec.Template.Spec.Volumes) != 0 || len(existing.Spec.Template.Spec.Containers[0].VolumeMounts) != 0 {
+		t.Fatalf("unexpected volumes %v", existing.Spec.Template.Spec.Volumes)
+	}
+}
+
+func TestOpenTelemetryEnabledEnvVars(t *testing.T) {
+	openTelemetry := &component.OpenTelemetryOptions{Endpoint: "http://collector:4318"}
+	envValue := func(envs []v1.EnvVar, name string) string {
+		for _, env := range envs {
+			if env.Name == name {
+				return env.Value
+			}
+		}
+		return ""
+	}
+
+	zync := component.NewZync(&component.ZyncOptions{OpenTelemetry: openTelemetry})
+	zyncDeployment, err := zync.Deployment(context.TODO(), fake.NewFakeClient(), "zync-image")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value := envValue(zyncDeployment.Spec.Template.Spec.Containers[0].Env, component.ZyncOpenTelemetryEnabledEnvVarName); value != "true" {
+		t.Errorf("zync %s = %q, want true", component.ZyncOpenTelemetryEnabledEnvVarName, value)
+	}
+
+}
@@ -0,0 +1,38 @@
+package operator
+
+import (
+	v1 "k8s.io/api/core/v1"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+)
+
+// SizingProfile returns the sizing profile selected in the APIManager,
+// nil when no profile is selected
+func SizingProfile(apimanager *appsv1alpha1.APIManager) *component.SizingProfile {
+	if !apimanager.IsProfileSet() {
+		return nil
+	}
+	return component.GetSizingProfile(string(*apimanager.Spec.Profile))
+}
+
+// profileResourceRequirements returns the default resource requirements scaled
+// by the sizing profile. The sizing profile has priority over spec.resourceRequirementsEnabled
+func profileResourceRequirements(apimanager *appsv1alpha1.APIManager, defaults v1.ResourceRequirements) v1.ResourceRequirements {
+	if profile := SizingProfile(apimanager); profile != nil {
+		return profile.ResourceRequirements(defaults)
+	}
+	if !*apimanager.Spec.ResourceRequirementsEnabled {
+		return v1.ResourceRequirements{}
+	}
+	return defaults
+}
+
+// profileReplicas returns the replicas the sizing profile sets to the given Deployment,
+// 1 when no profile is selected
+func profileReplicas(apimanager *appsv1alpha1.APIManager, deploymentName string) int32 {
+	if profile := SizingProfile(apimanager); profile != nil {
+		return profile.DeploymentReplicas(deploymentName)
+	}
+	return 1
+}
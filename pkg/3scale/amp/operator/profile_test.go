@@ -0,0 +1,95 @@
+package operator
+
+import (
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+)
+
+func testProfileApimanager(profile appsv1alpha1.APIManagerProfile) *appsv1alpha1.APIManager {
+	apimanager := basicApimanager()
+	if profile != "" {
+		apimanager.Spec.Profile = &profile
+	}
+	return apimanager
+}
+
+func TestProfileResourceRequirements(t *testing.T) {
+	cases := []struct {
+		testName         string
+		profile          appsv1alpha1.APIManagerProfile
+		expectedLimits   [2]string
+		expectedRequests [2]string
+	}{
+		{"NoProfile", "", [2]string{"1", "700Mi"}, [2]string{"500m", "550Mi"}},
+		{"Small", appsv1alpha1.APIManagerProfileSmall, [2]string{"500m", "350Mi"}, [2]string{"250m", "275Mi"}},
+		{"Medium", appsv1alpha1.APIManagerProfileMedium, [2]string{"1", "700Mi"}, [2]string{"500m", "550Mi"}},
+		{"Large", appsv1alpha1.APIManagerProfileLarge, [2]string{"2", "1400Mi"}, [2]string{"1", "1100Mi"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			resources := profileResourceRequirements(testProfileApimanager(tc.profile), component.DefaultBackendListenerResourceRequirements())
+			limits := [2]string{resources.Limits.Cpu().String(), resources.Limits.Memory().String()}
+			requests := [2]string{resources.Requests.Cpu().String(), resources.Requests.Memory().String()}
+			if limits != tc.expectedLimits || requests != tc.expectedRequests {
+				subT.Fatalf("expected limits %v requests %v, got limits %v requests %v", tc.expectedLimits, tc.expectedRequests, limits, requests)
+			}
+		})
+	}
+}
+
+func TestProfileResourceRequirementsWithoutResources(t *testing.T) {
+	falseValue := false
+
+	evaluation := profileResourceRequirements(testProfileApimanager(appsv1alpha1.APIManagerProfileEvaluation), component.DefaultBackendListenerResourceRequirements())
+	if evaluation.Limits != nil || evaluation.Requests != nil {
+		t.Fatalf("evaluation profile should remove resource requirements, got %v", evaluation)
+	}
+
+	// The profile has priority over spec.resourceRequirementsEnabled
+	apimanager := testProfileApimanager(appsv1alpha1.APIManagerProfileMedium)
+	apimanager.Spec.ResourceRequirementsEnabled = &falseValue
+	medium := profileResourceRequirements(apimanager, component.DefaultBackendListenerResourceRequirements())
+	if medium.Limits.Cpu().String() != "1" {
+		t.Fatalf("medium profile should keep default resource requirements, got %v", medium)
+	}
+
+	apimanager = testProfileApimanager("")
+	apimanager.Spec.ResourceRequirementsEnabled = &falseValue
+	disabled := profileResourceRequirements(apimanager, component.DefaultBackendListenerResourceRequirements())
+	if disabled.Limits != nil || disabled.Requests != nil {
+		t.Fatalf("disabled resource requirements should be empty, got %v", disabled)
+	}
+}
+
+func TestProfileReplicas(t *testing.T) {
+	cases := []struct {
+		testName       string
+		profile        appsv1alpha1.APIManagerProfile
+		deploymentName string
+		expected       int32
+	}{
+		{"NoProfile", "", component.BackendListenerName, 1},
+		{"Evaluation", appsv1alpha1.APIManagerProfileEvaluation, component.BackendListenerName, 1},
+		{"Medium", appsv1alpha1.APIManagerProfileMedium, component.SystemAppDeploymentName, 2},
+		{"XLarge", appsv1alpha1.APIManagerProfileXLarge, component.ApicastProductionName, 5},
+		{"NotScaledDeployment", appsv1alpha1.APIManagerProfileXLarge, component.BackendCronName, 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			replicas := profileReplicas(testProfileApimanager(tc.profile), tc.deploymentName)
+			if replicas != tc.expected {
+				subT.Fatalf("expected %d replicas, got %d", tc.expected, replicas)
+			}
+		})
+	}
+}
+
+func TestSizingProfileNotSet(t *testing.T) {
+	if profile := SizingProfile(testProfileApimanager("")); profile != nil {
+		t.Fatalf("expected no sizing profile, got %v", profile)
+	}
+}
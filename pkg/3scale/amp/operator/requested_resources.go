@@ -0,0 +1,78 @@
+package operator
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+)
+
+// DeploymentResources are the compute resources requested by the pods of a Deployment
+type DeploymentResources struct {
+	Name     string
+	Replicas int32
+	// Resource requirements of the main containers of the pod
+	Containers []v1.ResourceRequirements
+}
+
+// RequestedResources returns the compute resources the APIManager Deployments
+// are going to request. The APIManager is expected to have its defaults set
+func RequestedResources(apimanager *appsv1alpha1.APIManager, cl client.Client) []DeploymentResources {
+	apicast := NewApicastOptionsProvider(apimanager, cl)
+	apicast.setResourceRequirementsOptions()
+	apicast.setReplicas()
+
+	backend := NewOperatorBackendOptionsProvider(apimanager, apimanager.Namespace, cl)
+	backend.setResourceRequirementsOptions()
+	backend.setReplicas()
+
+	system := NewSystemOptionsProvider(apimanager, apimanager.Namespace, cl)
+	system.setResourceRequirementsOptions()
+	system.setReplicas()
+
+	memcached := NewMemcachedOptionsProvider(apimanager)
+	memcached.setResourceRequirementsOptions()
+
+	searchd := NewSystemSearchdOptionsProvider(apimanager)
+	searchd.setResourceRequirementsOptions()
+
+	result := []DeploymentResources{
+		{component.ApicastProductionName, apicast.apicastOptions.ProductionReplicas, []v1.ResourceRequirements{apicast.apicastOptions.ProductionResourceRequirements}},
+		{component.ApicastStagingName, apicast.apicastOptions.StagingReplicas, []v1.ResourceRequirements{apicast.apicastOptions.StagingResourceRequirements}},
+		{component.BackendListenerName, backend.backendOptions.ListenerReplicas, []v1.ResourceRequirements{backend.backendOptions.ListenerResourceRequirements}},
+		{component.BackendWorkerName, backend.backendOptions.WorkerReplicas, []v1.ResourceRequirements{backend.backendOptions.WorkerResourceRequirements}},
+		{component.BackendCronName, backend.backendOptions.CronReplicas, []v1.ResourceRequirements{backend.backendOptions.CronResourceRequirements}},
+		{component.SystemAppDeploymentName, system.options.AppReplicas, []v1.ResourceRequirements{
+			*system.options.AppMasterContainerResourceRequirements,
+			*system.options.AppProviderContainerResourceRequirements,
+			*system.options.AppDeveloperContainerResourceRequirements,
+		}},
+		{component.SystemSidekiqName, system.options.SidekiqReplicas, []v1.ResourceRequirements{*system.options.SidekiqContainerResourceRequirements}},
+		{component.SystemMemcachedDeploymentName, 1, []v1.ResourceRequirements{memcached.memcachedOptions.ResourceRequirements}},
+		{component.SystemSearchdDeploymentName, 1, []v1.ResourceRequirements{searchd.options.ContainerResourceRequirements}},
+	}
+
+	if apimanager.IsSystemDatabaseConnectionPoolerEnabled() {
+		pgbouncer := NewSystemPgBouncerOptionsProvider(apimanager, apimanager.Namespace, cl)
+		poolerSpec := pgbouncer.connectionPoolerSpec()
+		pgbouncer.setResourceRequirementsOptions(poolerSpec)
+		pgbouncer.setReplicas(poolerSpec)
+		result = append(result, DeploymentResources{component.SystemPgBouncerDeploymentName, pgbouncer.options.Replicas, []v1.ResourceRequirements{pgbouncer.options.ContainerResourceRequirements}})
+	}
+
+	if apimanager.IsZyncEnabled() {
+		zync := NewZyncOptionsProvider(apimanager, apimanager.Namespace, cl)
+		zync.setResourceRequirementsOptions()
+		zync.setReplicas()
+		result = append(result,
+			DeploymentResources{component.ZyncName, zync.zyncOptions.ZyncReplicas, []v1.ResourceRequirements{zync.zyncOptions.ContainerResourceRequirements}},
+			DeploymentResources{component.ZyncQueDeploymentName, zync.zyncOptions.ZyncQueReplicas, []v1.ResourceRequirements{zync.zyncOptions.QueContainerResourceRequirements}},
+		)
+		if !apimanager.IsExternal(appsv1alpha1.ZyncDatabase) {
+			result = append(result, DeploymentResources{component.ZyncDatabaseDeploymentName, 1, []v1.ResourceRequirements{zync.zyncOptions.DatabaseContainerResourceRequirements}})
+		}
+	}
+
+	return result
+}
@@ -3,6 +3,7 @@ package operator
 import (
 	"fmt"
 	"path/filepath"
+	"strconv"
 
 	v1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -50,6 +51,7 @@ func (s *SystemOptionsProvider) GetSystemOptions() (*component.SystemOptions, er
 	s.options.MemcachedLabels = s.memcachedLabels()
 	s.options.SMTPLabels = s.smtpLabels()
 	s.setSystemDBTLSEabled()
+	s.setSystemDatabaseConnectionPooler()
 
 	err := s.setSecretBasedOptions()
 	if err != nil {
@@ -63,6 +65,7 @@ func (s *SystemOptionsProvider) GetSystemOptions() (*component.SystemOptions, er
 		return nil, err
 	}
 	s.setReplicas()
+	s.setWorkers()
 	s.setPriorityClassNames()
 	s.setTopologySpreadConstraints()
 	s.setPodTemplateAnnotations()
@@ -73,6 +76,11 @@ func (s *SystemOptionsProvider) GetSystemOptions() (*component.SystemOptions, er
 
 	s.options.ZyncEnabled = s.apimanager.IsZyncEnabled()
 
+	s.options.OpenTelemetry, err = openTelemetryOptions(s.apimanager.Spec.System.OpenTelemetry, s.secretSource)
+	if err != nil {
+		return nil, fmt.Errorf("GetSystemOptions reading opentelemetry options: %w", err)
+	}
+
 	s.options.Namespace = s.namespace
 
 	err = s.options.Validate()
@@ -295,6 +303,15 @@ func (s *SystemOptionsProvider) setSystemMasterApicastOptions() error {
 }
 
 func (s *SystemOptionsProvider) setSystemSMTPOptions() error {
+	if s.apimanager.IsSMTPSpecEnabled() {
+		smtpSecretOptions, err := systemSMTPSecretOptionsFromSpec(s.apimanager.Spec.System.SMTP, s.secretSource)
+		if err != nil {
+			return err
+		}
+		s.options.SmtpSecretOptions = *smtpSecretOptions
+		return nil
+	}
+
 	smtpSecretOptions := component.SystemSMTPSecretOptions{}
 	cases := []struct {
 		field       **string
@@ -364,22 +381,92 @@ func (s *SystemOptionsProvider) setSystemSMTPOptions() error {
 	return nil
 }
 
+// systemSMTPSecretOptionsFromSpec builds the system-smtp secret content from the smtp spec.
+// Credentials are read from the referenced secret
+func systemSMTPSecretOptionsFromSpec(smtpSpec *appsv1alpha1.SystemSMTPSpec, secretSource *helper.SecretSource) (*component.SystemSMTPSecretOptions, error) {
+	tlsMode := appsv1alpha1.SMTPTLSModeStartTLS
+	if smtpSpec.TLSMode != nil {
+		tlsMode = *smtpSpec.TLSMode
+	}
+
+	port := int32(587)
+	if tlsMode == appsv1alpha1.SMTPTLSModeTLS {
+		port = 465
+	}
+	if smtpSpec.Port != nil {
+		port = *smtpSpec.Port
+	}
+
+	authentication := appsv1alpha1.SMTPAuthenticationNone
+	if smtpSpec.CredentialsSecretRef != nil {
+		authentication = appsv1alpha1.SMTPAuthenticationPlain
+	}
+	if smtpSpec.AuthenticationMethod != nil {
+		authentication = *smtpSpec.AuthenticationMethod
+	}
+
+	username := ""
+	password := ""
+	if authentication != appsv1alpha1.SMTPAuthenticationNone && smtpSpec.CredentialsSecretRef != nil {
+		var err error
+		username, err = secretSource.RequiredFieldValueFromRequiredSecret(smtpSpec.CredentialsSecretRef.Name, component.SystemSecretSystemSMTPUserNameFieldName)
+		if err != nil {
+			return nil, err
+		}
+		password, err = secretSource.RequiredFieldValueFromRequiredSecret(smtpSpec.CredentialsSecretRef.Name, component.SystemSecretSystemSMTPPasswordFieldName)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// system expects an empty authentication to skip it
+	authenticationValue := string(authentication)
+	if authentication == appsv1alpha1.SMTPAuthenticationNone {
+		authenticationValue = ""
+	}
+
+	domain := ""
+	if smtpSpec.Domain != nil {
+		domain = *smtpSpec.Domain
+	}
+
+	verifyMode := "peer"
+	if smtpSpec.InsecureSkipVerify != nil && *smtpSpec.InsecureSkipVerify {
+		verifyMode = "none"
+	}
+
+	host := smtpSpec.Host
+	portValue := strconv.Itoa(int(port))
+	fromAddress := smtpSpec.FromAddress
+	startTLS := strconv.FormatBool(tlsMode == appsv1alpha1.SMTPTLSModeStartTLS)
+	tls := strconv.FormatBool(tlsMode == appsv1alpha1.SMTPTLSModeTLS)
+
+	return &component.SystemSMTPSecretOptions{
+		Address:           &host,
+		Authentication:    &authenticationValue,
+		Domain:            &domain,
+		OpenSSLVerifyMode: &verifyMode,
+		Password:          &password,
+		Port:              &portValue,
+		Username:          &username,
+		FromAddress:       &fromAddress,
+		StartTLS:          &startTLS,
+		TLS:               &tls,
+	}, nil
+}
+
 func (s *SystemOptionsProvider) setResourceRequirementsOptions() {
-	if *s.apimanager.Spec.ResourceRequirementsEnabled {
-		s.options.AppMasterContainerResourceRequirements = component.DefaultAppMasterContainerResourceRequirements()
-		s.options.AppProviderContainerResourceRequirements = component.DefaultAppProviderContainerResourceRequirements()
-		s.options.AppDeveloperContainerResourceRequirements = component.DefaultAppDeveloperContainerResourceRequirements()
-		s.options.SidekiqContainerResourceRequirements = component.DefaultSidekiqContainerResourceRequirements()
-	} else {
-		s.options.AppMasterContainerResourceRequirements = &v1.ResourceRequirements{}
-		s.options.AppProviderContainerResourceRequirements = &v1.ResourceRequirements{}
-		s.options.AppDeveloperContainerResourceRequirements = &v1.ResourceRequirements{}
-		s.options.SidekiqContainerResourceRequirements = &v1.ResourceRequirements{}
+	resourceRequirements := func(defaults *v1.ResourceRequirements) *v1.ResourceRequirements {
+		result := profileResourceRequirements(s.apimanager, *defaults)
+		return &result
 	}
+	s.options.AppMasterContainerResourceRequirements = resourceRequirements(component.DefaultAppMasterContainerResourceRequirements())
+	s.options.AppProviderContainerResourceRequirements = resourceRequirements(component.DefaultAppProviderContainerResourceRequirements())
+	s.options.AppDeveloperContainerResourceRequirements = resourceRequirements(component.DefaultAppDeveloperContainerResourceRequirements())
+	s.options.SidekiqContainerResourceRequirements = resourceRequirements(component.DefaultSidekiqContainerResourceRequirements())
 
 	// Deployment-level ResourceRequirements CR fields have priority over
-	// spec.resourceRequirementsEnabled, overwriting that setting when they are
-	// defined
+	// spec.resourceRequirementsEnabled and spec.profile, overwriting those settings
+	// when they are defined
 	if s.apimanager.Spec.System.AppSpec.MasterContainerResources != nil {
 		s.options.AppMasterContainerResourceRequirements = s.apimanager.Spec.System.AppSpec.MasterContainerResources
 	}
@@ -402,130 +489,154 @@ func (s *SystemOptionsProvider) setNodeAffinityAndTolerationsOptions() {
 }
 
 func (s *SystemOptionsProvider) setFileStorageOptions() error {
-	if s.apimanager.IsS3STSEnabled() {
-		s.options.S3FileStorageOptions = &component.S3FileStorageOptions{
-			ConfigurationSecretName: s.apimanager.Spec.System.FileStorageSpec.S3.ConfigurationSecretRef.Name,
-			STSEnabled:              true,
-			STSAudience:             "openshift", // default value when "audience" is not specified
-		}
-
-		if s.apimanager.Spec.System.FileStorageSpec.S3.STS.Audience != nil {
-			s.options.S3FileStorageOptions.STSAudience = *s.apimanager.Spec.System.FileStorageSpec.S3.STS.Audience
-		}
-
-		// Validate it exists
-		_, err := s.secretSource.RequiredFieldValueFromRequiredSecret(
-			s.apimanager.Spec.System.FileStorageSpec.S3.ConfigurationSecretRef.Name,
-			appscommon.AwsRoleArn)
+	if s.apimanager.IsS3Enabled() {
+		// Keep system on the PVC until its contents are copied to the S3 bucket
+		migrationPending, err := IsSystemStorageMigrationPending(s.apimanager, s.client)
 		if err != nil {
 			return err
 		}
 
-		tokenPath, err := s.secretSource.RequiredFieldValueFromRequiredSecret(
-			s.apimanager.Spec.System.FileStorageSpec.S3.ConfigurationSecretRef.Name,
-			appscommon.AwsWebIdentityTokenFile)
-		if err != nil {
-			return err
-		}
-
-		s.options.S3FileStorageOptions.STSTokenMountRelativePath = filepath.Base(tokenPath)
-		s.options.S3FileStorageOptions.STSTokenMountPath = filepath.Dir(tokenPath)
-
-		// Validate it exists
-		_, err = s.secretSource.RequiredFieldValueFromRequiredSecret(
-			s.apimanager.Spec.System.FileStorageSpec.S3.ConfigurationSecretRef.Name,
-			appscommon.AwsBucket)
-		if err != nil {
+		if !migrationPending {
+			s.options.S3FileStorageOptions, err = s3FileStorageOptions(s.apimanager, s.secretSource)
 			return err
 		}
+	}
 
-		// Validate it exists
-		_, err = s.secretSource.RequiredFieldValueFromRequiredSecret(
-			s.apimanager.Spec.System.FileStorageSpec.S3.ConfigurationSecretRef.Name,
-			appscommon.AwsRegion)
-		if err != nil {
-			return err
+	// defaults to PVC
+	var storageClassName *string
+	var volumeName *string
+	storageRequests := component.DefaultSharedStorageResources()
+	if s.apimanager.Spec.System != nil &&
+		s.apimanager.Spec.System.FileStorageSpec != nil &&
+		s.apimanager.Spec.System.FileStorageSpec.PVC != nil {
+		storageClassName = s.apimanager.Spec.System.FileStorageSpec.PVC.StorageClassName
+		volumeName = s.apimanager.Spec.System.FileStorageSpec.PVC.VolumeName
+		if s.apimanager.Spec.System.FileStorageSpec.PVC.Resources != nil {
+			storageRequests = s.apimanager.Spec.System.FileStorageSpec.PVC.Resources.Requests
 		}
+	}
 
-		return nil
+	s.options.PvcFileStorageOptions = &component.PVCFileStorageOptions{
+		StorageClass:    storageClassName,
+		VolumeName:      volumeName,
+		StorageRequests: storageRequests,
 	}
 
-	if s.apimanager.IsS3IAMEnabled() {
-		s.options.S3FileStorageOptions = &component.S3FileStorageOptions{
-			ConfigurationSecretName: s.apimanager.Spec.System.FileStorageSpec.S3.ConfigurationSecretRef.Name,
-			STSEnabled:              false,
+	return nil
+}
+
+// s3FileStorageOptions reads the S3 file storage options, validating the
+// required fields of the configuration secret exist
+func s3FileStorageOptions(apimanager *appsv1alpha1.APIManager, secretSource *helper.SecretSource) (*component.S3FileStorageOptions, error) {
+	if apimanager.IsS3STSEnabled() {
+		s3Options := &component.S3FileStorageOptions{
+			ConfigurationSecretName: apimanager.Spec.System.FileStorageSpec.S3.ConfigurationSecretRef.Name,
+			STSEnabled:              true,
+			STSAudience:             "openshift", // default value when "audience" is not specified
+		}
+
+		if apimanager.Spec.System.FileStorageSpec.S3.STS.Audience != nil {
+			s3Options.STSAudience = *apimanager.Spec.System.FileStorageSpec.S3.STS.Audience
 		}
 
 		// Validate it exists
-		_, err := s.secretSource.RequiredFieldValueFromRequiredSecret(
-			s.apimanager.Spec.System.FileStorageSpec.S3.ConfigurationSecretRef.Name,
-			appscommon.AwsAccessKeyID)
+		_, err := secretSource.RequiredFieldValueFromRequiredSecret(
+			apimanager.Spec.System.FileStorageSpec.S3.ConfigurationSecretRef.Name,
+			appscommon.AwsRoleArn)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		// Validate it exists
-		_, err = s.secretSource.RequiredFieldValueFromRequiredSecret(
-			s.apimanager.Spec.System.FileStorageSpec.S3.ConfigurationSecretRef.Name,
-			appscommon.AwsSecretAccessKey)
+		tokenPath, err := secretSource.RequiredFieldValueFromRequiredSecret(
+			apimanager.Spec.System.FileStorageSpec.S3.ConfigurationSecretRef.Name,
+			appscommon.AwsWebIdentityTokenFile)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
+		s3Options.STSTokenMountRelativePath = filepath.Base(tokenPath)
+		s3Options.STSTokenMountPath = filepath.Dir(tokenPath)
+
 		// Validate it exists
-		_, err = s.secretSource.RequiredFieldValueFromRequiredSecret(
-			s.apimanager.Spec.System.FileStorageSpec.S3.ConfigurationSecretRef.Name,
+		_, err = secretSource.RequiredFieldValueFromRequiredSecret(
+			apimanager.Spec.System.FileStorageSpec.S3.ConfigurationSecretRef.Name,
 			appscommon.AwsBucket)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		// Validate it exists
-		_, err = s.secretSource.RequiredFieldValueFromRequiredSecret(
-			s.apimanager.Spec.System.FileStorageSpec.S3.ConfigurationSecretRef.Name,
+		_, err = secretSource.RequiredFieldValueFromRequiredSecret(
+			apimanager.Spec.System.FileStorageSpec.S3.ConfigurationSecretRef.Name,
 			appscommon.AwsRegion)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		return nil
+		return s3Options, nil
 	}
 
-	// defaults to PVC
-	var storageClassName *string
-	var volumeName *string
-	storageRequests := component.DefaultSharedStorageResources()
-	if s.apimanager.Spec.System != nil &&
-		s.apimanager.Spec.System.FileStorageSpec != nil &&
-		s.apimanager.Spec.System.FileStorageSpec.PVC != nil {
-		storageClassName = s.apimanager.Spec.System.FileStorageSpec.PVC.StorageClassName
-		volumeName = s.apimanager.Spec.System.FileStorageSpec.PVC.VolumeName
-		if s.apimanager.Spec.System.FileStorageSpec.PVC.Resources != nil {
-			storageRequests = s.apimanager.Spec.System.FileStorageSpec.PVC.Resources.Requests
-		}
+	s3Options := &component.S3FileStorageOptions{
+		ConfigurationSecretName: apimanager.Spec.System.FileStorageSpec.S3.ConfigurationSecretRef.Name,
+		STSEnabled:              false,
 	}
 
-	s.options.PvcFileStorageOptions = &component.PVCFileStorageOptions{
-		StorageClass:    storageClassName,
-		VolumeName:      volumeName,
-		StorageRequests: storageRequests,
+	// Validate it exists
+	_, err := secretSource.RequiredFieldValueFromRequiredSecret(
+		apimanager.Spec.System.FileStorageSpec.S3.ConfigurationSecretRef.Name,
+		appscommon.AwsAccessKeyID)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	// Validate it exists
+	_, err = secretSource.RequiredFieldValueFromRequiredSecret(
+		apimanager.Spec.System.FileStorageSpec.S3.ConfigurationSecretRef.Name,
+		appscommon.AwsSecretAccessKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate it exists
+	_, err = secretSource.RequiredFieldValueFromRequiredSecret(
+		apimanager.Spec.System.FileStorageSpec.S3.ConfigurationSecretRef.Name,
+		appscommon.AwsBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate it exists
+	_, err = secretSource.RequiredFieldValueFromRequiredSecret(
+		apimanager.Spec.System.FileStorageSpec.S3.ConfigurationSecretRef.Name,
+		appscommon.AwsRegion)
+	if err != nil {
+		return nil, err
+	}
+
+	return s3Options, nil
 }
 
 func (s *SystemOptionsProvider) setReplicas() {
-	s.options.AppReplicas = 1
+	s.options.AppReplicas = profileReplicas(s.apimanager, component.SystemAppDeploymentName)
 	if s.apimanager.Spec.System.AppSpec.Replicas != nil {
 		s.options.AppReplicas = int32(*s.apimanager.Spec.System.AppSpec.Replicas)
 	}
 
-	s.options.SidekiqReplicas = 1
+	s.options.SidekiqReplicas = profileReplicas(s.apimanager, component.SystemSidekiqName)
 	if s.apimanager.Spec.System.SidekiqSpec.Replicas != nil {
 		s.options.SidekiqReplicas = int32(*s.apimanager.Spec.System.SidekiqSpec.Replicas)
 	}
 }
 
+func (s *SystemOptionsProvider) setWorkers() {
+	s.options.SidekiqConcurrency = component.DefaultSystemSidekiqConcurrency
+	if profile := SizingProfile(s.apimanager); profile != nil {
+		appWorkers := profile.SystemAppWorkers
+		s.options.AppWorkers = &appWorkers
+		s.options.SidekiqConcurrency = profile.SystemSidekiqConcurrency
+	}
+}
+
 func (s *SystemOptionsProvider) commonLabels() map[string]string {
 	return map[string]string{
 		"app":                  *s.apimanager.Spec.AppLabel,
@@ -633,3 +744,7 @@ func (s *SystemOptionsProvider) setPodTemplateAnnotations() {
 func (s *SystemOptionsProvider) setSystemDBTLSEabled() {
 	s.options.SystemDbTLSEnabled = s.apimanager.IsSystemDatabaseTLSEnabled()
 }
+
+func (s *SystemOptionsProvider) setSystemDatabaseConnectionPooler() {
+	s.options.SystemDatabaseConnectionPoolerEnabled = s.apimanager.IsSystemDatabaseConnectionPoolerEnabled()
+}
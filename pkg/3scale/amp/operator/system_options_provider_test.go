@@ -15,6 +15,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -312,6 +313,7 @@ func defaultSystemOptions(opts *component.SystemOptions) *component.SystemOption
 		ApicastAccessToken:                        opts.ApicastAccessToken,
 		AppReplicas:                               1,
 		SidekiqReplicas:                           1,
+		SidekiqConcurrency:                        component.DefaultSystemSidekiqConcurrency,
 		AdminEmail:                                &tmpSystemAdminEmail,
 		UserSessionTTL:                            &tmpSystemUserSessionTTL,
 		PvcFileStorageOptions: &component.PVCFileStorageOptions{
@@ -680,3 +682,74 @@ func TestGetSystemOptionsProvider(t *testing.T) {
 		})
 	}
 }
+
+func TestSystemSMTPSecretOptionsFromSpec(t *testing.T) {
+	credentialsSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "smtp-credentials", Namespace: namespace},
+		Data: map[string][]byte{
+			component.SystemSecretSystemSMTPUserNameFieldName: []byte("mailer"),
+			component.SystemSecretSystemSMTPPasswordFieldName: []byte("mailerpass"),
+		},
+	}
+	tlsMode := appsv1alpha1.SMTPTLSModeTLS
+	loginMethod := appsv1alpha1.SMTPAuthenticationLogin
+
+	cases := []struct {
+		testName string
+		smtpSpec *appsv1alpha1.SystemSMTPSpec
+		expected map[string]string
+	}{
+		{"WithoutCredentials",
+			&appsv1alpha1.SystemSMTPSpec{Host: "smtp.example.com", FromAddress: "no-reply@example.com"},
+			map[string]string{
+				"address": "smtp.example.com", "port": "587", "authentication": "", "username": "", "password": "",
+				"openssl.verify.mode": "peer", "from_address": "no-reply@example.com", "enable_starttls": "true", "tls": "false",
+			},
+		},
+		{"WithCredentials",
+			&appsv1alpha1.SystemSMTPSpec{
+				Host: "smtp.example.com", FromAddress: "no-reply@example.com",
+				CredentialsSecretRef: &v1.LocalObjectReference{Name: "smtp-credentials"},
+			},
+			map[string]string{
+				"address": "smtp.example.com", "port": "587", "authentication": "plain", "username": "mailer", "password": "mailerpass",
+				"openssl.verify.mode": "peer", "from_address": "no-reply@example.com", "enable_starttls": "true", "tls": "false",
+			},
+		},
+		{"WithImplicitTLS",
+			&appsv1alpha1.SystemSMTPSpec{
+				Host: "smtp.example.com", FromAddress: "no-reply@example.com", TLSMode: &tlsMode,
+				AuthenticationMethod: &loginMethod, InsecureSkipVerify: &[]bool{true}[0],
+				CredentialsSecretRef: &v1.LocalObjectReference{Name: "smtp-credentials"},
+			},
+			map[string]string{
+				"address": "smtp.example.com", "port": "465", "authentication": "login", "username": "mailer", "password": "mailerpass",
+				"openssl.verify.mode": "none", "from_address": "no-reply@example.com", "enable_starttls": "false", "tls": "true",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			secretSource := helper.NewSecretSource(fake.NewFakeClient(credentialsSecret), namespace)
+			opts, err := systemSMTPSecretOptionsFromSpec(tc.smtpSpec, secretSource)
+			if err != nil {
+				subT.Fatal(err)
+			}
+			secret := component.NewSystem(&component.SystemOptions{SmtpSecretOptions: *opts}).SMTPSecret()
+			delete(secret.StringData, "domain")
+			if diff := cmp.Diff(tc.expected, secret.StringData); diff != "" {
+				subT.Fatalf("unexpected smtp secret (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	// the credentials secret is required
+	smtpSpec := &appsv1alpha1.SystemSMTPSpec{
+		Host: "smtp.example.com", FromAddress: "no-reply@example.com",
+		CredentialsSecretRef: &v1.LocalObjectReference{Name: "missing"},
+	}
+	if _, err := systemSMTPSecretOptionsFromSpec(smtpSpec, helper.NewSecretSource(fake.NewFakeClient(), namespace)); err == nil {
+		t.Fatal("expected error with a missing credentials secret")
+	}
+}
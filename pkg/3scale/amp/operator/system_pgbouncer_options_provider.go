@@ -0,0 +1,166 @@
+package operator
+
+import (
+	"fmt"
+	"net/url"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+)
+
+const (
+	systemPgBouncerDefaultDatabasePort = "5432"
+)
+
+type SystemPgBouncerOptionsProvider struct {
+	apimanager   *appsv1alpha1.APIManager
+	options      *component.SystemPgBouncerOptions
+	secretSource *helper.SecretSource
+}
+
+func NewSystemPgBouncerOptionsProvider(apimanager *appsv1alpha1.APIManager, namespace string, client client.Client) *SystemPgBouncerOptionsProvider {
+	return &SystemPgBouncerOptionsProvider{
+		apimanager:   apimanager,
+		options:      component.NewSystemPgBouncerOptions(),
+		secretSource: helper.NewSecretSource(client, namespace),
+	}
+}
+
+func (s *SystemPgBouncerOptionsProvider) GetOptions() (*component.SystemPgBouncerOptions, error) {
+	poolerSpec := s.connectionPoolerSpec()
+
+	s.options.Labels = s.labels()
+	s.options.PodTemplateLabels = s.podTemplateLabels(poolerSpec)
+	s.options.PodTemplateAnnotations = poolerSpec.Annotations
+	s.options.Affinity = poolerSpec.Affinity
+	s.options.Tolerations = poolerSpec.Tolerations
+	s.options.TopologySpreadConstraints = poolerSpec.TopologySpreadConstraints
+	if poolerSpec.PriorityClassName != nil {
+		s.options.PriorityClassName = *poolerSpec.PriorityClassName
+	}
+
+	s.setReplicas(poolerSpec)
+	s.setResourceRequirementsOptions(poolerSpec)
+	s.setPoolOptions(poolerSpec)
+
+	err := s.setDatabaseOptions()
+	if err != nil {
+		return nil, fmt.Errorf("GetSystemPgBouncerOptions reading secret options: %w", err)
+	}
+
+	err = s.options.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("GetSystemPgBouncerOptions validating: %w", err)
+	}
+
+	return s.options, nil
+}
+
+func (s *SystemPgBouncerOptionsProvider) connectionPoolerSpec() *appsv1alpha1.SystemDatabaseConnectionPoolerSpec {
+	if s.apimanager.Spec.System.DatabaseSpec != nil &&
+		s.apimanager.Spec.System.DatabaseSpec.PostgreSQL != nil &&
+		s.apimanager.Spec.System.DatabaseSpec.PostgreSQL.ConnectionPooler != nil {
+		return s.apimanager.Spec.System.DatabaseSpec.PostgreSQL.ConnectionPooler
+	}
+	return &appsv1alpha1.SystemDatabaseConnectionPoolerSpec{}
+}
+
+func (s *SystemPgBouncerOptionsProvider) setReplicas(poolerSpec *appsv1alpha1.SystemDatabaseConnectionPoolerSpec) {
+	s.options.Replicas = 1
+	if poolerSpec.Replicas != nil {
+		s.options.Replicas = int32(*poolerSpec.Replicas)
+	}
+}
+
+func (s *SystemPgBouncerOptionsProvider) setResourceRequirementsOptions(poolerSpec *appsv1alpha1.SystemDatabaseConnectionPoolerSpec) {
+	s.options.ContainerResourceRequirements = profileResourceRequirements(s.apimanager, component.DefaultSystemPgBouncerContainerResourceRequirements())
+	// Deployment-level ResourceRequirements CR fields have priority over
+	// spec.resourceRequirementsEnabled and spec.profile, overwriting those settings
+	// when they are defined
+	if poolerSpec.Resources != nil {
+		s.options.ContainerResourceRequirements = *poolerSpec.Resources
+	}
+}
+
+func (s *SystemPgBouncerOptionsProvider) setPoolOptions(poolerSpec *appsv1alpha1.SystemDatabaseConnectionPoolerSpec) {
+	s.options.PoolMode = component.DefaultSystemPgBouncerPoolMode()
+	if poolerSpec.PoolMode != nil {
+		s.options.PoolMode = *poolerSpec.PoolMode
+	}
+
+	s.options.DefaultPoolSize = component.DefaultSystemPgBouncerDefaultPoolSize()
+	if poolerSpec.DefaultPoolSize != nil {
+		s.options.DefaultPoolSize = *poolerSpec.DefaultPoolSize
+	}
+
+	s.options.MaxClientConnections = component.DefaultSystemPgBouncerMaxClientConnections()
+	if poolerSpec.MaxClientConnections != nil {
+		s.options.MaxClientConnections = *poolerSpec.MaxClientConnections
+	}
+}
+
+// setDatabaseOptions reads the upstream database from the system-database secret URL
+func (s *SystemPgBouncerOptionsProvider) setDatabaseOptions() error {
+	rawURL, err := s.secretSource.RequiredFieldValueFromRequiredSecret(component.SystemSecretSystemDatabaseSecretName, component.SystemSecretSystemDatabaseURLFieldName)
+	if err != nil {
+		return err
+	}
+
+	databaseURL, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid system database URL: %w", err)
+	}
+
+	if databaseURL.Scheme != "postgresql" && databaseURL.Scheme != "postgres" {
+		return fmt.Errorf("system database connection pooler requires a PostgreSQL database, found scheme '%s'", databaseURL.Scheme)
+	}
+
+	s.options.DatabaseHost = databaseURL.Hostname()
+	s.options.DatabasePort = databaseURL.Port()
+	if s.options.DatabasePort == "" {
+		s.options.DatabasePort = systemPgBouncerDefaultDatabasePort
+	}
+	if len(databaseURL.Path) > 1 {
+		s.options.DatabaseName = databaseURL.Path[1:]
+	}
+	if databaseURL.User != nil {
+		s.options.DatabaseUser = databaseURL.User.Username()
+		s.options.DatabasePassword, _ = databaseURL.User.Password()
+	}
+
+	// TLS applies to the pooler to database connection only
+	query := databaseURL.Query()
+	s.options.DatabaseSSLMode = query.Get("sslmode")
+	query.Del("sslmode")
+	s.options.DatabaseURLQuery = query.Encode()
+
+	return nil
+}
+
+func (s *SystemPgBouncerOptionsProvider) labels() map[string]string {
+	return map[string]string{
+		"app":                          *s.apimanager.Spec.AppLabel,
+		"threescale_component":         "system",
+		"threescale_component_element": "pgbouncer",
+	}
+}
+
+func (s *SystemPgBouncerOptionsProvider) podTemplateLabels(poolerSpec *appsv1alpha1.SystemDatabaseConnectionPoolerSpec) map[string]string {
+	labels := helper.MeteringLabels(component.SystemPgBouncerDeploymentName, helper.ApplicationType)
+
+	for k, v := range s.labels() {
+		labels[k] = v
+	}
+
+	for k, v := range poolerSpec.Labels {
+		labels[k] = v
+	}
+
+	labels[reconcilers.DeploymentLabelSelector] = component.SystemPgBouncerDeploymentName
+
+	return labels
+}
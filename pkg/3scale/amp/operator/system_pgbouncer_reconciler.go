@@ -0,0 +1,143 @@
+package operator
+
+import (
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/common"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+)
+
+type SystemPgBouncerReconciler struct {
+	*BaseAPIManagerLogicReconciler
+}
+
+func NewSystemPgBouncerReconciler(baseAPIManagerLogicReconciler *BaseAPIManagerLogicReconciler) *SystemPgBouncerReconciler {
+	return &SystemPgBouncerReconciler{
+		BaseAPIManagerLogicReconciler: baseAPIManagerLogicReconciler,
+	}
+}
+
+func (r *SystemPgBouncerReconciler) Reconcile() (reconcile.Result, error) {
+	if !r.apiManager.IsSystemDatabaseConnectionPoolerEnabled() {
+		return reconcile.Result{}, r.deleteConnectionPooler()
+	}
+
+	ampImages, err := AmpImages(r.apiManager)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	pgbouncer, err := SystemPgBouncer(r.apiManager, r.Client())
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// Secret
+	err = r.ReconcileSecret(pgbouncer.ConfigSecret(), reconcilers.DeploymentSecretMutator(reconcilers.SecretStringDataMutator))
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// Service
+	err = r.ReconcileService(pgbouncer.Service(), reconcilers.ServiceMutator(reconcilers.CreateOnlyMutator, reconcilers.ServiceSelectorMutator))
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// Deployment
+	deploymentMutators := []reconcilers.DMutateFn{
+		reconcilers.DeploymentContainerResourcesMutator,
+		reconcilers.DeploymentAffinityMutator,
+		reconcilers.DeploymentTolerationsMutator,
+		reconcilers.DeploymentPodTemplateLabelsMutator,
+		reconcilers.DeploymentPriorityClassMutator,
+		reconcilers.DeploymentTopologySpreadConstraintsMutator,
+		reconcilers.DeploymentPodTemplateAnnotationsMutator,
+		reconcilers.DeploymentPodContainerImageMutator,
+	}
+	if r.apiManager.Spec.System.DatabaseSpec.PostgreSQL.ConnectionPooler.Replicas != nil {
+		deploymentMutators = append(deploymentMutators, reconcilers.DeploymentReplicasMutator)
+	}
+	err = r.ReconcileDeployment(pgbouncer.Deployment(ampImages.Options.SystemPgBouncerImage, ampImages.Options.SystemPgBouncerExporterImage), reconcilers.DeploymentMutator(deploymentMutators...))
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// PDB
+	err = r.ReconcilePodDisruptionBudget(pgbouncer.PodDisruptionBudget(), reconcilers.GenericPDBMutator)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// PodMonitor
+	err = r.ReconcilePodMonitor(pgbouncer.PodMonitor(), reconcilers.GenericPodMonitorMutator)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// deleteConnectionPooler removes the pooler resources. Options are not read
+// because the system-database secret may not point to PostgreSQL anymore
+func (r *SystemPgBouncerReconciler) deleteConnectionPooler() error {
+	secret := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: component.SystemPgBouncerSecretName}}
+	common.TagObjectToDelete(secret)
+	err := r.ReconcileSecret(secret, reconcilers.CreateOnlyMutator)
+	if err != nil {
+		return err
+	}
+
+	service := &v1.Service{ObjectMeta: metav1.ObjectMeta{Name: component.SystemPgBouncerServiceName}}
+	common.TagObjectToDelete(service)
+	err = r.ReconcileService(service, reconcilers.CreateOnlyMutator)
+	if err != nil {
+		return err
+	}
+
+	deployment := &k8sappsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: component.SystemPgBouncerDeploymentName}}
+	common.TagObjectToDelete(deployment)
+	err = r.ReconcileDeployment(deployment, reconcilers.CreateOnlyMutator)
+	if err != nil {
+		return err
+	}
+
+	pdb := &policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Name: component.SystemPgBouncerDeploymentName}}
+	common.TagObjectToDelete(pdb)
+	err = r.ReconcileResource(&policyv1.PodDisruptionBudget{}, pdb, reconcilers.CreateOnlyMutator)
+	if err != nil {
+		return err
+	}
+
+	kindExists, err := r.HasPodMonitors()
+	if err != nil {
+		return err
+	}
+	if kindExists {
+		podMonitor := &monitoringv1.PodMonitor{ObjectMeta: metav1.ObjectMeta{Name: component.SystemPgBouncerDeploymentName}}
+		common.TagObjectToDelete(podMonitor)
+		err = r.ReconcileResource(&monitoringv1.PodMonitor{}, podMonitor, reconcilers.CreateOnlyMutator)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func SystemPgBouncer(apimanager *appsv1alpha1.APIManager, client client.Client) (*component.SystemPgBouncer, error) {
+	optsProvider := NewSystemPgBouncerOptionsProvider(apimanager, apimanager.Namespace, client)
+	opts, err := optsProvider.GetOptions()
+	if err != nil {
+		return nil, err
+	}
+	return component.NewSystemPgBouncer(opts), nil
+}
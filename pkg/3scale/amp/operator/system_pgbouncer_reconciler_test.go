@@ -0,0 +1,98 @@
+package operator
+
+import (
+	"context"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+
+	k8sappsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestSystemPgBouncerReconciler(t *testing.T) {
+	cases := []struct {
+		testName          string
+		apimanagerFactory func() *appsv1alpha1.APIManager
+		exists            bool
+	}{
+		{"disabled", basicApimanager, false},
+		{"enabled", testSystemPgBouncerApimanager, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			log := logf.Log.WithName("operator_test")
+			ctx := context.TODO()
+			apimanager := tc.apimanagerFactory()
+			s := scheme.Scheme
+			s.AddKnownTypes(appsv1alpha1.GroupVersion, apimanager)
+			err := k8sappsv1.AddToScheme(s)
+			if err != nil {
+				subT.Fatal(err)
+			}
+			err = policyv1.AddToScheme(s)
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			// Objects to track in the fake client.
+			objs := []runtime.Object{getSystemDBSecret(testSystemPgBouncerDatabaseURL, "user", "pass1")}
+			if !tc.exists {
+				// pooler resources left from a previous reconciliation must be removed
+				objs = append(objs,
+					&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: component.SystemPgBouncerSecretName, Namespace: namespace}},
+					&k8sappsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: component.SystemPgBouncerDeploymentName, Namespace: namespace}},
+				)
+			}
+
+			// Create a fake client to mock API calls.
+			cl := fake.NewFakeClient(objs...)
+			clientAPIReader := fake.NewFakeClient(objs...)
+			clientset := fakeclientset.NewSimpleClientset()
+			recorder := record.NewFakeRecorder(10000)
+
+			baseReconciler := reconcilers.NewBaseReconciler(ctx, cl, s, clientAPIReader, log, clientset.Discovery(), recorder)
+			baseAPIManagerLogicReconciler := NewBaseAPIManagerLogicReconciler(baseReconciler, apimanager)
+
+			reconciler := NewSystemPgBouncerReconciler(baseAPIManagerLogicReconciler)
+			_, err = reconciler.Reconcile()
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			objects := []struct {
+				objName string
+				obj     client.Object
+			}{
+				{component.SystemPgBouncerSecretName, &v1.Secret{}},
+				{component.SystemPgBouncerServiceName, &v1.Service{}},
+				{component.SystemPgBouncerDeploymentName, &k8sappsv1.Deployment{}},
+			}
+
+			for _, o := range objects {
+				namespacedName := types.NamespacedName{Name: o.objName, Namespace: namespace}
+				err = cl.Get(ctx, namespacedName, o.obj)
+				if tc.exists && err != nil {
+					subT.Errorf("error fetching object %s %T: %v", o.objName, o.obj, err)
+				}
+				if !tc.exists && !errors.IsNotFound(err) {
+					subT.Errorf("object %s %T should not exist: %v", o.objName, o.obj, err)
+				}
+			}
+		})
+	}
+}
@@ -11,12 +11,15 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	k8sappsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	appscommon "github.com/3scale/3scale-operator/apis/apps"
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 	"github.com/3scale/3scale-operator/pkg/common"
@@ -35,17 +38,179 @@ func NewSystemReconciler(baseAPIManagerLogicReconciler *BaseAPIManagerLogicRecon
 	}
 }
 
-func (r *SystemReconciler) reconcileFileStorage(system *component.System) error {
+// reconcileFileStorage returns true while the system-storage PVC contents are
+// being migrated to the S3 bucket
+func (r *SystemReconciler) reconcileFileStorage(system *component.System, ampImages *component.AmpImages) (bool, error) {
 	if r.apiManager.IsS3Enabled() {
-		return nil
+		return r.reconcileFileStorageMigration(ampImages)
 	}
 
 	if r.apiManager.Spec.System.FileStorageSpec != nil &&
 		r.apiManager.Spec.System.FileStorageSpec.DeprecatedS3 != nil {
 		r.Logger().Info("Warning: deprecated amazonSimpleStorageService field in CR being used. Ignoring it... Please use simpleStorageService")
 	}
+
+	// A migration to S3 reverted before its confirmation starts over the next time
+	for _, jobName := range []string{component.SystemStorageMigrationJobName, component.SystemStorageMigrationFinalJobName} {
+		job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: jobName}}
+		common.TagToObjectDeleteWithPropagationPolicy(job, metav1.DeletePropagationBackground)
+		err := r.ReconcileJob(job, reconcilers.CreateOnlyMutator)
+		if err != nil {
+			return false, err
+		}
+	}
+
 	// System RWX PVC, i.e. shared storage
-	return r.ReconcilePersistentVolumeClaim(system.SharedStorage(), reconcilers.CreateOnlyMutator)
+	return false, r.ReconcilePersistentVolumeClaim(system.SharedStorage(), reconcilers.CreateOnlyMutator)
+}
+
+// reconcileFileStorageMigrated records in the APIManager annotations that the system-storage
+// PVC contents have been copied to the S3 bucket, so System keeps using S3 when the migration
+// Job is deleted. The record is removed when the file storage is set back to the PVC
+func (r *SystemReconciler) reconcileFileStorageMigrated() error {
+	migrated := r.apiManager.IsS3Enabled() && (r.apiManager.IsSystemStorageMigrated() ||
+		helper.HasJobCompleted(component.SystemStorageMigrationJobName, r.apiManager.GetNamespace(), r.Client()))
+	if migrated == r.apiManager.IsSystemStorageMigrated() {
+		return nil
+	}
+
+	annotations := r.apiManager.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	if migrated {
+		annotations[appsv1alpha1.SystemStorageMigratedAnnotation] = "true"
+	} else {
+		delete(annotations, appsv1alpha1.SystemStorageMigratedAnnotation)
+	}
+	r.apiManager.SetAnnotations(annotations)
+
+	return r.UpdateResource(r.apiManager)
+}
+
+// reconcileFileStorageMigration copies the system-storage PVC contents to the S3 bucket
+// when the file storage is switched from PVC to S3. System keeps using the PVC until the
+// migration Job succeeds. Once no System pod mounts the PVC anymore, the final Job copies
+// the files written meanwhile. The PVC is only deleted once the user confirms the migration
+func (r *SystemReconciler) reconcileFileStorageMigration(ampImages *component.AmpImages) (bool, error) {
+	pvcExists, err := systemStoragePVCExists(r.apiManager, r.Client())
+	if err != nil || !pvcExists {
+		return false, err
+	}
+
+	migration, err := SystemStorageMigration(r.apiManager, r.Client())
+	if err != nil {
+		return false, err
+	}
+	migrationJob := migration.Job(ampImages.Options.SystemStorageMigrationImage)
+	finalJob := migration.FinalJob(ampImages.Options.SystemStorageMigrationImage)
+
+	if !r.apiManager.IsSystemStorageMigrated() {
+		return true, r.reconcileFileStorageMigrationJob(migrationJob)
+	}
+
+	inUse, err := r.isSystemStoragePVCInUse()
+	if err != nil || inUse {
+		return true, err
+	}
+
+	if !helper.HasJobCompleted(finalJob.Name, r.apiManager.GetNamespace(), r.Client()) {
+		return true, r.reconcileFileStorageMigrationJob(finalJob)
+	}
+
+	if !r.apiManager.IsSystemStorageMigrationConfirmed() {
+		return false, nil
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: component.SystemFileStoragePVCName}}
+	common.TagObjectToDelete(pvc)
+	err = r.ReconcilePersistentVolumeClaim(pvc, reconcilers.CreateOnlyMutator)
+	if err != nil {
+		return false, err
+	}
+
+	for _, job := range []*batchv1.Job{migrationJob, finalJob} {
+		common.TagToObjectDeleteWithPropagationPolicy(job, metav1.DeletePropagationBackground)
+		err = r.ReconcileJob(job, reconcilers.CreateOnlyMutator)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return false, nil
+}
+
+func (r *SystemReconciler) reconcileFileStorageMigrationJob(job *batchv1.Job) error {
+	err := r.ReconcileJob(job, reconcilers.CreateOnlyMutator)
+	if err != nil {
+		return err
+	}
+
+	if helper.HasJobFailed(job.Name, r.apiManager.GetNamespace(), r.Client()) {
+		errToLog := fmt.Errorf("job '%s' failed, the '%s' PVC contents are not fully copied to the bucket. Delete the job to retry the migration", job.Name, component.SystemFileStoragePVCName)
+		r.EventRecorder().Eventf(r.apiManager, corev1.EventTypeWarning, "SystemStorageMigrationFailed", errToLog.Error())
+		r.Logger().Error(errToLog, "SystemStorageMigrationFailed")
+	}
+
+	return nil
+}
+
+// isSystemStoragePVCInUse returns true while a pod other than the migration ones mounts the
+// system-storage PVC, i.e. System may still write files to it
+func (r *SystemReconciler) isSystemStoragePVCInUse() (bool, error) {
+	podList := &corev1.PodList{}
+	err := r.APIClientReader().List(r.Context(), podList, k8sclient.InNamespace(r.apiManager.GetNamespace()))
+	if err != nil {
+		return false, err
+	}
+
+	for _, pod := range podList.Items {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed ||
+			pod.Labels["threescale_component_element"] == "storage-migration" {
+			continue
+		}
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == component.SystemFileStoragePVCName {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// reconcileSMTPTestEmail runs the Job sending a probe email to the recipient of the
+// smtp-test-email annotation. The Job is recreated when the recipient changes and
+// deleted when the annotation is removed. Returns true while the Job is running
+func (r *SystemReconciler) reconcileSMTPTestEmail(ampImages *component.AmpImages) (bool, error) {
+	if r.apiManager.SMTPTestEmailRecipient() == "" {
+		return false, helper.DeleteJob(component.SystemSMTPTestEmailJobName, r.apiManager.GetNamespace(), r.Client())
+	}
+
+	testEmail, err := SystemSMTPTestEmail(r.apiManager)
+	if err != nil {
+		return false, err
+	}
+	testEmailJob := testEmail.Job(ampImages.Options.SystemImage)
+
+	existing := &batchv1.Job{}
+	err = r.Client().Get(r.Context(), k8sclient.ObjectKey{Name: testEmailJob.Name, Namespace: r.apiManager.GetNamespace()}, existing)
+	if err != nil && !k8serr.IsNotFound(err) {
+		return false, err
+	}
+	if err == nil && existing.Annotations[component.SystemSMTPTestEmailRecipientAnnotation] != testEmail.Options.Recipient {
+		// Jobs are immutable, recreate it for the new recipient
+		common.TagObjectToDelete(testEmailJob)
+		return true, r.ReconcileJob(testEmailJob, reconcilers.CreateOnlyMutator)
+	}
+
+	err = r.ReconcileJob(testEmailJob, reconcilers.CreateOnlyMutator)
+	if err != nil {
+		return false, err
+	}
+
+	namespace := r.apiManager.GetNamespace()
+	return !helper.HasJobCompleted(testEmailJob.Name, namespace, r.Client()) && !helper.HasJobFailed(testEmailJob.Name, namespace, r.Client()), nil
 }
 
 func (r *SystemReconciler) Reconcile() (reconcile.Result, error) {
@@ -54,12 +219,18 @@ func (r *SystemReconciler) Reconcile() (reconcile.Result, error) {
 		return reconcile.Result{}, err
 	}
 
+	// The system options depend on the file storage migration state
+	err = r.reconcileFileStorageMigrated()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
 	system, err := System(r.apiManager, r.Client())
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	err = r.reconcileFileStorage(system)
+	storageMigrationPending, err := r.reconcileFileStorage(system, ampImages)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -106,7 +277,17 @@ func (r *SystemReconciler) Reconcile() (reconcile.Result, error) {
 	}
 
 	// SMTP Secret
-	err = r.ReconcileSecret(system.SMTPSecret(), reconcilers.DefaultsOnlySecretMutator)
+	var smtpSecretMutator reconcilers.MutateFn = reconcilers.DefaultsOnlySecretMutator
+	if r.apiManager.IsSMTPSpecEnabled() {
+		// The smtp spec owns the secret content
+		smtpSecretMutator = reconcilers.DeploymentSecretMutator(reconcilers.SecretStringDataMutator)
+	}
+	err = r.ReconcileSecret(system.SMTPSecret(), smtpSecretMutator)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	smtpTestEmailPending, err := r.reconcileSMTPTestEmail(ampImages)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -205,8 +386,12 @@ func (r *SystemReconciler) Reconcile() (reconcile.Result, error) {
 			reconcilers.DeploymentPodContainerImageMutator,
 			r.systemZyncEnvVarMutator,
 			r.systemDatabaseTLSEnvVarMutator,
+			r.systemDatabaseURLEnvVarMutator,
+			r.systemFileStorageMutator,
+			openTelemetryMutator,
+			systemAppWorkersEnvVarMutator,
 		}
-		if r.apiManager.Spec.System.AppSpec.Replicas != nil {
+		if r.apiManager.Spec.System.AppSpec.Replicas != nil || r.apiManager.IsProfileSet() {
 			systemAppDeploymentMutators = append(systemAppDeploymentMutators, reconcilers.DeploymentReplicasMutator)
 		}
 		if r.apiManager.IsSystemDatabaseTLSEnabled() {
@@ -272,8 +457,11 @@ func (r *SystemReconciler) Reconcile() (reconcile.Result, error) {
 		reconcilers.DeploymentPodInitContainerMutator,
 		r.systemZyncEnvVarMutator,
 		r.systemDatabaseTLSEnvVarMutator,
+		r.systemDatabaseURLEnvVarMutator,
+		r.systemFileStorageMutator,
+		openTelemetryMutator,
 	}
-	if r.apiManager.Spec.System.SidekiqSpec.Replicas != nil {
+	if r.apiManager.Spec.System.SidekiqSpec.Replicas != nil || r.apiManager.IsProfileSet() {
 		sidekiqDeploymentMutators = append(sidekiqDeploymentMutators, reconcilers.DeploymentReplicasMutator)
 	}
 	if r.apiManager.IsSystemDatabaseTLSEnabled() {
@@ -347,8 +535,9 @@ func (r *SystemReconciler) Reconcile() (reconcile.Result, error) {
 		return reconcile.Result{}, err
 	}
 
-	// Requeue if any of the system-app Deployment's components aren't ready
-	if !systemComponentsReady {
+	// Requeue if any of the system-app Deployment's components aren't ready,
+	// the file storage migration or the SMTP test email are still running
+	if !systemComponentsReady || storageMigrationPending || smtpTestEmailPending {
 		return reconcile.Result{RequeueAfter: 5 * time.Second}, nil
 	}
 
@@ -418,6 +607,53 @@ func (r *SystemReconciler) systemZyncEnvVarMutator(desired, existing *k8sappsv1.
 	return update, nil
 }
 
+func (r *SystemReconciler) systemDatabaseURLEnvVarMutator(desired, existing *k8sappsv1.Deployment) (bool, error) {
+	update := false
+
+	// Reconcile DATABASE_URL env var, it points to the connection pooler when enabled
+	for idx := range existing.Spec.Template.Spec.Containers {
+		tmpChanged := helper.EnvVarReconciler(
+			desired.Spec.Template.Spec.Containers[idx].Env,
+			&existing.Spec.Template.Spec.Containers[idx].Env,
+			"DATABASE_URL")
+		update = update || tmpChanged
+	}
+
+	return update, nil
+}
+
+// systemFileStorageMutator switches the file storage between the system-storage PVC and S3
+func systemAppWorkersEnvVarMutator(desired, existing *k8sappsv1.Deployment) (bool, error) {
+	// Reconcile EnvVar only for "PUMA_WORKERS"
+	return reconcilers.DeploymentEnvVarReconciler(desired, existing, component.SystemAppWorkersEnvVarName), nil
+}
+
+func (r *SystemReconciler) systemFileStorageMutator(desired, existing *k8sappsv1.Deployment) (bool, error) {
+	update := false
+
+	for _, envVar := range []string{
+		appscommon.AwsBucket,
+		appscommon.AwsRegion,
+		appscommon.AwsProtocol,
+		appscommon.AwsHostname,
+		appscommon.AwsPathStyle,
+		appscommon.AwsRoleArn,
+		appscommon.AwsWebIdentityTokenFile,
+		appscommon.AwsAccessKeyID,
+		appscommon.AwsSecretAccessKey,
+	} {
+		tmpChanged := reconcilers.DeploymentEnvVarReconciler(desired, existing, envVar)
+		update = update || tmpChanged
+	}
+
+	for _, volumeName := range []string{component.SystemFileStoragePVCName, component.S3StsCredentialsSecretName} {
+		tmpChanged := reconcilers.DeploymentVolumeReconciler(desired, existing, volumeName)
+		update = update || tmpChanged
+	}
+
+	return update, nil
+}
+
 func (r *SystemReconciler) isZyncReady() (bool, error) {
 	zyncDeploymentNames := []string{"zync", "zync-database", "zync-que"}
 
@@ -501,6 +737,24 @@ func systemConfigMapMutator(existingObj, desiredObj common.KubernetesObject) (bo
 	return updated, nil
 }
 
+func SystemStorageMigration(cr *appsv1alpha1.APIManager, client k8sclient.Client) (*component.SystemStorageMigration, error) {
+	optsProvider := NewSystemStorageMigrationOptionsProvider(cr, cr.Namespace, client)
+	opts, err := optsProvider.GetOptions()
+	if err != nil {
+		return nil, err
+	}
+	return component.NewSystemStorageMigration(opts), nil
+}
+
+func SystemSMTPTestEmail(cr *appsv1alpha1.APIManager) (*component.SystemSMTPTestEmail, error) {
+	optsProvider := NewSystemSMTPTestEmailOptionsProvider(cr)
+	opts, err := optsProvider.GetOptions()
+	if err != nil {
+		return nil, err
+	}
+	return component.NewSystemSMTPTestEmail(opts), nil
+}
+
 func System(cr *appsv1alpha1.APIManager, client k8sclient.Client) (*component.System, error) {
 	optsProvider := NewSystemOptionsProvider(cr, cr.Namespace, client)
 	opts, err := optsProvider.GetSystemOptions()
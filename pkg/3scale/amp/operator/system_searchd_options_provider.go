@@ -3,7 +3,6 @@ package operator
 import (
 	"fmt"
 
-	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
@@ -46,13 +45,10 @@ func (s *SystemSearchdOptionsProvider) GetOptions() (*component.SystemSearchdOpt
 }
 
 func (s *SystemSearchdOptionsProvider) setResourceRequirementsOptions() {
-	s.options.ContainerResourceRequirements = v1.ResourceRequirements{}
-	if *s.apimanager.Spec.ResourceRequirementsEnabled {
-		s.options.ContainerResourceRequirements = component.DefaultSearchdContainerResourceRequirements()
-	}
+	s.options.ContainerResourceRequirements = profileResourceRequirements(s.apimanager, component.DefaultSearchdContainerResourceRequirements())
 	// Deployment-level ResourceRequirements CR fields have priority over
-	// spec.resourceRequirementsEnabled, overwriting that setting when they are
-	// defined
+	// spec.resourceRequirementsEnabled and spec.profile, overwriting those settings
+	// when they are defined
 	if s.apimanager.Spec.System.SearchdSpec.Resources != nil {
 		s.options.ContainerResourceRequirements = *s.apimanager.Spec.System.SearchdSpec.Resources
 	}
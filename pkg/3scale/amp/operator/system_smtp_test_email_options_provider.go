@@ -0,0 +1,36 @@
+package operator
+
+import (
+	"fmt"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+)
+
+type SystemSMTPTestEmailOptionsProvider struct {
+	apimanager *appsv1alpha1.APIManager
+	options    *component.SystemSMTPTestEmailOptions
+}
+
+func NewSystemSMTPTestEmailOptionsProvider(apimanager *appsv1alpha1.APIManager) *SystemSMTPTestEmailOptionsProvider {
+	return &SystemSMTPTestEmailOptionsProvider{
+		apimanager: apimanager,
+		options:    component.NewSystemSMTPTestEmailOptions(),
+	}
+}
+
+func (s *SystemSMTPTestEmailOptionsProvider) GetOptions() (*component.SystemSMTPTestEmailOptions, error) {
+	s.options.Labels = map[string]string{
+		"app":                          *s.apimanager.Spec.AppLabel,
+		"threescale_component":         "system",
+		"threescale_component_element": "smtp-test-email",
+	}
+	s.options.Recipient = s.apimanager.SMTPTestEmailRecipient()
+
+	err := s.options.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("GetSystemSMTPTestEmailOptions validating: %w", err)
+	}
+
+	return s.options, nil
+}
@@ -0,0 +1,65 @@
+package operator
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+)
+
+func TestGetSystemSMTPTestEmailOptionsProvider(t *testing.T) {
+	apimanager := basicApimanager()
+	apimanager.Annotations = map[string]string{appsv1alpha1.SMTPTestEmailAnnotation: "ops@example.com"}
+
+	opts, err := NewSystemSMTPTestEmailOptionsProvider(apimanager).GetOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &component.SystemSMTPTestEmailOptions{
+		Recipient: "ops@example.com",
+		Labels: map[string]string{
+			"app":                          appLabel,
+			"threescale_component":         "system",
+			"threescale_component_element": "smtp-test-email",
+		},
+	}
+	if diff := cmp.Diff(expected, opts); diff != "" {
+		t.Fatalf("unexpected options (-want +got):\n%s", diff)
+	}
+
+	job := component.NewSystemSMTPTestEmail(opts).Job("system-image")
+	if job.Annotations[component.SystemSMTPTestEmailRecipientAnnotation] != "ops@example.com" {
+		t.Fatalf("unexpected job annotations %v", job.Annotations)
+	}
+	if *job.Spec.BackoffLimit != 0 {
+		t.Fatalf("expected no retries, got backoffLimit %d", *job.Spec.BackoffLimit)
+	}
+	container := job.Spec.Template.Spec.Containers[0]
+	if container.Image != "system-image" {
+		t.Fatalf("unexpected image %s", container.Image)
+	}
+	envs := map[string]bool{}
+	for _, env := range container.Env {
+		envs[env.Name] = true
+		if env.Name == "TEST_EMAIL_RECIPIENT" && env.Value != "ops@example.com" {
+			t.Fatalf("unexpected recipient %s", env.Value)
+		}
+	}
+	for _, name := range []string{"SMTP_ADDRESS", "SMTP_PORT", "SMTP_USER_NAME", "SMTP_PASSWORD", "SMTP_STARTTLS", "SMTP_TLS", "NOREPLY_EMAIL", "TEST_EMAIL_RECIPIENT"} {
+		if !envs[name] {
+			t.Fatalf("missing env var %s", name)
+		}
+	}
+}
+
+func TestGetSystemSMTPTestEmailOptionsProviderInvalidRecipient(t *testing.T) {
+	apimanager := basicApimanager()
+	apimanager.Annotations = map[string]string{appsv1alpha1.SMTPTestEmailAnnotation: "not-an-email"}
+
+	if _, err := NewSystemSMTPTestEmailOptionsProvider(apimanager).GetOptions(); err == nil {
+		t.Fatal("expected error with an invalid recipient")
+	}
+}
@@ -0,0 +1,73 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/helper"
+)
+
+type SystemStorageMigrationOptionsProvider struct {
+	apimanager   *appsv1alpha1.APIManager
+	options      *component.SystemStorageMigrationOptions
+	secretSource *helper.SecretSource
+}
+
+func NewSystemStorageMigrationOptionsProvider(apimanager *appsv1alpha1.APIManager, namespace string, client client.Client) *SystemStorageMigrationOptionsProvider {
+	return &SystemStorageMigrationOptionsProvider{
+		apimanager:   apimanager,
+		options:      component.NewSystemStorageMigrationOptions(),
+		secretSource: helper.NewSecretSource(client, namespace),
+	}
+}
+
+func (s *SystemStorageMigrationOptionsProvider) GetOptions() (*component.SystemStorageMigrationOptions, error) {
+	s.options.Labels = map[string]string{
+		"app":                          *s.apimanager.Spec.AppLabel,
+		"threescale_component":         "system",
+		"threescale_component_element": "storage-migration",
+	}
+
+	s3Options, err := s3FileStorageOptions(s.apimanager, s.secretSource)
+	if err != nil {
+		return nil, fmt.Errorf("GetSystemStorageMigrationOptions reading secret options: %w", err)
+	}
+	s.options.S3FileStorageOptions = s3Options
+
+	err = s.options.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("GetSystemStorageMigrationOptions validating: %w", err)
+	}
+
+	return s.options, nil
+}
+
+// IsSystemStorageMigrationPending returns true when the file storage is set to S3
+// while the system-storage PVC still exists and the operator has not recorded its
+// contents as copied to the bucket yet
+func IsSystemStorageMigrationPending(apimanager *appsv1alpha1.APIManager, k8sclient client.Client) (bool, error) {
+	if !apimanager.IsS3Enabled() || apimanager.IsSystemStorageMigrated() {
+		return false, nil
+	}
+
+	return systemStoragePVCExists(apimanager, k8sclient)
+}
+
+func systemStoragePVCExists(apimanager *appsv1alpha1.APIManager, k8sclient client.Client) (bool, error) {
+	pvc := &v1.PersistentVolumeClaim{}
+	err := k8sclient.Get(context.TODO(), client.ObjectKey{Namespace: apimanager.Namespace, Name: component.SystemFileStoragePVCName}, pvc)
+	if k8serr.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,226 @@
+package operator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+)
+
+func testSystemStorageMigrationApimanager() *appsv1alpha1.APIManager {
+	apimanager := basicApimanager()
+	apimanager.Spec.System.FileStorageSpec = &appsv1alpha1.SystemFileStorageSpec{
+		S3: &appsv1alpha1.SystemS3Spec{
+			ConfigurationSecretRef: v1.LocalObjectReference{Name: "myawsauth"},
+		},
+	}
+	return apimanager
+}
+
+func testSystemStorageMigratedApimanager() *appsv1alpha1.APIManager {
+	apimanager := testSystemStorageMigrationApimanager()
+	apimanager.Annotations = map[string]string{appsv1alpha1.SystemStorageMigratedAnnotation: "true"}
+	return apimanager
+}
+
+func testSystemStoragePVC() *v1.PersistentVolumeClaim {
+	return &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: component.SystemFileStoragePVCName, Namespace: namespace},
+	}
+}
+
+func testSystemStorageMigrationJob(completed bool) *batchv1.Job {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: component.SystemStorageMigrationJobName, Namespace: namespace},
+	}
+	if completed {
+		job.Status.Conditions = []batchv1.JobCondition{
+			{Type: batchv1.JobComplete, Status: v1.ConditionTrue},
+		}
+	}
+	return job
+}
+
+func TestGetSystemStorageMigrationOptionsProvider(t *testing.T) {
+	objs := []runtime.Object{getS3IAMSecret()}
+	cl := fake.NewFakeClient(objs...)
+
+	optsProvider := NewSystemStorageMigrationOptionsProvider(testSystemStorageMigrationApimanager(), namespace, cl)
+	opts, err := optsProvider.GetOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &component.SystemStorageMigrationOptions{
+		S3FileStorageOptions: &component.S3FileStorageOptions{ConfigurationSecretName: "myawsauth"},
+		Labels: map[string]string{
+			"app":                          appLabel,
+			"threescale_component":         "system",
+			"threescale_component_element": "storage-migration",
+		},
+	}
+	if diff := cmp.Diff(expected, opts); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestGetSystemStorageMigrationOptionsProviderMissingSecret(t *testing.T) {
+	cl := fake.NewFakeClient()
+
+	optsProvider := NewSystemStorageMigrationOptionsProvider(testSystemStorageMigrationApimanager(), namespace, cl)
+	_, err := optsProvider.GetOptions()
+	if err == nil {
+		t.Fatal("expected error when the S3 configuration secret does not exist")
+	}
+}
+
+func TestIsSystemStorageMigrationPending(t *testing.T) {
+	cases := []struct {
+		testName   string
+		apimanager *appsv1alpha1.APIManager
+		objs       []runtime.Object
+		expected   bool
+	}{
+		{"PVCFileStorage", basicApimanager(), []runtime.Object{testSystemStoragePVC()}, false},
+		{"S3WithoutPVC", testSystemStorageMigrationApimanager(), nil, false},
+		{"S3WithPVC", testSystemStorageMigrationApimanager(), []runtime.Object{testSystemStoragePVC()}, true},
+		{"S3WithPVCAndRunningJob", testSystemStorageMigrationApimanager(),
+			[]runtime.Object{testSystemStoragePVC(), testSystemStorageMigrationJob(false)}, true},
+		{"S3WithPVCAndCompletedJob", testSystemStorageMigrationApimanager(),
+			[]runtime.Object{testSystemStoragePVC(), testSystemStorageMigrationJob(true)}, true},
+		{"MigratedWithPVC", testSystemStorageMigratedApimanager(), []runtime.Object{testSystemStoragePVC()}, false},
+		{"MigratedWithPVCAndCompletedJob", testSystemStorageMigratedApimanager(),
+			[]runtime.Object{testSystemStoragePVC(), testSystemStorageMigrationJob(true)}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			cl := fake.NewFakeClient(tc.objs...)
+			pending, err := IsSystemStorageMigrationPending(tc.apimanager, cl)
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if pending != tc.expected {
+				subT.Fatalf("expected pending: %t, got: %t", tc.expected, pending)
+			}
+		})
+	}
+}
+
+func TestSystemReconcilerFileStorageMigration(t *testing.T) {
+	apimanager := testSystemStorageMigrationApimanager()
+	systemPod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "system-app-1", Namespace: namespace},
+		Spec: v1.PodSpec{
+			Volumes: []v1.Volume{{
+				Name: "system-storage",
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: component.SystemFileStoragePVCName},
+				},
+			}},
+		},
+	}
+
+	s := scheme.Scheme
+	if err := appsv1alpha1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	cl := fake.NewClientBuilder().WithScheme(s).
+		WithRuntimeObjects(apimanager, getS3IAMSecret(), testSystemStoragePVC(), systemPod).Build()
+	baseReconciler := reconcilers.NewBaseReconciler(context.TODO(), cl, s, cl, logf.Log.WithName("operator_test"),
+		fakeclientset.NewSimpleClientset().Discovery(), record.NewFakeRecorder(100))
+	reconciler := NewSystemReconciler(NewBaseAPIManagerLogicReconciler(baseReconciler, apimanager))
+	ampImages := component.NewAmpImages(&component.AmpImagesOptions{SystemStorageMigrationImage: "aws-cli"})
+
+	reconcileMigration := func(expectedPending bool) {
+		t.Helper()
+		if err := reconciler.reconcileFileStorageMigrated(); err != nil {
+			t.Fatal(err)
+		}
+		pending, err := reconciler.reconcileFileStorageMigration(ampImages)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pending != expectedPending {
+			t.Fatalf("expected pending: %t, got: %t", expectedPending, pending)
+		}
+	}
+	completeJob := func(name string) {
+		t.Helper()
+		job := &batchv1.Job{}
+		if err := cl.Get(context.TODO(), k8sclient.ObjectKey{Name: name, Namespace: namespace}, job); err != nil {
+			t.Fatal(err)
+		}
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: v1.ConditionTrue}}
+		if err := cl.Status().Update(context.TODO(), job); err != nil {
+			t.Fatal(err)
+		}
+	}
+	exists := func(obj k8sclient.Object, name string) bool {
+		t.Helper()
+		err := cl.Get(context.TODO(), k8sclient.ObjectKey{Name: name, Namespace: namespace}, obj)
+		if err != nil && !k8serr.IsNotFound(err) {
+			t.Fatal(err)
+		}
+		return err == nil
+	}
+
+	// the first pass runs while System still uses the PVC
+	reconcileMigration(true)
+	if !exists(&batchv1.Job{}, component.SystemStorageMigrationJobName) {
+		t.Fatal("migration job not created")
+	}
+
+	// the completion is recorded, but the final pass waits for the PVC to be released
+	completeJob(component.SystemStorageMigrationJobName)
+	reconcileMigration(true)
+	if !apimanager.IsSystemStorageMigrated() {
+		t.Fatal("migration not recorded in the APIManager annotations")
+	}
+	if exists(&batchv1.Job{}, component.SystemStorageMigrationFinalJobName) {
+		t.Fatal("final migration job created while the PVC is mounted")
+	}
+
+	if err := cl.Delete(context.TODO(), systemPod); err != nil {
+		t.Fatal(err)
+	}
+	reconcileMigration(true)
+	if !exists(&batchv1.Job{}, component.SystemStorageMigrationFinalJobName) {
+		t.Fatal("final migration job not created")
+	}
+
+	// deleting the jobs does not switch System back to the PVC
+	completeJob(component.SystemStorageMigrationFinalJobName)
+	reconcileMigration(false)
+	pending, err := IsSystemStorageMigrationPending(apimanager, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pending {
+		t.Fatal("expected migration not pending once recorded")
+	}
+	if !exists(&v1.PersistentVolumeClaim{}, component.SystemFileStoragePVCName) {
+		t.Fatal("PVC deleted before the migration is confirmed")
+	}
+
+	apimanager.Annotations[appsv1alpha1.SystemStorageMigrationConfirmedAnnotation] = "true"
+	reconcileMigration(false)
+	if exists(&v1.PersistentVolumeClaim{}, component.SystemFileStoragePVCName) {
+		t.Fatal("PVC not deleted after the migration is confirmed")
+	}
+}
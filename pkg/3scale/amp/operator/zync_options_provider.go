@@ -65,6 +65,13 @@ func (z *ZyncOptionsProvider) GetZyncOptions() (*component.ZyncOptions, error) {
 
 	z.zyncOptions.Namespace = z.apimanager.Namespace
 
+	z.zyncOptions.OpenTelemetry, err = openTelemetryOptions(z.apimanager.Spec.Zync.OpenTelemetry, z.secretSource)
+	if err != nil {
+		return nil, fmt.Errorf("GetZyncOptions reading opentelemetry options: %w", err)
+	}
+
+	z.setDatabaseExporterOptions()
+
 	err = z.zyncOptions.Validate()
 	if err != nil {
 		return nil, fmt.Errorf("GetZyncOptions validating: %w", err)
@@ -191,19 +198,13 @@ func (z *ZyncOptionsProvider) validateZyncDatabaseURLAndPasswordFieldsConsistenc
 }
 
 func (z *ZyncOptionsProvider) setResourceRequirementsOptions() {
-	if *z.apimanager.Spec.ResourceRequirementsEnabled {
-		z.zyncOptions.ContainerResourceRequirements = component.DefaultZyncContainerResourceRequirements()
-		z.zyncOptions.QueContainerResourceRequirements = component.DefaultZyncQueContainerResourceRequirements()
-		z.zyncOptions.DatabaseContainerResourceRequirements = component.DefaultZyncDatabaseContainerResourceRequirements()
-	} else {
-		z.zyncOptions.ContainerResourceRequirements = v1.ResourceRequirements{}
-		z.zyncOptions.QueContainerResourceRequirements = v1.ResourceRequirements{}
-		z.zyncOptions.DatabaseContainerResourceRequirements = v1.ResourceRequirements{}
-	}
+	z.zyncOptions.ContainerResourceRequirements = profileResourceRequirements(z.apimanager, component.DefaultZyncContainerResourceRequirements())
+	z.zyncOptions.QueContainerResourceRequirements = profileResourceRequirements(z.apimanager, component.DefaultZyncQueContainerResourceRequirements())
+	z.zyncOptions.DatabaseContainerResourceRequirements = profileResourceRequirements(z.apimanager, component.DefaultZyncDatabaseContainerResourceRequirements())
 
 	// Deployment-level ResourceRequirements CR fields have priority over
-	// spec.resourceRequirementsEnabled, overwriting that setting when they are
-	// defined
+	// spec.resourceRequirementsEnabled and spec.profile, overwriting those settings
+	// when they are defined
 	if z.apimanager.Spec.Zync.AppSpec.Resources != nil {
 		z.zyncOptions.ContainerResourceRequirements = *z.apimanager.Spec.Zync.AppSpec.Resources
 	}
@@ -225,12 +226,12 @@ func (z *ZyncOptionsProvider) setNodeAffinityAndTolerationsOptions() {
 }
 
 func (z *ZyncOptionsProvider) setReplicas() {
-	z.zyncOptions.ZyncReplicas = 1
+	z.zyncOptions.ZyncReplicas = profileReplicas(z.apimanager, component.ZyncName)
 	if z.apimanager.Spec.Zync.AppSpec.Replicas != nil {
 		z.zyncOptions.ZyncReplicas = int32(*z.apimanager.Spec.Zync.AppSpec.Replicas)
 	}
 
-	z.zyncOptions.ZyncQueReplicas = 1
+	z.zyncOptions.ZyncQueReplicas = profileReplicas(z.apimanager, component.ZyncQueDeploymentName)
 	if z.apimanager.Spec.Zync.QueSpec.Replicas != nil {
 		z.zyncOptions.ZyncQueReplicas = int32(*z.apimanager.Spec.Zync.QueSpec.Replicas)
 	}
@@ -364,3 +365,13 @@ func (z *ZyncOptionsProvider) zyncQuePodTemplateAnnotations() map[string]string
 func (z *ZyncOptionsProvider) setZyncTLSEnabled() {
 	z.zyncOptions.ZyncDbTLSEnabled = z.apimanager.IsZyncDatabaseTLSEnabled()
 }
+
+// setDatabaseExporterOptions enables the exporter sidecar of the internal zync-database
+func (z *ZyncOptionsProvider) setDatabaseExporterOptions() {
+	if !z.apimanager.IsDatastoreExportersEnabled() || z.apimanager.IsExternal(appsv1alpha1.ZyncDatabase) {
+		return
+	}
+
+	z.zyncOptions.DatabaseExporterImage = PostgreSQLExporterImageURL()
+	z.zyncOptions.DatabaseExporterContainerResourceRequirements = profileResourceRequirements(z.apimanager, component.DefaultDatastoreExporterContainerResourceRequirements())
+}
@@ -73,8 +73,9 @@ func (r *ZyncReconciler) Reconcile() (reconcile.Result, error) {
 		reconcilers.DeploymentPodInitContainerImageMutator,
 		reconcilers.DeploymentPodInitContainerMutator,
 		zyncDatabaseTLSEnvVarMutator,
+		openTelemetryMutator,
 	}
-	if r.apiManager.Spec.Zync.AppSpec.Replicas != nil {
+	if r.apiManager.Spec.Zync.AppSpec.Replicas != nil || r.apiManager.IsProfileSet() {
 		zyncMutators = append(zyncMutators, reconcilers.DeploymentReplicasMutator)
 	}
 	if !r.apiManager.IsZyncDatabaseTLSEnabled() {
@@ -113,8 +114,9 @@ func (r *ZyncReconciler) Reconcile() (reconcile.Result, error) {
 		reconcilers.DeploymentPodContainerImageMutator,
 		reconcilers.DeploymentPodInitContainerMutator,
 		zyncDatabaseTLSEnvVarMutator,
+		openTelemetryMutator,
 	}
-	if r.apiManager.Spec.Zync.QueSpec.Replicas != nil {
+	if r.apiManager.Spec.Zync.QueSpec.Replicas != nil || r.apiManager.IsProfileSet() {
 		zyncQueMutators = append(zyncQueMutators, reconcilers.DeploymentReplicasMutator)
 	}
 	if !r.apiManager.IsZyncDatabaseTLSEnabled() {
@@ -184,6 +186,12 @@ func (r *ZyncReconciler) Reconcile() (reconcile.Result, error) {
 		if err != nil {
 			return reconcile.Result{}, err
 		}
+	} else {
+		// Switched to an external zync database
+		err = r.deleteZyncDatabase(zync, ampImages)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
 	}
 
 	// Zync Secret
@@ -320,18 +328,7 @@ func (r *ZyncReconciler) deleteZyncComponents(zync *component.Zync, ampImages *c
 	}
 
 	if !r.apiManager.IsExternal(appsv1alpha1.ZyncDatabase) {
-		// ZyncDB Service
-		zyncDBService := zync.DatabaseService()
-		common.TagObjectToDelete(zyncDBService)
-		err = r.ReconcileService(zyncDBService, reconcilers.DeleteOnlyMutator)
-		if err != nil {
-			return err
-		}
-
-		// ZyncDB Deployment
-		zyncDBDeployment := zync.DatabaseDeployment(ampImages.Options.ZyncDatabasePostgreSQLImage)
-		common.TagObjectToDelete(zyncDBDeployment)
-		err = r.ReconcileDeployment(zyncDBDeployment, reconcilers.DeleteOnlyMutator)
+		err = r.deleteZyncDatabase(zync, ampImages)
 		if err != nil {
 			return err
 		}
@@ -394,6 +391,23 @@ func (r *ZyncReconciler) deleteZyncComponents(zync *component.Zync, ampImages *c
 	return nil
 }
 
+// deleteZyncDatabase removes the internal zync database. Its storage is ephemeral,
+// so there is no data to migrate when switching to an external database
+func (r *ZyncReconciler) deleteZyncDatabase(zync *component.Zync, ampImages *component.AmpImages) error {
+	// ZyncDB Service
+	zyncDBService := zync.DatabaseService()
+	common.TagObjectToDelete(zyncDBService)
+	err := r.ReconcileService(zyncDBService, reconcilers.DeleteOnlyMutator)
+	if err != nil {
+		return err
+	}
+
+	// ZyncDB Deployment
+	zyncDBDeployment := zync.DatabaseDeployment(ampImages.Options.ZyncDatabasePostgreSQLImage)
+	common.TagObjectToDelete(zyncDBDeployment)
+	return r.ReconcileDeployment(zyncDBDeployment, reconcilers.DeleteOnlyMutator)
+}
+
 func Zync(apimanager *appsv1alpha1.APIManager, client client.Client) (*component.Zync, error) {
 	optsProvider := NewZyncOptionsProvider(apimanager, apimanager.Namespace, client)
 	opts, err := optsProvider.GetZyncOptions()
@@ -186,8 +186,12 @@ func TestNewZyncReconcilerWithAllExternalDatabases(t *testing.T) {
 
 	zyncExternalDatabaseSecret := getZyncSecretExternalDatabase(namespace)
 
+	// Internal zync database left over from before switching to an external database
+	zyncDBDeployment := &k8sappsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "zync-database", Namespace: namespace}}
+	zyncDBService := &v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "zync-database", Namespace: namespace}}
+
 	// Objects to track in the fake client.
-	objs := []runtime.Object{apimanager, zyncExternalDatabaseSecret}
+	objs := []runtime.Object{apimanager, zyncExternalDatabaseSecret, zyncDBDeployment, zyncDBService}
 	s := scheme.Scheme
 	s.AddKnownTypes(appsv1alpha1.GroupVersion, apimanager)
 	err := k8sappsv1.AddToScheme(s)
@@ -52,6 +52,7 @@ func backendOptions(ns string) (*component.BackendOptions, error) {
 	bo.ListenerPodTemplateLabels = map[string]string{}
 	bo.WorkerPodTemplateLabels = map[string]string{}
 	bo.CronPodTemplateLabels = map[string]string{}
+	bo.ListenerWorkers = component.DefaultBackendListenerWorkers
 
 	return bo, bo.Validate()
 }
@@ -0,0 +1,28 @@
+package prometheusrules
+
+import (
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+)
+
+func init() {
+	PrometheusRuleFactories = append(PrometheusRuleFactories, NewCertificatesPrometheusRuleFactory)
+}
+
+type CertificatesPrometheusRuleFactory struct {
+}
+
+func NewCertificatesPrometheusRuleFactory() PrometheusRuleFactory {
+	return &CertificatesPrometheusRuleFactory{}
+}
+
+func (s *CertificatesPrometheusRuleFactory) Type() string {
+	return component.CertificatesMonitoringName
+}
+
+// PrometheusRule ignores the namespace, the rule is deployed in the operator namespace
+func (s *CertificatesPrometheusRuleFactory) PrometheusRule(_ bool, _ string) *monitoringv1.PrometheusRule {
+	return component.CertificatesPrometheusRules(appsv1alpha1.Default3scaleAppLabel)
+}
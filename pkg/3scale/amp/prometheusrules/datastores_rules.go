@@ -0,0 +1,43 @@
+package prometheusrules
+
+import (
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+)
+
+func init() {
+	PrometheusRuleFactories = append(PrometheusRuleFactories, NewDatastoresPrometheusRuleFactory)
+}
+
+type DatastoresPrometheusRuleFactory struct {
+}
+
+func NewDatastoresPrometheusRuleFactory() PrometheusRuleFactory {
+	return &DatastoresPrometheusRuleFactory{}
+}
+
+func (s *DatastoresPrometheusRuleFactory) Type() string {
+	return component.DatastoresMonitoringName
+}
+
+func (s *DatastoresPrometheusRuleFactory) PrometheusRule(_ bool, ns string) *monitoringv1.PrometheusRule {
+	options, err := datastoreExportersOptions(ns)
+	if err != nil {
+		panic(err)
+	}
+	return component.NewDatastoreExporters(options).PrometheusRules()
+}
+
+func datastoreExportersOptions(ns string) (*component.DatastoreExportersOptions, error) {
+	o := component.NewDatastoreExportersOptions()
+
+	// Required options for generating PrometheusRules
+	o.CommonLabels = map[string]string{
+		"app": appsv1alpha1.Default3scaleAppLabel,
+	}
+	o.Namespace = ns
+
+	return o, o.Validate()
+}
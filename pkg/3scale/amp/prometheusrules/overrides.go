@@ -0,0 +1,124 @@
+package prometheusrules
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+)
+
+// Trailing comparison of the alert expressions, i.e. "> 5", "== 0" or "> ( 25 / 100 )"
+var alertThresholdRegexp = regexp.MustCompile(`(==|!=|>=|<=|>|<)\s*(\(\s*[0-9.]+\s*/\s*[0-9.]+\s*\)|[0-9.]+)\s*$`)
+
+// Alerts returns the alerts generated by all the PrometheusRule factories, by name
+func Alerts() map[string]monitoringv1.Rule {
+	alerts := map[string]monitoringv1.Rule{}
+	for _, factoryBuilder := range PrometheusRuleFactories {
+		prometheusRule := factoryBuilder().PrometheusRule(false, "_")
+		for _, group := range prometheusRule.Spec.Groups {
+			for _, rule := range group.Rules {
+				if rule.Alert != "" {
+					alerts[rule.Alert] = rule
+				}
+			}
+		}
+	}
+	return alerts
+}
+
+// AlertNames returns the sorted names of the generated alerts
+func AlertNames() []string {
+	alerts := Alerts()
+	names := make([]string, 0, len(alerts))
+	for name := range alerts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateAlertOverrides rejects overrides of unknown alerts
+// and thresholds of alerts whose expression is not compared with a value
+func ValidateAlertOverrides(fldPath *field.Path, overrides []appsv1alpha1.AlertOverrideSpec) field.ErrorList {
+	fieldErrors := field.ErrorList{}
+	if len(overrides) == 0 {
+		return fieldErrors
+	}
+
+	alerts := Alerts()
+	for idx, override := range overrides {
+		alert, ok := alerts[override.Name]
+		if !ok {
+			fieldErrors = append(fieldErrors, field.NotSupported(fldPath.Index(idx).Child("name"), override.Name, AlertNames()))
+			continue
+		}
+		if override.Threshold != nil && !alertThresholdRegexp.MatchString(alert.Expr.String()) {
+			fieldErrors = append(fieldErrors, field.Invalid(fldPath.Index(idx).Child("threshold"), *override.Threshold, "the alert expression has no threshold"))
+		}
+	}
+
+	return fieldErrors
+}
+
+// ApplyAlertOverrides updates the alerts of the PrometheusRule with the overrides.
+// Disabled alerts are removed
+func ApplyAlertOverrides(prometheusRule *monitoringv1.PrometheusRule, overrides []appsv1alpha1.AlertOverrideSpec) {
+	if len(overrides) == 0 {
+		return
+	}
+
+	overridesByName := map[string]appsv1alpha1.AlertOverrideSpec{}
+	for _, override := range overrides {
+		overridesByName[override.Name] = override
+	}
+
+	for groupIdx := range prometheusRule.Spec.Groups {
+		group := &prometheusRule.Spec.Groups[groupIdx]
+		rules := make([]monitoringv1.Rule, 0, len(group.Rules))
+		for _, rule := range group.Rules {
+			override, ok := overridesByName[rule.Alert]
+			if !ok {
+				rules = append(rules, rule)
+				continue
+			}
+			if !override.IsEnabled() {
+				continue
+			}
+			rules = append(rules, applyAlertOverride(rule, override))
+		}
+		group.Rules = rules
+	}
+}
+
+func applyAlertOverride(rule monitoringv1.Rule, override appsv1alpha1.AlertOverrideSpec) monitoringv1.Rule {
+	if override.Threshold != nil {
+		expr := alertThresholdRegexp.ReplaceAllString(rule.Expr.String(), fmt.Sprintf("${1} %s", *override.Threshold))
+		rule.Expr = intstr.FromString(expr)
+	}
+
+	if override.For != nil {
+		rule.For = *override.For
+	}
+
+	labels := map[string]string{}
+	for key, value := range rule.Labels {
+		labels[key] = value
+	}
+	for key, value := range override.Labels {
+		labels[key] = value
+	}
+	if override.Severity != nil {
+		labels["severity"] = *override.Severity
+	}
+	// nil labels are kept nil, as read back from the API server, so the rule does not look changed
+	if len(labels) > 0 {
+		rule.Labels = labels
+	}
+
+	return rule
+}
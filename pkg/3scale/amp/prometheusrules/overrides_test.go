@@ -0,0 +1,87 @@
+package prometheusrules
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+)
+
+func TestApplyAlertOverrides(t *testing.T) {
+	falseValue := false
+	threshold := "10"
+	ratioThreshold := "0.5"
+	forDuration := "15m"
+	severity := "critical"
+
+	newRule := func(alert, expr string) monitoringv1.Rule {
+		return monitoringv1.Rule{
+			Alert:  alert,
+			Expr:   intstr.FromString(expr),
+			For:    "5m",
+			Labels: map[string]string{"severity": "warning"},
+		}
+	}
+	prometheusRule := &monitoringv1.PrometheusRule{
+		Spec: monitoringv1.PrometheusRuleSpec{
+			Groups: []monitoringv1.RuleGroup{
+				{
+					Name: "ns/test.rules",
+					Rules: []monitoringv1.Rule{
+						newRule("Rate", `sum(rate(status{code=~"^5.."}[1m])) * 100 > 5`),
+						newRule("Throttling", `sum(throttled) by (pod) > ( 25 / 100 )`),
+						newRule("Disabled", `up == 0`),
+						newRule("Untouched", `up == 0`),
+					},
+				},
+			},
+		},
+	}
+
+	ApplyAlertOverrides(prometheusRule, []appsv1alpha1.AlertOverrideSpec{
+		{Name: "Rate", Threshold: &threshold, For: &forDuration, Severity: &severity, Labels: map[string]string{"team": "api"}},
+		{Name: "Throttling", Threshold: &ratioThreshold},
+		{Name: "Disabled", Enabled: &falseValue},
+	})
+
+	expected := []monitoringv1.Rule{
+		{
+			Alert:  "Rate",
+			Expr:   intstr.FromString(`sum(rate(status{code=~"^5.."}[1m])) * 100 > 10`),
+			For:    "15m",
+			Labels: map[string]string{"severity": "critical", "team": "api"},
+		},
+		newRule("Throttling", `sum(throttled) by (pod) > 0.5`),
+		newRule("Untouched", `up == 0`),
+	}
+	if diff := cmp.Diff(expected, prometheusRule.Spec.Groups[0].Rules); diff != "" {
+		t.Fatalf("unexpected rules (-want +got):\n%s", diff)
+	}
+}
+
+func TestValidateAlertOverrides(t *testing.T) {
+	threshold := "10"
+	cases := []struct {
+		testName       string
+		overrides      []appsv1alpha1.AlertOverrideSpec
+		expectedErrors int
+	}{
+		{"Empty", nil, 0},
+		{"KnownAlert", []appsv1alpha1.AlertOverrideSpec{{Name: "ThreescaleApicastHttp4xxErrorRate", Threshold: &threshold}}, 0},
+		{"UnknownAlert", []appsv1alpha1.AlertOverrideSpec{{Name: "Unknown"}}, 1},
+		{"AlertWithoutThreshold", []appsv1alpha1.AlertOverrideSpec{{Name: "ThreescaleReplicationControllerReplicasMismatch", Threshold: &threshold}}, 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			fieldErrors := ValidateAlertOverrides(field.NewPath("alertOverrides"), tc.overrides)
+			if len(fieldErrors) != tc.expectedErrors {
+				subT.Fatalf("expected %d errors, got %v", tc.expectedErrors, fieldErrors)
+			}
+		})
+	}
+}
@@ -210,6 +210,23 @@ func (conditions *Conditions) RemoveCondition(t ConditionType) bool {
 	return false
 }
 
+// RemoveConditionsByType removes every condition with the given ConditionType from
+// the conditions set. It returns false if no condition with that type is found.
+func (conditions *Conditions) RemoveConditionsByType(t ConditionType) bool {
+	if conditions == nil {
+		return false
+	}
+	filtered := make(Conditions, 0, len(*conditions))
+	for _, condition := range *conditions {
+		if condition.Type != t {
+			filtered = append(filtered, condition)
+		}
+	}
+	removed := len(filtered) != len(*conditions)
+	*conditions = filtered
+	return removed
+}
+
 // RemoveConditionByWarning removes the condition with the given Condition message from
 // the conditions set. If no condition with that type is found, RemoveCondition
 // returns without performing any action. If the passed condition type is not
@@ -245,10 +262,10 @@ func (conditions *Conditions) RemoveConditionByReason(reason ConditionReason) bo
 }
 
 // MarshalJSON marshals the set of conditions as a JSON array, sorted by
-// condition type.
+// condition type. Conditions of the same type keep their order.
 func (conditions Conditions) MarshalJSON() ([]byte, error) {
 	conds := []Condition(conditions)
-	sort.Slice(conds, func(a, b int) bool {
+	sort.SliceStable(conds, func(a, b int) bool {
 		return conds[a].Type < conds[b].Type
 	})
 	return json.Marshal(conds)
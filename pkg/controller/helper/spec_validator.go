@@ -0,0 +1,70 @@
+package helper
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/3scale/3scale-operator/pkg/helper"
+)
+
+// SpecValidator runs the spec validation of a custom resource in a validating admission webhook.
+// Field errors of the validation are returned to the client as an Invalid status.
+// Updates leaving the spec unchanged, and updates of resources being deleted, are always allowed
+// so finalizers can be removed from resources that became invalid
+type SpecValidator struct {
+	GroupKind schema.GroupKind
+	// Spec returns the spec of the resource, compared on updates
+	Spec func(obj runtime.Object) interface{}
+	// Validate returns the warnings and the validation error of the resource.
+	// Errors other than helper.SpecFieldError reject the request as internal errors
+	Validate func(obj runtime.Object) (admission.Warnings, error)
+}
+
+var _ admission.CustomValidator = &SpecValidator{}
+
+func (v *SpecValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return v.validate(obj)
+}
+
+func (v *SpecValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	accessor, err := meta.Accessor(newObj)
+	if err != nil {
+		return nil, err
+	}
+
+	if accessor.GetDeletionTimestamp() != nil || reflect.DeepEqual(v.Spec(oldObj), v.Spec(newObj)) {
+		return nil, nil
+	}
+
+	return v.validate(newObj)
+}
+
+func (v *SpecValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *SpecValidator) validate(obj runtime.Object) (admission.Warnings, error) {
+	warnings, err := v.Validate(obj)
+	if err == nil {
+		return warnings, nil
+	}
+
+	var specErr *helper.SpecFieldError
+	if !errors.As(err, &specErr) {
+		return warnings, apierrors.NewInternalError(err)
+	}
+
+	accessor, accessorErr := meta.Accessor(obj)
+	if accessorErr != nil {
+		return warnings, accessorErr
+	}
+
+	return warnings, apierrors.NewInvalid(v.GroupKind, accessor.GetName(), specErr.FieldErrorList)
+}
@@ -0,0 +1,76 @@
+package helper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/3scale/3scale-operator/pkg/helper"
+)
+
+func TestSpecValidator(t *testing.T) {
+	validator := &SpecValidator{
+		GroupKind: schema.GroupKind{Group: "", Kind: "ConfigMap"},
+		Spec:      func(obj runtime.Object) interface{} { return obj.(*corev1.ConfigMap).Data },
+		Validate: func(obj runtime.Object) (admission.Warnings, error) {
+			if obj.(*corev1.ConfigMap).Data["key"] == "internal" {
+				return nil, errors.New("unexpected error")
+			}
+			if obj.(*corev1.ConfigMap).Data["key"] != "valid" {
+				return admission.Warnings{"warning"}, &helper.SpecFieldError{
+					ErrorType:      helper.InvalidError,
+					FieldErrorList: field.ErrorList{field.Invalid(field.NewPath("data").Key("key"), obj.(*corev1.ConfigMap).Data["key"], "not valid")},
+				}
+			}
+			return nil, nil
+		},
+	}
+	newConfigMap := func(value string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+			Data:       map[string]string{"key": value},
+		}
+	}
+
+	if _, err := validator.ValidateCreate(context.TODO(), newConfigMap("valid")); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	warnings, err := validator.ValidateCreate(context.TODO(), newConfigMap("invalid"))
+	if !apierrors.IsInvalid(err) || len(warnings) != 1 {
+		t.Errorf("expected an invalid error and a warning, got %v %v", err, warnings)
+	}
+
+	_, err = validator.ValidateCreate(context.TODO(), newConfigMap("internal"))
+	if !apierrors.IsInternalError(err) {
+		t.Errorf("expected an internal error, got %v", err)
+	}
+
+	// metadata updates of invalid resources are allowed
+	invalid := newConfigMap("invalid")
+	updated := invalid.DeepCopy()
+	updated.Finalizers = []string{}
+	if _, err := validator.ValidateUpdate(context.TODO(), invalid, updated); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	_, err = validator.ValidateUpdate(context.TODO(), newConfigMap("valid"), invalid)
+	if !apierrors.IsInvalid(err) {
+		t.Errorf("expected an invalid error, got %v", err)
+	}
+
+	now := metav1.Now()
+	deleted := newConfigMap("other")
+	deleted.DeletionTimestamp = &now
+	if _, err := validator.ValidateUpdate(context.TODO(), invalid, deleted); err != nil {
+		t.Errorf("updates of deleted resources should be allowed: %v", err)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"net/url"
 
 	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/metrics"
 
 	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
 )
@@ -40,17 +41,25 @@ func PortaClientFromURL(url *url.URL, token string, insecureSkipVerify bool) (*t
 		return nil, err
 	}
 
+	return threescaleapi.NewThreeScale(adminPortal, token, PortaHTTPClient(insecureSkipVerify)), nil
+}
+
+// PortaHTTPClient instantiates the http client for the 3scale APIs not covered by porta_client
+func PortaHTTPClient(insecureSkipVerify bool) *http.Client {
 	// Activated by some env var or Spec param
 	var transport http.RoundTripper = &http.Transport{
 		Proxy:           http.ProxyFromEnvironment,
 		TLSClientConfig: &tls.Config{InsecureSkipVerify: insecureSkipVerify},
 	}
 
+	// Request latency and failures are exported in the operator metrics
+	transport = &metrics.Transport{Transport: transport}
+
 	if helper.GetEnvVar(HTTP_VERBOSE_ENVVAR, "0") == "1" {
 		transport = &helper.Transport{Transport: transport}
 	}
 
-	return threescaleapi.NewThreeScale(adminPortal, token, &http.Client{Transport: transport}), nil
+	return &http.Client{Transport: transport}
 }
 
 // GetInsecureSkipVerifyAnnotation extracts the insecure_skip_verify annotation from an object
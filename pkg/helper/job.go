@@ -63,6 +63,28 @@ func HasJobCompleted(jName string, jNamespace string, client k8sclient.Client) b
 	return false
 }
 
+// HasJobFailed returns true if the Job has reached its backoff limit
+func HasJobFailed(jName string, jNamespace string, client k8sclient.Client) bool {
+	job := &batchv1.Job{}
+	err := client.Get(context.TODO(), k8sclient.ObjectKey{
+		Namespace: jNamespace,
+		Name:      jName,
+	}, job)
+
+	// Return false on error
+	if err != nil {
+		return false
+	}
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+
+	return false
+}
+
 // HasAppGenerationChanged returns true if the system-app Deployment's generation doesn't match the Job's annotation tracking it
 func HasAppGenerationChanged(jName string, dName string, namespace string, client k8sclient.Client) (bool, error) {
 	job := &batchv1.Job{}
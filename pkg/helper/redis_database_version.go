@@ -1,6 +1,8 @@
 package helper
 
 import (
+	"strings"
+
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/go-logr/logr"
 
@@ -17,91 +19,101 @@ const (
 	backendRedisStorageURL           = "REDIS_STORAGE_URL"
 )
 
-func VerifySystemRedis(k8sclient client.Client, reqConfigMap *v1.ConfigMap, systemRedisRequirement string, apimInstance *appsv1alpha1.APIManager, logger logr.Logger) (bool, error) {
+// VerifySystemRedis returns whether the system redis version satisfies the requirement
+// and, when it does not, which constraint failed
+func VerifySystemRedis(k8sclient client.Client, reqConfigMap *v1.ConfigMap, systemRedisRequirement string, apimInstance *appsv1alpha1.APIManager, logger logr.Logger) (bool, string, error) {
 	logger.Info("Verifying system redis version")
-	systemRedisVerified := false
 	connSecret, err := fetchSecret(k8sclient, "system-redis", apimInstance.Namespace)
 	if err != nil {
 		logger.Info("System redis secret not found")
-		return false, err
+		return false, "", err
 	}
 
-	systemRedisVerified, err = verifySystemRedisVersion(*connSecret, apimInstance.Namespace, systemRedisRequirement, logger)
+	systemRedisVerified, unmet, err := verifySystemRedisVersion(*connSecret, apimInstance.Namespace, systemRedisRequirement, logger)
 	if err != nil {
 		logger.Info("Encountered error during version verification of system Redis")
-		return false, err
+		return false, "", err
 	}
 	if systemRedisVerified {
 		logger.Info("System redis version verified")
 	} else {
-		logger.Info("System redis version not matching the required version")
+		logger.Info("System redis version not matching the required version", "unmet", unmet)
 	}
 
-	return systemRedisVerified, nil
+	return systemRedisVerified, unmet, nil
 }
 
-func VerifyBackendRedis(k8sclient client.Client, reqConfigMap *v1.ConfigMap, backendRedisRequirement string, apimInstance *appsv1alpha1.APIManager, logger logr.Logger) (bool, error) {
+// VerifyBackendRedis returns whether the backend redis queues and storage versions satisfy
+// the requirement and, when they do not, which constraint failed
+func VerifyBackendRedis(k8sclient client.Client, reqConfigMap *v1.ConfigMap, backendRedisRequirement string, apimInstance *appsv1alpha1.APIManager, logger logr.Logger) (bool, string, error) {
 	logger.Info("Verifying backend redis version")
-	backendRedisVerified := false
 	connSecret, err := fetchSecret(k8sclient, "backend-redis", apimInstance.Namespace)
 	if err != nil {
 		logger.Info("Backend redis secret not found")
-		return false, err
+		return false, "", err
 	}
 
-	backendRedisVerified, err = verifyBackendRedisVersion(*connSecret, apimInstance.Namespace, backendRedisRequirement, logger)
+	backendRedisVerified, unmet, err := verifyBackendRedisVersion(*connSecret, apimInstance.Namespace, backendRedisRequirement, logger)
 	if err != nil {
 		logger.Info("Encountered error during version verification of backend Redis")
-		return false, err
+		return false, "", err
 	}
 	if backendRedisVerified {
 		logger.Info("Backend redis version verified")
 	} else {
-		logger.Info("Backend redis version not matching the required version")
+		logger.Info("Backend redis version not matching the required version", "unmet", unmet)
 	}
 
-	return backendRedisVerified, nil
+	return backendRedisVerified, unmet, nil
 }
 
-func verifySystemRedisVersion(connSecret v1.Secret, namespace string, requiredVersion string, logger logr.Logger) (bool, error) {
+func verifySystemRedisVersion(connSecret v1.Secret, namespace string, requiredVersion string, logger logr.Logger) (bool, string, error) {
 	redisOpts := reconcileSystemRedisSecret(connSecret)
 
 	rdb, err := Configure(redisOpts)
 	if err != nil {
 		logger.Info("Failed to setup Redis connection")
-		return false, err
+		return false, "", err
 	}
 
 	return verifyRedisVersion(rdb, requiredVersion)
 }
 
-func verifyBackendRedisVersion(connSecret v1.Secret, namespace string, requiredVersion string, logger logr.Logger) (bool, error) {
+func verifyBackendRedisVersion(connSecret v1.Secret, namespace string, requiredVersion string, logger logr.Logger) (bool, string, error) {
 	redisQueueOpts := reconcileQueuesRedisSecret(connSecret)
 
 	qrdb, err := Configure(redisQueueOpts)
 	if err != nil {
 		logger.Info("Failed to setup Redis connection")
-		return false, err
+		return false, "", err
 	}
 
-	redisQueuesVersionConfirmed, err := verifyRedisVersion(qrdb, requiredVersion)
+	redisQueuesVersionConfirmed, queuesUnmet, err := verifyRedisVersion(qrdb, requiredVersion)
 	if err != nil {
 		logger.Info("Failed to verify Redis version")
-		return false, err
+		return false, "", err
 	}
 
 	redisStorageOpts := reconcileStorageRedisSecret(connSecret)
 	srdb, err := Configure(redisStorageOpts)
 	if err != nil {
 		logger.Info("Failed to setup Redis connection")
-		return false, err
+		return false, "", err
 	}
 
-	redisStorageVersionConfirmed, err := verifyRedisVersion(srdb, requiredVersion)
+	redisStorageVersionConfirmed, storageUnmet, err := verifyRedisVersion(srdb, requiredVersion)
 	if err != nil {
 		logger.Info("Failed to verify Redis version")
-		return false, err
+		return false, "", err
+	}
+
+	var unmet []string
+	if !redisQueuesVersionConfirmed {
+		unmet = append(unmet, "queues "+queuesUnmet)
+	}
+	if !redisStorageVersionConfirmed {
+		unmet = append(unmet, "storage "+storageUnmet)
 	}
 
-	return redisQueuesVersionConfirmed && redisStorageVersionConfirmed, nil
+	return redisQueuesVersionConfirmed && redisStorageVersionConfirmed, strings.Join(unmet, ", "), nil
 }
@@ -145,24 +145,19 @@ func sentinelOptions(cfg *RedisConfig) (*goredis.FailoverOptions, error) {
 	return opts, nil
 }
 
-func verifyRedisVersion(client *goredis.Client, requiredVersion string) (bool, error) {
+func verifyRedisVersion(client *goredis.Client, requiredVersion string) (bool, string, error) {
 	info, err := client.Info(context.Background(), "server").Result()
 
 	if err != nil {
-		return false, fmt.Errorf("failed to execute command to retrieve the Redis version - error: %w", err)
+		return false, "", fmt.Errorf("failed to execute command to retrieve the Redis version - error: %w", err)
 	}
 
 	currentRedisVersion, err := retrieveCurrentVersionOfRedis(info)
 	if err != nil {
-		return false, fmt.Errorf("failed to retrieve current version of system Redis from the cli command - error: %w", err)
+		return false, "", fmt.Errorf("failed to retrieve current version of system Redis from the cli command - error: %w", err)
 	}
 
-	redisVersionConfirmed, err := CompareVersions(requiredVersion, currentRedisVersion)
-	if err != nil {
-		return false, err
-	}
-
-	return redisVersionConfirmed, nil
+	return VerifyVersionConstraint(requiredVersion, currentRedisVersion)
 }
 
 func retrieveCurrentVersionOfRedis(stdString string) (string, error) {
@@ -3,7 +3,6 @@ package helper
 import (
 	"context"
 
-	"github.com/blang/semver/v4"
 	"github.com/go-logr/logr"
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
@@ -20,22 +19,6 @@ const (
 	RHTThreescaleBackendRedisRequirements = "rht_backend_redis_requirements"
 )
 
-func CompareVersions(required, current string) (bool, error) {
-	currentVersion, err := semver.ParseTolerant(current)
-	if err != nil {
-		return false, err
-	}
-	requiredVersion, err := semver.ParseTolerant(required)
-	if err != nil {
-		return false, err
-	}
-
-	if requiredVersion.LTE(currentVersion) {
-		return true, nil
-	}
-	return false, nil
-}
-
 func fetchSecret(k8sclient client.Client, secretName, namespace string) (*v1.Secret, error) {
 	secret := &v1.Secret{}
 
@@ -197,3 +197,12 @@ func IsSecretWatchedBy3scale(secret *v1.Secret) bool {
 
 	return false
 }
+
+func IsConfigMapWatchedBy3scale(configMap *v1.ConfigMap) bool {
+	if configMap == nil {
+		return false
+	}
+
+	_, ok := configMap.Labels["apimanager.apps.3scale.net/watched-by"]
+	return ok
+}
@@ -28,10 +28,10 @@ func reconcileSystemDBSecret(secret v1.Secret) *DatabaseConfig {
 	}
 }
 
-func verifyMySQLVersion(cfg *DatabaseConfig, requiredVersion string) (bool, error) {
+func verifyMySQLVersion(cfg *DatabaseConfig, requiredVersion string) (bool, string, error) {
 	url, err := url.Parse(cfg.URL)
 	if err != nil {
-		return false, err
+		return false, "", err
 	}
 	password, _ := url.User.Password()
 	port := url.Port()
@@ -56,7 +56,7 @@ func verifyMySQLVersion(cfg *DatabaseConfig, requiredVersion string) (bool, erro
 
 	connector, err := mysql.NewConnector(dbConfig)
 	if err != nil {
-		return false, err
+		return false, "", err
 	}
 
 	db := sql.OpenDB(connector)
@@ -64,21 +64,21 @@ func verifyMySQLVersion(cfg *DatabaseConfig, requiredVersion string) (bool, erro
 	var version string
 	err = db.QueryRow("SELECT version()").Scan(&version)
 	if err != nil {
-		return false, fmt.Errorf("failed to retrieve database version. Error %s", err)
+		return false, "", fmt.Errorf("failed to retrieve database version. Error %s", err)
 	}
 
 	databaseCurrentVersion, err := retrieveMysqlVersion(version)
 	if err != nil {
-		return false, err
+		return false, "", err
 	}
 
-	return CompareVersions(requiredVersion, databaseCurrentVersion)
+	return VerifyVersionConstraint(requiredVersion, databaseCurrentVersion)
 }
 
-func verifyPostgresVersion(cfg *DatabaseConfig, requiredVersion string) (bool, error) {
+func verifyPostgresVersion(cfg *DatabaseConfig, requiredVersion string) (bool, string, error) {
 	dbConfig, err := pgx.ParseConfig(cfg.URL)
 	if err != nil {
-		return false, err
+		return false, "", err
 	}
 
 	db := pgxstd.OpenDB(*dbConfig)
@@ -86,15 +86,15 @@ func verifyPostgresVersion(cfg *DatabaseConfig, requiredVersion string) (bool, e
 
 	err = db.QueryRow("SELECT version()").Scan(&version)
 	if err != nil {
-		return false, fmt.Errorf("failed to retrieve database version. Error %s", err)
+		return false, "", fmt.Errorf("failed to retrieve database version. Error %s", err)
 	}
 
 	databaseCurrentVersion, err := retrievePostgresVersion(version)
 	if err != nil {
-		return false, err
+		return false, "", err
 	}
 
-	return CompareVersions(requiredVersion, databaseCurrentVersion)
+	return VerifyVersionConstraint(requiredVersion, databaseCurrentVersion)
 }
 
 func retrievePostgresVersion(stdout string) (string, error) {
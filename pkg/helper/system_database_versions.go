@@ -17,13 +17,16 @@ const (
 	mysqlScheme        = "mysql2"
 )
 
-func VerifySystemDatabase(k8sclient client.Client, reqConfigMap *v1.ConfigMap, apimInstance *appsv1alpha1.APIManager, logger logr.Logger) (bool, error) {
+// VerifySystemDatabase returns whether the system database version satisfies the requirement
+// of its flavour and, when it does not, which constraint failed
+func VerifySystemDatabase(k8sclient client.Client, reqConfigMap *v1.ConfigMap, apimInstance *appsv1alpha1.APIManager, logger logr.Logger) (bool, string, error) {
 	databaseVersionVerified := false
+	unmet := ""
 	logger.Info("Verifying system database version")
 	connSecret, err := fetchSecret(k8sclient, systemDatabaseName, apimInstance.Namespace)
 	if err != nil {
 		logger.Info("System database secret not found")
-		return databaseVersionVerified, err
+		return databaseVersionVerified, unmet, err
 	}
 
 	dbConfig := reconcileSystemDBSecret(*connSecret)
@@ -32,38 +35,44 @@ func VerifySystemDatabase(k8sclient client.Client, reqConfigMap *v1.ConfigMap, a
 	if strings.HasPrefix(dbConfig.URL, "postgres://") || strings.HasPrefix(dbConfig.URL, "postgresql://") {
 		databaseRequirement = reqConfigMap.Data[RHTThreescalePostgresRequirements]
 		if databaseRequirement == "" {
-			return true, nil
+			return true, "", nil
 		}
 
-		databaseVersionVerified, err = verifyPostgresVersion(dbConfig, databaseRequirement)
+		databaseVersionVerified, unmet, err = verifyPostgresVersion(dbConfig, databaseRequirement)
 		if err != nil {
 			logger.Info("Failed to verify Postgres database version", "err", err)
-			return false, err
+			return false, "", err
+		}
+		if unmet != "" {
+			unmet = "PostgreSQL " + unmet
 		}
 	} else if strings.HasPrefix(dbConfig.URL, "mysql://") || strings.HasPrefix(dbConfig.URL, "mysql2://") {
 		databaseRequirement = reqConfigMap.Data[RHTThreescaleMysqlRequirements]
 		if databaseRequirement == "" {
-			return true, nil
+			return true, "", nil
 		}
 
-		databaseVersionVerified, err = verifyMySQLVersion(dbConfig, databaseRequirement)
+		databaseVersionVerified, unmet, err = verifyMySQLVersion(dbConfig, databaseRequirement)
 
 		if err != nil {
 			logger.Info("Failed to verify MySQL database version", "err", err)
-			return false, err
+			return false, "", err
+		}
+		if unmet != "" {
+			unmet = "MySQL " + unmet
 		}
 	} else if strings.HasPrefix(dbConfig.URL, "oracle-enhanced://") {
 		logger.Info("Oracle system database discovered, bypassing version check")
-		return true, nil
+		return true, "", nil
 	} else {
-		return false, fmt.Errorf("unsupported database")
+		return false, "", fmt.Errorf("unsupported database")
 	}
 
 	if databaseVersionVerified {
 		logger.Info("System database version verified")
 	} else {
-		logger.Info("System database version not matching the required version")
+		logger.Info("System database version not matching the required version", "unmet", unmet)
 	}
 
-	return databaseVersionVerified, nil
+	return databaseVersionVerified, unmet, nil
 }
@@ -0,0 +1,158 @@
+package helper
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blang/semver/v4"
+)
+
+// VersionConstraint is a requirement on the version of a component.
+// Comparators (>, >=, <, <=, =, != or !) separated by spaces must all be satisfied,
+// alternatives are separated by ||. For example ">=8.0 <9 !=8.0.30 || >=9.1".
+// A bare version is a minimum version, "7.0.0" is the same as ">=7.0.0".
+// Versions can omit the minor and patch numbers.
+type VersionConstraint struct {
+	expression   string
+	alternatives [][]versionComparator
+}
+
+type versionComparator struct {
+	text     string
+	operator string
+	version  semver.Version
+}
+
+var versionOperators = []string{">=", "<=", "!=", "==", ">", "<", "=", "!"}
+
+// ParseVersionConstraint parses a version constraint expression
+func ParseVersionConstraint(expression string) (*VersionConstraint, error) {
+	constraint := &VersionConstraint{expression: strings.TrimSpace(expression)}
+	if constraint.expression == "" {
+		return nil, fmt.Errorf("empty version constraint")
+	}
+
+	for _, alternative := range strings.Split(constraint.expression, "||") {
+		var comparators []versionComparator
+		tokens := strings.Fields(alternative)
+		for idx := 0; idx < len(tokens); idx++ {
+			token := tokens[idx]
+			// Allow a space between the operator and the version
+			if isVersionOperator(token) && idx+1 < len(tokens) {
+				idx++
+				token += tokens[idx]
+			}
+			comparator, err := parseVersionComparator(token)
+			if err != nil {
+				return nil, fmt.Errorf("invalid version constraint %q: %w", constraint.expression, err)
+			}
+			comparators = append(comparators, comparator)
+		}
+		if len(comparators) == 0 {
+			return nil, fmt.Errorf("invalid version constraint %q: empty alternative", constraint.expression)
+		}
+		constraint.alternatives = append(constraint.alternatives, comparators)
+	}
+
+	return constraint, nil
+}
+
+func isVersionOperator(token string) bool {
+	for _, operator := range versionOperators {
+		if token == operator {
+			return true
+		}
+	}
+	return false
+}
+
+func parseVersionComparator(token string) (versionComparator, error) {
+	operator := ">="
+	versionText := token
+	for _, candidate := range versionOperators {
+		if strings.HasPrefix(token, candidate) {
+			operator = candidate
+			versionText = strings.TrimPrefix(token, candidate)
+			break
+		}
+	}
+	switch operator {
+	case "==":
+		operator = "="
+	case "!":
+		operator = "!="
+	}
+
+	version, err := semver.ParseTolerant(versionText)
+	if err != nil {
+		return versionComparator{}, fmt.Errorf("invalid version %q: %w", versionText, err)
+	}
+
+	return versionComparator{text: token, operator: operator, version: version}, nil
+}
+
+func (c versionComparator) satisfiedBy(version semver.Version) bool {
+	switch c.operator {
+	case ">":
+		return version.GT(c.version)
+	case "<":
+		return version.LT(c.version)
+	case "<=":
+		return version.LTE(c.version)
+	case "=":
+		return version.EQ(c.version)
+	case "!=":
+		return version.NE(c.version)
+	default:
+		return version.GTE(c.version)
+	}
+}
+
+func (c *VersionConstraint) String() string {
+	return c.expression
+}
+
+// Check returns an empty string when the version satisfies the constraint.
+// Otherwise it returns a message naming the comparators the version does not satisfy
+func (c *VersionConstraint) Check(current string) (string, error) {
+	version, err := semver.ParseTolerant(current)
+	if err != nil {
+		return "", err
+	}
+
+	unmet := make([]string, 0, len(c.alternatives))
+	for _, alternative := range c.alternatives {
+		var failed []string
+		for _, comparator := range alternative {
+			if !comparator.satisfiedBy(version) {
+				failed = append(failed, comparator.text)
+			}
+		}
+		if len(failed) == 0 {
+			return "", nil
+		}
+		unmet = append(unmet, fmt.Sprintf("%q", strings.Join(failed, " ")))
+	}
+
+	if len(c.alternatives) == 1 && len(c.alternatives[0]) == 1 {
+		return fmt.Sprintf("version %s does not satisfy %q", current, c.expression), nil
+	}
+
+	return fmt.Sprintf("version %s does not satisfy %s of %q", current, strings.Join(unmet, " || "), c.expression), nil
+}
+
+// VerifyVersionConstraint checks the current version against the constraint expression.
+// It returns whether the version is verified and, when it is not, which constraint failed
+func VerifyVersionConstraint(expression, current string) (bool, string, error) {
+	constraint, err := ParseVersionConstraint(expression)
+	if err != nil {
+		return false, "", err
+	}
+
+	unmet, err := constraint.Check(current)
+	if err != nil {
+		return false, "", err
+	}
+
+	return unmet == "", unmet, nil
+}
@@ -0,0 +1,49 @@
+package helper
+
+import (
+	"testing"
+)
+
+func TestVersionConstraint(t *testing.T) {
+	cases := []struct {
+		testName       string
+		constraint     string
+		currentVersion string
+		expectedResult bool
+		expectedUnmet  string
+	}{
+		{"BareVersionIsMinimum", "7.0.0", "7.2", true, ""},
+		{"BareVersionNotMet", "7.0.0", "6.2.0", false, `version 6.2.0 does not satisfy "7.0.0"`},
+		{"RangeMet", ">=8.0 <9", "8.0.36", true, ""},
+		{"RangeUpperBound", ">=8.0 <9", "9.0.1", false, `version 9.0.1 does not satisfy "<9" of ">=8.0 <9"`},
+		{"ExcludedVersion", ">=8.0 <9 !=8.0.30", "8.0.30", false, `version 8.0.30 does not satisfy "!=8.0.30" of ">=8.0 <9 !=8.0.30"`},
+		{"ExclamationExclusion", ">= 13 !13.2", "13.2.0", false, `version 13.2.0 does not satisfy "!13.2" of ">= 13 !13.2"`},
+		{"AlternativeMet", ">=8.0 <8.1 || >=9.1", "9.1.0", true, ""},
+		{"AlternativesNotMet", ">=8.0 <8.1 || >=9.1", "8.4.0", false, `version 8.4.0 does not satisfy "<8.1" || ">=9.1" of ">=8.0 <8.1 || >=9.1"`},
+		{"Equal", "=7.2.4", "7.2.4", true, ""},
+		{"GreaterThan", ">7.2.4", "7.2.4", false, `version 7.2.4 does not satisfy ">7.2.4"`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			value, unmet, err := VerifyVersionConstraint(tc.constraint, tc.currentVersion)
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if value != tc.expectedResult {
+				subT.Fatalf("test failed for test case %s, expected %v but got %v", tc.testName, tc.expectedResult, value)
+			}
+			if unmet != tc.expectedUnmet {
+				subT.Fatalf("test failed for test case %s, expected message %q but got %q", tc.testName, tc.expectedUnmet, unmet)
+			}
+		})
+	}
+}
+
+func TestParseVersionConstraintInvalid(t *testing.T) {
+	for _, constraint := range []string{"", ">=8.0 ||", ">=abc", "~>8.0"} {
+		if _, err := ParseVersionConstraint(constraint); err == nil {
+			t.Fatalf("expected error parsing %q", constraint)
+		}
+	}
+}
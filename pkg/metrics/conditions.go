@@ -0,0 +1,120 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const conditionsListTimeout = 10 * time.Second
+
+var resourceConditionsDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(metricsNamespace, "", "resource_conditions"),
+	"Number of custom resources per kind, status condition type and condition status",
+	[]string{"kind", "type", "status"}, nil,
+)
+
+// ConditionsCollector implements prometheus.Collector.
+// On every scrape, it counts the custom resources of the watched kinds by status condition.
+type ConditionsCollector struct {
+	reader client.Reader
+	// kind name -> list object
+	lists  map[string]client.ObjectList
+	logger logr.Logger
+}
+
+var _ prometheus.Collector = &ConditionsCollector{}
+
+func NewConditionsCollector(reader client.Reader, lists map[string]client.ObjectList, logger logr.Logger) *ConditionsCollector {
+	return &ConditionsCollector{
+		reader: reader,
+		lists:  lists,
+		logger: logger,
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *ConditionsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- resourceConditionsDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *ConditionsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), conditionsListTimeout)
+	defer cancel()
+
+	for kind, list := range c.lists {
+		counts, err := c.countConditions(ctx, list.DeepCopyObject().(client.ObjectList))
+		if err != nil {
+			c.logger.Error(err, "failed to list resources for metrics", "kind", kind)
+			continue
+		}
+
+		for key, count := range counts {
+			ch <- prometheus.MustNewConstMetric(resourceConditionsDesc, prometheus.GaugeValue, float64(count), kind, key.conditionType, key.status)
+		}
+	}
+}
+
+type conditionKey struct {
+	conditionType, status string
+}
+
+func (c *ConditionsCollector) countConditions(ctx context.Context, list client.ObjectList) (map[conditionKey]int, error) {
+	if err := c.reader.List(ctx, list); err != nil {
+		return nil, err
+	}
+
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := map[conditionKey]int{}
+	for _, item := range items {
+		conditions, err := statusConditions(item)
+		if err != nil {
+			return nil, err
+		}
+		for _, condition := range conditions {
+			counts[condition]++
+		}
+	}
+
+	return counts, nil
+}
+
+// statusConditions reads the status.conditions field of any custom resource kind
+func statusConditions(obj runtime.Object) ([]conditionKey, error) {
+	unstructuredObj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions, _, err := unstructured.NestedSlice(unstructuredObj, "status", "conditions")
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]conditionKey, 0, len(conditions))
+	for _, condition := range conditions {
+		conditionMap, ok := condition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		conditionType, _, _ := unstructured.NestedString(conditionMap, "type")
+		status, _, _ := unstructured.NestedString(conditionMap, "status")
+		if conditionType == "" {
+			continue
+		}
+		result = append(result, conditionKey{conditionType: conditionType, status: status})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/apispkg/common"
+)
+
+func TestConditionsCollector(t *testing.T) {
+	product := func(name string, conditions ...common.Condition) *capabilitiesv1beta1.Product {
+		return &capabilitiesv1beta1.Product{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+			Status:     capabilitiesv1beta1.ProductStatus{Conditions: conditions},
+		}
+	}
+	synced := common.Condition{Type: capabilitiesv1beta1.ProductSyncedConditionType, Status: v1.ConditionTrue}
+	notSynced := common.Condition{Type: capabilitiesv1beta1.ProductSyncedConditionType, Status: v1.ConditionFalse}
+	invalid := common.Condition{Type: capabilitiesv1beta1.ProductInvalidConditionType, Status: v1.ConditionTrue}
+
+	s := runtime.NewScheme()
+	if err := capabilitiesv1beta1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(
+		product("a", synced),
+		product("b", synced),
+		product("c", notSynced, invalid),
+		product("d"),
+	).Build()
+
+	collector := NewConditionsCollector(cl, map[string]client.ObjectList{
+		"Product": &capabilitiesv1beta1.ProductList{},
+	}, logr.Discard())
+
+	expected := `
+# HELP threescale_operator_resource_conditions Number of custom resources per kind, status condition type and condition status
+# TYPE threescale_operator_resource_conditions gauge
+threescale_operator_resource_conditions{kind="Product",status="False",type="Synced"} 1
+threescale_operator_resource_conditions{kind="Product",status="True",type="Invalid"} 1
+threescale_operator_resource_conditions{kind="Product",status="True",type="Synced"} 2
+`
+	if err := testutil.CollectAndCompare(collector, strings.NewReader(expected)); err != nil {
+		t.Fatal(err)
+	}
+}
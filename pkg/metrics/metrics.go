@@ -0,0 +1,157 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const metricsNamespace = "threescale_operator"
+
+var (
+	// ReconcileTotal counts the reconciliations of every custom resource kind by result and reason
+	ReconcileTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "reconcile_total",
+			Help:      "Number of reconciliations per custom resource kind, result and reason",
+		},
+		[]string{"kind", "result", "reason"},
+	)
+
+	// LastSuccessfulSync is the unix time of the last reconciliation of a custom resource completed without error
+	LastSuccessfulSync = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "last_successful_sync_timestamp_seconds",
+			Help:      "Unix time of the last reconciliation of the custom resource completed without error",
+		},
+		[]string{"kind", "namespace", "name"},
+	)
+
+	// PortaRequestDuration observes the latency of the 3scale account management API requests
+	PortaRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "porta_request_duration_seconds",
+			Help:      "Latency of the 3scale account management API requests per provider account host, endpoint, method and status code",
+			Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+		},
+		[]string{"host", "endpoint", "method", "code"},
+	)
+
+	// PortaRequestErrors counts the 3scale account management API requests failed at transport level
+	// or answered with a 4xx/5xx status code
+	PortaRequestErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "porta_request_errors_total",
+			Help:      "Number of failed 3scale account management API requests per provider account host, endpoint and method",
+		},
+		[]string{"host", "endpoint", "method"},
+	)
+
+	// CertificateExpiry is the unix time the TLS certificates referenced by the APIManagers expire.
+	// The APIManager namespace is not exposed as namespace, overwritten with the operator namespace when scraped
+	CertificateExpiry = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "certificate_expiry_timestamp_seconds",
+			Help:      "Unix time the TLS certificates referenced by the APIManager expire",
+		},
+		[]string{"apimanager_namespace", "apimanager", "certificate"},
+	)
+
+	// CertificateExpiryWarningDays is the expiry warning window of the APIManagers with certificates,
+	// so a single alert rule next to the operator compares each certificate with its APIManager window
+	CertificateExpiryWarningDays = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "certificate_expiry_warning_days",
+			Help:      "Days before the expiry of a certificate the APIManager warns about it",
+		},
+		[]string{"apimanager_namespace", "apimanager"},
+	)
+
+	// SmokeTestRuns counts the completed SmokeTest runs by result, passed or failed
+	SmokeTestRuns = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "smoketest_runs_total",
+			Help:      "Completed SmokeTest runs by result",
+		},
+		[]string{"namespace", "name", "result"},
+	)
+
+	// SmokeTestRequestDuration is the latency of the requests sent by the SmokeTest runs
+	SmokeTestRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "smoketest_request_duration_seconds",
+			Help:      "Latency of the requests sent through APIcast by the SmokeTest runs",
+			Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+		},
+		[]string{"namespace", "name", "environment"},
+	)
+
+	// SmokeTestLastRunPassed is 1 when the last SmokeTest run passed, 0 otherwise
+	SmokeTestLastRunPassed = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "smoketest_last_run_passed",
+			Help:      "Whether the last SmokeTest run passed",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	// SmokeTestLastRunTimestamp is the unix time the last SmokeTest run started
+	SmokeTestLastRunTimestamp = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "smoketest_last_run_timestamp_seconds",
+			Help:      "Unix time the last SmokeTest run started",
+		},
+		[]string{"namespace", "name"},
+	)
+)
+
+// DeleteSmokeTestMetrics removes the series of a deleted SmokeTest
+func DeleteSmokeTestMetrics(namespace, name string) {
+	labels := prometheus.Labels{"namespace": namespace, "name": name}
+	SmokeTestRuns.DeletePartialMatch(labels)
+	SmokeTestRequestDuration.DeletePartialMatch(labels)
+	SmokeTestLastRunPassed.DeletePartialMatch(labels)
+	SmokeTestLastRunTimestamp.DeletePartialMatch(labels)
+}
+
+// SetCertificateExpiry replaces the certificate expiry series and the warning days of the APIManager.
+// An empty expiry map removes them
+func SetCertificateExpiry(namespace, name string, warningDays int32, expiry map[string]time.Time) {
+	labels := prometheus.Labels{"apimanager_namespace": namespace, "apimanager": name}
+	CertificateExpiry.DeletePartialMatch(labels)
+	if len(expiry) == 0 {
+		CertificateExpiryWarningDays.Delete(labels)
+		return
+	}
+
+	for certificate, notAfter := range expiry {
+		CertificateExpiry.WithLabelValues(namespace, name, certificate).Set(float64(notAfter.Unix()))
+	}
+	CertificateExpiryWarningDays.With(labels).Set(float64(warningDays))
+}
+
+// Collectors returns the operator metrics, to be registered in the controller-runtime registry
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		ReconcileTotal,
+		LastSuccessfulSync,
+		PortaRequestDuration,
+		PortaRequestErrors,
+		CertificateExpiry,
+		CertificateExpiryWarningDays,
+		SmokeTestRuns,
+		SmokeTestRequestDuration,
+		SmokeTestLastRunPassed,
+		SmokeTestLastRunTimestamp,
+	}
+}
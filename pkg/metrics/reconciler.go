@@ -0,0 +1,91 @@
+package metrics
+
+import (
+	"context"
+	goerrors "errors"
+	"time"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	ReconcileResultSuccess = "success"
+	ReconcileResultRequeue = "requeue"
+	ReconcileResultError   = "error"
+)
+
+// InstrumentedReconciler records the outcome of every reconciliation of the wrapped reconciler
+type InstrumentedReconciler struct {
+	kind       string
+	prototype  client.Object
+	reader     client.Reader
+	reconciler reconcile.Reconciler
+}
+
+var _ reconcile.Reconciler = &InstrumentedReconciler{}
+
+// NewInstrumentedReconciler wraps the reconciler of the kind custom resource.
+// The prototype is an empty object of the kind, used to lookup the reconciled resources.
+func NewInstrumentedReconciler(kind string, prototype client.Object, reader client.Reader, reconciler reconcile.Reconciler) *InstrumentedReconciler {
+	return &InstrumentedReconciler{
+		kind:       kind,
+		prototype:  prototype,
+		reader:     reader,
+		reconciler: reconciler,
+	}
+}
+
+// Reconcile implements reconcile.Reconciler
+func (r *InstrumentedReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	result, err := r.reconciler.Reconcile(ctx, req)
+
+	switch {
+	case err != nil:
+		ReconcileTotal.WithLabelValues(r.kind, ReconcileResultError, ErrorReason(err)).Inc()
+	case result.Requeue || result.RequeueAfter > 0:
+		ReconcileTotal.WithLabelValues(r.kind, ReconcileResultRequeue, "").Inc()
+	default:
+		ReconcileTotal.WithLabelValues(r.kind, ReconcileResultSuccess, "").Inc()
+		r.observeSync(ctx, req)
+	}
+
+	return result, err
+}
+
+// observeSync updates the last successful sync of the resource. The series is removed once the resource is gone
+func (r *InstrumentedReconciler) observeSync(ctx context.Context, req reconcile.Request) {
+	obj := r.prototype.DeepCopyObject().(client.Object)
+	err := r.reader.Get(ctx, req.NamespacedName, obj)
+	if errors.IsNotFound(err) || (err == nil && obj.GetDeletionTimestamp() != nil) {
+		LastSuccessfulSync.DeleteLabelValues(r.kind, req.Namespace, req.Name)
+		return
+	}
+
+	if err == nil {
+		LastSuccessfulSync.WithLabelValues(r.kind, req.Namespace, req.Name).Set(float64(time.Now().Unix()))
+	}
+}
+
+// ErrorReason classifies the reconciliation errors with a bounded set of values
+func ErrorReason(err error) string {
+	switch {
+	case errors.IsConflict(err):
+		return "conflict"
+	case errors.IsNotFound(err):
+		return "not_found"
+	case errors.IsForbidden(err) || errors.IsUnauthorized(err):
+		return "forbidden"
+	case errors.ReasonForError(err) != "":
+		return "kubernetes_api"
+	}
+
+	var apiErr threescaleapi.ApiErr
+	if goerrors.As(err, &apiErr) {
+		return "threescale_api"
+	}
+
+	return "other"
+}
@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type reconcilerFunc func(context.Context, reconcile.Request) (reconcile.Result, error)
+
+func (f reconcilerFunc) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	return f(ctx, req)
+}
+
+func TestErrorReason(t *testing.T) {
+	gr := schema.GroupResource{Group: "capabilities.3scale.net", Resource: "products"}
+	cases := []struct {
+		testName string
+		err      error
+		expected string
+	}{
+		{"Conflict", k8serrors.NewConflict(gr, "product", errors.New("conflict")), "conflict"},
+		{"NotFound", k8serrors.NewNotFound(gr, "product"), "not_found"},
+		{"Forbidden", k8serrors.NewForbidden(gr, "product", errors.New("forbidden")), "forbidden"},
+		{"KubernetesAPI", k8serrors.NewInternalError(errors.New("internal")), "kubernetes_api"},
+		{"ThreescaleAPI", fmt.Errorf("sync failed: %w", threescaleapi.ApiErr{}), "threescale_api"},
+		{"Other", errors.New("other"), "other"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			if got := ErrorReason(tc.err); got != tc.expected {
+				subT.Errorf("ErrorReason() = %q, want %q", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestInstrumentedReconciler(t *testing.T) {
+	const kind = "TestKind"
+	configMap := &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"}}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "cm", Namespace: "ns"}}
+	cl := fake.NewFakeClient(configMap)
+
+	var result reconcile.Result
+	var err error
+	reconciler := NewInstrumentedReconciler(kind, &v1.ConfigMap{}, cl, reconcilerFunc(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+		return result, err
+	}))
+
+	// Success
+	if _, e := reconciler.Reconcile(context.TODO(), req); e != nil {
+		t.Fatal(e)
+	}
+	if value := testutil.ToFloat64(ReconcileTotal.WithLabelValues(kind, ReconcileResultSuccess, "")); value != 1 {
+		t.Fatalf("expected 1 success, got %f", value)
+	}
+	if value := testutil.ToFloat64(LastSuccessfulSync.WithLabelValues(kind, "ns", "cm")); value == 0 {
+		t.Fatal("expected the last successful sync to be set")
+	}
+
+	// Requeue
+	result = reconcile.Result{Requeue: true}
+	if _, e := reconciler.Reconcile(context.TODO(), req); e != nil {
+		t.Fatal(e)
+	}
+	if value := testutil.ToFloat64(ReconcileTotal.WithLabelValues(kind, ReconcileResultRequeue, "")); value != 1 {
+		t.Fatalf("expected 1 requeue, got %f", value)
+	}
+
+	// Error
+	result = reconcile.Result{}
+	err = errors.New("failure")
+	if _, e := reconciler.Reconcile(context.TODO(), req); e == nil {
+		t.Fatal("expected the error to be returned")
+	}
+	if value := testutil.ToFloat64(ReconcileTotal.WithLabelValues(kind, ReconcileResultError, "other")); value != 1 {
+		t.Fatalf("expected 1 error, got %f", value)
+	}
+
+	// Deleted resources are removed from the last successful sync metric
+	err = nil
+	if e := cl.Delete(context.TODO(), configMap); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := reconciler.Reconcile(context.TODO(), req); e != nil {
+		t.Fatal(e)
+	}
+	if count := testutil.CollectAndCount(LastSuccessfulSync); count != 0 {
+		t.Fatalf("expected the last successful sync series to be removed, got %d series", count)
+	}
+}
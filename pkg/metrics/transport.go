@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Numeric path segments, optionally followed by the format extension, i.e. "42" or "42.json"
+var idPathSegmentRegexp = regexp.MustCompile(`^[0-9]+(\.[a-z]+)?$`)
+
+// Transport implements http.RoundTripper. When set as Transport of http.Client,
+// it records the latency and failures of the 3scale account management API requests.
+// No field is mandatory.
+type Transport struct {
+	Transport http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.transport().RoundTrip(req)
+
+	host := req.URL.Hostname()
+	endpoint := Endpoint(req.URL.Path)
+	if err != nil {
+		PortaRequestErrors.WithLabelValues(host, endpoint, req.Method).Inc()
+		return resp, err
+	}
+
+	PortaRequestDuration.WithLabelValues(host, endpoint, req.Method, strconv.Itoa(resp.StatusCode)).Observe(time.Since(start).Seconds())
+	if resp.StatusCode >= http.StatusBadRequest {
+		PortaRequestErrors.WithLabelValues(host, endpoint, req.Method).Inc()
+	}
+
+	return resp, nil
+}
+
+func (t *Transport) transport() http.RoundTripper {
+	if t.Transport != nil {
+		return t.Transport
+	}
+
+	return http.DefaultTransport
+}
+
+// Endpoint replaces the object ids of the request path with ":id",
+// keeping the cardinality of the endpoint label bounded.
+// For instance, "/admin/api/services/12/metrics/3.json" becomes "/admin/api/services/:id/metrics/:id.json"
+func Endpoint(path string) string {
+	segments := strings.Split(path, "/")
+	for idx, segment := range segments {
+		if match := idPathSegmentRegexp.FindStringSubmatch(segment); match != nil {
+			segments[idx] = ":id" + match[1]
+		}
+	}
+	return strings.Join(segments, "/")
+}
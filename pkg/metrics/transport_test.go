@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestEndpoint(t *testing.T) {
+	cases := []struct {
+		path     string
+		expected string
+	}{
+		{"/admin/api/services.json", "/admin/api/services.json"},
+		{"/admin/api/services/12.json", "/admin/api/services/:id.json"},
+		{"/admin/api/services/12/metrics/3/methods.json", "/admin/api/services/:id/metrics/:id/methods.json"},
+		{"/admin/api/backend_apis/5/mapping_rules/77", "/admin/api/backend_apis/:id/mapping_rules/:id"},
+		{"/admin/api/accounts/find.json", "/admin/api/accounts/find.json"},
+		{"/admin/api/v2/policies.json", "/admin/api/v2/policies.json"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.path, func(subT *testing.T) {
+			if got := Endpoint(tc.path); got != tc.expected {
+				subT.Errorf("Endpoint(%q) = %q, want %q", tc.path, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/admin/api/services/1.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	host := serverURL.Hostname()
+
+	client := &http.Client{Transport: &Transport{}}
+	for _, path := range []string{"/admin/api/services.json", "/admin/api/services/1.json"} {
+		resp, err := client.Get(server.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	if count := testutil.CollectAndCount(PortaRequestDuration); count != 2 {
+		t.Fatalf("expected 2 latency series, got %d", count)
+	}
+	if value := testutil.ToFloat64(PortaRequestErrors.WithLabelValues(host, "/admin/api/services/:id.json", http.MethodGet)); value != 1 {
+		t.Fatalf("expected 1 error, got %f", value)
+	}
+	if value := testutil.ToFloat64(PortaRequestErrors.WithLabelValues(host, "/admin/api/services.json", http.MethodGet)); value != 0 {
+		t.Fatalf("expected no errors, got %f", value)
+	}
+
+	// Connection failures are counted as errors
+	server.Close()
+	if _, err := client.Get(server.URL + "/admin/api/services.json"); err == nil {
+		t.Fatal("expected the request to fail")
+	}
+	if value := testutil.ToFloat64(PortaRequestErrors.WithLabelValues(host, "/admin/api/services.json", http.MethodGet)); value != 1 {
+		t.Fatalf("expected 1 error, got %f", value)
+	}
+}
@@ -0,0 +1,96 @@
+package preflights
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+)
+
+const (
+	// SkipChecksAnnotation holds a comma separated list of check names not to run
+	SkipChecksAnnotation = "apps.3scale.net/skip-preflight-checks"
+)
+
+// Check verifies the environment is able to run the APIManager
+type Check interface {
+	// Name identifies the check in the SkipChecksAnnotation
+	Name() string
+	// Reason of the Preflights condition when the check fails
+	Reason() string
+	// Run returns an error describing why the check failed
+	Run(ctx context.Context, cl client.Client, apimanager *appsv1alpha1.APIManager) error
+}
+
+// DefaultChecks returns the checks run on every APIManager. The reconciliation
+// does not proceed while any of them fails
+func DefaultChecks() []Check {
+	return []Check{
+		&StorageClassCheck{},
+		&ResourceQuotaCheck{},
+		&S3BucketCheck{},
+	}
+}
+
+// WarningChecks returns the checks only reported as warnings when they fail.
+// Their result depends on how the operator pod sees the network, e.g. with
+// split-horizon DNS or a load balancer in front of the router
+func WarningChecks() []Check {
+	return []Check{
+		&WildcardDomainCheck{},
+	}
+}
+
+// CheckFailure is a failed check
+type CheckFailure struct {
+	Name   string
+	Reason string
+	Err    error
+}
+
+// Error is returned by Run when any check fails
+type Error struct {
+	Failures []CheckFailure
+}
+
+func (e *Error) Error() string {
+	messages := make([]string, 0, len(e.Failures))
+	for _, failure := range e.Failures {
+		messages = append(messages, fmt.Sprintf("%s: %s", failure.Reason, failure.Err))
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Run runs the checks not skipped by the SkipChecksAnnotation against a copy
+// of the APIManager with its defaults set. It returns an *Error when any check fails
+func Run(ctx context.Context, cl client.Client, apimanager *appsv1alpha1.APIManager, checks []Check) error {
+	apimanager = apimanager.DeepCopy()
+	_, err := apimanager.SetDefaults()
+	if err != nil {
+		return err
+	}
+
+	skipped := map[string]bool{}
+	for _, name := range strings.Split(apimanager.GetAnnotations()[SkipChecksAnnotation], ",") {
+		skipped[strings.TrimSpace(name)] = true
+	}
+
+	var failures []CheckFailure
+	for _, check := range checks {
+		if skipped[check.Name()] {
+			continue
+		}
+		if err := check.Run(ctx, cl, apimanager); err != nil {
+			failures = append(failures, CheckFailure{Name: check.Name(), Reason: check.Reason(), Err: err})
+		}
+	}
+
+	if len(failures) > 0 {
+		return &Error{Failures: failures}
+	}
+
+	return nil
+}
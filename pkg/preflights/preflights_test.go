@@ -0,0 +1,89 @@
+package preflights
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	routev1 "github.com/openshift/api/route/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+)
+
+const (
+	namespace      = "someNS"
+	wildcardDomain = "example.com"
+)
+
+func testAPIManager() *appsv1alpha1.APIManager {
+	apimanager := &appsv1alpha1.APIManager{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-apimanager", Namespace: namespace},
+		Spec: appsv1alpha1.APIManagerSpec{
+			APIManagerCommonSpec: appsv1alpha1.APIManagerCommonSpec{
+				WildcardDomain: wildcardDomain,
+			},
+		},
+	}
+	_, _ = apimanager.SetDefaults()
+	return apimanager
+}
+
+func testClient(t *testing.T, objs ...runtime.Object) client.Client {
+	s := runtime.NewScheme()
+	for _, addToScheme := range []func(*runtime.Scheme) error{clientgoscheme.AddToScheme, appsv1alpha1.AddToScheme, routev1.Install} {
+		if err := addToScheme(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objs...).Build()
+}
+
+type fakeCheck struct {
+	name string
+	err  error
+	runs int
+}
+
+func (c *fakeCheck) Name() string { return c.name }
+
+func (c *fakeCheck) Reason() string { return c.name + "Failed" }
+
+func (c *fakeCheck) Run(ctx context.Context, cl client.Client, apimanager *appsv1alpha1.APIManager) error {
+	c.runs++
+	return c.err
+}
+
+func TestRun(t *testing.T) {
+	passing := &fakeCheck{name: "passing"}
+	failing := &fakeCheck{name: "failing", err: errors.New("not available")}
+	skipped := &fakeCheck{name: "skipped", err: errors.New("not available")}
+
+	apimanager := testAPIManager()
+	apimanager.Annotations = map[string]string{SkipChecksAnnotation: "other, skipped"}
+
+	err := Run(context.TODO(), testClient(t), apimanager, []Check{passing, skipped, failing})
+
+	var checksErr *Error
+	if !errors.As(err, &checksErr) {
+		t.Fatalf("expected *Error, got %v", err)
+	}
+	if len(checksErr.Failures) != 1 || checksErr.Failures[0].Reason != "failingFailed" {
+		t.Fatalf("unexpected failures %v", checksErr.Failures)
+	}
+	if checksErr.Error() != "failingFailed: not available" {
+		t.Fatalf("unexpected error message %q", checksErr.Error())
+	}
+	if passing.runs != 1 || failing.runs != 1 || skipped.runs != 0 {
+		t.Fatalf("unexpected runs passing %d failing %d skipped %d", passing.runs, failing.runs, skipped.runs)
+	}
+
+	err = Run(context.TODO(), testClient(t), apimanager, []Check{passing})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
@@ -0,0 +1,273 @@
+package preflights
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	k8sappsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/operator"
+)
+
+// ResourceQuotaCheck verifies the compute resources requested by the APIManager
+// fit the ResourceQuotas and LimitRanges of the namespace
+type ResourceQuotaCheck struct{}
+
+func (c *ResourceQuotaCheck) Name() string { return "resource-quota" }
+
+func (c *ResourceQuotaCheck) Reason() string { return "ResourceQuotaExceeded" }
+
+func (c *ResourceQuotaCheck) Run(ctx context.Context, cl client.Client, apimanager *appsv1alpha1.APIManager) error {
+	limitRangeList := &v1.LimitRangeList{}
+	err := cl.List(ctx, limitRangeList, client.InNamespace(apimanager.Namespace))
+	if err != nil {
+		return fmt.Errorf("failed to list limit ranges: %w", err)
+	}
+
+	resourceQuotaList := &v1.ResourceQuotaList{}
+	err = cl.List(ctx, resourceQuotaList, client.InNamespace(apimanager.Namespace))
+	if err != nil {
+		return fmt.Errorf("failed to list resource quotas: %w", err)
+	}
+
+	if len(limitRangeList.Items) == 0 && len(resourceQuotaList.Items) == 0 {
+		return nil
+	}
+
+	deploymentList := &k8sappsv1.DeploymentList{}
+	err = cl.List(ctx, deploymentList, client.InNamespace(apimanager.Namespace))
+	if err != nil {
+		return fmt.Errorf("failed to list deployments: %w", err)
+	}
+
+	var messages []string
+	// Resources requested on top of the ones already requested by the existing Deployments
+	additional := v1.ResourceList{}
+	for _, deployment := range operator.RequestedResources(apimanager, cl) {
+		containers := applyLimitRangeDefaults(deployment.Containers, limitRangeList.Items)
+		messages = append(messages, limitRangeViolations(deployment.Name, containers, limitRangeList.Items)...)
+		messages = append(messages, missingQuotaResources(deployment.Name, containers, resourceQuotaList.Items)...)
+
+		required := scaleResourceList(quotaResources(containers), deployment.Replicas)
+		for idx := range deploymentList.Items {
+			if deploymentList.Items[idx].Name != deployment.Name {
+				continue
+			}
+			existing := &deploymentList.Items[idx]
+			replicas := int32(1)
+			if existing.Spec.Replicas != nil {
+				replicas = *existing.Spec.Replicas
+			}
+			var existingContainers []v1.ResourceRequirements
+			for _, container := range existing.Spec.Template.Spec.Containers {
+				existingContainers = append(existingContainers, container.Resources)
+			}
+			existingResources := scaleResourceList(quotaResources(existingContainers), replicas)
+			for name, quantity := range existingResources {
+				value := required[name]
+				value.Sub(quantity)
+				required[name] = value
+			}
+		}
+
+		for name, quantity := range required {
+			if quantity.Sign() <= 0 {
+				continue
+			}
+			value := additional[name]
+			value.Add(quantity)
+			additional[name] = value
+		}
+	}
+
+	for _, quota := range resourceQuotaList.Items {
+		if len(quota.Spec.Scopes) > 0 || quota.Spec.ScopeSelector != nil {
+			// Only part of the pods count against scoped quotas
+			continue
+		}
+		for name, hard := range quota.Spec.Hard {
+			requested, ok := additional[name]
+			if !ok {
+				continue
+			}
+			total := quota.Status.Used[name]
+			total.Add(requested)
+			if total.Cmp(hard) > 0 {
+				used := quota.Status.Used[name]
+				messages = append(messages, fmt.Sprintf("resource quota %s: %s requested %s, used %s, hard %s",
+					quota.Name, name, requested.String(), used.String(), hard.String()))
+			}
+		}
+	}
+
+	if len(messages) > 0 {
+		return fmt.Errorf("%s", strings.Join(messages, ", "))
+	}
+
+	return nil
+}
+
+// applyLimitRangeDefaults sets the default requests and limits of the Container LimitRanges
+// to the containers missing them, as the LimitRanger admission plugin does
+func applyLimitRangeDefaults(containers []v1.ResourceRequirements, limitRanges []v1.LimitRange) []v1.ResourceRequirements {
+	result := make([]v1.ResourceRequirements, 0, len(containers))
+	for _, container := range containers {
+		container = *container.DeepCopy()
+		for _, limitRange := range limitRanges {
+			for _, item := range limitRange.Spec.Limits {
+				if item.Type != v1.LimitTypeContainer {
+					continue
+				}
+				for name, quantity := range item.Default {
+					if _, ok := container.Limits[name]; !ok {
+						if container.Limits == nil {
+							container.Limits = v1.ResourceList{}
+						}
+						container.Limits[name] = quantity.DeepCopy()
+					}
+				}
+				for name, quantity := range item.DefaultRequest {
+					if _, ok := container.Requests[name]; !ok {
+						if container.Requests == nil {
+							container.Requests = v1.ResourceList{}
+						}
+						container.Requests[name] = quantity.DeepCopy()
+					}
+				}
+			}
+		}
+		// Requests default to the limits when not set
+		for name, quantity := range container.Limits {
+			if _, ok := container.Requests[name]; !ok {
+				if container.Requests == nil {
+					container.Requests = v1.ResourceList{}
+				}
+				container.Requests[name] = quantity.DeepCopy()
+			}
+		}
+		result = append(result, container)
+	}
+	return result
+}
+
+func limitRangeViolations(deploymentName string, containers []v1.ResourceRequirements, limitRanges []v1.LimitRange) []string {
+	var messages []string
+	pod := v1.ResourceRequirements{Limits: v1.ResourceList{}, Requests: v1.ResourceList{}}
+	for _, container := range containers {
+		addResourceList(pod.Limits, container.Limits)
+		addResourceList(pod.Requests, container.Requests)
+	}
+
+	for _, limitRange := range limitRanges {
+		for _, item := range limitRange.Spec.Limits {
+			var checked []v1.ResourceRequirements
+			switch item.Type {
+			case v1.LimitTypeContainer:
+				checked = containers
+			case v1.LimitTypePod:
+				checked = []v1.ResourceRequirements{pod}
+			default:
+				continue
+			}
+			for _, requirements := range checked {
+				for name, max := range item.Max {
+					limit, ok := requirements.Limits[name]
+					if !ok || limit.Cmp(max) > 0 {
+						messages = append(messages, fmt.Sprintf("limit range %s: %s %s limit exceeds max %s", limitRange.Name, deploymentName, name, max.String()))
+					}
+				}
+				for name, min := range item.Min {
+					request, ok := requirements.Requests[name]
+					if !ok || request.Cmp(min) < 0 {
+						messages = append(messages, fmt.Sprintf("limit range %s: %s %s request below min %s", limitRange.Name, deploymentName, name, min.String()))
+					}
+				}
+			}
+		}
+	}
+
+	return messages
+}
+
+// missingQuotaResources reports the compute resources tracked by the quotas
+// the containers do not set. Pods missing them are rejected
+func missingQuotaResources(deploymentName string, containers []v1.ResourceRequirements, quotas []v1.ResourceQuota) []string {
+	var messages []string
+	for _, quota := range quotas {
+		if len(quota.Spec.Scopes) > 0 || quota.Spec.ScopeSelector != nil {
+			continue
+		}
+		for quotaName := range quota.Spec.Hard {
+			var missing bool
+			for _, container := range containers {
+				switch quotaName {
+				case v1.ResourceRequestsCPU, v1.ResourceCPU:
+					_, ok := container.Requests[v1.ResourceCPU]
+					missing = missing || !ok
+				case v1.ResourceRequestsMemory, v1.ResourceMemory:
+					_, ok := container.Requests[v1.ResourceMemory]
+					missing = missing || !ok
+				case v1.ResourceLimitsCPU:
+					_, ok := container.Limits[v1.ResourceCPU]
+					missing = missing || !ok
+				case v1.ResourceLimitsMemory:
+					_, ok := container.Limits[v1.ResourceMemory]
+					missing = missing || !ok
+				}
+			}
+			if missing {
+				messages = append(messages, fmt.Sprintf("resource quota %s: %s does not set %s", quota.Name, deploymentName, quotaName))
+			}
+		}
+	}
+	return messages
+}
+
+// quotaResources returns the resources a pod with the given containers counts against quotas
+func quotaResources(containers []v1.ResourceRequirements) v1.ResourceList {
+	result := v1.ResourceList{v1.ResourcePods: resource.MustParse("1")}
+	for _, container := range containers {
+		for name, quantity := range container.Requests {
+			switch name {
+			case v1.ResourceCPU:
+				addResourceList(result, v1.ResourceList{v1.ResourceCPU: quantity, v1.ResourceRequestsCPU: quantity})
+			case v1.ResourceMemory:
+				addResourceList(result, v1.ResourceList{v1.ResourceMemory: quantity, v1.ResourceRequestsMemory: quantity})
+			}
+		}
+		for name, quantity := range container.Limits {
+			switch name {
+			case v1.ResourceCPU:
+				addResourceList(result, v1.ResourceList{v1.ResourceLimitsCPU: quantity})
+			case v1.ResourceMemory:
+				addResourceList(result, v1.ResourceList{v1.ResourceLimitsMemory: quantity})
+			}
+		}
+	}
+	return result
+}
+
+func addResourceList(list, other v1.ResourceList) {
+	for name, quantity := range other {
+		value := list[name]
+		value.Add(quantity)
+		list[name] = value
+	}
+}
+
+func scaleResourceList(list v1.ResourceList, replicas int32) v1.ResourceList {
+	result := v1.ResourceList{}
+	for name, quantity := range list {
+		value := resource.Quantity{Format: quantity.Format}
+		for i := int32(0); i < replicas; i++ {
+			value.Add(quantity)
+		}
+		result[name] = value
+	}
+	return result
+}
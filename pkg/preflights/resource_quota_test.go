@@ -0,0 +1,128 @@
+package preflights
+
+import (
+	"context"
+	"testing"
+
+	k8sappsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+)
+
+func testResourceQuota(hard, used v1.ResourceList) *v1.ResourceQuota {
+	return &v1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "quota", Namespace: namespace},
+		Spec:       v1.ResourceQuotaSpec{Hard: hard},
+		Status:     v1.ResourceQuotaStatus{Hard: hard, Used: used},
+	}
+}
+
+func testLimitRange(item v1.LimitRangeItem) *v1.LimitRange {
+	return &v1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{Name: "limits", Namespace: namespace},
+		Spec:       v1.LimitRangeSpec{Limits: []v1.LimitRangeItem{item}},
+	}
+}
+
+func TestResourceQuotaCheck(t *testing.T) {
+	// All the deployments of the APIManager already exist with the same resources
+	var deployments []runtime.Object
+	for _, name := range []string{
+		component.ApicastProductionName, component.ApicastStagingName,
+		component.BackendListenerName, component.BackendWorkerName, component.BackendCronName,
+		component.SystemAppDeploymentName, component.SystemSidekiqName, component.SystemMemcachedDeploymentName,
+		component.SystemSearchdDeploymentName, component.ZyncName, component.ZyncQueDeploymentName, component.ZyncDatabaseDeploymentName,
+	} {
+		replicas := int32(5)
+		deployments = append(deployments, &k8sappsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: k8sappsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Template: v1.PodTemplateSpec{Spec: v1.PodSpec{Containers: []v1.Container{
+					{Resources: v1.ResourceRequirements{
+						Limits:   v1.ResourceList{v1.ResourceCPU: resource.MustParse("4"), v1.ResourceMemory: resource.MustParse("8Gi")},
+						Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("4"), v1.ResourceMemory: resource.MustParse("8Gi")},
+					}},
+					{Resources: v1.ResourceRequirements{
+						Limits:   v1.ResourceList{v1.ResourceCPU: resource.MustParse("4"), v1.ResourceMemory: resource.MustParse("8Gi")},
+						Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("4"), v1.ResourceMemory: resource.MustParse("8Gi")},
+					}},
+					{Resources: v1.ResourceRequirements{
+						Limits:   v1.ResourceList{v1.ResourceCPU: resource.MustParse("4"), v1.ResourceMemory: resource.MustParse("8Gi")},
+						Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("4"), v1.ResourceMemory: resource.MustParse("8Gi")},
+					}},
+				}}},
+			},
+		})
+	}
+
+	fullQuota := testResourceQuota(
+		v1.ResourceList{v1.ResourceRequestsCPU: resource.MustParse("100"), v1.ResourcePods: resource.MustParse("100")},
+		v1.ResourceList{v1.ResourceRequestsCPU: resource.MustParse("100"), v1.ResourcePods: resource.MustParse("100")},
+	)
+
+	cases := []struct {
+		testName      string
+		objects       []runtime.Object
+		expectedError bool
+	}{
+		{"NoQuotas", nil, false},
+		{"EnoughQuota", []runtime.Object{testResourceQuota(
+			v1.ResourceList{v1.ResourceRequestsCPU: resource.MustParse("100"), v1.ResourceLimitsMemory: resource.MustParse("100Gi"), v1.ResourcePods: resource.MustParse("100")},
+			v1.ResourceList{v1.ResourceRequestsCPU: resource.MustParse("1")},
+		)}, false},
+		{"CPUExceeded", []runtime.Object{testResourceQuota(
+			v1.ResourceList{v1.ResourceRequestsCPU: resource.MustParse("1")},
+			v1.ResourceList{},
+		)}, true},
+		{"UsedExceeded", []runtime.Object{testResourceQuota(
+			v1.ResourceList{v1.ResourcePods: resource.MustParse("100")},
+			v1.ResourceList{v1.ResourcePods: resource.MustParse("95")},
+		)}, true},
+		{"FullQuotaExistingDeployments", append([]runtime.Object{fullQuota}, deployments...), false},
+		{"LimitRangeMaxExceeded", []runtime.Object{testLimitRange(v1.LimitRangeItem{
+			Type: v1.LimitTypeContainer,
+			Max:  v1.ResourceList{v1.ResourceMemory: resource.MustParse("100Mi")},
+		})}, true},
+		{"LimitRangeFits", []runtime.Object{testLimitRange(v1.LimitRangeItem{
+			Type: v1.LimitTypeContainer,
+			Max:  v1.ResourceList{v1.ResourceMemory: resource.MustParse("16Gi")},
+			Min:  v1.ResourceList{v1.ResourceMemory: resource.MustParse("1Mi")},
+		})}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			err := (&ResourceQuotaCheck{}).Run(context.TODO(), testClient(subT, tc.objects...), testAPIManager())
+			if (err != nil) != tc.expectedError {
+				subT.Fatalf("expected error %t, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestResourceQuotaCheckMissingRequests(t *testing.T) {
+	falseValue := false
+	apimanager := testAPIManager()
+	apimanager.Spec.ResourceRequirementsEnabled = &falseValue
+
+	quota := testResourceQuota(v1.ResourceList{v1.ResourceRequestsMemory: resource.MustParse("100Gi")}, v1.ResourceList{})
+	err := (&ResourceQuotaCheck{}).Run(context.TODO(), testClient(t, quota), apimanager)
+	if err == nil {
+		t.Fatal("expected error for containers without memory requests")
+	}
+
+	// Default requests of the LimitRange are set to the containers
+	limitRange := testLimitRange(v1.LimitRangeItem{
+		Type:           v1.LimitTypeContainer,
+		DefaultRequest: v1.ResourceList{v1.ResourceMemory: resource.MustParse("64Mi")},
+	})
+	err = (&ResourceQuotaCheck{}).Run(context.TODO(), testClient(t, quota, limitRange), apimanager)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
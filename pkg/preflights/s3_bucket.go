@@ -0,0 +1,156 @@
+package preflights
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/3scale/3scale-operator/apis/apps"
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+)
+
+const (
+	s3DefaultRegion = "us-east-1"
+	// Hex encoded SHA256 of the empty payload
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+// S3BucketCheck verifies the bucket of the system S3 file storage is reachable
+// with the credentials of the configuration secret
+type S3BucketCheck struct {
+	// HTTPClient sends the requests to the S3 endpoint. Defaults to a client with a short timeout
+	HTTPClient *http.Client
+}
+
+func (c *S3BucketCheck) Name() string { return "s3-bucket" }
+
+func (c *S3BucketCheck) Reason() string { return "S3BucketUnreachable" }
+
+func (c *S3BucketCheck) Run(ctx context.Context, cl client.Client, apimanager *appsv1alpha1.APIManager) error {
+	if !apimanager.IsS3Enabled() {
+		return nil
+	}
+
+	secretName := apimanager.Spec.System.FileStorageSpec.S3.ConfigurationSecretRef.Name
+	secret := &v1.Secret{}
+	err := cl.Get(ctx, client.ObjectKey{Namespace: apimanager.Namespace, Name: secretName}, secret)
+	if err != nil {
+		return fmt.Errorf("failed to read S3 configuration secret %s: %w", secretName, err)
+	}
+
+	bucket := string(secret.Data[apps.AwsBucket])
+	if bucket == "" {
+		return fmt.Errorf("S3 configuration secret %s has no %s", secretName, apps.AwsBucket)
+	}
+	region := string(secret.Data[apps.AwsRegion])
+	if region == "" {
+		region = s3DefaultRegion
+	}
+	protocol := string(secret.Data[apps.AwsProtocol])
+	if protocol == "" {
+		protocol = "https"
+	}
+	hostname := string(secret.Data[apps.AwsHostname])
+	if hostname == "" {
+		hostname = fmt.Sprintf("s3.%s.amazonaws.com", region)
+	}
+
+	host, path := fmt.Sprintf("%s.%s", bucket, hostname), "/"
+	if string(secret.Data[apps.AwsPathStyle]) == "true" {
+		host, path = hostname, "/"+bucket
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, fmt.Sprintf("%s://%s%s", protocol, host, path), nil)
+	if err != nil {
+		return err
+	}
+
+	// With STS the credentials are only available to the pods through the web identity token,
+	// only the reachability of the endpoint can be verified
+	sts := apimanager.IsS3STSEnabled()
+	if !sts {
+		signS3Request(req, string(secret.Data[apps.AwsAccessKeyID]), string(secret.Data[apps.AwsSecretAccessKey]), region, time.Now().UTC())
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("S3 endpoint %s unreachable: %w", host, err)
+	}
+	resp.Body.Close()
+
+	if sts {
+		return nil
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		return fmt.Errorf("S3 bucket %s not found", bucket)
+	case http.StatusForbidden, http.StatusUnauthorized:
+		return fmt.Errorf("access to S3 bucket %s denied with the credentials of secret %s", bucket, secretName)
+	case http.StatusMovedPermanently:
+		return fmt.Errorf("S3 bucket %s is not in region %s", bucket, region)
+	default:
+		return fmt.Errorf("S3 bucket %s check returned status %d", bucket, resp.StatusCode)
+	}
+}
+
+// signS3Request signs the payload-less request with AWS Signature Version 4
+func signS3Request(req *http.Request, accessKeyID, secretAccessKey, region string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Content-Sha256", emptyPayloadHash)
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + emptyPayloadHash,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		emptyPayloadHash,
+	}, "\n")
+
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", date, region)
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hex.EncodeToString(canonicalRequestHash[:]),
+	}, "\n")
+
+	key := []byte("AWS4" + secretAccessKey)
+	for _, part := range []string{date, region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,95 @@
+package preflights
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/3scale/3scale-operator/apis/apps"
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+)
+
+func TestS3BucketCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		authorization := r.Header.Get("Authorization")
+		switch {
+		case r.URL.Path != "/my-bucket":
+			w.WriteHeader(http.StatusNotFound)
+		case authorization == "":
+			w.WriteHeader(http.StatusForbidden)
+		case !strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=myKeyID/") || !strings.Contains(authorization, "/eu-west-1/s3/aws4_request"):
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secret := func(bucket, keyID string) *v1.Secret {
+		return &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "s3-credentials", Namespace: namespace},
+			Data: map[string][]byte{
+				apps.AwsAccessKeyID:     []byte(keyID),
+				apps.AwsSecretAccessKey: []byte("mySecretKey"),
+				apps.AwsBucket:          []byte(bucket),
+				apps.AwsRegion:          []byte("eu-west-1"),
+				apps.AwsProtocol:        []byte("http"),
+				apps.AwsHostname:        []byte(serverURL.Host),
+				apps.AwsPathStyle:       []byte("true"),
+			},
+		}
+	}
+	trueValue := true
+	withS3 := func(sts bool) *appsv1alpha1.APIManager {
+		apimanager := testAPIManager()
+		apimanager.Spec.System.FileStorageSpec = &appsv1alpha1.SystemFileStorageSpec{
+			S3: &appsv1alpha1.SystemS3Spec{ConfigurationSecretRef: v1.LocalObjectReference{Name: "s3-credentials"}},
+		}
+		if sts {
+			apimanager.Spec.System.FileStorageSpec.S3.STS = &appsv1alpha1.STSSpec{Enabled: &trueValue}
+		}
+		return apimanager
+	}
+
+	cases := []struct {
+		testName      string
+		apimanager    *appsv1alpha1.APIManager
+		secret        *v1.Secret
+		expectedError bool
+	}{
+		{"NoS3", testAPIManager(), nil, false},
+		{"Reachable", withS3(false), secret("my-bucket", "myKeyID"), false},
+		{"BucketNotFound", withS3(false), secret("other-bucket", "myKeyID"), true},
+		{"AccessDenied", withS3(false), secret("my-bucket", "otherKeyID"), true},
+		{"MissingSecret", withS3(false), nil, true},
+		{"STSReachable", withS3(true), secret("my-bucket", ""), false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			cl := testClient(subT)
+			if tc.secret != nil {
+				cl = testClient(subT, tc.secret)
+			}
+			check := &S3BucketCheck{HTTPClient: server.Client()}
+			err := check.Run(context.TODO(), cl, tc.apimanager)
+			if (err != nil) != tc.expectedError {
+				subT.Fatalf("expected error %t, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package preflights
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+)
+
+const (
+	defaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+)
+
+// blockStorageProvisioners only provide ReadWriteOnce volumes
+var blockStorageProvisioners = []string{
+	"kubernetes.io/aws-ebs",
+	"ebs.csi.aws.com",
+	"kubernetes.io/gce-pd",
+	"pd.csi.storage.gke.io",
+	"kubernetes.io/azure-disk",
+	"disk.csi.azure.com",
+	"kubernetes.io/cinder",
+	"cinder.csi.openstack.org",
+	"rbd.csi.ceph.com",
+	"kubernetes.io/no-provisioner",
+	"topolvm.io",
+	"topolvm.cybozu.com",
+}
+
+// StorageClassCheck verifies the StorageClass of the system-storage PVC exists
+// and supports the ReadWriteMany access mode
+type StorageClassCheck struct{}
+
+func (c *StorageClassCheck) Name() string { return "storage-class" }
+
+func (c *StorageClassCheck) Reason() string { return "StorageClassUnavailable" }
+
+func (c *StorageClassCheck) Run(ctx context.Context, cl client.Client, apimanager *appsv1alpha1.APIManager) error {
+	fileStorage := apimanager.Spec.System.FileStorageSpec
+	if fileStorage != nil && (fileStorage.S3 != nil || fileStorage.DeprecatedS3 != nil) {
+		return nil
+	}
+
+	var storageClassName *string
+	if fileStorage != nil && fileStorage.PVC != nil {
+		if fileStorage.PVC.VolumeName != nil {
+			// Statically bound to a PersistentVolume
+			return nil
+		}
+		storageClassName = fileStorage.PVC.StorageClassName
+	}
+
+	// Once created, the PVC is not modified by the operator
+	pvc := &v1.PersistentVolumeClaim{}
+	err := cl.Get(ctx, client.ObjectKey{Namespace: apimanager.Namespace, Name: component.SystemFileStoragePVCName}, pvc)
+	if err == nil {
+		return nil
+	}
+	if !errors.IsNotFound(err) {
+		return err
+	}
+
+	storageClass, err := findStorageClass(ctx, cl, storageClassName)
+	if err != nil {
+		return err
+	}
+
+	for _, provisioner := range blockStorageProvisioners {
+		if strings.EqualFold(storageClass.Provisioner, provisioner) {
+			return fmt.Errorf("storage class %s provisioner %s does not support the ReadWriteMany access mode required by the %s PVC",
+				storageClass.Name, storageClass.Provisioner, component.SystemFileStoragePVCName)
+		}
+	}
+
+	return nil
+}
+
+// findStorageClass returns the StorageClass with the given name or the default one when name is nil
+func findStorageClass(ctx context.Context, cl client.Client, name *string) (*storagev1.StorageClass, error) {
+	if name != nil {
+		storageClass := &storagev1.StorageClass{}
+		err := cl.Get(ctx, client.ObjectKey{Name: *name}, storageClass)
+		if errors.IsNotFound(err) {
+			return nil, fmt.Errorf("storage class %s not found", *name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return storageClass, nil
+	}
+
+	storageClassList := &storagev1.StorageClassList{}
+	err := cl.List(ctx, storageClassList)
+	if err != nil {
+		return nil, err
+	}
+	for idx := range storageClassList.Items {
+		if storageClassList.Items[idx].Annotations[defaultStorageClassAnnotation] == "true" {
+			return &storageClassList.Items[idx], nil
+		}
+	}
+
+	return nil, fmt.Errorf("no storage class set for the %s PVC and there is no default storage class", component.SystemFileStoragePVCName)
+}
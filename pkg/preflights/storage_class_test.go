@@ -0,0 +1,66 @@
+package preflights
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+)
+
+func testStorageClass(name, provisioner string, isDefault bool) *storagev1.StorageClass {
+	storageClass := &storagev1.StorageClass{
+		ObjectMeta:  metav1.ObjectMeta{Name: name},
+		Provisioner: provisioner,
+	}
+	if isDefault {
+		storageClass.Annotations = map[string]string{defaultStorageClassAnnotation: "true"}
+	}
+	return storageClass
+}
+
+func TestStorageClassCheck(t *testing.T) {
+	nfs := "nfs"
+	missing := "missing"
+
+	withStorageClass := func(name *string) *appsv1alpha1.APIManager {
+		apimanager := testAPIManager()
+		apimanager.Spec.System.FileStorageSpec = &appsv1alpha1.SystemFileStorageSpec{
+			PVC: &appsv1alpha1.PVCGenericSpec{StorageClassName: name},
+		}
+		return apimanager
+	}
+	withS3 := testAPIManager()
+	withS3.Spec.System.FileStorageSpec = &appsv1alpha1.SystemFileStorageSpec{S3: &appsv1alpha1.SystemS3Spec{}}
+
+	existingPVC := &v1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: component.SystemFileStoragePVCName, Namespace: namespace}}
+
+	cases := []struct {
+		testName      string
+		apimanager    *appsv1alpha1.APIManager
+		objects       []runtime.Object
+		expectedError bool
+	}{
+		{"DefaultRWX", testAPIManager(), []runtime.Object{testStorageClass("gp3", "ebs.csi.aws.com", false), testStorageClass("efs", "efs.csi.aws.com", true)}, false},
+		{"DefaultBlockStorage", testAPIManager(), []runtime.Object{testStorageClass("gp3", "ebs.csi.aws.com", true)}, true},
+		{"NoDefault", testAPIManager(), []runtime.Object{testStorageClass("gp3", "ebs.csi.aws.com", false)}, true},
+		{"NamedRWX", withStorageClass(&nfs), []runtime.Object{testStorageClass(nfs, "nfs.csi.k8s.io", false)}, false},
+		{"NamedNotFound", withStorageClass(&missing), nil, true},
+		{"ExistingPVC", testAPIManager(), []runtime.Object{existingPVC}, false},
+		{"S3", withS3, nil, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			err := (&StorageClassCheck{}).Run(context.TODO(), testClient(subT, tc.objects...), tc.apimanager)
+			if (err != nil) != tc.expectedError {
+				subT.Fatalf("expected error %t, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
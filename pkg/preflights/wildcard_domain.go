@@ -0,0 +1,82 @@
+package preflights
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	routev1 "github.com/openshift/api/route/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/helper"
+)
+
+// WildcardDomainCheck verifies the hosts of the wildcard domain resolve through the cluster DNS
+// to the router serving the routes of the namespace
+type WildcardDomainCheck struct {
+	// LookupHost resolves the host addresses. Defaults to the cluster DNS resolver
+	LookupHost func(ctx context.Context, host string) ([]string, error)
+}
+
+func (c *WildcardDomainCheck) Name() string { return "wildcard-domain" }
+
+func (c *WildcardDomainCheck) Reason() string { return "WildcardDomainUnresolvable" }
+
+func (c *WildcardDomainCheck) Run(ctx context.Context, cl client.Client, apimanager *appsv1alpha1.APIManager) error {
+	lookupHost := c.LookupHost
+	if lookupHost == nil {
+		lookupHost = net.DefaultResolver.LookupHost
+	}
+
+	host := fmt.Sprintf("%s-admin.%s", *apimanager.Spec.TenantName, apimanager.Spec.WildcardDomain)
+	addresses, err := lookupHost(ctx, host)
+	if err != nil {
+		return fmt.Errorf("host %s of the wildcard domain does not resolve: %w", host, err)
+	}
+
+	routerHostname, err := routerCanonicalHostname(ctx, cl, apimanager.Namespace, apimanager.Spec.WildcardDomain)
+	if err != nil {
+		return err
+	}
+	if routerHostname == "" {
+		// No admitted routes yet to find out the router
+		return nil
+	}
+
+	routerAddresses, err := lookupHost(ctx, routerHostname)
+	if err != nil {
+		return fmt.Errorf("router hostname %s does not resolve: %w", routerHostname, err)
+	}
+	for _, address := range addresses {
+		if helper.ArrayContains(routerAddresses, address) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("host %s of the wildcard domain resolves to %v, not to the router %s %v", host, addresses, routerHostname, routerAddresses)
+}
+
+// routerCanonicalHostname returns the canonical hostname of the router that admitted
+// the routes of the wildcard domain in the namespace, an empty string when there is none
+func routerCanonicalHostname(ctx context.Context, cl client.Client, namespace, wildcardDomain string) (string, error) {
+	routeList := &routev1.RouteList{}
+	err := cl.List(ctx, routeList, client.InNamespace(namespace))
+	if err != nil {
+		return "", fmt.Errorf("failed to list routes: %w", err)
+	}
+
+	for idx := range routeList.Items {
+		if !strings.HasSuffix(routeList.Items[idx].Spec.Host, "."+wildcardDomain) || !helper.IsRouteReady(&routeList.Items[idx]) {
+			continue
+		}
+		for _, ingress := range routeList.Items[idx].Status.Ingress {
+			if ingress.RouterCanonicalHostname != "" {
+				return ingress.RouterCanonicalHostname, nil
+			}
+		}
+	}
+
+	return "", nil
+}
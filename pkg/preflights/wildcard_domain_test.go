@@ -0,0 +1,65 @@
+package preflights
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	routev1 "github.com/openshift/api/route/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func testLookupHost(hosts map[string][]string) func(context.Context, string) ([]string, error) {
+	return func(ctx context.Context, host string) ([]string, error) {
+		addresses, ok := hosts[host]
+		if !ok {
+			return nil, fmt.Errorf("no such host %s", host)
+		}
+		return addresses, nil
+	}
+}
+
+func testAdmittedRoute(host, routerHostname string) *routev1.Route {
+	return &routev1.Route{
+		ObjectMeta: metav1.ObjectMeta{Name: "zync-3scale-api", Namespace: namespace},
+		Spec:       routev1.RouteSpec{Host: host},
+		Status: routev1.RouteStatus{
+			Ingress: []routev1.RouteIngress{{
+				Host:                    host,
+				RouterCanonicalHostname: routerHostname,
+				Conditions:              []routev1.RouteIngressCondition{{Type: routev1.RouteAdmitted, Status: v1.ConditionTrue}},
+			}},
+		},
+	}
+}
+
+func TestWildcardDomainCheck(t *testing.T) {
+	adminHost := "3scale-admin." + wildcardDomain
+	routerHostname := "router-default.apps.cluster.local"
+	route := testAdmittedRoute("api-3scale-apicast-production."+wildcardDomain, routerHostname)
+
+	cases := []struct {
+		testName      string
+		hosts         map[string][]string
+		objects       []runtime.Object
+		expectedError bool
+	}{
+		{"Unresolvable", map[string][]string{}, nil, true},
+		{"ResolvesWithoutRoutes", map[string][]string{adminHost: {"10.0.0.1"}}, nil, false},
+		{"ResolvesToRouter", map[string][]string{adminHost: {"10.0.0.1"}, routerHostname: {"10.0.0.2", "10.0.0.1"}}, []runtime.Object{route}, false},
+		{"ResolvesElsewhere", map[string][]string{adminHost: {"10.0.0.1"}, routerHostname: {"10.0.0.2"}}, []runtime.Object{route}, true},
+		{"RouterUnresolvable", map[string][]string{adminHost: {"10.0.0.1"}}, []runtime.Object{route}, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			check := &WildcardDomainCheck{LookupHost: testLookupHost(tc.hosts)}
+			err := check.Run(context.TODO(), testClient(subT, tc.objects...), testAPIManager())
+			if (err != nil) != tc.expectedError {
+				subT.Fatalf("expected error %t, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
@@ -133,25 +133,31 @@ func DeploymentTolerationsMutator(desired, existing *k8sappsv1.Deployment) (bool
 	return updated, nil
 }
 
+// DeploymentContainerResourcesMutator reconciles the resources of every container.
+// Containers are replaced when the number of containers changed, i.e. a sidecar is added or removed
 func DeploymentContainerResourcesMutator(desired, existing *k8sappsv1.Deployment) (bool, error) {
 	desiredName := common.ObjectInfo(desired)
 	update := false
 
-	if len(desired.Spec.Template.Spec.Containers) != 1 {
-		return false, fmt.Errorf("%s desired spec.template.spec.containers length changed to '%d', should be 1", desiredName, len(desired.Spec.Template.Spec.Containers))
+	if len(desired.Spec.Template.Spec.Containers) == 0 {
+		return false, fmt.Errorf("%s desired spec.template.spec.containers is empty", desiredName)
 	}
 
-	if len(existing.Spec.Template.Spec.Containers) != 1 {
+	if len(existing.Spec.Template.Spec.Containers) != len(desired.Spec.Template.Spec.Containers) {
 		log.Info(fmt.Sprintf("%s spec.template.spec.containers length changed to '%d', recreating dc", desiredName, len(existing.Spec.Template.Spec.Containers)))
 		existing.Spec.Template.Spec.Containers = desired.Spec.Template.Spec.Containers
 		update = true
 	}
 
-	if !helper.CmpResources(&existing.Spec.Template.Spec.Containers[0].Resources, &desired.Spec.Template.Spec.Containers[0].Resources) {
-		diff := cmp.Diff(existing.Spec.Template.Spec.Containers[0].Resources, desired.Spec.Template.Spec.Containers[0].Resources, cmpopts.IgnoreUnexported(resource.Quantity{}))
-		log.Info(fmt.Sprintf("%s spec.template.spec.containers[0].resources have changed: %s", desiredName, diff))
-		existing.Spec.Template.Spec.Containers[0].Resources = desired.Spec.Template.Spec.Containers[0].Resources
-		update = true
+	for idx := range desired.Spec.Template.Spec.Containers {
+		existingResources := &existing.Spec.Template.Spec.Containers[idx].Resources
+		desiredResources := &desired.Spec.Template.Spec.Containers[idx].Resources
+		if !helper.CmpResources(existingResources, desiredResources) {
+			diff := cmp.Diff(*existingResources, *desiredResources, cmpopts.IgnoreUnexported(resource.Quantity{}))
+			log.Info(fmt.Sprintf("%s spec.template.spec.containers[%d].resources have changed: %s", desiredName, idx, diff))
+			*existingResources = *desiredResources
+			update = true
+		}
 	}
 
 	return update, nil
@@ -200,6 +206,106 @@ func DeploymentEnvVarReconciler(desired, existing *k8sappsv1.Deployment, envVar
 	return updated
 }
 
+// DeploymentVolumeReconciler implements basic volume reconciliation for deployments.
+// The volume and its mounts in containers and init containers are
+// Added when in desired and not in existing
+// Updated when in desired and in existing but not equal
+// Removed when not in desired and exists in existing Deployment
+// Existing and desired Deployment must have same number of containers
+func DeploymentVolumeReconciler(desired, existing *k8sappsv1.Deployment, volumeName string) bool {
+	if len(desired.Spec.Template.Spec.Containers) != len(existing.Spec.Template.Spec.Containers) {
+		log.Info("[WARNING] not reconciling deployment",
+			"name", client.ObjectKeyFromObject(desired),
+			"reason", "existing and desired do not have same number of containers")
+		return false
+	}
+
+	if len(desired.Spec.Template.Spec.InitContainers) != len(existing.Spec.Template.Spec.InitContainers) {
+		log.Info("[WARNING] not reconciling deployment",
+			"name", client.ObjectKeyFromObject(desired),
+			"reason", "existing and desired do not have same number of init containers")
+		return false
+	}
+
+	updated := volumeReconciler(desired.Spec.Template.Spec.Volumes, &existing.Spec.Template.Spec.Volumes, volumeName)
+
+	// Init Containers
+	for idx := range existing.Spec.Template.Spec.InitContainers {
+		tmpChanged := volumeMountReconciler(
+			desired.Spec.Template.Spec.InitContainers[idx].VolumeMounts,
+			&existing.Spec.Template.Spec.InitContainers[idx].VolumeMounts,
+			volumeName)
+		updated = updated || tmpChanged
+	}
+
+	// Containers
+	for idx := range existing.Spec.Template.Spec.Containers {
+		tmpChanged := volumeMountReconciler(
+			desired.Spec.Template.Spec.Containers[idx].VolumeMounts,
+			&existing.Spec.Template.Spec.Containers[idx].VolumeMounts,
+			volumeName)
+		updated = updated || tmpChanged
+	}
+
+	return updated
+}
+
+func volumeReconciler(desired []corev1.Volume, existing *[]corev1.Volume, volumeName string) bool {
+	desiredIdx := -1
+	for idx := range desired {
+		if desired[idx].Name == volumeName {
+			desiredIdx = idx
+		}
+	}
+	existingIdx := -1
+	for idx := range *existing {
+		if (*existing)[idx].Name == volumeName {
+			existingIdx = idx
+		}
+	}
+
+	if desiredIdx < 0 && existingIdx >= 0 {
+		*existing = append((*existing)[:existingIdx], (*existing)[existingIdx+1:]...)
+		return true
+	} else if desiredIdx >= 0 && existingIdx < 0 {
+		*existing = append(*existing, desired[desiredIdx])
+		return true
+	} else if desiredIdx >= 0 && !reflect.DeepEqual((*existing)[existingIdx], desired[desiredIdx]) {
+		(*existing)[existingIdx] = desired[desiredIdx]
+		return true
+	}
+
+	return false
+}
+
+func volumeMountReconciler(desired []corev1.VolumeMount, existing *[]corev1.VolumeMount, volumeName string) bool {
+	desiredIdx := -1
+	for idx := range desired {
+		if desired[idx].Name == volumeName {
+			desiredIdx = idx
+		}
+	}
+	existingIdx := -1
+	for idx := range *existing {
+		if (*existing)[idx].Name == volumeName {
+			existingIdx = idx
+		}
+	}
+
+	if desiredIdx < 0 && existingIdx >= 0 {
+		*existing = append((*existing)[:existingIdx], (*existing)[existingIdx+1:]...)
+		return true
+	} else if desiredIdx >= 0 && existingIdx < 0 {
+		*existing = append(*existing, desired[desiredIdx])
+		return true
+	} else if desiredIdx >= 0 && !reflect.DeepEqual((*existing)[existingIdx], desired[desiredIdx]) {
+		(*existing)[existingIdx] = desired[desiredIdx]
+		return true
+	}
+
+	return false
+}
+
 // DeploymentPodTemplateLabelsMutator ensures pod template labels are reconciled
 func DeploymentPodTemplateLabelsMutator(desired, existing *k8sappsv1.Deployment) (bool, error) {
 	updated := false
@@ -137,6 +137,53 @@ func TestDeploymentContainerResourcesMutator(t *testing.T) {
 	}
 }
 
+func TestDeploymentContainerResourcesMutatorSidecar(t *testing.T) {
+	resources := corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+	}
+	dFactory := func(containers ...corev1.Container) *k8sappsv1.Deployment {
+		return &k8sappsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "myDeployment", Namespace: "myNS"},
+			Spec: k8sappsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: containers},
+				},
+			},
+		}
+	}
+	main := corev1.Container{Name: "container1"}
+	sidecar := corev1.Container{Name: "sidecar", Resources: resources}
+
+	// Sidecar added
+	existing := dFactory(main)
+	update, err := DeploymentContainerResourcesMutator(dFactory(main, sidecar), existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !update || len(existing.Spec.Template.Spec.Containers) != 2 {
+		t.Fatalf("expected the sidecar to be added, got %v", existing.Spec.Template.Spec.Containers)
+	}
+
+	// Sidecar resources reconciled
+	existing.Spec.Template.Spec.Containers[1].Resources = corev1.ResourceRequirements{}
+	update, err = DeploymentContainerResourcesMutator(dFactory(main, sidecar), existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !update || !helper.CmpResources(&existing.Spec.Template.Spec.Containers[1].Resources, &resources) {
+		t.Fatalf("expected the sidecar resources to be reconciled, got %v", existing.Spec.Template.Spec.Containers[1].Resources)
+	}
+
+	// Sidecar removed
+	update, err = DeploymentContainerResourcesMutator(dFactory(main), existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !update || len(existing.Spec.Template.Spec.Containers) != 1 {
+		t.Fatalf("expected the sidecar to be removed, got %v", existing.Spec.Template.Spec.Containers)
+	}
+}
+
 func TestDeploymentAffinityMutator(t *testing.T) {
 	testAffinity1 := &corev1.Affinity{
 		NodeAffinity: &corev1.NodeAffinity{
@@ -535,6 +582,97 @@ func TestDeploymentEnvVarReconciler(t *testing.T) {
 	})
 }
 
+func TestDeploymentVolumeReconciler(t *testing.T) {
+	dFactory := func(volumes []corev1.Volume, mounts []corev1.VolumeMount) *k8sappsv1.Deployment {
+		return &k8sappsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "myDeployment", Namespace: "myNS"},
+			Spec: k8sappsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Volumes: volumes,
+						Containers: []corev1.Container{
+							{Name: "container1", VolumeMounts: mounts},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	pvcVolume := corev1.Volume{
+		Name: "A",
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "pvc"},
+		},
+	}
+	emptyDirVolume := corev1.Volume{
+		Name:         "A",
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}
+	otherVolume := corev1.Volume{
+		Name:         "B",
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}
+	mountA := corev1.VolumeMount{Name: "A", MountPath: "/a"}
+	mountB := corev1.VolumeMount{Name: "B", MountPath: "/b"}
+
+	cases := []struct {
+		testName       string
+		desired        *k8sappsv1.Deployment
+		existing       *k8sappsv1.Deployment
+		expectedResult bool
+		expected       *k8sappsv1.Deployment
+	}{
+		{
+			"nothing to reconcile",
+			dFactory([]corev1.Volume{pvcVolume, otherVolume}, []corev1.VolumeMount{mountA, mountB}),
+			dFactory([]corev1.Volume{pvcVolume, otherVolume}, []corev1.VolumeMount{mountA, mountB}),
+			false,
+			dFactory([]corev1.Volume{pvcVolume, otherVolume}, []corev1.VolumeMount{mountA, mountB}),
+		},
+		{
+			"volume added",
+			dFactory([]corev1.Volume{otherVolume, pvcVolume}, []corev1.VolumeMount{mountB, mountA}),
+			dFactory([]corev1.Volume{otherVolume}, []corev1.VolumeMount{mountB}),
+			true,
+			dFactory([]corev1.Volume{otherVolume, pvcVolume}, []corev1.VolumeMount{mountB, mountA}),
+		},
+		{
+			"volume removed",
+			dFactory([]corev1.Volume{otherVolume}, []corev1.VolumeMount{mountB}),
+			dFactory([]corev1.Volume{pvcVolume, otherVolume}, []corev1.VolumeMount{mountA, mountB}),
+			true,
+			dFactory([]corev1.Volume{otherVolume}, []corev1.VolumeMount{mountB}),
+		},
+		{
+			"volume updated",
+			dFactory([]corev1.Volume{emptyDirVolume, otherVolume}, []corev1.VolumeMount{mountA, mountB}),
+			dFactory([]corev1.Volume{pvcVolume, otherVolume}, []corev1.VolumeMount{mountA, mountB}),
+			true,
+			dFactory([]corev1.Volume{emptyDirVolume, otherVolume}, []corev1.VolumeMount{mountA, mountB}),
+		},
+		{
+			"other volumes untouched",
+			dFactory([]corev1.Volume{pvcVolume}, []corev1.VolumeMount{mountA}),
+			dFactory([]corev1.Volume{pvcVolume, otherVolume}, []corev1.VolumeMount{mountA, mountB}),
+			false,
+			dFactory([]corev1.Volume{pvcVolume, otherVolume}, []corev1.VolumeMount{mountA, mountB}),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			update := DeploymentVolumeReconciler(tc.desired, tc.existing, "A")
+			if update != tc.expectedResult {
+				subT.Fatalf("result failed, expected: %t, got: %t", tc.expectedResult, update)
+			}
+			if !reflect.DeepEqual(tc.existing, tc.expected) {
+				subT.Fatal(cmp.Diff(tc.existing, tc.expected))
+			}
+		})
+	}
+}
+
 func TestDeploymentPodTemplateLabelsMutator(t *testing.T) {
 	dFactory := func(labels map[string]string) *k8sappsv1.Deployment {
 		return &k8sappsv1.Deployment{
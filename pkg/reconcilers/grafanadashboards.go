@@ -5,6 +5,7 @@ import (
 	"reflect"
 
 	"github.com/3scale/3scale-operator/pkg/common"
+	"github.com/3scale/3scale-operator/pkg/helper"
 
 	grafanav1alpha1 "github.com/grafana-operator/grafana-operator/v4/api/integreatly/v1alpha1"
 	grafanav1beta1 "github.com/grafana-operator/grafana-operator/v5/api/v1beta1"
@@ -58,3 +59,20 @@ func GenericGrafanaDashboardsMutator(existingObj, desiredObj common.KubernetesOb
 
 	return updated, nil
 }
+
+// GrafanaDashboardLabelsMutator reconciles the desired labels, i.e. the labels selecting the
+// grafana-operator v4 instances, along with the mutations of mutateFn
+func GrafanaDashboardLabelsMutator(mutateFn MutateFn) MutateFn {
+	return func(existingObj, desiredObj common.KubernetesObject) (bool, error) {
+		update, err := mutateFn(existingObj, desiredObj)
+		if err != nil {
+			return false, err
+		}
+
+		labels := existingObj.GetLabels()
+		helper.MergeMapStringString(&update, &labels, desiredObj.GetLabels())
+		existingObj.SetLabels(labels)
+
+		return update, nil
+	}
+}
@@ -26,3 +26,27 @@ func GenericHPAMutator(existingObj, desiredObj common.KubernetesObject) (bool, e
 
 	return updated, nil
 }
+
+// HPAReplicasBoundsMutator reconciles the min and max replicas of the HPA only
+func HPAReplicasBoundsMutator(existingObj, desiredObj common.KubernetesObject) (bool, error) {
+	existing, ok := existingObj.(*hpa.HorizontalPodAutoscaler)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *v2.HorizontalPodAutoscaler", existingObj)
+	}
+	desired, ok := desiredObj.(*hpa.HorizontalPodAutoscaler)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *v2.HorizontalPodAutoscaler", desiredObj)
+	}
+
+	updated := false
+	if !reflect.DeepEqual(desired.Spec.MinReplicas, existing.Spec.MinReplicas) {
+		existing.Spec.MinReplicas = desired.Spec.MinReplicas
+		updated = true
+	}
+	if desired.Spec.MaxReplicas != existing.Spec.MaxReplicas {
+		existing.Spec.MaxReplicas = desired.Spec.MaxReplicas
+		updated = true
+	}
+
+	return updated, nil
+}
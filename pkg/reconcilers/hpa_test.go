@@ -67,3 +67,33 @@ func TestGenericHPAMutator(t *testing.T) {
 		t.Fatalf("MaxReplicas not reconciled. Expected: %d, got: %d", desiredMaxPods, existing.Spec.MaxReplicas)
 	}
 }
+
+func TestHPAReplicasBoundsMutator(t *testing.T) {
+	existing := hpaTestFactory(5)
+	existing.Spec.Metrics[0].Resource.Target.AverageUtilization = helper.Int32Ptr(70)
+	desired := hpaTestFactory(10)
+	desired.Spec.MinReplicas = helper.Int32Ptr(3)
+
+	update, err := HPAReplicasBoundsMutator(existing, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !update {
+		t.Fatal("when replicas bounds changed, reconciler reported no update needed")
+	}
+
+	if *existing.Spec.MinReplicas != 3 || existing.Spec.MaxReplicas != 10 {
+		t.Fatalf("replicas bounds not reconciled. Expected: 3-10, got: %d-%d", *existing.Spec.MinReplicas, existing.Spec.MaxReplicas)
+	}
+	if *existing.Spec.Metrics[0].Resource.Target.AverageUtilization != 70 {
+		t.Fatal("metrics should not be reconciled")
+	}
+
+	update, err = HPAReplicasBoundsMutator(existing, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if update {
+		t.Fatal("when replicas bounds are reconciled, reconciler reported update needed")
+	}
+}
@@ -0,0 +1,28 @@
+package reconcilers
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/3scale/3scale-operator/pkg/common"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+func GenericNetworkPolicyMutator(existingObj, desiredObj common.KubernetesObject) (bool, error) {
+	existing, ok := existingObj.(*networkingv1.NetworkPolicy)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *networkingv1.NetworkPolicy", existingObj)
+	}
+	desired, ok := desiredObj.(*networkingv1.NetworkPolicy)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *networkingv1.NetworkPolicy", desiredObj)
+	}
+
+	updated := false
+	if !reflect.DeepEqual(desired.Spec, existing.Spec) {
+		existing.Spec = desired.Spec
+		updated = true
+	}
+
+	return updated, nil
+}
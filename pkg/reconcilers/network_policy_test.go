@@ -0,0 +1,40 @@
+package reconcilers
+
+import (
+	"testing"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func networkPolicyTestFactory(podSelectorValue string) *networkingv1.NetworkPolicy {
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myNetworkPolicy",
+			Namespace: "someNs",
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"test1": podSelectorValue},
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		},
+	}
+}
+
+func TestGenericNetworkPolicyMutator(t *testing.T) {
+	existing := networkPolicyTestFactory("existing")
+	desired := networkPolicyTestFactory("desired")
+
+	update, err := GenericNetworkPolicyMutator(existing, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !update {
+		t.Fatal("when spec differs, reconciler reported no update needed")
+	}
+
+	if existing.Spec.PodSelector.MatchLabels["test1"] != "desired" {
+		t.Fatalf("PodSelector not reconciled. Expected: desired, got: %s", existing.Spec.PodSelector.MatchLabels["test1"])
+	}
+}
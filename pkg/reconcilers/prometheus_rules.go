@@ -1,6 +1,11 @@
 package reconcilers
 
 import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
 	"github.com/3scale/3scale-operator/pkg/common"
 
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
@@ -30,3 +35,106 @@ func RemovePrometheusRulesMutator(existing, desired common.KubernetesObject) (bo
 	log.Info("Alert 'ThreescaleApicastRequestTime' not found, no update required.")
 	return false, nil
 }
+
+// OverriddenAlertsAnnotation records on the PrometheusRule the alerts kept in sync by the last
+// PrometheusRuleAlertsMutator run, so their generated definition is restored once no longer named
+const OverriddenAlertsAnnotation = "apps.3scale.net/overridden-alerts"
+
+// PrometheusRuleAlertsMutator runs the mutateFn and then keeps the named alerts of the existing PrometheusRule in sync
+// with the desired ones. Named alerts missing in the desired rule groups are removed. Alerts named in a previous run
+// are synced as well, restoring their desired definition
+func PrometheusRuleAlertsMutator(alertNames []string, mutateFn MutateFn) MutateFn {
+	return func(existingObj, desiredObj common.KubernetesObject) (bool, error) {
+		update, err := mutateFn(existingObj, desiredObj)
+		if err != nil {
+			return false, err
+		}
+
+		existing, ok := existingObj.(*monitoringv1.PrometheusRule)
+		if !ok {
+			return false, fmt.Errorf("%T is not a *monitoringv1.PrometheusRule", existingObj)
+		}
+		desired, ok := desiredObj.(*monitoringv1.PrometheusRule)
+		if !ok {
+			return false, fmt.Errorf("%T is not a *monitoringv1.PrometheusRule", desiredObj)
+		}
+
+		syncedAlertNames := append([]string{}, alertNames...)
+		if previous := existing.GetAnnotations()[OverriddenAlertsAnnotation]; previous != "" {
+			syncedAlertNames = append(syncedAlertNames, strings.Split(previous, ",")...)
+		}
+
+		for groupIdx := range existing.Spec.Groups {
+			existingGroup := &existing.Spec.Groups[groupIdx]
+			desiredGroup := findRuleGroup(desired.Spec.Groups, existingGroup.Name)
+			if desiredGroup == nil {
+				continue
+			}
+
+			for _, alertName := range syncedAlertNames {
+				existingIdx := findAlert(existingGroup.Rules, alertName)
+				desiredIdx := findAlert(desiredGroup.Rules, alertName)
+
+				switch {
+				case desiredIdx < 0 && existingIdx >= 0:
+					existingGroup.Rules = append(existingGroup.Rules[:existingIdx], existingGroup.Rules[existingIdx+1:]...)
+					update = true
+				case desiredIdx >= 0 && existingIdx < 0:
+					existingGroup.Rules = append(existingGroup.Rules, desiredGroup.Rules[desiredIdx])
+					update = true
+				case desiredIdx >= 0 && !reflect.DeepEqual(existingGroup.Rules[existingIdx], desiredGroup.Rules[desiredIdx]):
+					existingGroup.Rules[existingIdx] = desiredGroup.Rules[desiredIdx]
+					update = true
+				}
+			}
+		}
+
+		if overriddenAlertsAnnotationMutator(existing, alertNames) {
+			update = true
+		}
+
+		return update, nil
+	}
+}
+
+// overriddenAlertsAnnotationMutator sets the OverriddenAlertsAnnotation to the sorted alert names,
+// removing it when there are none
+func overriddenAlertsAnnotationMutator(existing *monitoringv1.PrometheusRule, alertNames []string) bool {
+	sortedAlertNames := append([]string{}, alertNames...)
+	sort.Strings(sortedAlertNames)
+	value := strings.Join(sortedAlertNames, ",")
+
+	annotations := existing.GetAnnotations()
+	if annotations[OverriddenAlertsAnnotation] == value {
+		return false
+	}
+
+	if value == "" {
+		delete(annotations, OverriddenAlertsAnnotation)
+	} else {
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[OverriddenAlertsAnnotation] = value
+	}
+	existing.SetAnnotations(annotations)
+	return true
+}
+
+func findRuleGroup(groups []monitoringv1.RuleGroup, name string) *monitoringv1.RuleGroup {
+	for idx := range groups {
+		if groups[idx].Name == name {
+			return &groups[idx]
+		}
+	}
+	return nil
+}
+
+func findAlert(rules []monitoringv1.Rule, alertName string) int {
+	for idx := range rules {
+		if rules[idx].Alert == alertName {
+			return idx
+		}
+	}
+	return -1
+}
@@ -1,6 +1,7 @@
 package reconcilers
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/3scale/3scale-operator/pkg/common"
@@ -105,3 +106,80 @@ func TestRemovePrometheusRulesMutator(t *testing.T) {
 		})
 	}
 }
+
+func TestPrometheusRuleAlertsMutator(t *testing.T) {
+	prometheusRule := func(rules ...monitoringv1.Rule) *monitoringv1.PrometheusRule {
+		return &monitoringv1.PrometheusRule{
+			Spec: monitoringv1.PrometheusRuleSpec{
+				Groups: []monitoringv1.RuleGroup{{Name: "test-group", Rules: rules}},
+			},
+		}
+	}
+
+	existing := prometheusRule(
+		monitoringv1.Rule{Alert: "Tuned", For: "1m"},
+		monitoringv1.Rule{Alert: "Disabled"},
+		monitoringv1.Rule{Alert: "NotOverridden", For: "1m"},
+	)
+	desired := prometheusRule(
+		monitoringv1.Rule{Alert: "Tuned", For: "10m"},
+		monitoringv1.Rule{Alert: "NotOverridden", For: "5m"},
+		monitoringv1.Rule{Alert: "Enabled"},
+	)
+
+	mutator := PrometheusRuleAlertsMutator([]string{"Tuned", "Disabled", "Enabled"}, CreateOnlyMutator)
+	update, err := mutator(existing, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !update {
+		t.Fatal("expected the overridden alerts to be updated")
+	}
+
+	expected := []monitoringv1.Rule{
+		{Alert: "Tuned", For: "10m"},
+		{Alert: "NotOverridden", For: "1m"},
+		{Alert: "Enabled"},
+	}
+	if !reflect.DeepEqual(existing.Spec.Groups[0].Rules, expected) {
+		t.Fatalf("unexpected rules %v", existing.Spec.Groups[0].Rules)
+	}
+
+	if existing.Annotations[OverriddenAlertsAnnotation] != "Disabled,Enabled,Tuned" {
+		t.Fatalf("unexpected overridden alerts annotation %v", existing.Annotations)
+	}
+
+	update, err = mutator(existing, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if update {
+		t.Fatal("expected no update once in sync")
+	}
+
+	// Alerts no longer overridden are restored to their generated definition
+	generated := prometheusRule(
+		monitoringv1.Rule{Alert: "Tuned", For: "1m"},
+		monitoringv1.Rule{Alert: "Disabled"},
+		monitoringv1.Rule{Alert: "NotOverridden", For: "5m"},
+	)
+	update, err = PrometheusRuleAlertsMutator(nil, CreateOnlyMutator)(existing, generated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !update {
+		t.Fatal("expected the previously overridden alerts to be restored")
+	}
+
+	expected = []monitoringv1.Rule{
+		{Alert: "Tuned", For: "1m"},
+		{Alert: "NotOverridden", For: "1m"},
+		{Alert: "Disabled"},
+	}
+	if !reflect.DeepEqual(existing.Spec.Groups[0].Rules, expected) {
+		t.Fatalf("unexpected rules %v", existing.Spec.Groups[0].Rules)
+	}
+	if _, ok := existing.Annotations[OverriddenAlertsAnnotation]; ok {
+		t.Fatalf("expected the overridden alerts annotation to be removed, got %v", existing.Annotations)
+	}
+}
@@ -0,0 +1,103 @@
+package upgrade
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+)
+
+// Deprecation is a deprecated APIManager field
+type Deprecation struct {
+	// FieldPath of the field in the APIManager, dot separated
+	FieldPath string
+	// Value of the field that is deprecated. Any value when empty
+	Value string
+	// RemovedIn is the 3scale version no longer supporting the field
+	RemovedIn string
+	// MigrationHint explains how to stop using the field
+	MigrationHint string
+}
+
+// APIManagerDeprecations is the table of the deprecated APIManager fields
+// and the 3scale version removing them
+var APIManagerDeprecations = []Deprecation{
+	{
+		FieldPath:     "spec.apicast.productionSpec.openTracing.enabled",
+		Value:         "true",
+		RemovedIn:     "2.17",
+		MigrationHint: "configure tracing in spec.apicast.productionSpec.openTelemetry instead",
+	},
+	{
+		FieldPath:     "spec.apicast.stagingSpec.openTracing.enabled",
+		Value:         "true",
+		RemovedIn:     "2.17",
+		MigrationHint: "configure tracing in spec.apicast.stagingSpec.openTelemetry instead",
+	},
+	{
+		FieldPath:     "spec.system.fileStorage.amazonSimpleStorageService",
+		RemovedIn:     "2.17",
+		MigrationHint: "move the bucket settings to a configuration secret referenced from spec.system.fileStorage.simpleStorageService",
+	},
+	{
+		FieldPath:     "spec.system.sphinxSpec",
+		RemovedIn:     "2.17",
+		MigrationHint: "move the settings to spec.system.searchdSpec",
+	},
+}
+
+// DeprecatedFieldUsage is a removed field set in an APIManager
+type DeprecatedFieldUsage struct {
+	APIManager client.ObjectKey
+	Deprecation
+}
+
+func (u DeprecatedFieldUsage) String() string {
+	return fmt.Sprintf("%s: %s is removed in %s, %s", u.APIManager, u.FieldPath, u.RemovedIn, u.MigrationHint)
+}
+
+// RemovedFieldsInUse returns the fields of the APIManager the upgrade from the current
+// to the target version removes
+func RemovedFieldsInUse(apimanager *appsv1alpha1.APIManager, deprecations []Deprecation, currentVersion, targetVersion string) ([]DeprecatedFieldUsage, error) {
+	current, err := semver.ParseTolerant(currentVersion)
+	if err != nil {
+		return nil, err
+	}
+	target, err := semver.ParseTolerant(targetVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	object, err := runtime.DefaultUnstructuredConverter.ToUnstructured(apimanager)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []DeprecatedFieldUsage
+	for _, deprecation := range deprecations {
+		removedIn, err := semver.ParseTolerant(deprecation.RemovedIn)
+		if err != nil {
+			return nil, fmt.Errorf("deprecation of %s: %w", deprecation.FieldPath, err)
+		}
+		if removedIn.LTE(current) || removedIn.GT(target) {
+			continue
+		}
+
+		value, found, err := unstructured.NestedFieldNoCopy(object, strings.Split(deprecation.FieldPath, ".")...)
+		if err != nil || !found || value == nil {
+			continue
+		}
+		if deprecation.Value != "" && fmt.Sprint(value) != deprecation.Value {
+			continue
+		}
+
+		result = append(result, DeprecatedFieldUsage{APIManager: client.ObjectKeyFromObject(apimanager), Deprecation: deprecation})
+	}
+
+	return result, nil
+}
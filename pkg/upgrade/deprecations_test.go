@@ -0,0 +1,70 @@
+package upgrade
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+)
+
+func TestRemovedFieldsInUse(t *testing.T) {
+	trueValue := true
+	falseValue := false
+
+	apimanager := func(openTracing *bool, deprecatedS3 bool) *appsv1alpha1.APIManager {
+		apimanager := &appsv1alpha1.APIManager{
+			ObjectMeta: metav1.ObjectMeta{Name: "example-apimanager", Namespace: "someNS"},
+			Spec: appsv1alpha1.APIManagerSpec{
+				Apicast: &appsv1alpha1.ApicastSpec{
+					ProductionSpec: &appsv1alpha1.ApicastProductionSpec{
+						OpenTracing: &appsv1alpha1.APIcastOpenTracingSpec{Enabled: openTracing},
+					},
+				},
+				System: &appsv1alpha1.SystemSpec{},
+			},
+		}
+		if deprecatedS3 {
+			apimanager.Spec.System.FileStorageSpec = &appsv1alpha1.SystemFileStorageSpec{
+				DeprecatedS3: &appsv1alpha1.DeprecatedSystemS3Spec{AWSBucket: "bucket"},
+			}
+		}
+		return apimanager
+	}
+
+	cases := []struct {
+		testName       string
+		apimanager     *appsv1alpha1.APIManager
+		currentVersion string
+		targetVersion  string
+		expectedFields []string
+	}{
+		{"NoDeprecatedFields", apimanager(nil, false), "2.16", "2.17", nil},
+		{"OpenTracingDisabled", apimanager(&falseValue, false), "2.16", "2.17", nil},
+		{"OpenTracingEnabled", apimanager(&trueValue, false), "2.16", "2.17", []string{"spec.apicast.productionSpec.openTracing.enabled"}},
+		{"DeprecatedS3", apimanager(nil, true), "2.16", "2.17", []string{"spec.system.fileStorage.amazonSimpleStorageService"}},
+		{"NotRemovedYet", apimanager(&trueValue, true), "2.15", "2.16", nil},
+		{"AlreadyRemoved", apimanager(&trueValue, true), "2.17", "2.18", nil},
+		{"MultiHop", apimanager(&trueValue, true), "2.16", "2.18", []string{"spec.apicast.productionSpec.openTracing.enabled", "spec.system.fileStorage.amazonSimpleStorageService"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			usages, err := RemovedFieldsInUse(tc.apimanager, APIManagerDeprecations, tc.currentVersion, tc.targetVersion)
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if len(usages) != len(tc.expectedFields) {
+				subT.Fatalf("expected fields %v, got %v", tc.expectedFields, usages)
+			}
+			for idx, usage := range usages {
+				if usage.FieldPath != tc.expectedFields[idx] {
+					subT.Fatalf("expected fields %v, got %v", tc.expectedFields, usages)
+				}
+				if usage.APIManager.Name != "example-apimanager" || usage.MigrationHint == "" {
+					subT.Fatalf("unexpected usage %v", usage)
+				}
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	apps "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	appsv1beta1 "github.com/3scale/3scale-operator/apis/apps/v1beta1"
 	capabilitiesv1alpha1 "github.com/3scale/3scale-operator/apis/capabilities/v1alpha1"
 	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
 	"github.com/RHsyseng/operator-utils/pkg/validation"
@@ -23,11 +24,13 @@ const (
 	startTimePath                                    = "/status/startTime"
 	completionTimePath                               = "/status/completionTime"
 	lastTransitionTimePath                           = "/status/conditions/lastTransitionTime"
+	certificateNotAfterPath                          = "/status/certificates/notAfter"
 	systemSharedPVCResourceRequestsPath              = "/spec/system/fileStorage/persistentVolumeClaim/resources/requests"
 	systemMySQLPVCResourceRequestsPath               = "/spec/system/database/mysql/persistentVolumeClaim/resources/requests"
 	systemPostgreSQLPVCResourceRequestsPath          = "/spec/system/database/postgresql/persistentVolumeClaim/resources/requests"
 	systemSearchdResourceRequestsPath                = "/spec/system/searchdSpec/resources/requests"
 	systemSearchdPVCResourceRequestsPath             = "/spec/system/searchdSpec/persistentVolumeClaim/resources/requests"
+	systemSearchdV1beta1PVCResourceRequestsPath      = "/spec/system/searchd/persistentVolumeClaim/resources/requests"
 	productPoliciesConfigurationPath                 = "/spec/policies/configuration"
 	policyConfigurationPath                          = "/spec/schema/configuration"
 	resourceClaimsRegex                              = "^/([a-zA-Z]+)/([a-zA-Z]+)/([a-zA-Z]+)(?:/([a-zA-Z]+))?(?:/([a-zA-Z]+))?/claims.*"
@@ -35,6 +38,9 @@ const (
 	topologySpreadConstraintsMatchLabelKeysRegex     = "^/([a-zA-Z]+)/([a-zA-Z]+)(?:/([a-zA-Z]+))?(?:/([a-zA-Z]+))?/.*[tT]opologySpreadConstraints/matchLabelKeys$"
 	topologySpreadConstraintsNodeAffinityPolicyRegex = "^/([a-zA-Z]+)/([a-zA-Z]+)(?:/([a-zA-Z]+))?(?:/([a-zA-Z]+))?/.*[tT]opologySpreadConstraints/nodeAffinityPolicy$"
 	topologySpreadConstraintsNodeTaintsPolicyRegex   = "^/([a-zA-Z]+)/([a-zA-Z]+)(?:/([a-zA-Z]+))?(?:/([a-zA-Z]+))?/.*[tT]opologySpreadConstraints/nodeTaintsPolicy$"
+	componentLastRolloutTimeRegex                    = "^/status/components/([a-zA-Z]+)/lastRolloutTime"
+	smokeTestRunTimeRegex                            = "^/status/(lastRun|history)/(startTime|completionTime)"
+	smokeTestLastFailureTimePath                     = "/status/lastFailureTime"
 )
 
 type testCRInfo struct {
@@ -94,6 +100,10 @@ func TestSampleCustomResources(t *testing.T) {
 			crPrefix:   "capabilities_v1beta1_developeruser",
 			apiVersion: capabilitiesv1beta1.GroupVersion.Version,
 		},
+		"capabilities.3scale.net_smoketests.yaml": {
+			crPrefix:   "capabilities_v1beta1_smoketest",
+			apiVersion: capabilitiesv1beta1.GroupVersion.Version,
+		},
 	}
 
 	for crd, elem := range crdCrMap {
@@ -124,6 +134,8 @@ func validateCustomResources(t *testing.T, schemaRoot, samplesRoot, crd, prefix
 }
 
 type testCRDInfo struct {
+	// crd file name, defaults to the test name
+	crd        string
 	obj        interface{}
 	apiVersion string
 }
@@ -135,6 +147,11 @@ func TestCompleteCRD(t *testing.T) {
 			obj:        &apps.APIManager{},
 			apiVersion: apps.GroupVersion.Version,
 		},
+		"apps.3scale.net_apimanagers.yaml_v1beta1": {
+			crd:        "apps.3scale.net_apimanagers.yaml",
+			obj:        &appsv1beta1.APIManager{},
+			apiVersion: appsv1beta1.GroupVersion.Version,
+		},
 		"apps.3scale.net_apimanagerbackups.yaml": {
 			obj:        &apps.APIManagerBackup{},
 			apiVersion: apps.GroupVersion.Version,
@@ -147,6 +164,11 @@ func TestCompleteCRD(t *testing.T) {
 			obj:        &capabilitiesv1alpha1.Tenant{},
 			apiVersion: capabilitiesv1alpha1.GroupVersion.Version,
 		},
+		"capabilities.3scale.net_tenants.yaml_v1beta1": {
+			crd:        "capabilities.3scale.net_tenants.yaml",
+			obj:        &capabilitiesv1beta1.Tenant{},
+			apiVersion: capabilitiesv1beta1.GroupVersion.Version,
+		},
 		"capabilities.3scale.net_backends.yaml": {
 			obj:        &capabilitiesv1beta1.Backend{},
 			apiVersion: capabilitiesv1beta1.GroupVersion.Version,
@@ -175,6 +197,10 @@ func TestCompleteCRD(t *testing.T) {
 			obj:        &capabilitiesv1beta1.DeveloperUser{},
 			apiVersion: capabilitiesv1beta1.GroupVersion.Version,
 		},
+		"capabilities.3scale.net_smoketests.yaml": {
+			obj:        &capabilitiesv1beta1.SmokeTest{},
+			apiVersion: capabilitiesv1beta1.GroupVersion.Version,
+		},
 	}
 
 	pathOmissions := []string{
@@ -182,6 +208,8 @@ func TestCompleteCRD(t *testing.T) {
 		startTimePath,
 		completionTimePath,
 		lastTransitionTimePath,
+		certificateNotAfterPath,
+		smokeTestLastFailureTimePath,
 		systemSharedPVCResourceRequestsPath,
 		systemMySQLPVCResourceRequestsPath,
 		systemPostgreSQLPVCResourceRequestsPath,
@@ -189,6 +217,7 @@ func TestCompleteCRD(t *testing.T) {
 		policyConfigurationPath,
 		systemSearchdResourceRequestsPath,
 		systemSearchdPVCResourceRequestsPath,
+		systemSearchdV1beta1PVCResourceRequestsPath,
 	}
 	regexPathOmissions := []*regexp.Regexp{
 		regexp.MustCompile(resourceClaimsRegex),
@@ -196,10 +225,16 @@ func TestCompleteCRD(t *testing.T) {
 		regexp.MustCompile(topologySpreadConstraintsNodeAffinityPolicyRegex),
 		regexp.MustCompile(topologySpreadConstraintsNodeTaintsPolicyRegex),
 		regexp.MustCompile(podAffinityMatchLabelKeysRegex),
+		regexp.MustCompile(componentLastRolloutTimeRegex),
+		regexp.MustCompile(smokeTestRunTimeRegex),
 	}
 
-	for crd, elem := range crdStructMap {
-		t.Run(crd, func(subT *testing.T) {
+	for name, elem := range crdStructMap {
+		crd := name
+		if elem.crd != "" {
+			crd = elem.crd
+		}
+		t.Run(name, func(subT *testing.T) {
 			schema := getSchemaVersioned(subT, fmt.Sprintf("%s/%s", root, crd), elem.apiVersion)
 			missingEntries := schema.GetMissingEntries(elem.obj)
 			for _, missing := range missingEntries {